@@ -6,8 +6,14 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/zevwings/workflow/internal/commands"
+	branchCmd "github.com/zevwings/workflow/internal/commands/branch"
 	configCmd "github.com/zevwings/workflow/internal/commands/config"
+	githubCmd "github.com/zevwings/workflow/internal/commands/github"
+	jiraCmd "github.com/zevwings/workflow/internal/commands/jira"
+	llmCmd "github.com/zevwings/workflow/internal/commands/llm"
+	prCmd "github.com/zevwings/workflow/internal/commands/pr"
 	repoCmd "github.com/zevwings/workflow/internal/commands/repo"
+	stashCmd "github.com/zevwings/workflow/internal/commands/stash"
 	infrastructurelogging "github.com/zevwings/workflow/internal/infrastructure/logging"
 	"github.com/zevwings/workflow/internal/logging"
 )
@@ -34,7 +40,14 @@ supporting PR management, Jira integration, LLM integration, and more.`,
 	// Register subcommands
 	rootCmd.AddCommand(commands.NewSetupCmd())
 	rootCmd.AddCommand(configCmd.NewConfigCmd())
+	rootCmd.AddCommand(githubCmd.NewGitHubCmd())
 	rootCmd.AddCommand(repoCmd.NewRepoCmd())
+	rootCmd.AddCommand(branchCmd.NewBranchCmd())
+	rootCmd.AddCommand(prCmd.NewPRCmd())
+	rootCmd.AddCommand(jiraCmd.NewJiraCmd())
+	rootCmd.AddCommand(stashCmd.NewStashCmd())
+	rootCmd.AddCommand(commands.NewCommitCmd())
+	rootCmd.AddCommand(llmCmd.NewLLMCmd())
 	rootCmd.AddCommand(commands.NewCheckCmd())
 	rootCmd.AddCommand(commands.NewVersionCmd(version, buildDate, gitCommit))
 
@@ -0,0 +1,19 @@
+package branch
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewBranchCmd creates the branch command
+func NewBranchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "branch",
+		Short: "Branch management",
+		Long:  `Create branches for Jira tickets.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(NewCreateCmd())
+
+	return cmd
+}
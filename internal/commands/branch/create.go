@@ -0,0 +1,276 @@
+package branch
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/commands/repo"
+	"github.com/zevwings/workflow/internal/config"
+	"github.com/zevwings/workflow/internal/git"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	infrastructurellm "github.com/zevwings/workflow/internal/infrastructure/llm"
+	"github.com/zevwings/workflow/internal/jira"
+	"github.com/zevwings/workflow/internal/llm/utils"
+	"github.com/zevwings/workflow/internal/prompt"
+	"github.com/zevwings/workflow/internal/util"
+)
+
+const (
+	// defaultBranchTemplate is used when [template.branch] default is not set
+	defaultBranchTemplate = "{prefix}/{ticket}-{slug}"
+	// maxSlugLength limits the part of the branch name derived from the summary
+	maxSlugLength = 50
+	// defaultStartStatus is the Jira status the ticket is moved to
+	defaultStartStatus = "In Progress"
+)
+
+// separatorRuns matches repeated separators left by empty placeholders
+var separatorRuns = regexp.MustCompile(`/{2,}|-{2,}|/-|-/`)
+
+var (
+	createPrefix       string
+	createStatus       string
+	createNoTransition bool
+	createNoCheckout   bool
+	createDryRun       bool
+	createYes          bool
+)
+
+// NewCreateCmd creates the branch create command
+func NewCreateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create JIRA_TICKET",
+		Short: "Create a branch for a Jira ticket and start working on it",
+		Long: `Create and check out a branch for a Jira ticket.
+
+The branch name is rendered from [template.branch] default in
+.workflow/config.toml (default "{prefix}/{ticket}-{slug}"):
+  {prefix}  branch prefix from the personal repository config (or --prefix)
+  {ticket}  the Jira ticket key
+  {slug}    the ticket summary, translated to English by the LLM when needed
+
+The ticket is then moved to "In Progress" (see --status).`,
+		Args: cobra.ExactArgs(1),
+		RunE: runCreate,
+	}
+
+	cmd.Flags().StringVarP(&createPrefix, "prefix", "p", "", "Branch prefix (overrides the repository config)")
+	cmd.Flags().StringVarP(&createStatus, "status", "s", defaultStartStatus, "Jira status to move the ticket to")
+	cmd.Flags().BoolVar(&createNoTransition, "no-transition", false, "Do not change the Jira status")
+	cmd.Flags().BoolVar(&createNoCheckout, "no-checkout", false, "Create the branch without checking it out")
+	cmd.Flags().BoolVar(&createDryRun, "dry-run", false, "Print the branch name without creating it")
+	cmd.Flags().BoolVarP(&createYes, "yes", "y", false, "Use the generated branch name without asking")
+
+	return cmd
+}
+
+func runCreate(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	if err := repo.Ensure(); err != nil {
+		return err
+	}
+
+	ticket := jira.NormalizeTicketKey(args[0])
+	if err := jira.ValidateTicketKey(ticket); err != nil {
+		return err
+	}
+
+	gitRepo, err := git.OpenCurrent()
+	if err != nil {
+		return fmt.Errorf("不在 Git 仓库中: %w", err)
+	}
+
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+	client, err := infrastructureconfig.NewJiraClient(manager)
+	if err != nil {
+		return err
+	}
+
+	// 1. Fetch the ticket
+	spinner := prompt.NewSpinner(fmt.Sprintf("Fetching %s...", ticket))
+	spinner.Start()
+	issue, err := client.GetTicketInfo(ticket)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("获取 Jira ticket %s 失败: %w", ticket, err)
+	}
+	summary, status := "", ""
+	if issue.Fields != nil {
+		summary = strings.TrimSpace(issue.Fields.Summary)
+		if issue.Fields.Status != nil {
+			status = issue.Fields.Status.Name
+		}
+	}
+	msg.Info("%s: %s", ticket, summary)
+
+	// 2. Build the branch name
+	slug, err := summarySlug(manager, summary)
+	if err != nil {
+		return err
+	}
+
+	repoManager, err := infrastructureconfig.NewRepoManagerWithDefaultGit("")
+	if err != nil {
+		return fmt.Errorf("初始化配置管理器失败: %w", err)
+	}
+	if err := repoManager.Load(); err != nil {
+		msg.Debug("Failed to load repository config, using defaults")
+	}
+
+	prefix := createPrefix
+	if prefix == "" {
+		prefix = repoManager.GetBranchPrefix()
+	}
+	branchName := renderBranchName(branchTemplate(repoManager.GetTemplateConfig()), prefix, ticket, slug)
+
+	if createDryRun {
+		msg.Break()
+		msg.Info("Branch: %s", branchName)
+		if !createNoTransition && !strings.EqualFold(status, createStatus) {
+			msg.Info("Jira: %s -> %s", status, createStatus)
+		}
+		msg.Info("Dry run: no changes were made")
+		return nil
+	}
+
+	if !createYes {
+		branchName, err = prompt.Input().
+			Prompt("Branch name:").
+			DefaultValue(branchName).
+			Validate(prompt.ValidateRequired()).
+			Run()
+		if err != nil {
+			return fmt.Errorf("获取分支名失败: %w", err)
+		}
+		branchName = strings.TrimSpace(branchName)
+	}
+
+	// 3. Create the branch
+	exists, err := gitRepo.BranchExists(branchName)
+	if err != nil {
+		return fmt.Errorf("检查分支失败: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("分支 %s 已存在", branchName)
+	}
+
+	if createNoCheckout {
+		err = gitRepo.CreateBranch(branchName)
+	} else {
+		err = gitRepo.CreateAndCheckoutBranch(branchName)
+	}
+	if err != nil {
+		return fmt.Errorf("创建分支 %s 失败: %w", branchName, err)
+	}
+	if createNoCheckout {
+		msg.Success("Created branch %s", branchName)
+	} else {
+		msg.Success("Created and switched to branch %s", branchName)
+	}
+
+	// 4. Move the ticket
+	if createNoTransition {
+		return nil
+	}
+	if strings.EqualFold(status, createStatus) {
+		msg.Info("%s is already %s", ticket, status)
+		return nil
+	}
+	if err := client.MoveTicket(ticket, createStatus); err != nil {
+		msg.Warning("Failed to move %s to %s: %v", ticket, createStatus, err)
+		return nil
+	}
+	msg.Success("Moved %s to %s", ticket, createStatus)
+
+	return nil
+}
+
+// summarySlug turns the ticket summary into the slug of the branch name
+//
+// Non-English summaries are translated by the LLM first. Without a usable
+// summary or translation, the user is asked for the slug.
+func summarySlug(manager *config.GlobalManager, summary string) (string, error) {
+	msg := prompt.GetMessage()
+
+	text := summary
+	if !utils.IsASCII(text) {
+		if _, _, _, err := manager.LLMConfig.CurrentProvider(); err != nil {
+			msg.Warning("LLM is not configured, cannot translate the summary")
+			text = ""
+		} else {
+			var translated string
+			spinner := prompt.NewSpinner("Translating summary...")
+			err := spinner.Do(func() error {
+				var err error
+				translated, err = infrastructurellm.NewBranchLLMClient().TranslateToEnglish(text)
+				return err
+			})
+			if err != nil {
+				msg.Warning("Failed to translate the summary: %v", err)
+				text = ""
+			} else {
+				msg.Info("Translated: %s", translated)
+				text = translated
+			}
+		}
+	}
+
+	if slug := utils.BranchSlug(text, maxSlugLength); slug != "" {
+		return slug, nil
+	}
+
+	input, err := prompt.Input().
+		Prompt("Short description for the branch name (English):").
+		Validate(prompt.ValidateRequired()).
+		Run()
+	if err != nil {
+		return "", fmt.Errorf("获取分支描述失败: %w", err)
+	}
+	slug := utils.BranchSlug(input, maxSlugLength)
+	if slug == "" {
+		return "", fmt.Errorf("分支描述必须包含英文字母或数字")
+	}
+	return slug, nil
+}
+
+// branchTemplate returns the repository's branch template or the default one
+func branchTemplate(templateConfig *config.TemplateConfig) string {
+	if templateConfig != nil {
+		if value, ok := templateConfig.Branch["default"].(string); ok && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return defaultBranchTemplate
+}
+
+// renderBranchName renders the branch template
+//
+// Separators left behind by empty placeholders, such as a missing prefix,
+// are removed.
+func renderBranchName(tmpl, prefix, ticket, slug string) string {
+	name := util.RenderTemplate(tmpl, map[string]string{
+		"prefix": strings.Trim(prefix, "/ "),
+		"ticket": ticket,
+		"slug":   slug,
+	})
+
+	for {
+		collapsed := separatorRuns.ReplaceAllStringFunc(name, func(run string) string {
+			if strings.Contains(run, "/") {
+				return "/"
+			}
+			return "-"
+		})
+		if collapsed == name {
+			break
+		}
+		name = collapsed
+	}
+	return strings.Trim(name, "/-")
+}
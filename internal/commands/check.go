@@ -58,6 +58,7 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	verify.VerifyLLMConfig(manager.LLMConfig)
 	verify.VerifyJiraConfig(manager.JiraConfig)
 	verify.VerifyGitHubConfig(manager.GitHubConfig)
+	verify.VerifyRepoGitHubAccount(manager)
 
 	return nil
 }
@@ -0,0 +1,260 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/commands/repo"
+	"github.com/zevwings/workflow/internal/config"
+	"github.com/zevwings/workflow/internal/git"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	infrastructurellm "github.com/zevwings/workflow/internal/infrastructure/llm"
+	"github.com/zevwings/workflow/internal/jira"
+	"github.com/zevwings/workflow/internal/llm"
+	"github.com/zevwings/workflow/internal/prompt"
+	"github.com/zevwings/workflow/internal/util"
+)
+
+// Choices offered after showing a generated commit message
+const (
+	commitAccept     = "Accept"
+	commitRegenerate = "Regenerate"
+	commitEdit       = "Edit in $EDITOR"
+	commitCancel     = "Cancel"
+)
+
+var (
+	commitHint     string
+	commitNoTicket bool
+	commitDryRun   bool
+	commitYes      bool
+	commitNoCache  bool
+)
+
+// NewCommitCmd creates the commit command
+func NewCommitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "commit",
+		Short: "Commit staged changes with a generated Conventional Commits message",
+		Long: `Commit the staged changes with a message generated by the LLM.
+
+The LLM picks the type, scope and subject from the staged diff. They are
+rendered through [template.commit] in .workflow/config.toml:
+  default            header template, supports {type}, {scope}, {subject}
+                     and {jira_ticket} (default "{type}({scope}): {subject}"
+                     with use_scope, otherwise "{type}: {subject}")
+  use_scope          require a scope
+  types              allowed commit types
+  max_header_length  maximum length of the first line (default 72)
+
+The Jira ticket found in the branch name is appended to the header unless
+the template places {jira_ticket} itself. The message can be accepted,
+regenerated or edited in $EDITOR, and is validated against these rules
+before committing.
+
+Large diffs are compacted to the token budget of the configured model
+([llm] max_diff_tokens or [[llm.budgets]] in the global config).
+
+LLM responses are cached ([llm.cache] in the global config), so the same
+staged diff produces the same message; Regenerate and --no-cache call the
+LLM again.`,
+		Args: cobra.NoArgs,
+		RunE: runCommit,
+	}
+
+	cmd.Flags().StringVar(&commitHint, "hint", "", "Describe the intent of the change to guide the LLM")
+	cmd.Flags().BoolVar(&commitNoTicket, "no-ticket", false, "Do not add the Jira ticket from the branch name")
+	cmd.Flags().BoolVar(&commitDryRun, "dry-run", false, "Print the generated message without committing")
+	cmd.Flags().BoolVarP(&commitYes, "yes", "y", false, "Commit with the generated message if it is valid")
+	cmd.Flags().BoolVar(&commitNoCache, "no-cache", false, "Call the LLM even if a cached response exists")
+
+	return cmd
+}
+
+func runCommit(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	if commitNoCache {
+		infrastructurellm.DisableCache()
+	}
+
+	if err := repo.Ensure(); err != nil {
+		return err
+	}
+
+	gitRepo, err := git.OpenCurrent()
+	if err != nil {
+		return fmt.Errorf("不在 Git 仓库中: %w", err)
+	}
+
+	staged, err := gitRepo.DiffStaged()
+	if err != nil {
+		return fmt.Errorf("获取暂存区差异失败: %w", err)
+	}
+	if staged.IsEmpty() {
+		return fmt.Errorf("没有暂存的变更（请先运行 'git add'）")
+	}
+	added, deleted := staged.Totals()
+	msg.Info("%d file(s) staged (+%d -%d)", len(staged.Files), added, deleted)
+
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+	if _, _, _, err := manager.LLMConfig.CurrentProvider(); err != nil {
+		return fmt.Errorf("LLM 未配置（请先运行 'workflow setup'）: %w", err)
+	}
+
+	repoManager, err := infrastructureconfig.NewRepoManagerWithDefaultGit("")
+	if err != nil {
+		return fmt.Errorf("初始化配置管理器失败: %w", err)
+	}
+	if err := repoManager.Load(); err != nil {
+		msg.Debug("Failed to load repository config, using defaults")
+	}
+	rules := git.CommitRulesFromTemplate(repoManager.GetTemplateConfig().Commit)
+
+	ticket := ""
+	if !commitNoTicket {
+		if branch, err := gitRepo.CurrentBranch(); err == nil {
+			ticket = jira.ExtractTicketKey(branch)
+		}
+	}
+
+	llmClient := infrastructurellm.NewCommitLLMClient()
+	diff, err := compactStagedDiff(manager, llmClient, staged.Patch)
+	if err != nil {
+		return err
+	}
+
+	message, err := generateCommitMessage(llmClient, diff, rules, ticket)
+	if err != nil {
+		return err
+	}
+
+	for {
+		msg.Break()
+		msg.Break('-', 40, "Commit message")
+		msg.Print("%s", message)
+		msg.Break('-', 40)
+
+		validationErr := rules.Validate(message)
+		if validationErr != nil {
+			msg.Warning("%v", validationErr)
+		}
+
+		if commitDryRun {
+			msg.Info("Dry run: nothing was committed")
+			return nil
+		}
+
+		if commitYes {
+			if validationErr != nil {
+				return fmt.Errorf("生成的提交消息不符合规则: %w", validationErr)
+			}
+			return createCommit(gitRepo, message)
+		}
+
+		choices := []string{commitAccept, commitRegenerate, commitEdit, commitCancel}
+		index, err := prompt.Select().
+			Prompt("What do you want to do?").
+			Options(choices).
+			Run()
+		if err != nil {
+			return fmt.Errorf("选择操作失败: %w", err)
+		}
+
+		switch choices[index] {
+		case commitAccept:
+			if validationErr != nil {
+				msg.Warning("Edit or regenerate the message before committing")
+				continue
+			}
+			return createCommit(gitRepo, message)
+		case commitRegenerate:
+			regenerated, err := generateCommitMessage(llmClient.Refresh(), diff, rules, ticket)
+			if err != nil {
+				msg.Warning("%v", err)
+				continue
+			}
+			message = regenerated
+		case commitEdit:
+			edited, err := util.EditText(message+"\n", "COMMIT_EDITMSG-*.txt")
+			if err != nil {
+				msg.Warning("%v", err)
+				continue
+			}
+			if strings.TrimSpace(edited) == "" {
+				msg.Warning("The message is empty, keeping the previous one")
+				continue
+			}
+			message = strings.TrimSpace(edited)
+		default:
+			msg.Info("Cancelled, nothing was committed")
+			return nil
+		}
+	}
+}
+
+// compactStagedDiff compacts the staged diff to the LLM token budget of the commit feature
+//
+// What was trimmed is reported. When the compacted diff is still over the
+// budget, the files are summarized one by one and the summaries are returned
+// in place of the diff.
+func compactStagedDiff(manager *config.GlobalManager, llmClient *llm.CommitLLMClient, diff string) (string, error) {
+	msg := prompt.GetMessage()
+
+	result := llm.CompactDiff(diff, manager.LLMConfig.DiffTokenBudget(llm.FeatureCommit))
+	for _, line := range result.Report() {
+		msg.Info("%s", line)
+	}
+	if !result.OverBudget {
+		return result.Diff, nil
+	}
+
+	spinner := prompt.NewSpinner("Summarizing files...")
+	spinner.Start()
+	summaries, err := llmClient.SummarizeFileChanges(result.Files, result.MaxTokens, func(index, total int, path string) {
+		spinner.UpdateMessage(fmt.Sprintf("Summarizing file %d/%d: %s", index+1, total, path))
+	})
+	spinner.Stop()
+	if err != nil {
+		return "", fmt.Errorf("总结文件变更失败: %w", err)
+	}
+	return llm.FormatFileSummaries(summaries), nil
+}
+
+// generateCommitMessage asks the LLM for the message parts and renders them
+func generateCommitMessage(llmClient *llm.CommitLLMClient, diff string, rules git.CommitRules, ticket string) (string, error) {
+	var content *llm.CommitContent
+	spinner := prompt.NewSpinner("Generating commit message...")
+	err := spinner.Do(func() error {
+		var err error
+		content, err = llmClient.Generate(diff, commitHint, rules.Types)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("生成提交消息失败: %w", err)
+	}
+
+	return rules.Render(git.CommitMessage{
+		Type:       content.Type,
+		Scope:      content.Scope,
+		Subject:    content.Subject,
+		Body:       content.Body,
+		JiraTicket: ticket,
+	}), nil
+}
+
+// createCommit commits the staged changes
+func createCommit(gitRepo *git.Repository, message string) error {
+	hash, err := gitRepo.Commit(message, nil)
+	if err != nil {
+		return fmt.Errorf("提交失败: %w", err)
+	}
+
+	header, _, _ := strings.Cut(message, "\n")
+	prompt.GetMessage().Success("Committed %s %s", hash.String()[:7], header)
+	return nil
+}
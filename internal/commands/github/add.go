@@ -0,0 +1,94 @@
+package github
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/config"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	"github.com/zevwings/workflow/internal/prompt"
+	"github.com/zevwings/workflow/internal/prompt/form"
+)
+
+// NewAddCmd creates the github add command
+func NewAddCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add",
+		Short: "Add a GitHub account",
+		Long: `Add a GitHub account from a Personal Access Token.
+
+The token is validated against the GitHub API, and the account name and
+email default to the login and public email of the token's user. The first
+account added becomes the current account.`,
+		Args: cobra.NoArgs,
+		RunE: runAdd,
+	}
+}
+
+func runAdd(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+	githubConfig := manager.GetGitHubConfig()
+
+	tokenResult, err := prompt.Form().
+		SetTitle("Add GitHub Account").
+		AddPassword(form.PasswordFormField{
+			Key:         "api_token",
+			Prompt:      "Please enter your GitHub Personal Access Token (required)",
+			Validator:   prompt.ValidateRequired(),
+			ResultTitle: "Your GitHub Personal Access Token",
+		}).
+		Run()
+	if err != nil {
+		return fmt.Errorf("输入 GitHub Token 失败: %w", err)
+	}
+	token := tokenResult.GetString("api_token")
+
+	user, err := validateToken(token)
+	if err != nil {
+		return err
+	}
+	msg.Success("Token belongs to %s", user.login)
+
+	result, err := prompt.Form().
+		AddInput(form.InputFormField{
+			Key:          "name",
+			Prompt:       "Please enter your account name",
+			DefaultValue: user.login,
+			Validator:    uniqueName(githubConfig, ""),
+			ResultTitle:  "Your account name",
+		}).
+		AddInput(form.InputFormField{
+			Key:          "email",
+			Prompt:       "Please enter your email",
+			DefaultValue: user.email,
+			Validator:    prompt.ValidateEmail(),
+			ResultTitle:  "Your email",
+		}).
+		Run()
+	if err != nil {
+		return fmt.Errorf("输入账号信息失败: %w", err)
+	}
+
+	account := config.GitHubAccount{
+		Name:     result.GetString("name"),
+		Email:    result.GetString("email"),
+		APIToken: token,
+	}
+	if err := githubConfig.AddAccount(account); err != nil {
+		return err
+	}
+	if err := saveGlobalConfig(manager); err != nil {
+		return err
+	}
+
+	msg.Success("Added GitHub account %s", account.Name)
+	if manager.GetGitHubConfig().Current != account.Name {
+		msg.Info("Run 'workflow github switch %s' to use it", account.Name)
+	}
+	return nil
+}
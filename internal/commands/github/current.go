@@ -0,0 +1,39 @@
+package github
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	"github.com/zevwings/workflow/internal/prompt"
+	"github.com/zevwings/workflow/internal/util"
+)
+
+// NewCurrentCmd creates the github current command
+func NewCurrentCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "current",
+		Short: "Show the current GitHub account",
+		Args:  cobra.NoArgs,
+		RunE:  runCurrent,
+	}
+}
+
+func runCurrent(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+
+	account, err := manager.GetCurrentGitHubAccount()
+	if err != nil {
+		return fmt.Errorf("获取当前 GitHub 账号失败（请先运行 'workflow github add'）: %w", err)
+	}
+
+	msg.Info("Name: %s", account.Name)
+	msg.Info("Email: %s", account.Email)
+	msg.Info("API Token: %s", util.MaskSensitiveValue(account.APIToken))
+	return nil
+}
@@ -0,0 +1,28 @@
+package github
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewGitHubCmd creates the github command
+func NewGitHubCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "github",
+		Short: "GitHub account management",
+		Long: `Manage the GitHub accounts used for pull requests and pushes.
+
+Several accounts can be configured; the current one is used by default.
+Switching accounts also sets user.name and user.email in the local config
+of the current repository, so that commits are attributed to that account.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(NewListCmd())
+	cmd.AddCommand(NewCurrentCmd())
+	cmd.AddCommand(NewAddCmd())
+	cmd.AddCommand(NewRemoveCmd())
+	cmd.AddCommand(NewSwitchCmd())
+	cmd.AddCommand(NewUpdateCmd())
+
+	return cmd
+}
@@ -0,0 +1,119 @@
+package github
+
+import (
+	"fmt"
+
+	"github.com/zevwings/workflow/internal/config"
+	"github.com/zevwings/workflow/internal/git"
+	githubapi "github.com/zevwings/workflow/internal/pr/github"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+// saveGlobalConfig saves the global configuration
+func saveGlobalConfig(manager *config.GlobalManager) error {
+	if err := manager.Save(); err != nil {
+		return fmt.Errorf("保存配置失败: %w", err)
+	}
+	return nil
+}
+
+// githubUser is the identity returned by the GitHub API for a token
+type githubUser struct {
+	login string
+	email string
+}
+
+// validateToken checks the token against the GitHub API and returns its user
+func validateToken(token string) (*githubUser, error) {
+	var result *githubapi.AuthResult
+	spinner := prompt.NewSpinner("Validating GitHub token...")
+	err := spinner.Do(func() error {
+		var err error
+		result, err = githubapi.ValidateAuth(token)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("验证 GitHub Token 失败: %w", err)
+	}
+	if !result.Valid {
+		if result.Error != nil {
+			return nil, fmt.Errorf("%s: %w", result.Message, result.Error)
+		}
+		return nil, fmt.Errorf("%s", result.Message)
+	}
+
+	user := &githubUser{}
+	user.login, _ = result.Details["username"].(string)
+	user.email, _ = result.Details["email"].(string)
+	return user, nil
+}
+
+// chooseAccount returns the account named in the arguments, or lets the user pick one
+func chooseAccount(githubConfig *config.GitHubConfig, args []string, message string) (*config.GitHubAccount, error) {
+	if len(githubConfig.Accounts) == 0 {
+		return nil, fmt.Errorf("未配置 GitHub 账号（请先运行 'workflow github add'）")
+	}
+
+	if len(args) > 0 {
+		account := githubConfig.FindAccount(args[0])
+		if account == nil {
+			return nil, fmt.Errorf("未找到 GitHub 账号: %s", args[0])
+		}
+		return account, nil
+	}
+
+	options := make([]string, 0, len(githubConfig.Accounts))
+	defaultIndex := 0
+	for i, account := range githubConfig.Accounts {
+		option := fmt.Sprintf("%s <%s>", account.Name, account.Email)
+		if account.Name == githubConfig.Current {
+			option += " (current)"
+			defaultIndex = i
+		}
+		options = append(options, option)
+	}
+
+	index, err := prompt.AskSelect(prompt.SelectField{
+		Message:      message,
+		Options:      options,
+		DefaultIndex: defaultIndex,
+		ResultTitle:  message,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("选择 GitHub 账号失败: %w", err)
+	}
+	return &githubConfig.Accounts[index], nil
+}
+
+// uniqueName returns a validator that rejects account names already in use
+//
+// The name the account already has is accepted, so that it can be kept.
+func uniqueName(githubConfig *config.GitHubConfig, current string) prompt.Validator {
+	return func(value string) error {
+		if value == "" {
+			return fmt.Errorf("账号名称不能为空")
+		}
+		if value != current && githubConfig.FindAccount(value) != nil {
+			return fmt.Errorf("GitHub 账号 %s 已存在", value)
+		}
+		return nil
+	}
+}
+
+// applyGitIdentity sets user.name and user.email of the current repository to the account
+//
+// Nothing is done outside a git repository.
+func applyGitIdentity(account *config.GitHubAccount) error {
+	msg := prompt.GetMessage()
+
+	gitRepo, err := git.OpenCurrent()
+	if err != nil {
+		return nil
+	}
+
+	if err := gitRepo.SetLocalUser(account.Name, account.Email); err != nil {
+		return fmt.Errorf("设置仓库的 Git 用户信息失败: %w", err)
+	}
+	msg.Success("Set user.name=%s and user.email=%s for this repository", account.Name, account.Email)
+	return nil
+}
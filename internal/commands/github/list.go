@@ -0,0 +1,50 @@
+package github
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	"github.com/zevwings/workflow/internal/prompt"
+	"github.com/zevwings/workflow/internal/util"
+)
+
+// NewListCmd creates the github list command
+func NewListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List GitHub accounts",
+		Args:  cobra.NoArgs,
+		RunE:  runList,
+	}
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+
+	githubConfig := manager.GetGitHubConfig()
+	if len(githubConfig.Accounts) == 0 {
+		msg.Info("No GitHub accounts configured, run 'workflow github add' to add one")
+		return nil
+	}
+
+	current, _ := manager.GetCurrentGitHubAccount()
+
+	table := prompt.NewTable([]string{"Name", "Email", "API Token", "Rules", "Status"})
+	table.SetRowLine(false)
+	for _, account := range githubConfig.Accounts {
+		status := ""
+		if current != nil && account.Name == current.Name {
+			status = "Current"
+		}
+		table.AddRow([]string{account.Name, account.Email, util.MaskSensitiveValue(account.APIToken), strings.Join(account.Rules, ", "), status})
+	}
+	table.Render()
+
+	return nil
+}
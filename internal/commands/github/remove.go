@@ -0,0 +1,65 @@
+package github
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+// NewRemoveCmd creates the github remove command
+func NewRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove [NAME]",
+		Short: "Remove a GitHub account",
+		Long: `Remove a GitHub account from the configuration.
+
+Without NAME, the account is picked from a list. When the current account is
+removed, the first remaining account becomes current.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runRemove,
+	}
+}
+
+func runRemove(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+	githubConfig := manager.GetGitHubConfig()
+
+	account, err := chooseAccount(githubConfig, args, "Select the account to remove")
+	if err != nil {
+		return err
+	}
+	name := account.Name
+
+	confirmed, err := prompt.Confirm().
+		Prompt(fmt.Sprintf("Remove GitHub account %s?", name)).
+		Default(false).
+		Run()
+	if err != nil {
+		return fmt.Errorf("确认操作失败: %w", err)
+	}
+	if !confirmed {
+		msg.Info("Cancelled")
+		return nil
+	}
+
+	if err := githubConfig.RemoveAccount(name); err != nil {
+		return err
+	}
+	if err := saveGlobalConfig(manager); err != nil {
+		return err
+	}
+
+	msg.Success("Removed GitHub account %s", name)
+	msg.Warning("Repositories bound to %s will fall back to account rules or the current account. Run 'workflow repo setup' in them to rebind.", name)
+	if current := manager.GetGitHubConfig().Current; current != "" {
+		msg.Info("Current account: %s", current)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package github
+
+import (
+	"github.com/spf13/cobra"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+// NewSwitchCmd creates the github switch command
+func NewSwitchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "switch [NAME]",
+		Short: "Switch the current GitHub account",
+		Long: `Switch the current GitHub account.
+
+Without NAME, the account is picked from a list. Inside a git repository,
+user.name and user.email of the repository's local config are set to the
+account's name and email.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runSwitch,
+	}
+}
+
+func runSwitch(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+	githubConfig := manager.GetGitHubConfig()
+
+	account, err := chooseAccount(githubConfig, args, "Select the account to use")
+	if err != nil {
+		return err
+	}
+	selected := *account
+
+	if githubConfig.Current == selected.Name {
+		msg.Info("%s is already the current account", selected.Name)
+	} else {
+		if err := githubConfig.SwitchAccount(selected.Name); err != nil {
+			return err
+		}
+		if err := saveGlobalConfig(manager); err != nil {
+			return err
+		}
+		msg.Success("Switched to GitHub account %s", selected.Name)
+	}
+
+	return applyGitIdentity(&selected)
+}
@@ -0,0 +1,103 @@
+package github
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	"github.com/zevwings/workflow/internal/prompt"
+	"github.com/zevwings/workflow/internal/prompt/form"
+)
+
+// NewUpdateCmd creates the github update command
+func NewUpdateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "update [NAME]",
+		Short: "Update a GitHub account",
+		Long: `Update the token, name or email of a GitHub account.
+
+Without NAME, the account is picked from a list. Press Enter to keep a
+value. The token is validated against the GitHub API, and an empty email is
+filled in from the token's user.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runUpdate,
+	}
+}
+
+func runUpdate(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+	githubConfig := manager.GetGitHubConfig()
+
+	account, err := chooseAccount(githubConfig, args, "Select the account to update")
+	if err != nil {
+		return err
+	}
+	oldName := account.Name
+
+	tokenResult, err := prompt.Form().
+		SetTitle(fmt.Sprintf("Update GitHub Account %s", oldName)).
+		AddPassword(form.PasswordFormField{
+			Key:          "api_token",
+			Prompt:       "Please enter your GitHub Personal Access Token (press Enter to keep)",
+			DefaultValue: account.APIToken,
+			Validator:    prompt.ValidateRequired(),
+			ResultTitle:  "Your GitHub Personal Access Token",
+		}).
+		Run()
+	if err != nil {
+		return fmt.Errorf("输入 GitHub Token 失败: %w", err)
+	}
+	token := tokenResult.GetString("api_token")
+
+	user, err := validateToken(token)
+	if err != nil {
+		return err
+	}
+	msg.Success("Token belongs to %s", user.login)
+
+	email := account.Email
+	if email == "" {
+		email = user.email
+	}
+
+	result, err := prompt.Form().
+		AddInput(form.InputFormField{
+			Key:          "name",
+			Prompt:       "Please enter your account name (press Enter to keep)",
+			DefaultValue: oldName,
+			Validator:    uniqueName(githubConfig, oldName),
+			ResultTitle:  "Your account name",
+		}).
+		AddInput(form.InputFormField{
+			Key:          "email",
+			Prompt:       "Please enter your email (press Enter to keep)",
+			DefaultValue: email,
+			Validator:    prompt.ValidateEmail(),
+			ResultTitle:  "Your email",
+		}).
+		Run()
+	if err != nil {
+		return fmt.Errorf("输入账号信息失败: %w", err)
+	}
+
+	account.APIToken = token
+	account.Email = result.GetString("email")
+	newName := result.GetString("name")
+	if err := githubConfig.RenameAccount(oldName, newName); err != nil {
+		return err
+	}
+	if err := saveGlobalConfig(manager); err != nil {
+		return err
+	}
+
+	msg.Success("Updated GitHub account %s", newName)
+	if newName != oldName {
+		msg.Warning("Repositories bound to %s will fall back to account rules or the current account. Run 'workflow repo setup' in them to rebind to %s.", oldName, newName)
+	}
+	return nil
+}
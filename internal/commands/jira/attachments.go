@@ -0,0 +1,128 @@
+package jira
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	jiraclient "github.com/zevwings/workflow/internal/jira"
+	"github.com/zevwings/workflow/internal/prompt"
+	"github.com/zevwings/workflow/internal/util"
+)
+
+var (
+	attachmentsOutput  string
+	attachmentsFilter  string
+	attachmentsWorkers int
+)
+
+// NewAttachmentsCmd creates the jira attachments command
+func NewAttachmentsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attachments [TICKET]",
+		Short: "Download the attachments of a Jira ticket",
+		Long: `Download all attachments of a Jira ticket concurrently.
+
+Attachments are saved to the Workflow data directory (see 'workflow jira clean
+--list'), or to --output. Files that already exist with the same size are
+skipped, so an interrupted download can simply be run again. A manifest.json
+with the ID, file name, size and SHA-256 of each attachment is written next
+to the files.
+
+Without TICKET, the key in the current branch name is used.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runAttachments,
+	}
+
+	cmd.Flags().StringVarP(&attachmentsOutput, "output", "o", "", "Directory to save the attachments to")
+	cmd.Flags().StringVarP(&attachmentsFilter, "filter", "f", "", "Only download attachments whose file name matches this glob (e.g. '*.png')")
+	cmd.Flags().IntVarP(&attachmentsWorkers, "workers", "j", jiraclient.DefaultDownloadWorkers, "Number of concurrent downloads")
+
+	return cmd
+}
+
+func runAttachments(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	if attachmentsWorkers < 1 {
+		return fmt.Errorf("--workers 必须大于 0")
+	}
+
+	_, client, ticket, err := setupTicket(args)
+	if err != nil {
+		return err
+	}
+
+	attachments, err := client.GetAttachments(ticket)
+	if err != nil {
+		return fmt.Errorf("获取 ticket %s 的附件失败: %w", ticket, err)
+	}
+	attachments, err = jiraclient.FilterAttachments(attachments, attachmentsFilter)
+	if err != nil {
+		return err
+	}
+	if len(attachments) == 0 {
+		msg.Info("No attachments to download for %s", ticket)
+		return nil
+	}
+
+	dir := attachmentsOutput
+	if dir == "" {
+		dir, err = jiraclient.AttachmentsDir(ticket)
+		if err != nil {
+			return fmt.Errorf("获取下载目录失败: %w", err)
+		}
+	}
+
+	total := len(attachments)
+	done := 0
+	spinner := prompt.NewSpinner(fmt.Sprintf("Downloading %d attachments...", total))
+	spinner.Start()
+	results, err := jiraclient.DownloadAttachments(client, attachments, dir, jiraclient.DownloadOptions{
+		Workers: attachmentsWorkers,
+		Progress: func(result jiraclient.DownloadResult) {
+			done++
+			spinner.UpdateMessage(fmt.Sprintf("Downloading attachments (%d/%d): %s", done, total, result.Attachment.Filename))
+		},
+	})
+	spinner.Stop()
+	if err != nil {
+		return err
+	}
+
+	msg.Break()
+	table := prompt.NewTable([]string{"File", "Size", "Status"})
+	table.SetRowLine(false)
+	var downloaded, skipped, failed int
+	for _, result := range results {
+		table.AddRow([]string{filepath.Base(result.Path), util.FormatSize(int64(result.Attachment.Size)), result.Status})
+		switch result.Status {
+		case jiraclient.DownloadStatusDownloaded:
+			downloaded++
+		case jiraclient.DownloadStatusSkipped:
+			skipped++
+		default:
+			failed++
+		}
+	}
+	table.Render()
+
+	msg.Break()
+	for _, result := range results {
+		if result.Err != nil {
+			msg.Warning("%s: %v", result.Attachment.Filename, result.Err)
+		}
+	}
+
+	manifestPath, err := jiraclient.WriteManifest(dir, ticket, results)
+	if err != nil {
+		return err
+	}
+	msg.Success("Downloaded %d, skipped %d attachments to %s", downloaded, skipped, dir)
+	msg.Info("Manifest: %s", manifestPath)
+
+	if failed > 0 {
+		return fmt.Errorf("%d 个附件下载失败", failed)
+	}
+	return nil
+}
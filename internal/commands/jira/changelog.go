@@ -0,0 +1,92 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	jiraclient "github.com/zevwings/workflow/internal/jira"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+// NewChangelogCmd creates the jira changelog command
+func NewChangelogCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "changelog [TICKET]",
+		Short: "Show the change history of a Jira ticket",
+		Long: `Show who changed which fields of a Jira ticket, and when.
+
+Without TICKET, the key in the current branch name is used.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runChangelog,
+	}
+
+	addFormatFlags(cmd)
+
+	return cmd
+}
+
+func runChangelog(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	_, client, ticket, err := setupTicket(args)
+	if err != nil {
+		return err
+	}
+
+	changelog, err := client.GetChangelog(ticket)
+	if err != nil {
+		return fmt.Errorf("获取 ticket %s 的变更历史失败: %w", ticket, err)
+	}
+	histories := jiraclient.ToJiraChangelog(changelog)
+
+	switch {
+	case outputJSON:
+		return printJSON(histories)
+	case outputMarkdown:
+		fmt.Print(changelogMarkdown(ticket, histories))
+		return nil
+	}
+
+	if len(histories) == 0 {
+		msg.Info("No changes recorded for %s", ticket)
+		return nil
+	}
+
+	msg.Break()
+	table := prompt.NewTable([]string{"Date", "Author", "Field", "From", "To"})
+	table.SetRowLine(false)
+	for _, history := range histories {
+		for _, item := range history.Items {
+			table.AddRow([]string{
+				formatDate(history.Created),
+				userName(history.Author),
+				item.Field,
+				singleLine(valueOrDash(item.FromString), 40),
+				singleLine(valueOrDash(item.ToString), 40),
+			})
+		}
+	}
+	table.Render()
+	return nil
+}
+
+// changelogMarkdown renders the change history as Markdown, one section per change
+func changelogMarkdown(ticket string, histories []*jiraclient.JiraChangelog) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# Changelog of %s\n", ticket)
+	if len(histories) == 0 {
+		sb.WriteString("\nNo changes recorded.\n")
+		return sb.String()
+	}
+
+	for _, history := range histories {
+		fmt.Fprintf(&sb, "\n## %s by %s\n\n", formatDate(history.Created), userName(history.Author))
+		for _, item := range history.Items {
+			fmt.Fprintf(&sb, "- **%s**: %s → %s\n", item.Field,
+				escapeMarkdownCell(valueOrDash(item.FromString)), escapeMarkdownCell(valueOrDash(item.ToString)))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,176 @@
+package jira
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	jiraclient "github.com/zevwings/workflow/internal/jira"
+	"github.com/zevwings/workflow/internal/prompt"
+	"github.com/zevwings/workflow/internal/util"
+)
+
+var (
+	cleanAll    bool
+	cleanDryRun bool
+	cleanList   bool
+)
+
+// NewCleanCmd creates the jira clean command
+func NewCleanCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clean [TICKET]",
+		Short: "Remove downloaded Jira attachments",
+		Long: `Remove the attachment directories created by 'workflow jira attachments'.
+
+Without TICKET, the key in the current branch name is used; if there is none,
+a directory can be picked from the downloaded tickets. Use --all to remove
+every download directory and --list to only show them.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runClean,
+	}
+
+	cmd.Flags().BoolVar(&cleanAll, "all", false, "Remove the download directories of all tickets")
+	cmd.Flags().BoolVar(&cleanDryRun, "dry-run", false, "Show what would be removed without removing it")
+	cmd.Flags().BoolVar(&cleanList, "list", false, "List the download directories")
+	cmd.MarkFlagsMutuallyExclusive("all", "list")
+
+	return cmd
+}
+
+func runClean(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	if cleanAll && len(args) > 0 {
+		return fmt.Errorf("--all 不能与 TICKET 同时使用")
+	}
+
+	root, err := jiraclient.AttachmentsRoot()
+	if err != nil {
+		return fmt.Errorf("获取下载目录失败: %w", err)
+	}
+	dirs, err := jiraclient.ListAttachmentDirs(root)
+	if err != nil {
+		return err
+	}
+
+	if cleanList {
+		printDownloadDirs(root, dirs)
+		return nil
+	}
+	if len(dirs) == 0 {
+		msg.Info("No downloaded attachments in %s", root)
+		return nil
+	}
+
+	targets, err := resolveCleanTargets(dirs, args)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	var size int64
+	for _, dir := range targets {
+		size += dir.Size
+	}
+
+	if cleanDryRun {
+		for _, dir := range targets {
+			msg.Info("Would remove %s (%d files, %s)", dir.Path, dir.Files, util.FormatSize(dir.Size))
+		}
+		msg.Info("Dry run: %s would be freed", util.FormatSize(size))
+		return nil
+	}
+
+	if cleanAll {
+		confirmed, err := prompt.Confirm().
+			Prompt(fmt.Sprintf("Remove the downloaded attachments of %d tickets (%s)?", len(targets), util.FormatSize(size))).
+			Default(false).
+			Run()
+		if err != nil {
+			return fmt.Errorf("确认操作失败: %w", err)
+		}
+		if !confirmed {
+			msg.Info("Cancelled")
+			return nil
+		}
+	}
+
+	for _, dir := range targets {
+		if err := os.RemoveAll(dir.Path); err != nil {
+			return fmt.Errorf("删除目录 %s 失败: %w", dir.Path, err)
+		}
+		msg.Success("Removed %s", dir.Path)
+	}
+	msg.Info("Freed %s", util.FormatSize(size))
+	return nil
+}
+
+// resolveCleanTargets returns the download directories to remove
+//
+// With --all, every directory is returned. Otherwise the ticket comes from the
+// arguments or the current branch, and the user picks one when neither has it.
+func resolveCleanTargets(dirs []jiraclient.DownloadDirInfo, args []string) ([]jiraclient.DownloadDirInfo, error) {
+	msg := prompt.GetMessage()
+
+	if cleanAll {
+		return dirs, nil
+	}
+
+	var ticket string
+	if len(args) > 0 {
+		ticket = jiraclient.NormalizeTicketKey(args[0])
+	} else {
+		ticket = ticketFromBranch()
+	}
+
+	if ticket == "" {
+		options := make([]string, 0, len(dirs))
+		for _, dir := range dirs {
+			options = append(options, fmt.Sprintf("%s (%d files, %s)", dir.Ticket, dir.Files, util.FormatSize(dir.Size)))
+		}
+		index, err := prompt.Select().
+			Prompt("Which ticket's attachments do you want to remove?").
+			Options(options).
+			Run()
+		if err != nil {
+			return nil, fmt.Errorf("选择 ticket 失败: %w", err)
+		}
+		return dirs[index : index+1], nil
+	}
+
+	for _, dir := range dirs {
+		if strings.EqualFold(dir.Ticket, ticket) {
+			return []jiraclient.DownloadDirInfo{dir}, nil
+		}
+	}
+
+	msg.Info("No downloaded attachments for %s", ticket)
+	return nil, nil
+}
+
+// printDownloadDirs prints the download directories as a table
+func printDownloadDirs(root string, dirs []jiraclient.DownloadDirInfo) {
+	msg := prompt.GetMessage()
+
+	if len(dirs) == 0 {
+		msg.Info("No downloaded attachments in %s", root)
+		return
+	}
+
+	msg.Break()
+	table := prompt.NewTable([]string{"Ticket", "Files", "Size", "Modified"})
+	table.SetRowLine(false)
+	var size int64
+	for _, dir := range dirs {
+		table.AddRow([]string{dir.Ticket, fmt.Sprintf("%d", dir.Files), util.FormatSize(dir.Size), dir.ModTime.Local().Format("2006-01-02 15:04")})
+		size += dir.Size
+	}
+	table.Render()
+
+	msg.Break()
+	msg.Info("%d tickets, %s in %s", len(dirs), util.FormatSize(size), root)
+}
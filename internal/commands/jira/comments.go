@@ -0,0 +1,122 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	jiraclient "github.com/zevwings/workflow/internal/jira"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+// maxCommentPreview is the length of a comment shown in the terminal table
+const maxCommentPreview = 80
+
+var (
+	commentsLimit  int
+	commentsOffset int
+	commentsAuthor string
+	commentsSince  string
+)
+
+// NewCommentsCmd creates the jira comments command
+func NewCommentsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "comments [TICKET]",
+		Short: "Show the comments of a Jira ticket",
+		Long: `Show the comments of a Jira ticket, oldest first.
+
+Comments can be filtered by author (display name, email or account ID) and
+creation date, and paged with --offset and --limit. The terminal view shows
+a preview of each comment; use --markdown or --json for the full text.
+
+Without TICKET, the key in the current branch name is used.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runComments,
+	}
+
+	addFormatFlags(cmd)
+	cmd.Flags().IntVarP(&commentsLimit, "limit", "n", 0, "Maximum number of comments to show (0 for all)")
+	cmd.Flags().IntVar(&commentsOffset, "offset", 0, "Number of comments to skip")
+	cmd.Flags().StringVarP(&commentsAuthor, "author", "a", "", "Only show comments by this author")
+	cmd.Flags().StringVar(&commentsSince, "since", "", "Only show comments created since this date (YYYY-MM-DD)")
+
+	return cmd
+}
+
+func runComments(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	if commentsLimit < 0 || commentsOffset < 0 {
+		return fmt.Errorf("--limit 和 --offset 不能为负数")
+	}
+	filter := jiraclient.CommentFilter{
+		Author: commentsAuthor,
+		Offset: commentsOffset,
+		Limit:  commentsLimit,
+	}
+	if commentsSince != "" {
+		since, err := parseSince(commentsSince)
+		if err != nil {
+			return err
+		}
+		filter.Since = since
+	}
+
+	_, client, ticket, err := setupTicket(args)
+	if err != nil {
+		return err
+	}
+
+	all, err := client.GetComments(ticket)
+	if err != nil {
+		return fmt.Errorf("获取 ticket %s 的评论失败: %w", ticket, err)
+	}
+	comments := jiraclient.FilterComments(jiraclient.ToJiraComments(all), filter)
+
+	switch {
+	case outputJSON:
+		return printJSON(comments)
+	case outputMarkdown:
+		fmt.Print(commentsMarkdown(ticket, comments))
+		return nil
+	}
+
+	if len(comments) == 0 {
+		msg.Info("No comments for %s", ticket)
+		return nil
+	}
+
+	msg.Break()
+	table := prompt.NewTable([]string{"#", "Author", "Created", "Comment"})
+	table.SetRowLine(false)
+	for i, comment := range comments {
+		table.AddRow([]string{
+			fmt.Sprintf("%d", commentsOffset+i+1),
+			userName(comment.Author),
+			formatDate(comment.Created),
+			singleLine(comment.Body, maxCommentPreview),
+		})
+	}
+	table.Render()
+
+	msg.Break()
+	msg.Info("Showing %d of %d comments", len(comments), len(all))
+	return nil
+}
+
+// commentsMarkdown renders the comments as Markdown, one section per comment
+func commentsMarkdown(ticket string, comments []*jiraclient.JiraComment) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# Comments on %s\n", ticket)
+	if len(comments) == 0 {
+		sb.WriteString("\nNo comments.\n")
+		return sb.String()
+	}
+
+	for _, comment := range comments {
+		fmt.Fprintf(&sb, "\n## %s, %s\n\n%s\n", userName(comment.Author), formatDate(comment.Created), strings.TrimSpace(comment.Body))
+	}
+	return sb.String()
+}
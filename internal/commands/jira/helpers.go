@@ -0,0 +1,174 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/config"
+	"github.com/zevwings/workflow/internal/git"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	jiraclient "github.com/zevwings/workflow/internal/jira"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+// Output formats shared by the jira subcommands
+var (
+	outputJSON     bool
+	outputMarkdown bool
+)
+
+// whitespace matches runs of whitespace, used to flatten text into one table cell
+var whitespace = regexp.MustCompile(`\s+`)
+
+// addFormatFlags adds the mutually exclusive --json and --markdown flags
+func addFormatFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&outputJSON, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&outputMarkdown, "markdown", false, "Output as Markdown")
+	cmd.MarkFlagsMutuallyExclusive("json", "markdown")
+}
+
+// setupTicket loads the configuration, creates the Jira client and resolves the ticket key
+func setupTicket(args []string) (*config.GlobalManager, *jiraclient.JiraClient, string, error) {
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	client, err := infrastructureconfig.NewJiraClient(manager)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	ticket, err := resolveTicket(args)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	return manager, client, ticket, nil
+}
+
+// resolveTicket returns the ticket key from the arguments
+//
+// When omitted, the key is taken from the current branch name; if the
+// branch has none, the user is asked for one.
+func resolveTicket(args []string) (string, error) {
+	var ticket string
+	if len(args) > 0 {
+		ticket = jiraclient.NormalizeTicketKey(args[0])
+	}
+
+	if ticket == "" {
+		ticket = ticketFromBranch()
+	}
+
+	if ticket == "" {
+		input, err := prompt.Input().
+			Prompt("Jira ticket (e.g. PROJ-123):").
+			Validate(prompt.ValidateRequired()).
+			Run()
+		if err != nil {
+			return "", fmt.Errorf("输入 Jira ticket 失败: %w", err)
+		}
+		ticket = jiraclient.NormalizeTicketKey(input)
+	}
+
+	if err := jiraclient.ValidateTicketKey(ticket); err != nil {
+		return "", err
+	}
+	return ticket, nil
+}
+
+// ticketFromBranch extracts the ticket key from the current branch name
+func ticketFromBranch() string {
+	gitRepo, err := git.OpenCurrent()
+	if err != nil {
+		return ""
+	}
+	branch, err := gitRepo.CurrentBranch()
+	if err != nil {
+		return ""
+	}
+
+	ticket := jiraclient.ExtractTicketKey(branch)
+	if ticket != "" {
+		prompt.GetMessage().Info("Using ticket %s from branch %s", ticket, branch)
+	}
+	return ticket
+}
+
+// ticketURL builds the browse URL of a Jira ticket
+func ticketURL(manager *config.GlobalManager, ticket string) string {
+	if manager.JiraConfig == nil || manager.JiraConfig.ServiceAddress == "" {
+		return ""
+	}
+	return strings.TrimSuffix(manager.JiraConfig.ServiceAddress, "/") + "/browse/" + ticket
+}
+
+// printJSON prints the value as indented JSON
+func printJSON(value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化 JSON 失败: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// userName returns the display name of a user, or "-" when unset
+func userName(user *jiraclient.JiraUser) string {
+	if user == nil || user.DisplayName == "" {
+		return "-"
+	}
+	return user.DisplayName
+}
+
+// formatDate formats a Jira timestamp in local time, keeping it as-is when it cannot be parsed
+func formatDate(value string) string {
+	if value == "" {
+		return "-"
+	}
+	t, err := jiraclient.ParseTime(value)
+	if err != nil {
+		return value
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// valueOrDash dereferences an optional string, returning "-" when unset
+func valueOrDash(value *string) string {
+	if value == nil || *value == "" {
+		return "-"
+	}
+	return *value
+}
+
+// singleLine collapses whitespace and shortens text to at most n characters
+func singleLine(text string, n int) string {
+	text = strings.TrimSpace(whitespace.ReplaceAllString(text, " "))
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// escapeMarkdownCell escapes text for use in a Markdown table cell
+func escapeMarkdownCell(text string) string {
+	return strings.ReplaceAll(whitespace.ReplaceAllString(strings.TrimSpace(text), " "), "|", `\|`)
+}
+
+// parseSince parses a --since value given as a date or an RFC 3339 timestamp
+func parseSince(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("无效的日期: %s（格式应为 YYYY-MM-DD 或 RFC 3339）", value)
+}
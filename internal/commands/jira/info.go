@@ -0,0 +1,157 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	jiraclient "github.com/zevwings/workflow/internal/jira"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+// NewInfoCmd creates the jira info command
+func NewInfoCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info [TICKET]",
+		Short: "Show a Jira ticket",
+		Long: `Show the summary, status, people, dates and description of a Jira ticket.
+
+Without TICKET, the key in the current branch name is used.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runInfo,
+	}
+
+	addFormatFlags(cmd)
+
+	return cmd
+}
+
+func runInfo(cmd *cobra.Command, args []string) error {
+	manager, client, ticket, err := setupTicket(args)
+	if err != nil {
+		return err
+	}
+
+	issue, err := client.GetTicketInfo(ticket)
+	if err != nil {
+		return fmt.Errorf("获取 ticket %s 失败: %w", ticket, err)
+	}
+	info := jiraclient.ToJiraIssue(issue)
+	url := ticketURL(manager, info.Key)
+
+	switch {
+	case outputJSON:
+		return printJSON(info)
+	case outputMarkdown:
+		fmt.Print(infoMarkdown(info, url))
+	default:
+		printInfo(info, url)
+	}
+	return nil
+}
+
+// infoField is one labelled value of a ticket
+type infoField struct {
+	name  string
+	value string
+}
+
+// infoFields lists the fields shown by the table and Markdown views
+func infoFields(issue *jiraclient.JiraIssue, url string) []infoField {
+	fields := issue.Fields
+
+	issueType := "-"
+	if fields.IssueType != nil {
+		issueType = fields.IssueType.Name
+	}
+	priority := "-"
+	if fields.Priority != nil {
+		priority = fields.Priority.Name
+	}
+	parent := "-"
+	if fields.Parent != nil {
+		parent = fields.Parent.Key
+	}
+
+	components := make([]string, 0, len(fields.Components))
+	for _, component := range fields.Components {
+		components = append(components, component.Name)
+	}
+	versions := make([]string, 0, len(fields.FixVersions))
+	for _, version := range fields.FixVersions {
+		versions = append(versions, version.Name)
+	}
+
+	result := []infoField{
+		{"Type", issueType},
+		{"Status", fields.Status.Name},
+		{"Priority", priority},
+		{"Assignee", userName(fields.Assignee)},
+		{"Reporter", userName(fields.Reporter)},
+		{"Labels", joinOrDash(fields.Labels)},
+		{"Components", joinOrDash(components)},
+		{"Fix Versions", joinOrDash(versions)},
+		{"Parent", parent},
+		{"Subtasks", fmt.Sprintf("%d", len(fields.Subtasks))},
+		{"Created", formatDate(valueOrEmpty(fields.Created))},
+		{"Updated", formatDate(valueOrEmpty(fields.Updated))},
+	}
+	if url != "" {
+		result = append(result, infoField{"URL", url})
+	}
+	return result
+}
+
+// printInfo prints the ticket as a table followed by its description
+func printInfo(issue *jiraclient.JiraIssue, url string) {
+	msg := prompt.GetMessage()
+
+	msg.Break()
+	msg.Info("%s: %s", issue.Key, issue.Fields.Summary)
+	msg.Break()
+
+	table := prompt.NewTable([]string{"Field", "Value"})
+	table.SetRowLine(false)
+	for _, field := range infoFields(issue, url) {
+		table.AddRow([]string{field.name, field.value})
+	}
+	table.Render()
+
+	if description := strings.TrimSpace(valueOrEmpty(issue.Fields.Description)); description != "" {
+		msg.Break()
+		msg.Break('-', 40, "Description")
+		msg.Print("%s", description)
+	}
+}
+
+// infoMarkdown renders the ticket as Markdown
+func infoMarkdown(issue *jiraclient.JiraIssue, url string) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# %s: %s\n\n", issue.Key, issue.Fields.Summary)
+	sb.WriteString("| Field | Value |\n|---|---|\n")
+	for _, field := range infoFields(issue, url) {
+		fmt.Fprintf(&sb, "| %s | %s |\n", field.name, escapeMarkdownCell(field.value))
+	}
+
+	if description := strings.TrimSpace(valueOrEmpty(issue.Fields.Description)); description != "" {
+		fmt.Fprintf(&sb, "\n## Description\n\n%s\n", description)
+	}
+	return sb.String()
+}
+
+// joinOrDash joins the values with commas, returning "-" when there are none
+func joinOrDash(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ", ")
+}
+
+// valueOrEmpty dereferences an optional string
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,28 @@
+package jira
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewJiraCmd creates the jira command
+func NewJiraCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jira",
+		Short: "Jira ticket operations",
+		Long: `Show Jira tickets, their related issues, change history and comments,
+and download their attachments.
+
+Commands that take a ticket key fall back to the key in the current branch
+name (e.g. feature/PROJ-123-login), and prompt for one otherwise.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(NewInfoCmd())
+	cmd.AddCommand(NewRelatedCmd())
+	cmd.AddCommand(NewChangelogCmd())
+	cmd.AddCommand(NewCommentsCmd())
+	cmd.AddCommand(NewAttachmentsCmd())
+	cmd.AddCommand(NewCleanCmd())
+
+	return cmd
+}
@@ -0,0 +1,90 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	jiraclient "github.com/zevwings/workflow/internal/jira"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+// NewRelatedCmd creates the jira related command
+func NewRelatedCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "related [TICKET]",
+		Short: "Show issues related to a Jira ticket",
+		Long: `Show the issues linked to a Jira ticket, its subtasks, and its parent
+issues up to the epic.
+
+Without TICKET, the key in the current branch name is used.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runRelated,
+	}
+
+	addFormatFlags(cmd)
+
+	return cmd
+}
+
+func runRelated(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	_, client, ticket, err := setupTicket(args)
+	if err != nil {
+		return err
+	}
+
+	related, err := client.GetRelated(ticket)
+	if err != nil {
+		return fmt.Errorf("获取 ticket %s 的关联信息失败: %w", ticket, err)
+	}
+
+	switch {
+	case outputJSON:
+		return printJSON(related)
+	case outputMarkdown:
+		fmt.Print(relatedMarkdown(ticket, related))
+		return nil
+	}
+
+	if len(related) == 0 {
+		msg.Info("No related issues for %s", ticket)
+		return nil
+	}
+
+	msg.Break()
+	table := prompt.NewTable([]string{"Relation", "Key", "Summary", "Status"})
+	table.SetRowLine(false)
+	for _, issue := range related {
+		table.AddRow([]string{issue.Relation, issue.Key, issue.Summary, dashIfEmpty(issue.Status)})
+	}
+	table.Render()
+	return nil
+}
+
+// relatedMarkdown renders the related issues as a Markdown table
+func relatedMarkdown(ticket string, related []*jiraclient.JiraRelatedIssue) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# Related issues of %s\n\n", ticket)
+	if len(related) == 0 {
+		sb.WriteString("No related issues.\n")
+		return sb.String()
+	}
+
+	sb.WriteString("| Relation | Key | Summary | Status |\n|---|---|---|---|\n")
+	for _, issue := range related {
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n",
+			issue.Relation, issue.Key, escapeMarkdownCell(issue.Summary), dashIfEmpty(issue.Status))
+	}
+	return sb.String()
+}
+
+// dashIfEmpty returns "-" for empty values
+func dashIfEmpty(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
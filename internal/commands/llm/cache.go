@@ -0,0 +1,99 @@
+package llm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/config"
+	infrastructurellm "github.com/zevwings/workflow/internal/infrastructure/llm"
+	"github.com/zevwings/workflow/internal/prompt"
+	"github.com/zevwings/workflow/internal/util"
+)
+
+// NewCacheCmd creates the llm cache command
+func NewCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect and clear the LLM response cache",
+		Long: `Inspect and clear the LLM response cache.
+
+Responses are cached on disk, keyed by a hash of the provider, model, system
+prompt, user prompt and temperature, so running a command again on the same
+diff returns the same text without calling the API. The cache is configured
+in the global config:
+
+  [llm.cache]
+  disabled = false   # turn the cache off
+  ttl_hours = 168    # how long responses are kept (default 7 days)
+  max_size_mb = 50   # size cap, least recently used responses are evicted first
+
+Commands that call the LLM accept --no-cache to skip the cache once.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(newCacheStatsCmd())
+	cmd.AddCommand(newCacheClearCmd())
+
+	return cmd
+}
+
+func newCacheStatsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats",
+		Short: "Show the size and age of the response cache",
+		Args:  cobra.NoArgs,
+		RunE:  runCacheStats,
+	}
+}
+
+func newCacheClearCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached responses",
+		Args:  cobra.NoArgs,
+		RunE:  runCacheClear,
+	}
+}
+
+func runCacheStats(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	responses, err := infrastructurellm.NewResponseCache()
+	if err != nil {
+		return fmt.Errorf("初始化响应缓存失败: %w", err)
+	}
+	stats, err := responses.Stats()
+	if err != nil {
+		return fmt.Errorf("读取响应缓存失败: %w", err)
+	}
+
+	if manager, err := config.Global(); err == nil && manager.LLMConfig.Cache.Disabled {
+		msg.Warning("The response cache is disabled ([llm.cache] disabled = true)")
+	}
+
+	msg.Info("Directory: %s", stats.Dir)
+	msg.Info("Entries:   %d (%d expired)", stats.Entries, stats.Expired)
+	msg.Info("Size:      %s of %s", util.FormatSize(stats.Size), util.FormatSize(stats.MaxSize))
+	msg.Info("TTL:       %s", stats.TTL)
+	if stats.Entries > 0 {
+		now := time.Now()
+		msg.Info("Oldest:    %s", util.FormatAge(now.Sub(stats.Oldest)))
+		msg.Info("Newest:    %s", util.FormatAge(now.Sub(stats.Newest)))
+	}
+	return nil
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	responses, err := infrastructurellm.NewResponseCache()
+	if err != nil {
+		return fmt.Errorf("初始化响应缓存失败: %w", err)
+	}
+	removed, err := responses.Clear()
+	if err != nil {
+		return fmt.Errorf("清空响应缓存失败: %w", err)
+	}
+
+	prompt.GetMessage().Success("Removed %d cached response(s)", removed)
+	return nil
+}
@@ -0,0 +1,21 @@
+package llm
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewLLMCmd creates the llm command
+func NewLLMCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "llm",
+		Short: "LLM prompt, response cache and usage management",
+		Long:  `Inspect and customize the prompts sent to the LLM, manage the response cache, and report token usage and cost.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(NewPromptsCmd())
+	cmd.AddCommand(NewCacheCmd())
+	cmd.AddCommand(NewUsageCmd())
+
+	return cmd
+}
@@ -0,0 +1,241 @@
+package llm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/git"
+	infrastructurellm "github.com/zevwings/workflow/internal/infrastructure/llm"
+	llmclient "github.com/zevwings/workflow/internal/llm"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+var (
+	showDefault bool
+	ejectUser   bool
+	ejectForce  bool
+)
+
+// NewPromptsCmd creates the llm prompts command
+func NewPromptsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prompts",
+		Short: "Inspect and override prompt templates",
+		Long: `Inspect and override the prompt templates sent to the LLM.
+
+Each template is looked up in three layers, the first match wins:
+  repo      .workflow/prompts/<name> in the current repository
+  user      prompts/<name> in the workflow config directory
+  embedded  the default compiled into workflow
+
+Templates use Go text/template syntax and can reference:
+  {{.Language}} {{.LanguageCode}}  output language
+  {{.Repo}} {{.Branch}} {{.Ticket}}  repository, branch and Jira ticket
+  {{.Files}} {{.Additions}} {{.Deletions}}  stats of the diff being sent
+
+Use 'eject' to copy a default out for editing and 'diff' to compare an
+override with the default it replaces.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(newPromptsListCmd())
+	cmd.AddCommand(newPromptsShowCmd())
+	cmd.AddCommand(newPromptsEjectCmd())
+	cmd.AddCommand(newPromptsDiffCmd())
+
+	return cmd
+}
+
+func newPromptsListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List prompt templates and the layer each one comes from",
+		Args:  cobra.NoArgs,
+		RunE:  runPromptsList,
+	}
+}
+
+func newPromptsShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show <name>",
+		Short: "Print the prompt template in effect",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runPromptsShow,
+	}
+
+	cmd.Flags().BoolVar(&showDefault, "default", false, "Print the embedded default instead")
+
+	return cmd
+}
+
+func newPromptsEjectCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "eject <name>",
+		Short: "Copy an embedded prompt template out for editing",
+		Long: `Copy the embedded default of a prompt template to .workflow/prompts/ in
+the current repository, or to the user prompts directory with --user.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runPromptsEject,
+	}
+
+	cmd.Flags().BoolVar(&ejectUser, "user", false, "Eject to the user prompts directory instead of the repository")
+	cmd.Flags().BoolVarP(&ejectForce, "force", "f", false, "Overwrite an existing template")
+
+	return cmd
+}
+
+func newPromptsDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff [name]",
+		Short: "Show how overridden prompt templates differ from the defaults",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runPromptsDiff,
+	}
+}
+
+func runPromptsList(cmd *cobra.Command, args []string) error {
+	infrastructurellm.ConfigurePrompts()
+
+	templates, err := llmclient.ResolvePromptTemplates()
+	if err != nil {
+		return fmt.Errorf("获取模板列表失败: %w", err)
+	}
+
+	table := prompt.NewTable([]string{"Template", "Source", "Path"})
+	table.SetRowLine(false)
+	for _, template := range templates {
+		table.AddRow([]string{template.Name, template.Source, template.Path})
+	}
+	table.Render()
+	return nil
+}
+
+func runPromptsShow(cmd *cobra.Command, args []string) error {
+	infrastructurellm.ConfigurePrompts()
+	name := templateName(args[0])
+
+	if showDefault {
+		content, err := llmclient.LoadEmbeddedPromptTemplate(name)
+		if err != nil {
+			return err
+		}
+		fmt.Print(content)
+		return nil
+	}
+
+	template, err := llmclient.ResolvePromptTemplate(name)
+	if err != nil {
+		return err
+	}
+	prompt.GetMessage().Info("%s (%s: %s)", template.Name, template.Source, template.Path)
+	fmt.Print(template.Content)
+	return nil
+}
+
+func runPromptsEject(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+	name := templateName(args[0])
+
+	content, err := llmclient.LoadEmbeddedPromptTemplate(name)
+	if err != nil {
+		return err
+	}
+
+	dir, err := ejectDir()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, name)
+
+	if _, err := os.Stat(path); err == nil && !ejectForce {
+		return fmt.Errorf("模板已存在 (%s)，使用 --force 覆盖", path)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("创建模板目录失败: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("写入模板失败: %w", err)
+	}
+
+	msg.Success("Ejected %s to %s", name, path)
+	return nil
+}
+
+func runPromptsDiff(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+	infrastructurellm.ConfigurePrompts()
+
+	var templates []*llmclient.PromptTemplate
+	if len(args) == 1 {
+		template, err := llmclient.ResolvePromptTemplate(templateName(args[0]))
+		if err != nil {
+			return err
+		}
+		templates = append(templates, template)
+	} else {
+		all, err := llmclient.ResolvePromptTemplates()
+		if err != nil {
+			return fmt.Errorf("获取模板列表失败: %w", err)
+		}
+		templates = all
+	}
+
+	overridden := 0
+	for _, template := range templates {
+		if template.Source == llmclient.PromptSourceEmbedded {
+			if len(args) == 1 {
+				msg.Info("%s uses the embedded default", template.Name)
+			}
+			continue
+		}
+		overridden++
+
+		embedded, err := llmclient.LoadEmbeddedPromptTemplate(template.Name)
+		if err != nil {
+			return err
+		}
+		patch, err := git.DiffContents(template.Name, template.Name, []byte(embedded), []byte(template.Content))
+		if err != nil {
+			return fmt.Errorf("比较模板失败: %w", err)
+		}
+		if patch == "" {
+			msg.Info("%s (%s) is identical to the embedded default", template.Name, template.Source)
+			continue
+		}
+		msg.Info("%s (%s: %s)", template.Name, template.Source, template.Path)
+		fmt.Print(patch)
+	}
+
+	if len(args) == 0 && overridden == 0 {
+		msg.Info("No prompt templates are overridden")
+	}
+	return nil
+}
+
+// ejectDir returns the directory eject writes to
+func ejectDir() (string, error) {
+	if ejectUser {
+		dir, err := infrastructurellm.UserPromptsDir()
+		if err != nil {
+			return "", fmt.Errorf("获取配置目录失败: %w", err)
+		}
+		return dir, nil
+	}
+
+	gitRepo, err := git.OpenCurrent()
+	if err != nil {
+		return "", fmt.Errorf("不在 Git 仓库中（使用 --user 导出到用户目录）: %w", err)
+	}
+	return infrastructurellm.RepoPromptsDir(gitRepo), nil
+}
+
+// templateName accepts template names with or without the .md extension
+func templateName(name string) string {
+	if strings.HasSuffix(name, ".md") {
+		return name
+	}
+	return name + ".md"
+}
@@ -0,0 +1,154 @@
+package llm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	infrastructurellm "github.com/zevwings/workflow/internal/infrastructure/llm"
+	"github.com/zevwings/workflow/internal/llm"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+var (
+	usageSince   string
+	usageGroupBy string
+)
+
+// usageGroupHeaders header of the first column for each grouping
+var usageGroupHeaders = map[string]string{
+	llm.UsageGroupByModel:   "Model",
+	llm.UsageGroupByFeature: "Feature",
+	llm.UsageGroupByRepo:    "Repository",
+}
+
+// NewUsageCmd creates the llm usage command
+func NewUsageCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Show LLM token usage and estimated cost",
+		Long: `Show the token usage and estimated cost of LLM calls.
+
+Every API call is recorded with its model, feature (pr-create, pr-summarize,
+pr-reword, file-summary, commit, translate), repository, tokens and latency.
+Responses served from the response cache are not recorded. When the API does
+not report token usage, the tokens are estimated.
+
+Costs are estimated from the prices in the global config, in USD per million
+tokens. Entries are matched like [[llm.budgets]], and approximate prices of
+the default models are built in:
+
+  [[llm.prices]]
+  model = "gpt-4o"
+  input = 2.5
+  output = 10.0
+
+  [llm.usage]
+  disabled = false       # stop recording usage
+  monthly_budget = 20.0  # warn before calls once this month's cost reaches it
+
+--since accepts "month" (default), "all", a number of days ("7d"), a duration
+("12h") or a date ("2024-05-01").`,
+		Args: cobra.NoArgs,
+		RunE: runUsage,
+	}
+
+	cmd.Flags().StringVar(&usageSince, "since", "month", "Only include calls since this time")
+	cmd.Flags().StringVar(&usageGroupBy, "by", llm.UsageGroupByModel, fmt.Sprintf("Group by %s", strings.Join(llm.UsageGroupByOptions, ", ")))
+
+	return cmd
+}
+
+func runUsage(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	now := time.Now()
+	since, err := llm.ParseUsageSince(usageSince, now)
+	if err != nil {
+		return err
+	}
+
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+	llmConfig := manager.LLMConfig
+	pricer := infrastructurellm.NewUsagePricer(llmConfig)
+
+	store, err := infrastructurellm.NewUsageStore()
+	if err != nil {
+		return fmt.Errorf("初始化用量记录失败: %w", err)
+	}
+	records, err := store.Load(since)
+	if err != nil {
+		return fmt.Errorf("读取用量记录失败: %w", err)
+	}
+
+	if llmConfig.Usage.Disabled {
+		msg.Warning("Usage recording is disabled ([llm.usage] disabled = true)")
+	}
+
+	report, err := llm.SummarizeUsage(records, usageGroupBy, pricer)
+	if err != nil {
+		return err
+	}
+
+	if since.IsZero() {
+		msg.Info("LLM usage: %d call(s)", report.Total.Calls)
+	} else {
+		msg.Info("LLM usage since %s: %d call(s)", since.Format("2006-01-02 15:04"), report.Total.Calls)
+	}
+
+	if report.Total.Calls > 0 {
+		table := prompt.NewTable([]string{usageGroupHeaders[usageGroupBy], "Calls", "Prompt tokens", "Completion tokens", "Avg latency", "Cost"})
+		table.SetRowLine(false)
+		for _, row := range report.Rows {
+			table.AddRow(usageRow(row.Key, row))
+		}
+		table.AddRow(usageRow("Total", report.Total))
+		table.Render()
+
+		if report.Total.Estimated > 0 {
+			msg.Info("%d call(s) did not report token usage, their tokens are estimated", report.Total.Estimated)
+		}
+		if report.Total.Unpriced > 0 {
+			msg.Warning("%d call(s) have no price, add their model to [[llm.prices]] in the global config", report.Total.Unpriced)
+		}
+	}
+
+	if budget := llmConfig.Usage.MonthlyBudget; budget > 0 {
+		monthRecords, err := store.Load(llm.UsageMonthStart(now))
+		if err != nil {
+			return fmt.Errorf("读取用量记录失败: %w", err)
+		}
+		spent := llm.UsageSpent(monthRecords, pricer)
+		if spent >= budget {
+			msg.Warning("This month: %s of the %s monthly budget", llm.FormatUsageCost(spent), llm.FormatUsageCost(budget))
+		} else {
+			msg.Info("This month: %s of the %s monthly budget", llm.FormatUsageCost(spent), llm.FormatUsageCost(budget))
+		}
+	}
+
+	return nil
+}
+
+// usageRow formats a row of the usage table
+//
+// The cost is "-" when none of the calls has a price.
+func usageRow(key string, row llm.UsageRow) []string {
+	cost := "-"
+	if row.Unpriced < row.Calls {
+		cost = llm.FormatUsageCost(row.Cost)
+	}
+	return []string{
+		key,
+		strconv.Itoa(row.Calls),
+		strconv.Itoa(row.PromptTokens),
+		strconv.Itoa(row.CompletionTokens),
+		row.AverageLatency().Round(time.Millisecond).String(),
+		cost,
+	}
+}
@@ -0,0 +1,46 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/commands/repo"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+// NewApproveCmd creates the pr approve command
+func NewApproveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approve [PR_ID]",
+		Short: "Approve a pull request",
+		Long: `Approve a pull request.
+
+Without PR_ID, the open pull request of the current branch is used.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runApprove,
+	}
+
+	return cmd
+}
+
+func runApprove(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	if err := repo.Ensure(); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	provider, prID, err := resolvePullRequest(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	if err := provider.ApprovePullRequest(ctx, prID); err != nil {
+		return fmt.Errorf("批准 PR %s 失败: %w", prID, err)
+	}
+
+	msg.Success("Approved PR #%s", prID)
+	return nil
+}
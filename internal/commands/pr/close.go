@@ -0,0 +1,46 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/commands/repo"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+// NewCloseCmd creates the pr close command
+func NewCloseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "close [PR_ID]",
+		Short: "Close a pull request without merging",
+		Long: `Close a pull request without merging it.
+
+Without PR_ID, the open pull request of the current branch is used.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runClose,
+	}
+
+	return cmd
+}
+
+func runClose(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	if err := repo.Ensure(); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	provider, prID, err := resolvePullRequest(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	if err := provider.ClosePullRequest(ctx, prID); err != nil {
+		return fmt.Errorf("关闭 PR %s 失败: %w", prID, err)
+	}
+
+	msg.Success("Closed PR #%s", prID)
+	return nil
+}
@@ -0,0 +1,64 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/commands/repo"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+var commentMessage string
+
+// NewCommentCmd creates the pr comment command
+func NewCommentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "comment [PR_ID]",
+		Short: "Comment on a pull request",
+		Long: `Add a comment to a pull request.
+
+Without PR_ID, the open pull request of the current branch is used.
+Without --message, the comment is asked for interactively.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runComment,
+	}
+
+	cmd.Flags().StringVarP(&commentMessage, "message", "m", "", "Comment body")
+
+	return cmd
+}
+
+func runComment(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	if err := repo.Ensure(); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	provider, prID, err := resolvePullRequest(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	body := strings.TrimSpace(commentMessage)
+	if body == "" {
+		body, err = prompt.Input().
+			Prompt("Comment:").
+			Validate(prompt.ValidateRequired()).
+			Run()
+		if err != nil {
+			return fmt.Errorf("获取评论内容失败: %w", err)
+		}
+		body = strings.TrimSpace(body)
+	}
+
+	if err := provider.AddComment(ctx, prID, body); err != nil {
+		return fmt.Errorf("评论 PR %s 失败: %w", prID, err)
+	}
+
+	msg.Success("Commented on PR #%s", prID)
+	return nil
+}
@@ -0,0 +1,418 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/commands/repo"
+	"github.com/zevwings/workflow/internal/config"
+	"github.com/zevwings/workflow/internal/git"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	infrastructurellm "github.com/zevwings/workflow/internal/infrastructure/llm"
+	"github.com/zevwings/workflow/internal/jira"
+	"github.com/zevwings/workflow/internal/llm"
+	"github.com/zevwings/workflow/internal/prompt"
+	"github.com/zevwings/workflow/internal/util"
+)
+
+var (
+	createTitle       string
+	createDescription string
+	createDryRun      bool
+	createNoCache     bool
+	createAll         bool
+)
+
+// NewCreateCmd creates the pr create command
+func NewCreateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create [JIRA_TICKET]",
+		Short: "Create a pull request",
+		Long: `Create a pull request from the current changes.
+
+The branch name, PR title and description are generated by the LLM from the
+staged changes and the commits of the current branch. Staged changes are
+committed (to a new branch when on the default branch), and the branch is
+pushed and opened as a pull request against the default branch.
+
+Unstaged and untracked files are left alone unless --all is given, which
+stages and commits every change like 'git add -A'. The files to be committed
+are listed in the plan, also with --dry-run.
+
+LLM responses are cached ([llm.cache] in the global config), so the same
+diff produces the same proposal; use --no-cache to call the LLM again.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runCreate,
+	}
+
+	cmd.Flags().StringVarP(&createTitle, "title", "t", "", "PR title (generated from the Jira ticket summary by default)")
+	cmd.Flags().StringVarP(&createDescription, "description", "d", "", "PR description (defaults to the generated description)")
+	cmd.Flags().BoolVar(&createDryRun, "dry-run", false, "Print the plan without touching the remote")
+	cmd.Flags().BoolVar(&createNoCache, "no-cache", false, "Call the LLM even if a cached response exists")
+	cmd.Flags().BoolVarP(&createAll, "all", "a", false, "Stage and commit all changes, including untracked files")
+
+	return cmd
+}
+
+// createPlan describes everything pr create is about to do
+type createPlan struct {
+	ticket        string
+	ticketURL     string
+	baseBranch    string
+	currentBranch string
+	branchName    string
+	newBranch     bool
+	commitChanges bool
+	title         string
+	body          string
+
+	// stageAll stages every change (--all) before committing
+	stageAll bool
+	// commitFiles files included in the commit
+	commitFiles []git.DiffFileStat
+}
+
+func runCreate(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	if createNoCache {
+		infrastructurellm.DisableCache()
+	}
+
+	if err := repo.Ensure(); err != nil {
+		return err
+	}
+
+	gitRepo, err := git.OpenCurrent()
+	if err != nil {
+		return fmt.Errorf("不在 Git 仓库中: %w", err)
+	}
+
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+
+	// 1. Resolve Jira ticket
+	ticket := ""
+	if len(args) > 0 {
+		ticket = jira.NormalizeTicketKey(args[0])
+		if err := jira.ValidateTicketKey(ticket); err != nil {
+			return err
+		}
+	}
+
+	// 2. Collect the diff
+	plan := &createPlan{ticket: ticket}
+	diff, err := collectCreateDiff(gitRepo, plan)
+	if err != nil {
+		return err
+	}
+
+	// 3. Resolve the title used as LLM input
+	title, err := resolveCreateTitle(manager, ticket)
+	if err != nil {
+		return err
+	}
+
+	// 4. Generate branch name, PR title and description
+	// A --title is used as is; the LLM then only provides what is still missing
+	// (branch name and description).
+	userTitle := strings.TrimSpace(createTitle)
+	content := &llm.PullRequestContent{}
+	if userTitle == "" || plan.newBranch || createDescription == "" {
+		if _, _, _, err := manager.LLMConfig.CurrentProvider(); err != nil {
+			return fmt.Errorf("LLM 未配置（请先运行 'workflow setup'）: %w", err)
+		}
+
+		existingBranches, err := localBranchNames(gitRepo)
+		if err != nil {
+			return err
+		}
+
+		llmClient := infrastructurellm.NewPullRequestLLMClient()
+		promptDiff, err := diffForPrompt(context.Background(), llmClient, compactDiff(manager, llm.FeaturePRCreate, diff))
+		if err != nil {
+			return err
+		}
+
+		spinner := prompt.NewSpinner("Generating pull request content...")
+		spinner.Start()
+		content, err = llmClient.GenerateContent(title, existingBranches, promptDiff)
+		spinner.Stop()
+		if err != nil {
+			return fmt.Errorf("生成 PR 内容失败: %w", err)
+		}
+	}
+
+	// 5. Build the plan
+	repoManager, err := infrastructureconfig.NewRepoManagerWithDefaultGit("")
+	if err != nil {
+		return fmt.Errorf("初始化配置管理器失败: %w", err)
+	}
+	if err := repoManager.Load(); err != nil {
+		msg.Debug("Failed to load repository config, using defaults")
+	}
+
+	if plan.newBranch {
+		plan.branchName = buildBranchName(repoManager.GetBranchPrefix(), ticket, content.BranchName)
+	}
+
+	plan.title = content.PRTitle
+	if userTitle != "" {
+		plan.title = userTitle
+	}
+	if ticket != "" {
+		plan.title = fmt.Sprintf("%s: %s", ticket, plan.title)
+		plan.ticketURL = jiraTicketURL(manager, ticket)
+	}
+
+	description := createDescription
+	if description == "" && content.Description != nil {
+		description = *content.Description
+	}
+	plan.body = renderPullRequestBody(repoManager.GetTemplateConfig(), plan, description)
+
+	printCreatePlan(plan)
+
+	if createDryRun {
+		msg.Break()
+		msg.Info("Dry run: no changes were made")
+		return nil
+	}
+
+	// 6. Execute
+	return executeCreatePlan(gitRepo, manager, plan)
+}
+
+// collectCreateDiff returns the diff the PR is built from and fills in the branch part of the plan
+//
+// The diff is made of the commits of the current branch relative to the
+// default branch and the staged changes, which are committed (to a new
+// branch when on the default branch). With --all, unstaged and untracked
+// changes are staged and committed as well.
+func collectCreateDiff(gitRepo *git.Repository, plan *createPlan) (string, error) {
+	msg := prompt.GetMessage()
+
+	currentBranch, err := gitRepo.CurrentBranch()
+	if err != nil {
+		return "", fmt.Errorf("获取当前分支失败: %w", err)
+	}
+	plan.currentBranch = currentBranch
+
+	baseBranch, err := gitRepo.GetDefaultBranch()
+	if err != nil {
+		return "", fmt.Errorf("获取默认分支失败: %w", err)
+	}
+	plan.baseBranch = baseBranch
+
+	var changes *git.Diff
+	if createAll {
+		changes, err = gitRepo.DiffWorktree()
+	} else {
+		changes, err = gitRepo.DiffStaged()
+	}
+	if err != nil {
+		return "", fmt.Errorf("获取工作区差异失败: %w", err)
+	}
+
+	if !createAll {
+		if worktree, err := gitRepo.DiffWorktree(); err == nil && len(worktree.Files) > len(changes.Files) {
+			msg.Warning("Unstaged and untracked changes are not included, stage them or use --all")
+		}
+	}
+
+	var diffs []string
+	if currentBranch != baseBranch {
+		branchDiff, err := gitRepo.BranchDiff(baseBranch)
+		if err != nil {
+			return "", fmt.Errorf("获取分支差异失败: %w", err)
+		}
+		if strings.TrimSpace(branchDiff) != "" {
+			diffs = append(diffs, branchDiff)
+		}
+	}
+	if !changes.IsEmpty() {
+		diffs = append(diffs, changes.Patch)
+		plan.commitChanges = true
+		plan.stageAll = createAll
+		plan.commitFiles = changes.Files
+	}
+
+	if len(diffs) == 0 {
+		if currentBranch == baseBranch {
+			return "", fmt.Errorf("没有可用于创建 PR 的变更（当前位于默认分支 %s 且没有已暂存的变更，请先 git add 或使用 --all）", baseBranch)
+		}
+		return "", fmt.Errorf("分支 %s 相对于 %s 没有变更", currentBranch, baseBranch)
+	}
+
+	plan.newBranch = currentBranch == baseBranch
+	if !plan.newBranch {
+		plan.branchName = currentBranch
+	}
+
+	return strings.Join(diffs, "\n"), nil
+}
+
+// resolveCreateTitle resolves the title used as LLM input
+//
+// Order: --title flag, Jira ticket summary, interactive input. A --title is
+// also used as the PR title instead of the generated one.
+func resolveCreateTitle(manager *config.GlobalManager, ticket string) (string, error) {
+	if strings.TrimSpace(createTitle) != "" {
+		return strings.TrimSpace(createTitle), nil
+	}
+
+	if ticket != "" {
+		client, err := infrastructureconfig.NewJiraClient(manager)
+		if err != nil {
+			return "", err
+		}
+		issue, err := client.GetTicketInfo(ticket)
+		if err != nil {
+			return "", fmt.Errorf("获取 Jira ticket %s 失败: %w", ticket, err)
+		}
+		if issue.Fields != nil && strings.TrimSpace(issue.Fields.Summary) != "" {
+			return strings.TrimSpace(issue.Fields.Summary), nil
+		}
+	}
+
+	title, err := prompt.Input().
+		Prompt("PR title:").
+		Validate(prompt.ValidateRequired()).
+		Run()
+	if err != nil {
+		return "", fmt.Errorf("获取 PR 标题失败: %w", err)
+	}
+
+	return strings.TrimSpace(title), nil
+}
+
+// localBranchNames lists local branch names
+func localBranchNames(gitRepo *git.Repository) ([]string, error) {
+	branches, err := gitRepo.ListBranches()
+	if err != nil {
+		return nil, fmt.Errorf("获取分支列表失败: %w", err)
+	}
+
+	names := make([]string, 0, len(branches))
+	for _, branch := range branches {
+		names = append(names, branch.Name)
+	}
+	return names, nil
+}
+
+// buildBranchName joins the branch prefix, Jira ticket and generated branch name
+func buildBranchName(prefix, ticket, generated string) string {
+	name := generated
+	if ticket != "" {
+		name = ticket + "-" + name
+	}
+	if prefix = strings.Trim(prefix, "/ "); prefix != "" {
+		name = prefix + "/" + name
+	}
+	return name
+}
+
+// renderPullRequestBody renders the PR body with the repository's pull request template
+//
+// Supported placeholders: {title}, {description}, {jira_ticket}, {jira_url}, {branch}.
+// Without a template, the description is used, followed by the Jira link.
+func renderPullRequestBody(templateConfig *config.TemplateConfig, plan *createPlan, description string) string {
+	tmpl := ""
+	if templateConfig != nil {
+		if value, ok := templateConfig.PullRequests["default"].(string); ok {
+			tmpl = value
+		}
+	}
+
+	if strings.TrimSpace(tmpl) == "" {
+		body := description
+		if plan.ticketURL != "" {
+			body = strings.TrimSpace(body + "\n\nJira: " + plan.ticketURL)
+		}
+		return body
+	}
+
+	return util.RenderTemplate(tmpl, map[string]string{
+		"title":       plan.title,
+		"description": description,
+		"jira_ticket": plan.ticket,
+		"jira_url":    plan.ticketURL,
+		"branch":      plan.branchName,
+	})
+}
+
+// printCreatePlan prints what pr create is about to do
+func printCreatePlan(plan *createPlan) {
+	msg := prompt.GetMessage()
+
+	msg.Break()
+	msg.Info("Pull Request Plan")
+	msg.Break('-', 40)
+	if plan.newBranch {
+		msg.Info("Branch: %s (new, from %s)", plan.branchName, plan.currentBranch)
+	} else {
+		msg.Info("Branch: %s", plan.branchName)
+	}
+	msg.Info("Base: %s", plan.baseBranch)
+	if plan.commitChanges {
+		msg.Info("Commit: %s", plan.title)
+		for _, file := range plan.commitFiles {
+			msg.Print("  %s %s", file.Status, file.Path)
+		}
+	}
+	msg.Info("Push: %s/%s", defaultRemote, plan.branchName)
+	msg.Info("Title: %s", plan.title)
+	if plan.body != "" {
+		msg.Break()
+		msg.Print("%s", plan.body)
+	}
+}
+
+// executeCreatePlan creates the branch, commits, pushes and opens the pull request
+func executeCreatePlan(gitRepo *git.Repository, manager *config.GlobalManager, plan *createPlan) error {
+	msg := prompt.GetMessage()
+
+	provider, token, err := newPlatformProvider(manager)
+	if err != nil {
+		return err
+	}
+
+	msg.Break()
+
+	if plan.newBranch {
+		if err := gitRepo.CreateAndCheckoutBranch(plan.branchName); err != nil {
+			return fmt.Errorf("创建分支失败: %w", err)
+		}
+		msg.Success("Created branch %s", plan.branchName)
+	}
+
+	if plan.commitChanges {
+		if plan.stageAll {
+			if err := gitRepo.AddAll(); err != nil {
+				return fmt.Errorf("暂存变更失败: %w", err)
+			}
+		}
+		if _, err := gitRepo.Commit(plan.title, nil); err != nil {
+			return fmt.Errorf("提交变更失败: %w", err)
+		}
+		msg.Success("Committed changes")
+	}
+
+	if err := gitRepo.PushWithUpstream(defaultRemote, plan.branchName, infrastructureconfig.RemoteAuth(gitRepo, defaultRemote, provider.GetPlatformName(), token)); err != nil {
+		return fmt.Errorf("推送分支失败: %w", err)
+	}
+	msg.Success("Pushed %s to %s", plan.branchName, defaultRemote)
+
+	baseBranch := plan.baseBranch
+	url, err := provider.CreatePullRequest(context.Background(), plan.title, plan.body, plan.branchName, &baseBranch)
+	if err != nil {
+		return fmt.Errorf("创建 PR 失败: %w", err)
+	}
+
+	msg.Success("Pull request created: %s", url)
+	return nil
+}
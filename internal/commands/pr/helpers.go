@@ -0,0 +1,213 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/zevwings/workflow/internal/config"
+	"github.com/zevwings/workflow/internal/git"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	"github.com/zevwings/workflow/internal/llm"
+	platform "github.com/zevwings/workflow/internal/pr"
+	prhelpers "github.com/zevwings/workflow/internal/pr/helpers"
+	"github.com/zevwings/workflow/internal/pr/provider"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+// defaultRemote is the remote used for pushing branches
+const defaultRemote = "origin"
+
+// newPlatformProvider creates the platform provider for the repository's remote
+//
+// The platform, owner and repository are detected from the git remote,
+// using the [platform.hosts] table for enterprise and self-hosted domains.
+// Returns the provider together with the token it was created with,
+// so that callers can reuse the token for pushing.
+func newPlatformProvider(manager *config.GlobalManager) (platform.PlatformProvider, string, error) {
+	info, err := provider.AutoDetect(provider.WithHostMapping(manager.GetPlatformConfig().Hosts))
+	if err != nil {
+		return nil, "", fmt.Errorf("检测代码托管平台失败: %w", err)
+	}
+
+	token, err := infrastructureconfig.PlatformToken(manager, info)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var opts []provider.Option
+	if info.Platform == provider.PlatformGitLab && manager.GitLabConfig != nil && manager.GitLabConfig.BaseURL != "" {
+		opts = append(opts, provider.WithBaseURL(manager.GitLabConfig.BaseURL))
+	}
+
+	p, err := provider.NewPlatformProviderFromInfo(info, token, opts...)
+	if err != nil {
+		return nil, "", fmt.Errorf("创建平台客户端失败: %w", err)
+	}
+
+	return p, token, nil
+}
+
+// resolvePRID returns the PR number from the arguments
+//
+// The argument can be a number or a pull request URL (see prhelpers.ParsePRID).
+// When omitted, the open pull request whose head is the current branch is
+// used. If several pull requests match, the user picks one.
+func resolvePRID(ctx context.Context, p platform.PlatformProvider, args []string) (string, error) {
+	if len(args) > 0 && strings.TrimSpace(args[0]) != "" {
+		prID, err := prhelpers.ParsePRID(args[0])
+		if err != nil {
+			return "", fmt.Errorf("无效的 PR ID: %w", err)
+		}
+		return prID, nil
+	}
+
+	gitRepo, err := git.OpenCurrent()
+	if err != nil {
+		return "", fmt.Errorf("不在 Git 仓库中: %w", err)
+	}
+	branch, err := gitRepo.CurrentBranch()
+	if err != nil {
+		return "", fmt.Errorf("获取当前分支失败: %w", err)
+	}
+
+	prID, err := prhelpers.ResolvePRForBranch(ctx, p, branch, choosePullRequest)
+	if errors.Is(err, prhelpers.ErrNoPullRequestForBranch) {
+		return "", fmt.Errorf("分支 %s 没有打开的 PR，请指定 PR_ID", branch)
+	}
+	if err != nil {
+		return "", fmt.Errorf("查找分支 %s 的 PR 失败: %w", branch, err)
+	}
+
+	prompt.GetMessage().Info("Using PR #%s for branch %s", prID, branch)
+	return prID, nil
+}
+
+// resolvePullRequest creates the platform provider and resolves the PR ID from the arguments
+func resolvePullRequest(ctx context.Context, args []string) (platform.PlatformProvider, string, error) {
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return nil, "", err
+	}
+
+	p, _, err := newPlatformProvider(manager)
+	if err != nil {
+		return nil, "", err
+	}
+
+	prID, err := resolvePRID(ctx, p, args)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return p, prID, nil
+}
+
+// choosePullRequest lets the user pick one of several pull requests
+func choosePullRequest(prs []*platform.PullRequestInfo) (int, error) {
+	options := make([]string, 0, len(prs))
+	for _, info := range prs {
+		options = append(options, fmt.Sprintf("#%d %s (%s)", info.Number, info.Title, info.Author))
+	}
+
+	index, err := prompt.Select().
+		Prompt("Several pull requests match the current branch:").
+		Options(options).
+		Run()
+	if err != nil {
+		return 0, fmt.Errorf("选择 PR 失败: %w", err)
+	}
+	return index, nil
+}
+
+// jiraTicketURL builds the browse URL of a Jira ticket
+func jiraTicketURL(manager *config.GlobalManager, ticket string) string {
+	if manager.JiraConfig == nil || manager.JiraConfig.ServiceAddress == "" {
+		return ""
+	}
+	return strings.TrimSuffix(manager.JiraConfig.ServiceAddress, "/") + "/browse/" + ticket
+}
+
+// compactDiff compacts the diff to the LLM token budget of a feature and reports what was trimmed
+func compactDiff(manager *config.GlobalManager, feature, diff string) *llm.CompactResult {
+	result := llm.CompactDiff(diff, manager.LLMConfig.DiffTokenBudget(feature))
+
+	msg := prompt.GetMessage()
+	for _, line := range result.Report() {
+		msg.Info("%s", line)
+	}
+
+	return result
+}
+
+// diffForPrompt returns the text sent to the LLM in place of the diff
+//
+// This is the compacted diff, or the file summaries when the compacted diff
+// is still over the token budget.
+func diffForPrompt(ctx context.Context, llmClient *llm.PullRequestLLMClient, result *llm.CompactResult) (string, error) {
+	if !result.OverBudget {
+		return result.Diff, nil
+	}
+
+	summaries, err := summarizeFiles(ctx, llmClient, result)
+	if err != nil {
+		return "", err
+	}
+	return llm.FormatFileSummaries(summaries), nil
+}
+
+// summarizeFiles summarizes the files of a compacted diff one by one
+//
+// The requests are streamed without rendering so that cancelling ctx aborts them.
+func summarizeFiles(ctx context.Context, llmClient *llm.PullRequestLLMClient, result *llm.CompactResult) ([]llm.FileChangeSummary, error) {
+	spinner := prompt.NewSpinner("Summarizing files...")
+	spinner.Start()
+	defer spinner.Stop()
+
+	summaries, err := llmClient.WithStream(ctx, nil, nil).SummarizeFileChanges(result.Files, result.MaxTokens, func(index, total int, path string) {
+		spinner.UpdateMessage(fmt.Sprintf("Summarizing file %d/%d: %s", index+1, total, path))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("总结文件变更失败: %w", err)
+	}
+	return summaries, nil
+}
+
+// interruptContext returns a context that is cancelled when the user presses Ctrl-C
+func interruptContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt)
+}
+
+// streamResponse runs an LLM request and renders its response in the terminal as it arrives
+//
+// Only the given JSON fields of the response are printed. The spinner is shown
+// until the first token arrives, and the request is aborted when ctx is cancelled.
+// When the response is rejected and requested again, the repaired response is
+// rendered below a warning instead of being appended to the rejected one.
+func streamResponse(ctx context.Context, llmClient *llm.PullRequestLLMClient, message string, fields []string, call func(*llm.PullRequestLLMClient) error) error {
+	spinner := prompt.NewSpinner(message)
+	spinner.Start()
+	defer spinner.Stop()
+
+	renderer := llm.NewJSONFieldStream(os.Stdout, fields...)
+	err := call(llmClient.WithStream(ctx, func(delta string) {
+		spinner.Stop()
+		renderer.Write(delta)
+	}, func() {
+		spinner.Stop()
+		if renderer.Written() > 0 {
+			fmt.Println()
+		}
+		prompt.GetMessage().Warning("The response was rejected, asking the model to fix it")
+		renderer.Reset()
+		spinner.Start()
+	}))
+	spinner.Stop()
+	if renderer.Written() > 0 {
+		fmt.Println()
+	}
+	return err
+}
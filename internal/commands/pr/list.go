@@ -0,0 +1,128 @@
+package pr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/commands/repo"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	platform "github.com/zevwings/workflow/internal/pr"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+var (
+	listState  string
+	listLimit  int
+	listAuthor string
+	listJSON   bool
+)
+
+// NewListCmd creates the pr list command
+func NewListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List pull requests",
+		Long: `List pull requests of the repository as a table.
+
+Use --json for machine-readable output.`,
+		Args: cobra.NoArgs,
+		RunE: runList,
+	}
+
+	cmd.Flags().StringVarP(&listState, "state", "s", "open", "Filter by state: open, closed, merged or all")
+	cmd.Flags().IntVarP(&listLimit, "limit", "n", 30, "Maximum number of pull requests to list")
+	cmd.Flags().StringVarP(&listAuthor, "author", "a", "", "Filter by author username")
+	cmd.Flags().BoolVar(&listJSON, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	if err := repo.Ensure(); err != nil {
+		return err
+	}
+
+	state := strings.ToLower(strings.TrimSpace(listState))
+	switch state {
+	case "open", "closed", "merged", "all":
+	default:
+		return fmt.Errorf("不支持的状态: %s（可选: open, closed, merged, all）", listState)
+	}
+	if listLimit <= 0 {
+		return fmt.Errorf("--limit 必须大于 0")
+	}
+
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+
+	provider, _, err := newPlatformProvider(manager)
+	if err != nil {
+		return err
+	}
+
+	prs, err := provider.ListPullRequests(context.Background(), platform.ListOptions{
+		State:  state,
+		Author: strings.TrimSpace(listAuthor),
+		Limit:  listLimit,
+	})
+	if err != nil {
+		return fmt.Errorf("获取 PR 列表失败: %w", err)
+	}
+
+	if listJSON {
+		data, err := json.MarshalIndent(prs, "", "  ")
+		if err != nil {
+			return fmt.Errorf("序列化 PR 列表失败: %w", err)
+		}
+		msg.Print("%s", data)
+		return nil
+	}
+
+	if len(prs) == 0 {
+		msg.Info("No pull requests found")
+		return nil
+	}
+
+	printPullRequestTable(prs)
+	return nil
+}
+
+// printPullRequestTable renders the pull requests as a table
+func printPullRequestTable(prs []*platform.PullRequestInfo) {
+	table := prompt.NewTable([]string{"#", "Title", "Author", "Branch", "State", "Updated"})
+	table.SetRowLine(false)
+
+	for _, info := range prs {
+		state := info.State
+		if info.Draft && state == "open" {
+			state = "draft"
+		}
+		table.AddRow([]string{
+			strconv.Itoa(info.Number),
+			truncate(info.Title, 60),
+			info.Author,
+			fmt.Sprintf("%s -> %s", info.HeadBranch, info.BaseBranch),
+			state,
+			info.UpdatedAt.Local().Format("2006-01-02 15:04"),
+		})
+	}
+
+	table.Render()
+}
+
+// truncate shortens text to at most n runes, marking the cut with an ellipsis
+func truncate(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,224 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/commands/repo"
+	"github.com/zevwings/workflow/internal/git"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	platform "github.com/zevwings/workflow/internal/pr"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+// defaultMergeMethod is used when neither --method nor the repository config sets one
+const defaultMergeMethod = "squash"
+
+var (
+	mergeMethod       string
+	mergeForce        bool
+	mergeDeleteBranch bool
+)
+
+// NewMergeCmd creates the pr merge command
+func NewMergeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "merge [PR_ID]",
+		Short: "Merge a pull request",
+		Long: `Merge a pull request on the hosting platform.
+
+Without PR_ID, the open pull request of the current branch is merged.
+The merge is refused when the pull request is not mergeable or its checks
+are failing, unless --force is given. The merged branch is then deleted
+locally; when it is checked out, the default branch is checked out and
+fast-forwarded first.
+
+The merge method defaults to [merge] method in .workflow/config.toml,
+then to squash. The remote branch is kept unless --delete-branch is given or
+[merge] delete_branch = true is set in .workflow/config.toml.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runMerge,
+	}
+
+	cmd.Flags().StringVarP(&mergeMethod, "method", "m", "", "Merge method: merge, squash or rebase")
+	cmd.Flags().BoolVarP(&mergeForce, "force", "f", false, "Merge even if the pull request is not mergeable or checks are failing")
+	cmd.Flags().BoolVar(&mergeDeleteBranch, "delete-branch", false, "Delete the remote branch after merging (default from [merge] delete_branch)")
+
+	return cmd
+}
+
+func runMerge(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	if err := repo.Ensure(); err != nil {
+		return err
+	}
+
+	gitRepo, err := git.OpenCurrent()
+	if err != nil {
+		return fmt.Errorf("不在 Git 仓库中: %w", err)
+	}
+
+	method, err := resolveMergeMethod()
+	if err != nil {
+		return err
+	}
+
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+
+	provider, token, err := newPlatformProvider(manager)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	prID, err := resolvePRID(ctx, provider, args)
+	if err != nil {
+		return err
+	}
+
+	status, err := provider.GetPullRequestStatus(ctx, prID)
+	if err != nil {
+		return fmt.Errorf("获取 PR %s 失败: %w", prID, err)
+	}
+
+	if err := checkMergeable(status); err != nil {
+		return err
+	}
+
+	deleteBranch := resolveMergeDeleteBranch(cmd)
+	if err := provider.MergePullRequest(ctx, prID, method, deleteBranch); err != nil {
+		return fmt.Errorf("合并 PR #%d 失败: %w", status.Number, err)
+	}
+	msg.Success("Merged PR #%d (%s): %s", status.Number, method, status.Title)
+
+	return cleanupMergedBranch(gitRepo, provider.GetPlatformName(), token, status.HeadBranch)
+}
+
+// resolveMergeMethod resolves the merge method
+//
+// Order: --method flag, repository config, squash.
+func resolveMergeMethod() (string, error) {
+	method := strings.ToLower(strings.TrimSpace(mergeMethod))
+
+	if method == "" {
+		repoManager, err := infrastructureconfig.NewRepoManagerWithDefaultGit("")
+		if err == nil && repoManager.Load() == nil {
+			method = strings.ToLower(strings.TrimSpace(repoManager.GetMergeMethod()))
+		}
+	}
+
+	if method == "" {
+		method = defaultMergeMethod
+	}
+
+	switch method {
+	case "merge", "squash", "rebase":
+		return method, nil
+	default:
+		return "", fmt.Errorf("不支持的合并方式: %s（可选: merge, squash, rebase）", method)
+	}
+}
+
+// resolveMergeDeleteBranch resolves whether the remote branch is deleted after merging
+//
+// Order: --delete-branch flag, repository config, keep the branch.
+func resolveMergeDeleteBranch(cmd *cobra.Command) bool {
+	if cmd.Flags().Changed("delete-branch") {
+		return mergeDeleteBranch
+	}
+
+	repoManager, err := infrastructureconfig.NewRepoManagerWithDefaultGit("")
+	if err == nil && repoManager.Load() == nil {
+		return repoManager.GetMergeDeleteBranch()
+	}
+	return false
+}
+
+// checkMergeable refuses pull requests that are closed, unmergeable or failing checks
+//
+// With --force, only closed and already merged pull requests are refused.
+func checkMergeable(status *platform.PullRequestStatus) error {
+	msg := prompt.GetMessage()
+
+	if status.Merged {
+		return fmt.Errorf("PR #%d 已合并", status.Number)
+	}
+	if status.State != "open" {
+		return fmt.Errorf("PR #%d 未处于打开状态（当前状态: %s）", status.Number, status.State)
+	}
+
+	var problems []string
+	if status.Mergeable != nil && !*status.Mergeable {
+		problems = append(problems, "存在冲突或不满足合并条件")
+	}
+	if status.Checks == platform.ChecksFailure {
+		problems = append(problems, "CI 检查未通过")
+	}
+
+	if len(problems) > 0 {
+		if !mergeForce {
+			return fmt.Errorf("PR #%d 无法合并: %s（使用 --force 强制合并）", status.Number, strings.Join(problems, "，"))
+		}
+		msg.Warning("Forcing merge of PR #%d: %s", status.Number, strings.Join(problems, ", "))
+	}
+
+	if status.Mergeable == nil {
+		msg.Warning("Mergeability of PR #%d is not known yet", status.Number)
+	}
+	if status.Checks == platform.ChecksPending {
+		msg.Warning("Checks of PR #%d are still running", status.Number)
+	}
+
+	return nil
+}
+
+// cleanupMergedBranch deletes the merged branch locally
+//
+// When the merged branch is checked out, it first switches to the default branch
+// and fast-forwards it. Nothing is done when the branch does not exist locally.
+func cleanupMergedBranch(gitRepo *git.Repository, platformName, token, headBranch string) error {
+	msg := prompt.GetMessage()
+
+	if headBranch == "" {
+		return nil
+	}
+	exists, err := gitRepo.BranchExists(headBranch)
+	if err != nil || !exists {
+		return nil
+	}
+
+	defaultBranch, err := gitRepo.GetDefaultBranch()
+	if err != nil {
+		return fmt.Errorf("获取默认分支失败: %w", err)
+	}
+	if defaultBranch == headBranch {
+		return nil
+	}
+
+	if currentBranch, err := gitRepo.CurrentBranch(); err == nil && currentBranch == headBranch {
+		if err := gitRepo.CheckoutBranch(defaultBranch); err != nil {
+			return fmt.Errorf("切换到分支 %s 失败: %w", defaultBranch, err)
+		}
+		msg.Success("Switched to %s", defaultBranch)
+
+		if err := gitRepo.Pull(defaultRemote, defaultBranch, infrastructureconfig.RemoteAuth(gitRepo, defaultRemote, platformName, token)); err != nil {
+			msg.Warning("Failed to update %s: %v", defaultBranch, err)
+			msg.Warning("%s does not contain the merged changes yet, run 'git pull %s %s' to update it", defaultBranch, defaultRemote, defaultBranch)
+		} else {
+			msg.Success("Updated %s from %s", defaultBranch, defaultRemote)
+		}
+	}
+
+	if err := gitRepo.DeleteBranch(headBranch); err != nil {
+		return fmt.Errorf("删除本地分支 %s 失败: %w", headBranch, err)
+	}
+	msg.Success("Deleted local branch %s", headBranch)
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package pr
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewPRCmd creates the pr command
+func NewPRCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pr",
+		Short: "Pull request management",
+		Long:  `Create and manage pull requests on the repository's hosting platform.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(NewCreateCmd())
+	cmd.AddCommand(NewListCmd())
+	cmd.AddCommand(NewStatusCmd())
+	cmd.AddCommand(NewMergeCmd())
+	cmd.AddCommand(NewCloseCmd())
+	cmd.AddCommand(NewApproveCmd())
+	cmd.AddCommand(NewCommentCmd())
+	cmd.AddCommand(NewRewordCmd())
+	cmd.AddCommand(NewSummarizeCmd())
+
+	return cmd
+}
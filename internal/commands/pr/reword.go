@@ -0,0 +1,277 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/commands/repo"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	infrastructurellm "github.com/zevwings/workflow/internal/infrastructure/llm"
+	"github.com/zevwings/workflow/internal/llm"
+	platform "github.com/zevwings/workflow/internal/pr"
+	"github.com/zevwings/workflow/internal/prompt"
+	"github.com/zevwings/workflow/internal/util"
+)
+
+var (
+	rewordTitle       bool
+	rewordDescription bool
+	rewordDryRun      bool
+	rewordNoCache     bool
+)
+
+// Choices offered after showing a reword proposal
+const (
+	rewordAccept     = "Accept"
+	rewordRegenerate = "Regenerate"
+	rewordEdit       = "Edit in $EDITOR"
+	rewordCancel     = "Cancel"
+)
+
+// NewRewordCmd creates the pr reword command
+func NewRewordCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reword [PR_ID]",
+		Short: "Rewrite the title and description of a pull request",
+		Long: `Rewrite the title and description of a pull request with the LLM.
+
+The proposal is generated from the current title and the PR diff and shown
+next to the current values. It can then be accepted, regenerated, or edited
+in $EDITOR before the pull request is updated.
+
+By default both the title and the description are rewritten; use --title or
+--description to rewrite only one of them.
+
+The proposal is rendered in the terminal as it is generated. Press Ctrl-C to
+cancel; the pull request is not updated in that case.
+
+LLM responses are cached ([llm.cache] in the global config), so the same diff
+produces the same proposal; Regenerate and --no-cache call the LLM again.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runReword,
+	}
+
+	cmd.Flags().BoolVarP(&rewordTitle, "title", "t", false, "Only rewrite the title")
+	cmd.Flags().BoolVarP(&rewordDescription, "description", "d", false, "Only rewrite the description")
+	cmd.Flags().BoolVar(&rewordDryRun, "dry-run", false, "Print the proposal without updating the pull request")
+	cmd.Flags().BoolVar(&rewordNoCache, "no-cache", false, "Call the LLM even if a cached response exists")
+
+	return cmd
+}
+
+// rewordFields are the fields of the reword response rendered while streaming
+var rewordFields = []string{"pr_title", "description"}
+
+// rewordProposal is a title and description for a pull request
+type rewordProposal struct {
+	title string
+	body  string
+}
+
+func runReword(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	if rewordNoCache {
+		infrastructurellm.DisableCache()
+	}
+
+	if err := repo.Ensure(); err != nil {
+		return err
+	}
+
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+
+	if _, _, _, err := manager.LLMConfig.CurrentProvider(); err != nil {
+		return fmt.Errorf("LLM 未配置（请先运行 'workflow setup'）: %w", err)
+	}
+
+	provider, _, err := newPlatformProvider(manager)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	prID, err := resolvePRID(ctx, provider, args)
+	if err != nil {
+		return err
+	}
+
+	status, err := provider.GetPullRequestStatus(ctx, prID)
+	if err != nil {
+		return fmt.Errorf("获取 PR %s 失败: %w", prID, err)
+	}
+	current := rewordProposal{title: status.Title, body: strings.TrimSpace(status.Body)}
+
+	spinner := prompt.NewSpinner("Fetching pull request diff...")
+	spinner.Start()
+	diff, err := provider.GetPullRequestDiff(ctx, prID)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("获取 PR diff 失败: %w", err)
+	}
+
+	llmClient := infrastructurellm.NewPullRequestLLMClient()
+	diff, err = diffForPrompt(ctx, llmClient, compactDiff(manager, llm.FeaturePRReword, diff))
+	if err != nil && ctx.Err() != nil {
+		return rewordCancelled()
+	}
+	if err != nil {
+		return err
+	}
+
+	proposal, err := generateReword(ctx, llmClient, diff, current)
+	if err != nil && ctx.Err() != nil {
+		return rewordCancelled()
+	}
+	if err != nil {
+		return err
+	}
+
+	for {
+		printRewordComparison(current, proposal)
+
+		if rewordDryRun {
+			msg.Break()
+			msg.Info("Dry run: the pull request was not updated")
+			return nil
+		}
+
+		msg.Break()
+		choices := []string{rewordAccept, rewordRegenerate, rewordEdit, rewordCancel}
+		index, err := prompt.Select().
+			Prompt("What do you want to do?").
+			Options(choices).
+			Run()
+		if err != nil {
+			return fmt.Errorf("选择操作失败: %w", err)
+		}
+
+		switch choices[index] {
+		case rewordAccept:
+			return applyReword(ctx, provider, prID, current, proposal)
+		case rewordRegenerate:
+			regenerated, err := generateReword(ctx, llmClient.Refresh(), diff, current)
+			if err != nil && ctx.Err() != nil {
+				return rewordCancelled()
+			}
+			if err != nil {
+				msg.Warning("%v", err)
+				continue
+			}
+			proposal = regenerated
+		case rewordEdit:
+			edited, err := editReword(proposal)
+			if err != nil {
+				msg.Warning("%v", err)
+				continue
+			}
+			proposal = edited
+		default:
+			msg.Info("Cancelled, the pull request was not updated")
+			return nil
+		}
+	}
+}
+
+// rewordCancelled reports that the command was cancelled with Ctrl-C
+func rewordCancelled() error {
+	msg := prompt.GetMessage()
+	msg.Break()
+	msg.Info("Cancelled, the pull request was not updated")
+	return nil
+}
+
+// generateReword asks the LLM for a new title and description
+//
+// The proposal is streamed to the terminal while it is generated.
+// Parts excluded by --title or --description keep their current value.
+func generateReword(ctx context.Context, llmClient *llm.PullRequestLLMClient, diff string, current rewordProposal) (rewordProposal, error) {
+	var reword *llm.PullRequestReword
+	err := streamResponse(ctx, llmClient, "Rewording pull request...", rewordFields, func(c *llm.PullRequestLLMClient) error {
+		var err error
+		reword, err = c.Reword(diff, &current.title)
+		return err
+	})
+	if err != nil {
+		return rewordProposal{}, fmt.Errorf("生成 PR 标题和描述失败: %w", err)
+	}
+
+	proposal := current
+	onlyTitle, onlyDescription := rewordTitle && !rewordDescription, rewordDescription && !rewordTitle
+	if !onlyDescription {
+		proposal.title = reword.PRTitle
+	}
+	if !onlyTitle && reword.Description != nil {
+		proposal.body = *reword.Description
+	}
+	return proposal, nil
+}
+
+// editReword opens the proposal in $EDITOR
+//
+// The first line is the title; everything after it is the description.
+func editReword(proposal rewordProposal) (rewordProposal, error) {
+	text, err := util.EditText(proposal.title+"\n\n"+proposal.body+"\n", "pr-reword-*.md")
+	if err != nil {
+		return proposal, err
+	}
+
+	title, body, _ := strings.Cut(strings.TrimLeft(text, "\r\n"), "\n")
+	edited := rewordProposal{title: strings.TrimSpace(title), body: strings.TrimSpace(body)}
+	if edited.title == "" {
+		return proposal, fmt.Errorf("标题不能为空，已保留编辑前的内容")
+	}
+	return edited, nil
+}
+
+// applyReword updates the parts of the pull request that changed
+func applyReword(ctx context.Context, provider platform.PlatformProvider, prID string, current, proposal rewordProposal) error {
+	msg := prompt.GetMessage()
+
+	var title, body *string
+	if proposal.title != current.title {
+		title = &proposal.title
+	}
+	if proposal.body != current.body {
+		body = &proposal.body
+	}
+	if title == nil && body == nil {
+		msg.Info("Nothing changed, the pull request was not updated")
+		return nil
+	}
+
+	if err := provider.UpdatePullRequest(ctx, prID, title, body, nil); err != nil {
+		return fmt.Errorf("更新 PR %s 失败: %w", prID, err)
+	}
+
+	msg.Success("Updated PR #%s", prID)
+	return nil
+}
+
+// printRewordComparison prints the current and proposed title and description
+func printRewordComparison(current, proposal rewordProposal) {
+	msg := prompt.GetMessage()
+
+	msg.Break()
+	msg.Break('-', 40, "Before")
+	msg.Info("Title: %s", current.title)
+	if current.body != "" {
+		msg.Break()
+		msg.Print("%s", current.body)
+	}
+
+	msg.Break()
+	msg.Break('-', 40, "After")
+	msg.Info("Title: %s", proposal.title)
+	if proposal.body != "" {
+		msg.Break()
+		msg.Print("%s", proposal.body)
+	}
+}
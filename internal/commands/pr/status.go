@@ -0,0 +1,241 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/commands/repo"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	platform "github.com/zevwings/workflow/internal/pr"
+	prhelpers "github.com/zevwings/workflow/internal/pr/helpers"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+var (
+	statusWatch    bool
+	statusInterval time.Duration
+)
+
+// NewStatusCmd creates the pr status command
+func NewStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status [PR_ID_OR_BRANCH]",
+		Short: "Show the status of a pull request",
+		Long: `Show the state, mergeability, reviews and checks of a pull request.
+
+The argument is a PR number, a PR URL or a branch name. A number is taken as
+a PR number; when no pull request has that number, the open pull request of
+the branch with that name is used. Without an argument, the open pull request
+of the current branch is used.
+
+With --watch, the status is refreshed until the pull request is merged or
+closed, or its checks have finished.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runStatus,
+	}
+
+	cmd.Flags().BoolVarP(&statusWatch, "watch", "w", false, "Refresh until merged or checks finish")
+	cmd.Flags().DurationVarP(&statusInterval, "interval", "i", 15*time.Second, "Refresh interval for --watch")
+
+	return cmd
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	if err := repo.Ensure(); err != nil {
+		return err
+	}
+	if statusWatch && statusInterval < time.Second {
+		return fmt.Errorf("--interval 不能小于 1s")
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+
+	provider, _, err := newPlatformProvider(manager)
+	if err != nil {
+		return err
+	}
+
+	prID, err := resolveStatusTarget(ctx, provider, args)
+	if err != nil {
+		return err
+	}
+
+	if !statusWatch {
+		status, err := provider.GetPullRequestStatus(ctx, prID)
+		if err != nil {
+			return fmt.Errorf("获取 PR %s 失败: %w", prID, err)
+		}
+		printStatus(status)
+		return nil
+	}
+
+	return watchStatus(ctx, provider, prID)
+}
+
+// resolveStatusTarget resolves the PR ID from a PR number, URL or branch name
+//
+// An all-digit argument is a PR number, unless no pull request has that
+// number and a branch of that name has an open one.
+func resolveStatusTarget(ctx context.Context, p platform.PlatformProvider, args []string) (string, error) {
+	if len(args) == 0 || strings.TrimSpace(args[0]) == "" {
+		return resolvePRID(ctx, p, nil)
+	}
+
+	target := strings.TrimSpace(args[0])
+	if strings.Contains(target, "://") {
+		return resolvePRID(ctx, p, []string{target})
+	}
+
+	prID, err := prhelpers.ParsePRID(target)
+	if err != nil {
+		return resolveBranchPR(ctx, p, target)
+	}
+	if prID != target {
+		return prID, nil
+	}
+
+	if _, err := p.GetPullRequestStatus(ctx, prID); err != nil {
+		if branchPR, branchErr := resolveBranchPR(ctx, p, target); branchErr == nil {
+			prompt.GetMessage().Info("No PR #%s, using PR #%s for branch %s", prID, branchPR, target)
+			return branchPR, nil
+		}
+	}
+	return prID, nil
+}
+
+// resolveBranchPR resolves the PR ID of the open pull request of a branch
+func resolveBranchPR(ctx context.Context, p platform.PlatformProvider, branch string) (string, error) {
+	prID, err := prhelpers.ResolvePRForBranch(ctx, p, branch, choosePullRequest)
+	if err != nil {
+		return "", fmt.Errorf("查找分支 %s 的 PR 失败: %w", branch, err)
+	}
+	return prID, nil
+}
+
+// watchStatus redraws the status until the pull request is done or the user interrupts
+func watchStatus(ctx context.Context, p platform.PlatformProvider, prID string) error {
+	msg := prompt.GetMessage()
+
+	ticker := time.NewTicker(statusInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := p.GetPullRequestStatus(ctx, prID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("获取 PR %s 失败: %w", prID, err)
+		}
+
+		fmt.Print(clearScreen)
+		printStatus(status)
+		msg.Break()
+
+		if watchFinished(status) {
+			msg.Success("Done watching PR #%d", status.Number)
+			return nil
+		}
+		msg.Info("Refreshing every %s at %s, press Ctrl-C to stop", statusInterval, time.Now().Format("15:04:05"))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchFinished reports whether --watch has nothing left to wait for
+func watchFinished(status *platform.PullRequestStatus) bool {
+	if status.Merged || status.State != "open" {
+		return true
+	}
+	return status.Checks != platform.ChecksPending
+}
+
+// printStatus prints the status of a pull request
+func printStatus(status *platform.PullRequestStatus) {
+	msg := prompt.GetMessage()
+
+	msg.Break()
+	msg.Info("PR #%d: %s", status.Number, status.Title)
+	msg.Break('-', 40)
+	msg.Info("URL: %s", status.HTMLURL)
+	msg.Info("Branch: %s -> %s", status.HeadBranch, status.BaseBranch)
+	msg.Info("State: %s", describeState(status))
+	msg.Info("Mergeable: %s", describeMergeable(status.Mergeable))
+	if len(status.Labels) > 0 {
+		msg.Info("Labels: %s", strings.Join(status.Labels, ", "))
+	}
+	msg.Info("Reviews: %s", describeReviews(status.Reviews))
+	msg.Info("Checks: %s", describeChecks(status.Checks))
+	msg.Info("Updated: %s", status.UpdatedAt.Local().Format("2006-01-02 15:04"))
+
+	if len(status.CheckResults) > 0 {
+		msg.Break()
+		table := prompt.NewTable([]string{"Check", "State", "URL"})
+		table.SetRowLine(false)
+		for _, check := range status.CheckResults {
+			table.AddRow([]string{check.Name, check.State, check.URL})
+		}
+		table.Render()
+	}
+}
+
+// describeState formats the state, including merged and draft
+func describeState(status *platform.PullRequestStatus) string {
+	switch {
+	case status.Merged:
+		return "merged"
+	case status.Draft && status.State == "open":
+		return "open (draft)"
+	default:
+		return status.State
+	}
+}
+
+// describeMergeable formats the mergeable flag
+func describeMergeable(mergeable *bool) string {
+	switch {
+	case mergeable == nil:
+		return "unknown"
+	case *mergeable:
+		return "yes"
+	default:
+		return "no"
+	}
+}
+
+// describeReviews formats the reviewers and their review state
+func describeReviews(reviews []platform.Review) string {
+	if len(reviews) == 0 {
+		return "none"
+	}
+
+	parts := make([]string, 0, len(reviews))
+	for _, review := range reviews {
+		parts = append(parts, fmt.Sprintf("%s (%s)", review.Reviewer, strings.ReplaceAll(review.State, "_", " ")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeChecks formats the aggregated checks state
+func describeChecks(checks string) string {
+	if checks == "" {
+		return "none"
+	}
+	return checks
+}
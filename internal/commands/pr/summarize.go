@@ -0,0 +1,201 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/commands/repo"
+	"github.com/zevwings/workflow/internal/config"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	infrastructurellm "github.com/zevwings/workflow/internal/infrastructure/llm"
+	"github.com/zevwings/workflow/internal/llm"
+	platform "github.com/zevwings/workflow/internal/pr"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+var (
+	summarizeLanguage string
+	summarizeNoCache  bool
+)
+
+// summaryFields are the fields of the summary response rendered while streaming
+var summaryFields = []string{"summary"}
+
+// NewSummarizeCmd creates the pr summarize command
+func NewSummarizeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "summarize [PR_ID]",
+		Short: "Summarize a pull request",
+		Long: `Summarize a pull request with the LLM and save the summary as Markdown.
+
+The summary is written to a file named by the LLM under the repository's
+directory in the workflow data directory. The diff is compacted to the
+token budget of the configured model ([llm] max_diff_tokens or [[llm.budgets]]);
+diffs that still do not fit are summarized file by file and then merged into
+a single summary.
+
+The summary is rendered in the terminal as it is generated. Press Ctrl-C to
+cancel; nothing is saved in that case.
+
+LLM responses are cached ([llm.cache] in the global config), so summarizing
+the same diff again returns the same summary; use --no-cache to call the LLM again.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runSummarize,
+	}
+
+	cmd.Flags().StringVarP(&summarizeLanguage, "language", "l", "", "Summary language code (defaults to the configured LLM language)")
+	cmd.Flags().BoolVar(&summarizeNoCache, "no-cache", false, "Call the LLM even if a cached response exists")
+
+	return cmd
+}
+
+func runSummarize(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	if summarizeNoCache {
+		infrastructurellm.DisableCache()
+	}
+
+	if err := repo.Ensure(); err != nil {
+		return err
+	}
+
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+
+	if _, _, _, err := manager.LLMConfig.CurrentProvider(); err != nil {
+		return fmt.Errorf("LLM 未配置（请先运行 'workflow setup'）: %w", err)
+	}
+
+	llmClient := infrastructurellm.NewPullRequestLLMClient()
+	if summarizeLanguage != "" {
+		lang, err := infrastructurellm.LanguageFromCode(summarizeLanguage)
+		if err != nil {
+			return fmt.Errorf("不支持的语言: %w", err)
+		}
+		llmClient = llmClient.WithLanguage(lang)
+	}
+
+	provider, _, err := newPlatformProvider(manager)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	prID, err := resolvePRID(ctx, provider, args)
+	if err != nil {
+		return err
+	}
+
+	status, err := provider.GetPullRequestStatus(ctx, prID)
+	if err != nil {
+		return fmt.Errorf("获取 PR %s 失败: %w", prID, err)
+	}
+
+	summary, err := summarizePullRequest(ctx, provider, manager, llmClient, prID, status.Title)
+	if err != nil && ctx.Err() != nil {
+		msg.Break()
+		msg.Info("Cancelled, the summary was not saved")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	path, err := writeSummary(summary)
+	if err != nil {
+		return err
+	}
+
+	msg.Success("Summary of PR #%d saved to %s", status.Number, path)
+	return nil
+}
+
+// summarizePullRequest summarizes the pull request diff
+//
+// The diff is compacted to the LLM token budget first. If it still does not
+// fit, the files are summarized one by one and the file summaries are merged.
+// The summary is streamed to the terminal while it is generated.
+func summarizePullRequest(ctx context.Context, provider platform.PlatformProvider, manager *config.GlobalManager, llmClient *llm.PullRequestLLMClient, prID, title string) (*llm.PullRequestSummary, error) {
+	spinner := prompt.NewSpinner("Fetching pull request diff...")
+	spinner.Start()
+	diff, err := provider.GetPullRequestDiff(ctx, prID)
+	spinner.Stop()
+	if err != nil {
+		return nil, fmt.Errorf("获取 PR diff 失败: %w", err)
+	}
+	if strings.TrimSpace(diff) == "" {
+		return nil, fmt.Errorf("PR %s 没有变更", prID)
+	}
+
+	var summary *llm.PullRequestSummary
+	result := compactDiff(manager, llm.FeaturePRSummarize, diff)
+	if !result.OverBudget {
+		err = streamResponse(ctx, llmClient, "Summarizing pull request...", summaryFields, func(c *llm.PullRequestLLMClient) error {
+			var err error
+			summary, err = c.Summarize(title, result.Diff)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("生成 PR 总结失败: %w", err)
+		}
+		return summary, nil
+	}
+
+	summaries, err := summarizeFiles(ctx, llmClient, result)
+	if err != nil {
+		return nil, err
+	}
+
+	err = streamResponse(ctx, llmClient, "Merging file summaries...", summaryFields, func(c *llm.PullRequestLLMClient) error {
+		var err error
+		summary, err = c.SummarizeFromFileSummaries(title, summaries)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("合并文件总结失败: %w", err)
+	}
+	return summary, nil
+}
+
+// writeSummary writes the summary to <data dir>/summaries/<repo id>/<filename>.md
+func writeSummary(summary *llm.PullRequestSummary) (string, error) {
+	dir, err := summaryDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("创建总结目录失败: %w", err)
+	}
+
+	path := filepath.Join(dir, summary.Filename+".md")
+	if err := os.WriteFile(path, []byte(summary.Summary+"\n"), 0644); err != nil {
+		return "", fmt.Errorf("写入总结文件失败: %w", err)
+	}
+
+	return path, nil
+}
+
+// summaryDir returns the directory PR summaries of the current repository are written to
+func summaryDir() (string, error) {
+	dataDir, err := config.DataDir()
+	if err != nil {
+		return "", fmt.Errorf("获取数据目录失败: %w", err)
+	}
+
+	repoManager, err := infrastructureconfig.NewRepoManagerWithDefaultGit("")
+	if err != nil {
+		return "", fmt.Errorf("初始化配置管理器失败: %w", err)
+	}
+
+	return filepath.Join(dataDir, "summaries", repoManager.GetRepoID()), nil
+}
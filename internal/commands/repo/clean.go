@@ -0,0 +1,442 @@
+package repo
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/config"
+	"github.com/zevwings/workflow/internal/git"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
+	"github.com/zevwings/workflow/internal/pr/provider"
+	"github.com/zevwings/workflow/internal/prompt"
+	"github.com/zevwings/workflow/internal/util"
+)
+
+// defaultRemote is the remote whose tags are compared and whose token is used
+const defaultRemote = "origin"
+
+var (
+	cleanDryRun bool
+	cleanUndo   bool
+	cleanDays   int
+	cleanRemote bool
+	cleanTags   bool
+)
+
+// NewCleanCmd creates the repo clean command
+func NewCleanCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clean",
+		Short: "Delete merged and stale local branches",
+		Long: `Find local branches that can be deleted and let you choose which ones to delete.
+
+A branch is offered when it is merged into the default branch, its upstream
+branch was deleted on the remote, or its last commit is older than --days.
+The current branch, the default branch and branches matching [branch] ignore
+in the personal repository config are never offered.
+
+With --tags, tags that only exist locally are offered as well.
+
+Before deleting, the branches and tags with their commits are recorded, so
+that 'workflow repo clean --undo' can restore the last clean, including
+remote branches deleted with it.`,
+		Args: cobra.NoArgs,
+		RunE: runClean,
+	}
+
+	cmd.Flags().BoolVar(&cleanDryRun, "dry-run", false, "Only list what would be deleted")
+	cmd.Flags().BoolVar(&cleanUndo, "undo", false, "Restore the branches and tags deleted by the last clean")
+	cmd.Flags().IntVar(&cleanDays, "days", 90, "Offer branches whose last commit is older than this many days (0 to disable)")
+	cmd.Flags().BoolVarP(&cleanRemote, "remote", "r", false, "Also delete the remote branches without asking")
+	cmd.Flags().BoolVarP(&cleanTags, "tags", "t", false, "Also offer tags that only exist locally")
+	cmd.MarkFlagsMutuallyExclusive("dry-run", "undo")
+
+	return cmd
+}
+
+func runClean(cmd *cobra.Command, args []string) error {
+	if cleanDays < 0 {
+		return fmt.Errorf("--days 不能小于 0")
+	}
+
+	gitRepo, err := git.OpenCurrent()
+	if err != nil {
+		return fmt.Errorf("不在 Git 仓库中: %w", err)
+	}
+
+	manager, err := infrastructureconfig.NewRepoManagerWithDefaultGit("")
+	if err != nil {
+		return fmt.Errorf("初始化配置管理器失败: %w", err)
+	}
+	recordsPath, err := cleanRecordsPath(manager.GetRepoID())
+	if err != nil {
+		return err
+	}
+
+	if cleanUndo {
+		return undoClean(gitRepo, recordsPath)
+	}
+	return clean(gitRepo, manager, recordsPath)
+}
+
+// clean finds the candidates, lets the user choose and deletes them
+func clean(gitRepo *git.Repository, manager *config.RepoManager, recordsPath string) error {
+	msg := prompt.GetMessage()
+
+	defaultBranch, err := gitRepo.GetDefaultBranch()
+	if err != nil {
+		return fmt.Errorf("获取默认分支失败: %w", err)
+	}
+
+	auth := remoteAuth(gitRepo)
+	remoteRefs := listRemoteRefs(gitRepo)
+
+	candidates, err := gitRepo.FindCleanupCandidates(git.CleanupOptions{
+		DefaultBranch:  defaultBranch,
+		StaleAfter:     time.Duration(cleanDays) * 24 * time.Hour,
+		Ignore:         manager.GetIgnoreBranches(),
+		RemoteBranches: remoteBranches(remoteRefs),
+	})
+	if err != nil {
+		return fmt.Errorf("查找可清理的分支失败: %w", err)
+	}
+
+	var tags []git.TagInfo
+	if cleanTags {
+		refs, ok := remoteRefs[defaultRemote]
+		if !ok {
+			msg.Warning("Cannot list tags on %s, skipping tags", defaultRemote)
+		} else {
+			tags, err = gitRepo.FindLocalOnlyTags(refNames(refs, "refs/tags/"))
+			if err != nil {
+				return fmt.Errorf("查找本地 tag 失败: %w", err)
+			}
+		}
+	}
+
+	if len(candidates) == 0 && len(tags) == 0 {
+		msg.Success("Nothing to clean")
+		return nil
+	}
+
+	options := make([]string, 0, len(candidates)+len(tags))
+	for _, candidate := range candidates {
+		options = append(options, describeCandidate(candidate))
+	}
+	for _, tag := range tags {
+		options = append(options, fmt.Sprintf("tag %s (local only)", tag.Name))
+	}
+
+	if cleanDryRun {
+		msg.Info("Would offer %d item(s) for deletion:", len(options))
+		for _, option := range options {
+			msg.Print("  - %s", option)
+		}
+		return nil
+	}
+
+	selected, err := prompt.MultiSelect().
+		Prompt("Select the branches and tags to delete:").
+		Options(options).
+		Run()
+	if err != nil {
+		return fmt.Errorf("选择失败: %w", err)
+	}
+	if len(selected) == 0 {
+		msg.Info("Nothing selected")
+		return nil
+	}
+
+	record := git.CleanupRecord{Time: time.Now()}
+	var remoteCandidates []git.CleanupCandidate
+	for _, index := range selected {
+		if index >= len(candidates) {
+			tag := tags[index-len(candidates)]
+			record.Tags = append(record.Tags, git.DeletedRef{Name: tag.Name, Hash: tag.CommitHash})
+			continue
+		}
+		candidate := candidates[index]
+		record.Branches = append(record.Branches, git.DeletedRef{Name: candidate.Name, Hash: candidate.Hash})
+		// Only upstreams with the same name, so that undo can push the branch back
+		if candidate.UpstreamBranch == candidate.Name && !candidate.HasReason(git.CleanupReasonGone) {
+			remoteCandidates = append(remoteCandidates, candidate)
+		}
+	}
+
+	deleteRemote, err := confirmRemoteDelete(remoteCandidates)
+	if err != nil {
+		return err
+	}
+	if deleteRemote {
+		for i := range record.Branches {
+			for _, candidate := range remoteCandidates {
+				if candidate.Name == record.Branches[i].Name {
+					record.Branches[i].Remote = candidate.Remote
+				}
+			}
+		}
+	}
+
+	// Record before deleting so that an interrupted clean can still be undone
+	if err := appendCleanupRecord(recordsPath, record); err != nil {
+		return err
+	}
+
+	deleted, failed := deleteRefs(gitRepo, record, auth)
+	if err := replaceLastCleanupRecord(recordsPath, deleted); err != nil {
+		return err
+	}
+
+	msg.Break()
+	msg.Info("Run 'workflow repo clean --undo' to restore them")
+	if failed > 0 {
+		return fmt.Errorf("%d 个分支或 tag 删除失败", failed)
+	}
+	return nil
+}
+
+// deleteRefs deletes the recorded branches and tags
+//
+// Returns the record of what was actually deleted and the number of failures.
+func deleteRefs(gitRepo *git.Repository, record git.CleanupRecord, auth transport.AuthMethod) (git.CleanupRecord, int) {
+	msg := prompt.GetMessage()
+
+	deleted := git.CleanupRecord{Time: record.Time}
+	failed := 0
+	for _, branch := range record.Branches {
+		if err := gitRepo.DeleteBranch(branch.Name); err != nil {
+			msg.Warning("Failed to delete branch %s: %v", branch.Name, err)
+			failed++
+			continue
+		}
+		msg.Success("Deleted branch %s (was %s)", branch.Name, shortHash(branch.Hash))
+
+		if branch.Remote != "" {
+			if err := gitRepo.DeleteRemoteBranch(branch.Remote, branch.Name, auth); err != nil {
+				msg.Warning("Failed to delete %s/%s: %v", branch.Remote, branch.Name, err)
+				failed++
+				branch.Remote = ""
+			} else {
+				msg.Success("Deleted remote branch %s/%s", branch.Remote, branch.Name)
+			}
+		}
+		deleted.Branches = append(deleted.Branches, branch)
+	}
+
+	for _, tag := range record.Tags {
+		if err := gitRepo.DeleteTag(tag.Name); err != nil {
+			msg.Warning("Failed to delete tag %s: %v", tag.Name, err)
+			failed++
+			continue
+		}
+		msg.Success("Deleted tag %s (was %s)", tag.Name, shortHash(tag.Hash))
+		deleted.Tags = append(deleted.Tags, tag)
+	}
+
+	return deleted, failed
+}
+
+// undoClean restores the branches and tags deleted by the last clean
+func undoClean(gitRepo *git.Repository, recordsPath string) error {
+	msg := prompt.GetMessage()
+
+	records, err := git.ReadCleanupRecords(recordsPath)
+	if err != nil {
+		return fmt.Errorf("读取清理记录失败: %w", err)
+	}
+	if len(records) == 0 {
+		msg.Info("Nothing to undo")
+		return nil
+	}
+
+	record := records[len(records)-1]
+	msg.Info("Restoring the clean from %s", record.Time.Local().Format("2006-01-02 15:04"))
+
+	auth := remoteAuth(gitRepo)
+	failed := 0
+	for _, branch := range record.Branches {
+		if err := gitRepo.CreateBranchAt(branch.Name, plumbing.NewHash(branch.Hash)); err != nil {
+			msg.Warning("Failed to restore branch %s: %v", branch.Name, err)
+			failed++
+			continue
+		}
+		msg.Success("Restored branch %s at %s", branch.Name, shortHash(branch.Hash))
+
+		if branch.Remote == "" {
+			continue
+		}
+		if err := gitRepo.Push(branch.Remote, branch.Name, auth); err != nil {
+			msg.Warning("Failed to restore %s/%s: %v", branch.Remote, branch.Name, err)
+			failed++
+			continue
+		}
+		msg.Success("Restored remote branch %s/%s", branch.Remote, branch.Name)
+	}
+
+	for _, tag := range record.Tags {
+		if err := gitRepo.CreateTag(tag.Name, plumbing.NewHash(tag.Hash)); err != nil {
+			msg.Warning("Failed to restore tag %s: %v", tag.Name, err)
+			failed++
+			continue
+		}
+		msg.Success("Restored tag %s at %s", tag.Name, shortHash(tag.Hash))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d 个分支或 tag 恢复失败，清理记录已保留", failed)
+	}
+
+	if err := git.WriteCleanupRecords(recordsPath, records[:len(records)-1]); err != nil {
+		return fmt.Errorf("更新清理记录失败: %w", err)
+	}
+	return nil
+}
+
+// confirmRemoteDelete asks whether the upstream branches should be deleted too
+func confirmRemoteDelete(candidates []git.CleanupCandidate) (bool, error) {
+	if len(candidates) == 0 {
+		return false, nil
+	}
+	if cleanRemote {
+		return true, nil
+	}
+
+	names := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		names = append(names, candidate.Remote+"/"+candidate.UpstreamBranch)
+	}
+
+	confirmed, err := prompt.Confirm().
+		Prompt(fmt.Sprintf("Also delete the remote branches %s?", strings.Join(names, ", "))).
+		Default(false).
+		Run()
+	if err != nil {
+		return false, fmt.Errorf("确认失败: %w", err)
+	}
+	return confirmed, nil
+}
+
+// appendCleanupRecord adds a record to the restore file
+func appendCleanupRecord(path string, record git.CleanupRecord) error {
+	records, err := git.ReadCleanupRecords(path)
+	if err != nil {
+		return fmt.Errorf("读取清理记录失败: %w", err)
+	}
+	if err := git.WriteCleanupRecords(path, append(records, record)); err != nil {
+		return fmt.Errorf("保存清理记录失败: %w", err)
+	}
+	return nil
+}
+
+// replaceLastCleanupRecord replaces the last record, dropping it when nothing was deleted
+func replaceLastCleanupRecord(path string, record git.CleanupRecord) error {
+	records, err := git.ReadCleanupRecords(path)
+	if err != nil {
+		return fmt.Errorf("读取清理记录失败: %w", err)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	records = records[:len(records)-1]
+	if len(record.Branches) > 0 || len(record.Tags) > 0 {
+		records = append(records, record)
+	}
+	if err := git.WriteCleanupRecords(path, records); err != nil {
+		return fmt.Errorf("保存清理记录失败: %w", err)
+	}
+	return nil
+}
+
+// cleanRecordsPath returns the restore file of the repository
+func cleanRecordsPath(repoID string) (string, error) {
+	stateDir, err := config.StateDir()
+	if err != nil {
+		return "", fmt.Errorf("获取状态目录失败: %w", err)
+	}
+	return filepath.Join(stateDir, "clean", repoID+".json"), nil
+}
+
+// listRemoteRefs lists the refs of every remote, skipping remotes that cannot be reached
+func listRemoteRefs(gitRepo *git.Repository) map[string]map[string]plumbing.Hash {
+	msg := prompt.GetMessage()
+
+	remotes, err := gitRepo.ListRemotes()
+	if err != nil {
+		return nil
+	}
+
+	result := make(map[string]map[string]plumbing.Hash)
+	for _, remote := range remotes {
+		spinner := prompt.NewSpinner(fmt.Sprintf("Listing branches on %s...", remote.Name))
+		spinner.Start()
+		refs, err := gitRepo.ListRemoteRefs(remote.Name)
+		spinner.Stop()
+		if err != nil {
+			msg.Warning("Cannot reach %s, using local remote-tracking branches: %v", remote.Name, err)
+			continue
+		}
+		result[remote.Name] = refs
+	}
+	return result
+}
+
+// remoteBranches converts remote refs to the branch names per remote
+func remoteBranches(remoteRefs map[string]map[string]plumbing.Hash) map[string]map[string]bool {
+	result := make(map[string]map[string]bool, len(remoteRefs))
+	for remote, refs := range remoteRefs {
+		result[remote] = refNames(refs, "refs/heads/")
+	}
+	return result
+}
+
+// refNames returns the names of the refs with the given prefix, without the prefix
+func refNames(refs map[string]plumbing.Hash, prefix string) map[string]bool {
+	names := make(map[string]bool)
+	for name := range refs {
+		if short, ok := strings.CutPrefix(name, prefix); ok {
+			names[strings.TrimSuffix(short, "^{}")] = true
+		}
+	}
+	return names
+}
+
+// remoteAuth returns the auth method for the default remote
+//
+// HTTPS remotes use the API token of the detected platform, selected the same
+// way as for pull requests; SSH remotes, and remotes without a configured
+// token, return nil so that go-git falls back to the SSH agent.
+func remoteAuth(gitRepo *git.Repository) transport.AuthMethod {
+	manager, err := infrastructureconfig.LoadGlobalConfig()
+	if err != nil {
+		return nil
+	}
+	info, err := provider.AutoDetect(provider.WithHostMapping(manager.GetPlatformConfig().Hosts))
+	if err != nil {
+		return nil
+	}
+	token, err := infrastructureconfig.PlatformToken(manager, info)
+	if err != nil {
+		return nil
+	}
+	return infrastructureconfig.RemoteAuth(gitRepo, defaultRemote, info.Platform, token)
+}
+
+// describeCandidate formats a branch with its last commit and reasons
+func describeCandidate(candidate git.CleanupCandidate) string {
+	age := util.FormatAge(time.Since(candidate.LastCommit))
+	return fmt.Sprintf("%s (%s, last commit %s)", candidate.Name, strings.Join(candidate.Reasons, ", "), age)
+}
+
+// shortHash shortens a commit hash for display
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -15,6 +15,7 @@ func NewRepoCmd() *cobra.Command {
 	// Add subcommands
 	cmd.AddCommand(NewSetupCmd())
 	cmd.AddCommand(NewShowCmd())
+	cmd.AddCommand(NewCleanCmd())
 
 	return cmd
 }
@@ -27,6 +27,7 @@ This command helps you configure:
 - Branch templates (project standard)
 - Pull request templates (project standard)
 - Auto-accept change type in PR creation (personal preference)
+- GitHub account bound to the repository (personal preference)
 
 Project standard configurations are saved to .workflow/config.toml (can be committed to Git).
 Personal preference configurations are saved to your global config directory (not committed).`,
@@ -167,6 +168,7 @@ func collectConfig(manager *config.RepoManager) (*repoConfig, error) {
 	// Prepare existing values
 	currentPrefix := manager.GetBranchPrefix()
 	currentAutoAccept := manager.GetAutoAcceptChangeType()
+	accountOptions, currentAccountIndex := githubAccountOptions(manager.GetGitHubAccount())
 
 	// Get existing template config
 	templateConfig := manager.GetTemplateConfig()
@@ -194,6 +196,14 @@ func collectConfig(manager *config.RepoManager) (*repoConfig, error) {
 			Prompt:       "Auto-accept auto-selected change type in PR creation? (skip confirmation prompt)",
 			DefaultValue: currentAutoAccept,
 		})
+	if len(accountOptions) > 1 {
+		personalForm.AddSelect(form.SelectFormField{
+			Key:          "github_account",
+			Prompt:       "GitHub account for this repository:",
+			Options:      accountOptions,
+			DefaultIndex: currentAccountIndex,
+		})
+	}
 
 	personalResult, err := personalForm.Run()
 	if err != nil {
@@ -267,6 +277,7 @@ func collectConfig(manager *config.RepoManager) (*repoConfig, error) {
 		// Personal preference
 		branchPrefix:         strings.TrimSpace(personalResult.GetString("branch_prefix")),
 		autoAcceptChangeType: personalResult.GetBool("auto_accept_change_type"),
+		githubAccount:        manager.GetGitHubAccount(),
 		// Project template
 		useScope:                projectResult.GetBool("use_scope"),
 		configureCommitTemplate: projectResult.GetBool("configure_commit_template"),
@@ -277,6 +288,10 @@ func collectConfig(manager *config.RepoManager) (*repoConfig, error) {
 		customPRTemplate:        strings.TrimSpace(projectResult.GetString("custom_pr_template")),
 	}
 
+	if len(accountOptions) > 1 {
+		cfg.githubAccount = selectedGitHubAccount(accountOptions, personalResult.GetInt("github_account"))
+	}
+
 	return cfg, nil
 }
 
@@ -285,6 +300,7 @@ type repoConfig struct {
 	// Personal preference
 	branchPrefix         string
 	autoAcceptChangeType bool
+	githubAccount        string
 	// Project template
 	useScope                bool
 	configureCommitTemplate bool
@@ -370,6 +386,13 @@ func savePrivateConfig(manager *config.RepoManager, cfg *repoConfig) error {
 	// Update auto-accept change type
 	repoSection.AutoAcceptChangeType = &cfg.autoAcceptChangeType
 
+	// Update GitHub account binding (empty means select automatically)
+	if cfg.githubAccount != "" {
+		repoSection.GitHubAccount = &cfg.githubAccount
+	} else {
+		repoSection.GitHubAccount = nil
+	}
+
 	// Save back
 	privateConfig.Repositories[repoID] = repoSection
 
@@ -379,6 +402,39 @@ func savePrivateConfig(manager *config.RepoManager, cfg *repoConfig) error {
 
 // Helper functions
 
+// autoGitHubAccount is the option for not binding a GitHub account to the repository
+const autoGitHubAccount = "(automatic: account rules, then current account)"
+
+// githubAccountOptions lists the GitHub accounts that can be bound to the repository
+//
+// The first option leaves the repository unbound. Returns the options and the
+// index of the currently bound account.
+func githubAccountOptions(bound string) ([]string, int) {
+	options := []string{autoGitHubAccount}
+
+	manager, err := config.Global()
+	if err != nil || manager.Load() != nil {
+		return options, 0
+	}
+
+	current := 0
+	for _, account := range manager.GetGitHubConfig().Accounts {
+		if account.Name == bound {
+			current = len(options)
+		}
+		options = append(options, account.Name)
+	}
+	return options, current
+}
+
+// selectedGitHubAccount returns the account name for the selected option, empty for automatic selection
+func selectedGitHubAccount(options []string, index int) string {
+	if index <= 0 || index >= len(options) {
+		return ""
+	}
+	return options[index]
+}
+
 func getBranchPrefixPrompt(currentPrefix string) string {
 	if currentPrefix != "" {
 		return fmt.Sprintf("Enter branch prefix (press Enter to keep current: %s):", currentPrefix)
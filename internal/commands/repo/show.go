@@ -60,6 +60,10 @@ func runShow(cmd *cobra.Command, args []string) error {
 		msg.Info("Run 'workflow repo setup' to configure branch prefix")
 	}
 
+	if account := manager.GetGitHubAccount(); account != "" {
+		msg.Info("GitHub account: %s (personal preference)", account)
+	}
+
 	// 3. Show template configuration
 	msg.Break()
 	msg.Info("Template Configuration")
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/zevwings/workflow/internal/config"
 	"github.com/zevwings/workflow/internal/infrastructure/verify"
+	"github.com/zevwings/workflow/internal/llm"
 	"github.com/zevwings/workflow/internal/prompt"
 	"github.com/zevwings/workflow/internal/prompt/form"
 )
@@ -357,7 +358,7 @@ func handleLLMConfig(msg *prompt.Message, cfg *config.GlobalConfig, configExists
 	}
 
 	// Select LLM provider type
-	providerOptions := []string{"openai", "deepseek", "proxy"}
+	providerOptions := config.LLMProviders
 	providerPrompt := "Please select your LLM provider (required)"
 	if hasLLM {
 		providerPrompt = fmt.Sprintf("Please select your LLM provider [current: %s]", cfg.LLM.Provider)
@@ -383,10 +384,218 @@ func handleLLMConfig(msg *prompt.Message, cfg *config.GlobalConfig, configExists
 		return err
 	}
 
+	provider := providerOptions[providerIndex]
+	if err := configureLLMProvider(cfg, provider); err != nil {
+		return err
+	}
+	cfg.LLM.Provider = provider
+
+	// Select Output Language
+	languageOptions := config.GetSupportedLanguageDisplayNames()
+	languageCodes := config.GetSupportedLanguageCodes()
+	defaultLanguageIndex := 0 // English is the default
+
+	// If configuration exists, find the corresponding index
+	if cfg.LLM.Language != "" {
+		for i, code := range languageCodes {
+			if code == cfg.LLM.Language {
+				defaultLanguageIndex = i
+				break
+			}
+		}
+	}
+
+	languagePrompt := "Please select your output language (required)"
+	if cfg.LLM.Language != "" {
+		lang := config.FindLanguage(cfg.LLM.Language)
+		if lang != nil {
+			languagePrompt = fmt.Sprintf("Please select your output language [current: %s]", lang.NativeName)
+		} else {
+			languagePrompt = fmt.Sprintf("Please select your output language [current: %s]", cfg.LLM.Language)
+		}
+	}
+
+	languageIndex, err := prompt.AskSelect(prompt.SelectField{
+		Message:      languagePrompt,
+		Options:      languageOptions,
+		DefaultIndex: defaultLanguageIndex,
+		ResultTitle:  "Your output language",
+	})
+	if err != nil {
+		return err
+	}
+
+	cfg.LLM.Language = languageCodes[languageIndex]
+
+	if err := handleLLMFallbacks(cfg); err != nil {
+		return err
+	}
+	return handleLLMRoutes(cfg)
+}
+
+// handleLLMFallbacks handles the providers tried when the LLM provider fails
+//
+// Fallbacks are used on server errors, rate limits and timeouts. The order of fallbacks that
+// are kept is preserved, new ones are appended in the order of the options.
+func handleLLMFallbacks(cfg *config.GlobalConfig) error {
+	var options []string
+	var defaultSelected []int
+	for _, provider := range config.LLMProviders {
+		if provider == cfg.LLM.Provider {
+			continue
+		}
+		for _, fallback := range cfg.LLM.Fallbacks {
+			if fallback == provider {
+				defaultSelected = append(defaultSelected, len(options))
+			}
+		}
+		options = append(options, provider)
+	}
+
+	selected, err := prompt.AskMultiSelect(prompt.MultiSelectField{
+		Message:         fmt.Sprintf("Select fallback providers, used when %s fails with a server error, rate limit or timeout (optional)", cfg.LLM.Provider),
+		Options:         options,
+		DefaultSelected: defaultSelected,
+		ResultTitle:     "Your fallback providers",
+	})
+	if err != nil {
+		return err
+	}
+
+	selectedProviders := map[string]bool{}
+	for _, index := range selected {
+		selectedProviders[options[index]] = true
+	}
+	var fallbacks []string
+	for _, fallback := range cfg.LLM.Fallbacks {
+		if selectedProviders[fallback] {
+			fallbacks = append(fallbacks, fallback)
+			delete(selectedProviders, fallback)
+		}
+	}
+	for _, index := range selected {
+		if provider := options[index]; selectedProviders[provider] {
+			fallbacks = append(fallbacks, provider)
+		}
+	}
+
+	for _, provider := range fallbacks {
+		if err := ensureLLMProvider(cfg, provider); err != nil {
+			return err
+		}
+	}
+	cfg.LLM.Fallbacks = fallbacks
+
+	return nil
+}
+
+// handleLLMRoutes handles the providers and models used for specific features
+func handleLLMRoutes(cfg *config.GlobalConfig) error {
+	configureRoutes, err := prompt.AskConfirm(prompt.ConfirmField{
+		Message:     "Do you want to use another provider or model for specific features (e.g. a cheaper model for translation)?",
+		DefaultYes:  len(cfg.LLM.Routes) > 0,
+		ResultTitle: "Route features",
+	})
+	if err != nil {
+		return err
+	}
+	if !configureRoutes {
+		cfg.LLM.Routes = nil
+		return nil
+	}
+
+	features := llm.LLMFeatures
+	var defaultSelected []int
+	for i, feature := range features {
+		for _, route := range cfg.LLM.Routes {
+			if route.Feature == feature {
+				defaultSelected = append(defaultSelected, i)
+			}
+		}
+	}
+
+	selected, err := prompt.AskMultiSelect(prompt.MultiSelectField{
+		Message:         "Select the features to route",
+		Options:         features,
+		DefaultSelected: defaultSelected,
+		ResultTitle:     "Routed features",
+	})
+	if err != nil {
+		return err
+	}
+
+	var routes []config.LLMRoute
+	for _, index := range selected {
+		feature := features[index]
+		currentProvider, currentModel := cfg.LLM.Route(feature)
+
+		defaultProviderIndex := 0
+		for i, provider := range config.LLMProviders {
+			if provider == currentProvider {
+				defaultProviderIndex = i
+				break
+			}
+		}
+		providerIndex, err := prompt.AskSelect(prompt.SelectField{
+			Message:      fmt.Sprintf("Please select the LLM provider for %s [current: %s]", feature, currentProvider),
+			Options:      config.LLMProviders,
+			DefaultIndex: defaultProviderIndex,
+			ResultTitle:  fmt.Sprintf("Provider for %s", feature),
+		})
+		if err != nil {
+			return err
+		}
+		provider := config.LLMProviders[providerIndex]
+		if err := ensureLLMProvider(cfg, provider); err != nil {
+			return err
+		}
+
+		modelDefaultValue := ""
+		if provider == currentProvider {
+			modelDefaultValue = currentModel
+		}
+		model, err := prompt.AskInput(prompt.InputField{
+			Message:      fmt.Sprintf("Please enter the model for %s (leave empty to use the %s model)", feature, provider),
+			DefaultValue: modelDefaultValue,
+			ResultTitle:  fmt.Sprintf("Model for %s", feature),
+		})
+		if err != nil {
+			return err
+		}
+
+		route := config.LLMRoute{Feature: feature, Model: strings.TrimSpace(model)}
+		if provider != cfg.LLM.Provider {
+			route.Provider = provider
+		}
+		if route.Provider == "" && route.Model == "" {
+			// Same as the default provider and model
+			continue
+		}
+		routes = append(routes, route)
+	}
+	cfg.LLM.Routes = routes
+
+	return nil
+}
+
+// ensureLLMProvider asks for the configuration of a fallback or routed provider if it is incomplete
+func ensureLLMProvider(cfg *config.GlobalConfig, provider string) error {
+	apiKey, _, _, err := cfg.LLM.ProviderSettings(provider)
+	if err == nil && (apiKey != "" || provider == "ollama") {
+		return nil
+	}
+	return configureLLMProvider(cfg, provider)
+}
+
+// configureLLMProvider asks for the API key, model and URL of an LLM provider
+//
+// Used for the main provider as well as fallback and routed providers. Does not change cfg.LLM.Provider.
+func configureLLMProvider(cfg *config.GlobalConfig, provider string) error {
 	var result *prompt.FormResult
+	var err error
 
-	switch providerIndex {
-	case 0: // OpenAI
+	switch provider {
+	case "openai":
 		apiKeyPrompt := "Please enter your OpenAI API key (required)"
 		var apiKeyDefaultValue string
 		if cfg.LLM.OpenAI.APIKey != "" {
@@ -427,7 +636,6 @@ func handleLLMConfig(msg *prompt.Message, cfg *config.GlobalConfig, configExists
 		if err != nil {
 			return fmt.Errorf("failed to configure OpenAI: %w", err)
 		}
-		cfg.LLM.Provider = "openai"
 		apiKey := result.GetString("api_key")
 		if apiKey != "" {
 			cfg.LLM.OpenAI.APIKey = apiKey
@@ -437,7 +645,7 @@ func handleLLMConfig(msg *prompt.Message, cfg *config.GlobalConfig, configExists
 			cfg.LLM.OpenAI.Model = model
 		}
 
-	case 1: // DeepSeek
+	case "deepseek":
 		apiKeyPrompt := "Please enter your DeepSeek API key (required)"
 		var apiKeyDefaultValue string
 		if cfg.LLM.DeepSeek.APIKey != "" {
@@ -478,7 +686,6 @@ func handleLLMConfig(msg *prompt.Message, cfg *config.GlobalConfig, configExists
 		if err != nil {
 			return fmt.Errorf("failed to configure DeepSeek: %w", err)
 		}
-		cfg.LLM.Provider = "deepseek"
 		apiKey := result.GetString("api_key")
 		if apiKey != "" {
 			cfg.LLM.DeepSeek.APIKey = apiKey
@@ -488,7 +695,7 @@ func handleLLMConfig(msg *prompt.Message, cfg *config.GlobalConfig, configExists
 			cfg.LLM.DeepSeek.Model = model
 		}
 
-	case 2: // Custom Provider (Proxy)
+	case "proxy":
 		urlPrompt := "Please enter your LLM proxy URL (required)"
 		if cfg.LLM.Proxy.URL != "" {
 			urlPrompt = "Please enter your LLM proxy URL (press Enter to keep)"
@@ -540,7 +747,6 @@ func handleLLMConfig(msg *prompt.Message, cfg *config.GlobalConfig, configExists
 		if err != nil {
 			return fmt.Errorf("failed to configure custom provider (proxy): %w", err)
 		}
-		cfg.LLM.Provider = "proxy"
 		url := result.GetString("url")
 		if url != "" {
 			cfg.LLM.Proxy.URL = url
@@ -559,44 +765,95 @@ func handleLLMConfig(msg *prompt.Message, cfg *config.GlobalConfig, configExists
 		} else if cfg.LLM.Proxy.Model == "" {
 			return fmt.Errorf("Model is required for proxy provider")
 		}
-	}
 
-	// Select Output Language
-	languageOptions := config.GetSupportedLanguageDisplayNames()
-	languageCodes := config.GetSupportedLanguageCodes()
-	defaultLanguageIndex := 0 // English is the default
+	case "anthropic":
+		apiKeyPrompt := "Please enter your Anthropic API key (required)"
+		var apiKeyDefaultValue string
+		if cfg.LLM.Anthropic.APIKey != "" {
+			apiKeyPrompt = "Please enter your Anthropic API key (press Enter to keep)"
+			apiKeyDefaultValue = cfg.LLM.Anthropic.APIKey
+		}
 
-	// If configuration exists, find the corresponding index
-	if cfg.LLM.Language != "" {
-		for i, code := range languageCodes {
-			if code == cfg.LLM.Language {
-				defaultLanguageIndex = i
-				break
-			}
+		modelPrompt := "Please enter your Anthropic model (required)"
+		var modelDefaultValue string
+		if cfg.LLM.Anthropic.Model != "" {
+			modelPrompt = "Please enter your Anthropic model (press Enter to keep)"
+			modelDefaultValue = cfg.LLM.Anthropic.Model
 		}
-	}
 
-	languagePrompt := "Please select your output language (required)"
-	if cfg.LLM.Language != "" {
-		lang := config.FindLanguage(cfg.LLM.Language)
-		if lang != nil {
-			languagePrompt = fmt.Sprintf("Please select your output language [current: %s]", lang.NativeName)
-		} else {
-			languagePrompt = fmt.Sprintf("Please select your output language [current: %s]", cfg.LLM.Language)
+		result, err = prompt.Form().
+			SetTitle("Anthropic Configuration").
+			AddPassword(form.PasswordFormField{
+				Key:          "api_key",
+				Prompt:       apiKeyPrompt,
+				DefaultValue: apiKeyDefaultValue,
+				Validator:    prompt.ValidateRequired(),
+				ResultTitle:  "Your Anthropic API key",
+			}).
+			AddInput(form.InputFormField{
+				Key:          "model",
+				Prompt:       modelPrompt,
+				DefaultValue: modelDefaultValue,
+				Validator:    prompt.ValidateRequired(),
+				ResultTitle:  "Your Anthropic model",
+			}).
+			Run()
+		if err != nil {
+			return fmt.Errorf("failed to configure Anthropic: %w", err)
+		}
+		apiKey := result.GetString("api_key")
+		if apiKey != "" {
+			cfg.LLM.Anthropic.APIKey = apiKey
+		}
+		model := result.GetString("model")
+		if model != "" {
+			cfg.LLM.Anthropic.Model = model
 		}
-	}
 
-	languageIndex, err := prompt.AskSelect(prompt.SelectField{
-		Message:      languagePrompt,
-		Options:      languageOptions,
-		DefaultIndex: defaultLanguageIndex,
-		ResultTitle:  "Your output language",
-	})
-	if err != nil {
-		return err
-	}
+	case "ollama":
+		urlDefaultValue := cfg.LLM.Ollama.URL
+		if urlDefaultValue == "" {
+			urlDefaultValue = config.DefaultOllamaURL
+		}
 
-	cfg.LLM.Language = languageCodes[languageIndex]
+		modelPrompt := "Please enter your Ollama model, e.g. llama3.2 (required)"
+		if cfg.LLM.Ollama.Model != "" {
+			modelPrompt = "Please enter your Ollama model (press Enter to keep)"
+		}
+
+		result, err = prompt.Form().
+			SetTitle("Ollama Configuration").
+			AddInput(form.InputFormField{
+				Key:          "url",
+				Prompt:       "Please enter your Ollama URL (press Enter to keep)",
+				DefaultValue: urlDefaultValue,
+				Validator:    prompt.ValidateRequired(),
+				ResultTitle:  "Your Ollama URL",
+			}).
+			AddInput(form.InputFormField{
+				Key:          "model",
+				Prompt:       modelPrompt,
+				DefaultValue: cfg.LLM.Ollama.Model,
+				Validator:    prompt.ValidateRequired(),
+				ResultTitle:  "Your Ollama model",
+			}).
+			Run()
+		if err != nil {
+			return fmt.Errorf("failed to configure Ollama: %w", err)
+		}
+		url := result.GetString("url")
+		if url == config.DefaultOllamaURL {
+			// Keep the default out of the config file
+			url = ""
+		}
+		cfg.LLM.Ollama.URL = url
+		model := result.GetString("model")
+		if model != "" {
+			cfg.LLM.Ollama.Model = model
+		} else if cfg.LLM.Ollama.Model == "" {
+			return fmt.Errorf("Model is required for ollama provider")
+		}
+	}
 
 	return nil
 }
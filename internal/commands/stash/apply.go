@@ -0,0 +1,35 @@
+package stash
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+// NewApplyCmd creates the stash apply command
+func NewApplyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "apply [STASH]",
+		Short: "Apply a stash and keep it",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runApply,
+	}
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	stash, err := openStash()
+	if err != nil {
+		return err
+	}
+
+	ref, err := resolveStash(stash, args, "apply")
+	if err != nil {
+		return err
+	}
+
+	if err := stash.Apply(ref); err != nil {
+		return handleApplyError(err, "应用")
+	}
+
+	prompt.GetMessage().Success("Applied %s", ref)
+	return nil
+}
@@ -0,0 +1,59 @@
+package stash
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+var dropYes bool
+
+// NewDropCmd creates the stash drop command
+func NewDropCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drop [STASH]",
+		Short: "Drop a stash",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runDrop,
+	}
+
+	cmd.Flags().BoolVarP(&dropYes, "yes", "y", false, "Drop without confirmation")
+
+	return cmd
+}
+
+func runDrop(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	stash, err := openStash()
+	if err != nil {
+		return err
+	}
+
+	ref, err := resolveStash(stash, args, "drop")
+	if err != nil {
+		return err
+	}
+
+	if !dropYes {
+		confirmed, err := prompt.Confirm().
+			Prompt(fmt.Sprintf("Drop %s? The changes in it will be lost", ref)).
+			Default(false).
+			Run()
+		if err != nil {
+			return fmt.Errorf("确认失败: %w", err)
+		}
+		if !confirmed {
+			msg.Info("Cancelled")
+			return nil
+		}
+	}
+
+	if err := stash.Drop(ref); err != nil {
+		return fmt.Errorf("删除 %s 失败: %w", ref, err)
+	}
+
+	msg.Success("Dropped %s", ref)
+	return nil
+}
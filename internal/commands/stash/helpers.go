@@ -0,0 +1,89 @@
+package stash
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zevwings/workflow/internal/git"
+	"github.com/zevwings/workflow/internal/prompt"
+	"github.com/zevwings/workflow/internal/util"
+)
+
+// openStash opens the stash of the repository in the current directory
+func openStash() (*git.Stash, error) {
+	gitRepo, err := git.OpenCurrent()
+	if err != nil {
+		return nil, fmt.Errorf("不在 Git 仓库中: %w", err)
+	}
+	return gitRepo.Stash(), nil
+}
+
+// resolveStash returns the stash reference from the arguments
+//
+// Accepts stash@{N} or N. When omitted, the user picks one of the stashes.
+func resolveStash(stash *git.Stash, args []string, action string) (string, error) {
+	if len(args) > 0 && strings.TrimSpace(args[0]) != "" {
+		ref := strings.TrimSpace(args[0])
+		if _, err := strconv.Atoi(ref); err == nil {
+			ref = fmt.Sprintf("stash@{%s}", ref)
+		}
+		return ref, nil
+	}
+
+	entries, err := stash.List(false)
+	if err != nil {
+		return "", fmt.Errorf("获取 stash 列表失败: %w", err)
+	}
+	if len(entries) == 0 {
+		return "", fmt.Errorf("没有 stash")
+	}
+
+	now := time.Now()
+	options := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		options = append(options, describeEntry(entry, now))
+	}
+
+	index, err := prompt.Select().
+		Prompt(fmt.Sprintf("Select a stash to %s:", action)).
+		Options(options).
+		Run()
+	if err != nil {
+		return "", fmt.Errorf("选择 stash 失败: %w", err)
+	}
+	return entries[index].Ref, nil
+}
+
+// describeEntry formats a stash as a single line for the picker
+func describeEntry(entry git.StashEntry, now time.Time) string {
+	branch := entry.Branch
+	if branch == "" {
+		branch = "-"
+	}
+	return fmt.Sprintf("%s  %s  %s  %s", entry.Ref, branch, util.FormatAge(now.Sub(entry.Date)), entry.Message)
+}
+
+// handleApplyError explains how to recover from conflicts while applying a stash
+func handleApplyError(err error, action string) error {
+	var conflict *git.StashConflictError
+	if !errors.As(err, &conflict) {
+		return fmt.Errorf("%s stash 失败: %w", action, err)
+	}
+
+	msg := prompt.GetMessage()
+	msg.Break()
+	msg.Warning("Applying %s produced conflicts", conflict.Ref)
+	for _, file := range conflict.Files {
+		msg.Print("  - %s", file)
+	}
+	msg.Break()
+	msg.Info("To recover:")
+	msg.Print("  1. Resolve the conflicts in the files above and stage them with 'git add'")
+	msg.Print("  2. Or undo the partially applied stash with 'git reset --merge'")
+	msg.Print("  %s was kept; drop it with 'workflow stash drop %s' once the changes are restored", conflict.Ref, conflict.Ref)
+
+	return fmt.Errorf("应用 %s 时发生冲突", conflict.Ref)
+}
@@ -0,0 +1,99 @@
+package stash
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/git"
+	"github.com/zevwings/workflow/internal/prompt"
+	"github.com/zevwings/workflow/internal/util"
+)
+
+var listStat bool
+
+// NewListCmd creates the stash list command
+func NewListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List stashes",
+		Long: `List stashes with the branch they were created on, their age and message.
+
+With --stat, the changed files of each stash are listed with their added and
+deleted lines.`,
+		Args: cobra.NoArgs,
+		RunE: runList,
+	}
+
+	cmd.Flags().BoolVarP(&listStat, "stat", "s", false, "Show per-file diffstat")
+
+	return cmd
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	stash, err := openStash()
+	if err != nil {
+		return err
+	}
+
+	entries, err := stash.List(listStat)
+	if err != nil {
+		return fmt.Errorf("获取 stash 列表失败: %w", err)
+	}
+	if len(entries) == 0 {
+		msg.Info("No stashes")
+		return nil
+	}
+
+	now := time.Now()
+	if !listStat {
+		table := prompt.NewTable([]string{"Stash", "Branch", "Age", "Message"})
+		table.SetRowLine(false)
+		for _, entry := range entries {
+			table.AddRow([]string{entry.Ref, entry.Branch, util.FormatAge(now.Sub(entry.Date)), entry.Message})
+		}
+		table.Render()
+		return nil
+	}
+
+	for i, entry := range entries {
+		if i > 0 {
+			msg.Break()
+		}
+		msg.Info("%s", describeEntry(entry, now))
+		printStat(entry.Files)
+	}
+	return nil
+}
+
+// printStat prints the per-file diffstat of a stash
+func printStat(files []git.StashFileStat) {
+	msg := prompt.GetMessage()
+
+	if len(files) == 0 {
+		msg.Print("  (no tracked changes)")
+		return
+	}
+
+	table := prompt.NewTable([]string{"File", "Added", "Deleted"})
+	table.SetRowLine(false)
+	added, deleted := 0, 0
+	for _, file := range files {
+		path := file.Path
+		if file.Untracked {
+			path += " (untracked)"
+		}
+		if file.Binary {
+			table.AddRow([]string{path, "binary", "binary"})
+			continue
+		}
+		added += file.Added
+		deleted += file.Deleted
+		table.AddRow([]string{path, "+" + strconv.Itoa(file.Added), "-" + strconv.Itoa(file.Deleted)})
+	}
+	table.Render()
+	msg.Print("  %d file(s) changed, %d insertion(s), %d deletion(s)", len(files), added, deleted)
+}
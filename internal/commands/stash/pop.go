@@ -0,0 +1,38 @@
+package stash
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+// NewPopCmd creates the stash pop command
+func NewPopCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pop [STASH]",
+		Short: "Apply a stash and drop it",
+		Long: `Apply a stash and drop it.
+
+When applying produces conflicts, the stash is kept.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runPop,
+	}
+}
+
+func runPop(cmd *cobra.Command, args []string) error {
+	stash, err := openStash()
+	if err != nil {
+		return err
+	}
+
+	ref, err := resolveStash(stash, args, "pop")
+	if err != nil {
+		return err
+	}
+
+	if err := stash.Pop(ref); err != nil {
+		return handleApplyError(err, "应用")
+	}
+
+	prompt.GetMessage().Success("Applied and dropped %s", ref)
+	return nil
+}
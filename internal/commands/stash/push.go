@@ -0,0 +1,58 @@
+package stash
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/zevwings/workflow/internal/git"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+var (
+	pushMessage          string
+	pushIncludeUntracked bool
+)
+
+// NewPushCmd creates the stash push command
+func NewPushCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "push [-- PATHSPEC...]",
+		Short: "Save local changes to a new stash",
+		Long: `Save local changes to a new stash and revert the working tree.
+
+Pathspecs limit the stash to matching paths. Untracked files are only
+included with --include-untracked.`,
+		RunE: runPush,
+	}
+
+	cmd.Flags().StringVarP(&pushMessage, "message", "m", "", "Stash message")
+	cmd.Flags().BoolVarP(&pushIncludeUntracked, "include-untracked", "u", false, "Include untracked files")
+
+	return cmd
+}
+
+func runPush(cmd *cobra.Command, args []string) error {
+	msg := prompt.GetMessage()
+
+	stash, err := openStash()
+	if err != nil {
+		return err
+	}
+
+	err = stash.Push(git.StashPushOptions{
+		Message:          pushMessage,
+		IncludeUntracked: pushIncludeUntracked,
+		Pathspecs:        args,
+	})
+	if errors.Is(err, git.ErrNoLocalChanges) {
+		msg.Info("No local changes to save")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("保存 stash 失败: %w", err)
+	}
+
+	msg.Success("Saved local changes to stash@{0}")
+	return nil
+}
@@ -0,0 +1,26 @@
+package stash
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewStashCmd creates the stash command
+func NewStashCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stash",
+		Short: "Git stash management",
+		Long: `List, save, apply and drop git stashes.
+
+apply, pop and drop take a stash reference (stash@{N} or N); without it,
+a stash is picked interactively.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(NewListCmd())
+	cmd.AddCommand(NewPushCmd())
+	cmd.AddCommand(NewApplyCmd())
+	cmd.AddCommand(NewPopCmd())
+	cmd.AddCommand(NewDropCmd())
+
+	return cmd
+}
@@ -1,5 +1,21 @@
 package config
 
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// GitHub 账号的选择来源
+const (
+	AccountSourceRepo    = "repo"    // 仓库私有配置中绑定的账号
+	AccountSourceRule    = "rule"    // 远程仓库所有者匹配账号规则
+	AccountSourceCurrent = "current" // 全局当前账号
+)
+
+// accountRulePrefixes 账号规则支持的前缀，均匹配远程仓库的所有者
+var accountRulePrefixes = []string{"org:", "user:", "owner:"}
+
 // GitHubConfig GitHub 配置
 type GitHubConfig struct {
 	Accounts []GitHubAccount `toml:"accounts,omitempty"`
@@ -11,4 +27,219 @@ type GitHubAccount struct {
 	Name     string `toml:"name,omitempty"`
 	Email    string `toml:"email,omitempty"`
 	APIToken string `toml:"api_token,omitempty"`
+	// Rules 账号规则，远程仓库所有者匹配时自动使用此账号
+	// 格式：org:NAME、user:NAME 或 owner:NAME，NAME 支持通配符（如 "org:acme-*"）
+	Rules []string `toml:"rules,omitempty"`
+}
+
+// GitHubAccountMatch 账号选择结果
+type GitHubAccountMatch struct {
+	Account *GitHubAccount
+	Source  string // repo、rule 或 current
+	Rule    string // Source 为 rule 时匹配的规则
+	// StaleBinding 仓库绑定但已不存在（被重命名或删除）的账号名称，为空表示没有失效的绑定
+	StaleBinding string
+}
+
+// Reason 返回选择此账号的原因说明
+func (m *GitHubAccountMatch) Reason() string {
+	var reason string
+	switch m.Source {
+	case AccountSourceRepo:
+		reason = "bound to this repository"
+	case AccountSourceRule:
+		reason = fmt.Sprintf("remote owner matches rule %q", m.Rule)
+	default:
+		reason = "current global account"
+	}
+	if m.StaleBinding != "" {
+		reason += fmt.Sprintf(" (bound account %q no longer exists)", m.StaleBinding)
+	}
+	return reason
+}
+
+// FindAccount 按名称查找 GitHub 账号
+//
+// 参数:
+//   - name: 账号名称
+//
+// 返回:
+//   - *GitHubAccount: 账号（指向 Accounts 中的元素），未找到时返回 nil
+func (c *GitHubConfig) FindAccount(name string) *GitHubAccount {
+	for i := range c.Accounts {
+		if c.Accounts[i].Name == name {
+			return &c.Accounts[i]
+		}
+	}
+	return nil
+}
+
+// AddAccount 添加 GitHub 账号
+//
+// 如果还没有当前账号，新账号会成为当前账号。
+//
+// 参数:
+//   - account: 要添加的账号
+//
+// 返回:
+//   - error: 如果名称为空或已存在，返回错误
+func (c *GitHubConfig) AddAccount(account GitHubAccount) error {
+	if account.Name == "" {
+		return fmt.Errorf("账号名称不能为空")
+	}
+	if c.FindAccount(account.Name) != nil {
+		return fmt.Errorf("GitHub 账号 %s 已存在", account.Name)
+	}
+
+	c.Accounts = append(c.Accounts, account)
+	if c.Current == "" {
+		c.Current = account.Name
+	}
+	return nil
+}
+
+// RenameAccount 重命名 GitHub 账号
+//
+// 如果被重命名的是当前账号，同时更新 Current。
+//
+// 参数:
+//   - oldName: 原账号名称
+//   - newName: 新账号名称
+//
+// 返回:
+//   - error: 如果原账号不存在或新名称已被使用，返回错误
+func (c *GitHubConfig) RenameAccount(oldName, newName string) error {
+	if oldName == newName {
+		return nil
+	}
+	if newName == "" {
+		return fmt.Errorf("账号名称不能为空")
+	}
+
+	account := c.FindAccount(oldName)
+	if account == nil {
+		return fmt.Errorf("未找到 GitHub 账号: %s", oldName)
+	}
+	if c.FindAccount(newName) != nil {
+		return fmt.Errorf("GitHub 账号 %s 已存在", newName)
+	}
+
+	account.Name = newName
+	if c.Current == oldName {
+		c.Current = newName
+	}
+	return nil
+}
+
+// RemoveAccount 删除 GitHub 账号
+//
+// 如果删除的是当前账号，剩余的第一个账号成为当前账号。
+//
+// 参数:
+//   - name: 账号名称
+//
+// 返回:
+//   - error: 如果账号不存在，返回错误
+func (c *GitHubConfig) RemoveAccount(name string) error {
+	for i := range c.Accounts {
+		if c.Accounts[i].Name != name {
+			continue
+		}
+
+		c.Accounts = append(c.Accounts[:i], c.Accounts[i+1:]...)
+		if c.Current == name {
+			c.Current = ""
+			if len(c.Accounts) > 0 {
+				c.Current = c.Accounts[0].Name
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("未找到 GitHub 账号: %s", name)
+}
+
+// SwitchAccount 切换当前 GitHub 账号
+//
+// 参数:
+//   - name: 账号名称
+//
+// 返回:
+//   - error: 如果账号不存在，返回错误
+func (c *GitHubConfig) SwitchAccount(name string) error {
+	if c.FindAccount(name) == nil {
+		return fmt.Errorf("未找到 GitHub 账号: %s", name)
+	}
+	c.Current = name
+	return nil
+}
+
+// ResolveAccount 选择仓库使用的 GitHub 账号
+//
+// 选择顺序：
+//  1. 仓库私有配置中绑定的账号（boundName）
+//  2. 规则匹配远程仓库所有者（owner）的第一个账号
+//  3. 全局当前账号（Current，未设置时为第一个账号）
+//
+// 绑定的账号已被重命名或删除时，按规则和当前账号选择，并在 StaleBinding 中返回失效的账号名称。
+//
+// 参数:
+//   - boundName: 仓库绑定的账号名称（为空表示未绑定）
+//   - owner: 远程仓库所有者（为空时跳过规则匹配）
+//
+// 返回:
+//   - *GitHubAccountMatch: 选中的账号及原因
+//   - error: 如果没有可用账号，返回错误
+func (c *GitHubConfig) ResolveAccount(boundName, owner string) (*GitHubAccountMatch, error) {
+	staleBinding := ""
+	if boundName != "" {
+		if account := c.FindAccount(boundName); account != nil {
+			return &GitHubAccountMatch{Account: account, Source: AccountSourceRepo}, nil
+		}
+		staleBinding = boundName
+	}
+
+	if owner != "" {
+		for i := range c.Accounts {
+			for _, rule := range c.Accounts[i].Rules {
+				if MatchAccountRule(rule, owner) {
+					return &GitHubAccountMatch{Account: &c.Accounts[i], Source: AccountSourceRule, Rule: rule, StaleBinding: staleBinding}, nil
+				}
+			}
+		}
+	}
+
+	if len(c.Accounts) == 0 {
+		return nil, fmt.Errorf("未找到 GitHub 账号配置")
+	}
+	if c.Current == "" {
+		return &GitHubAccountMatch{Account: &c.Accounts[0], Source: AccountSourceCurrent, StaleBinding: staleBinding}, nil
+	}
+	account := c.FindAccount(c.Current)
+	if account == nil {
+		return nil, fmt.Errorf("未找到当前 GitHub 账号: %s", c.Current)
+	}
+	return &GitHubAccountMatch{Account: account, Source: AccountSourceCurrent, StaleBinding: staleBinding}, nil
+}
+
+// MatchAccountRule 判断账号规则是否匹配远程仓库所有者
+//
+// 匹配不区分大小写。GitLab 子组等多级所有者按第一级匹配。
+//
+// 参数:
+//   - rule: 账号规则（如 "org:acme"）
+//   - owner: 远程仓库所有者（如 "acme"）
+//
+// 返回:
+//   - bool: 是否匹配，规则格式无效时返回 false
+func MatchAccountRule(rule, owner string) bool {
+	rule = strings.ToLower(strings.TrimSpace(rule))
+	owner = strings.ToLower(strings.SplitN(owner, "/", 2)[0])
+
+	for _, prefix := range accountRulePrefixes {
+		if pattern, ok := strings.CutPrefix(rule, prefix); ok && pattern != "" {
+			matched, err := path.Match(pattern, owner)
+			return err == nil && matched
+		}
+	}
+	return false
 }
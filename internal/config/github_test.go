@@ -0,0 +1,298 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGitHubConfig() *GitHubConfig {
+	return &GitHubConfig{
+		Accounts: []GitHubAccount{
+			{Name: "personal", Email: "me@example.com", APIToken: "token-1"},
+			{Name: "work", Email: "me@work.com", APIToken: "token-2"},
+		},
+		Current: "work",
+	}
+}
+
+// ==================== FindAccount 测试 ====================
+
+func TestGitHubConfig_FindAccount(t *testing.T) {
+	cfg := newTestGitHubConfig()
+
+	account := cfg.FindAccount("work")
+	require.NotNil(t, account)
+	assert.Equal(t, "me@work.com", account.Email)
+
+	// 返回的指针指向配置中的账号
+	account.Email = "new@work.com"
+	assert.Equal(t, "new@work.com", cfg.Accounts[1].Email)
+
+	assert.Nil(t, cfg.FindAccount("missing"))
+}
+
+// ==================== AddAccount 测试 ====================
+
+func TestGitHubConfig_AddAccount(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *GitHubConfig
+		account     GitHubAccount
+		wantErr     bool
+		wantCurrent string
+		wantCount   int
+	}{
+		{
+			name:        "first account becomes current",
+			config:      &GitHubConfig{},
+			account:     GitHubAccount{Name: "personal"},
+			wantCurrent: "personal",
+			wantCount:   1,
+		},
+		{
+			name:        "keeps existing current",
+			config:      newTestGitHubConfig(),
+			account:     GitHubAccount{Name: "oss"},
+			wantCurrent: "work",
+			wantCount:   3,
+		},
+		{
+			name:        "duplicate name",
+			config:      newTestGitHubConfig(),
+			account:     GitHubAccount{Name: "work"},
+			wantErr:     true,
+			wantCurrent: "work",
+			wantCount:   2,
+		},
+		{
+			name:      "empty name",
+			config:    &GitHubConfig{},
+			account:   GitHubAccount{},
+			wantErr:   true,
+			wantCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.AddAccount(tt.account)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCurrent, tt.config.Current)
+			assert.Len(t, tt.config.Accounts, tt.wantCount)
+		})
+	}
+}
+
+// ==================== RenameAccount 测试 ====================
+
+func TestGitHubConfig_RenameAccount(t *testing.T) {
+	cfg := newTestGitHubConfig()
+
+	require.NoError(t, cfg.RenameAccount("work", "company"))
+	assert.Equal(t, "company", cfg.Current)
+	assert.NotNil(t, cfg.FindAccount("company"))
+	assert.Nil(t, cfg.FindAccount("work"))
+
+	assert.NoError(t, cfg.RenameAccount("personal", "personal"))
+	assert.Error(t, cfg.RenameAccount("personal", "company"))
+	assert.Error(t, cfg.RenameAccount("missing", "other"))
+	assert.Error(t, cfg.RenameAccount("personal", ""))
+}
+
+// ==================== RemoveAccount 测试 ====================
+
+func TestGitHubConfig_RemoveAccount(t *testing.T) {
+	tests := []struct {
+		name        string
+		remove      string
+		wantErr     bool
+		wantCurrent string
+		wantCount   int
+	}{
+		{
+			name:        "remove other account",
+			remove:      "personal",
+			wantCurrent: "work",
+			wantCount:   1,
+		},
+		{
+			name:        "remove current account",
+			remove:      "work",
+			wantCurrent: "personal",
+			wantCount:   1,
+		},
+		{
+			name:        "missing account",
+			remove:      "missing",
+			wantErr:     true,
+			wantCurrent: "work",
+			wantCount:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestGitHubConfig()
+			err := cfg.RemoveAccount(tt.remove)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCurrent, cfg.Current)
+			assert.Len(t, cfg.Accounts, tt.wantCount)
+		})
+	}
+}
+
+func TestGitHubConfig_RemoveAccount_LastAccount(t *testing.T) {
+	cfg := &GitHubConfig{Accounts: []GitHubAccount{{Name: "only"}}, Current: "only"}
+
+	require.NoError(t, cfg.RemoveAccount("only"))
+	assert.Empty(t, cfg.Accounts)
+	assert.Empty(t, cfg.Current)
+}
+
+// ==================== SwitchAccount 测试 ====================
+
+func TestGitHubConfig_SwitchAccount(t *testing.T) {
+	cfg := newTestGitHubConfig()
+
+	require.NoError(t, cfg.SwitchAccount("personal"))
+	assert.Equal(t, "personal", cfg.Current)
+
+	assert.Error(t, cfg.SwitchAccount("missing"))
+	assert.Equal(t, "personal", cfg.Current)
+}
+
+// ==================== MatchAccountRule 测试 ====================
+
+func TestMatchAccountRule(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		owner string
+		want  bool
+	}{
+		{name: "org rule", rule: "org:acme", owner: "acme", want: true},
+		{name: "user rule", rule: "user:octocat", owner: "octocat", want: true},
+		{name: "owner rule", rule: "owner:acme", owner: "acme", want: true},
+		{name: "case insensitive", rule: "org:ACME", owner: "Acme", want: true},
+		{name: "glob", rule: "org:acme-*", owner: "acme-labs", want: true},
+		{name: "different owner", rule: "org:acme", owner: "other", want: false},
+		{name: "subgroup matches first level", rule: "org:acme", owner: "acme/platform", want: true},
+		{name: "unknown prefix", rule: "team:acme", owner: "acme", want: false},
+		{name: "empty pattern", rule: "org:", owner: "acme", want: false},
+		{name: "invalid glob", rule: "org:[", owner: "acme", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchAccountRule(tt.rule, tt.owner))
+		})
+	}
+}
+
+// ==================== ResolveAccount 测试 ====================
+
+func TestGitHubConfig_ResolveAccount(t *testing.T) {
+	cfg := &GitHubConfig{
+		Accounts: []GitHubAccount{
+			{Name: "personal"},
+			{Name: "work", Rules: []string{"org:acme", "org:acme-*"}},
+		},
+		Current: "personal",
+	}
+
+	tests := []struct {
+		name       string
+		config     *GitHubConfig
+		bound      string
+		owner      string
+		wantName   string
+		wantSource string
+		wantRule   string
+		wantStale  string
+		wantErr    bool
+	}{
+		{
+			name:       "repository binding wins over rules",
+			config:     cfg,
+			bound:      "personal",
+			owner:      "acme",
+			wantName:   "personal",
+			wantSource: AccountSourceRepo,
+		},
+		{
+			name:       "owner matches rule",
+			config:     cfg,
+			owner:      "acme-labs",
+			wantName:   "work",
+			wantSource: AccountSourceRule,
+			wantRule:   "org:acme-*",
+		},
+		{
+			name:       "fall back to current",
+			config:     cfg,
+			owner:      "zevwings",
+			wantName:   "personal",
+			wantSource: AccountSourceCurrent,
+		},
+		{
+			name:       "no current uses first account",
+			config:     &GitHubConfig{Accounts: []GitHubAccount{{Name: "only"}}},
+			wantName:   "only",
+			wantSource: AccountSourceCurrent,
+		},
+		{
+			name:       "missing bound account falls back to rules",
+			config:     cfg,
+			bound:      "missing",
+			owner:      "acme",
+			wantName:   "work",
+			wantSource: AccountSourceRule,
+			wantRule:   "org:acme",
+			wantStale:  "missing",
+		},
+		{
+			name:       "missing bound account falls back to current",
+			config:     cfg,
+			bound:      "missing",
+			wantName:   "personal",
+			wantSource: AccountSourceCurrent,
+			wantStale:  "missing",
+		},
+		{
+			name:    "no accounts",
+			config:  &GitHubConfig{},
+			wantErr: true,
+		},
+		{
+			name:    "current account missing",
+			config:  &GitHubConfig{Accounts: []GitHubAccount{{Name: "only"}}, Current: "gone"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := tt.config.ResolveAccount(tt.bound, tt.owner)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, match.Account.Name)
+			assert.Equal(t, tt.wantSource, match.Source)
+			assert.Equal(t, tt.wantRule, match.Rule)
+			assert.Equal(t, tt.wantStale, match.StaleBinding)
+			assert.NotEmpty(t, match.Reason())
+		})
+	}
+}
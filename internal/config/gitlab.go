@@ -0,0 +1,8 @@
+package config
+
+// GitLabConfig GitLab 配置
+type GitLabConfig struct {
+	// BaseURL GitLab 实例地址（自建实例如 "https://gitlab.example.com"，为空时使用 gitlab.com）
+	BaseURL  string `toml:"base_url,omitempty"`
+	APIToken string `toml:"api_token,omitempty"`
+}
@@ -21,7 +21,7 @@ var (
 //
 // 管理用户级别的全局配置：遵循 XDG Base Directory Specification
 // 配置文件位置：$XDG_CONFIG_HOME/Workflow/config.toml（默认：~/.config/Workflow/config.toml）
-// 包含：用户信息、认证配置（GitHub、Jira）、工具配置（LLM、Proxy、Log）
+// 包含：用户信息、认证配置（GitHub、GitLab、Jira）、工具配置（LLM、Proxy、Log）
 //
 // 配置字段可以直接访问，例如：
 //   - manager.LLMConfig.Provider
@@ -36,11 +36,13 @@ type GlobalManager struct {
 	Config *GlobalConfig
 
 	// 便捷字段：直接访问子配置（指向 Config 中的对应字段）
-	LLMConfig    *LLMConfig    // 指向 Config.LLM
-	GitHubConfig *GitHubConfig // 指向 Config.GitHub
-	JiraConfig   *JiraConfig   // 指向 Config.Jira
-	LogConfig    *LogConfig    // 指向 Config.Log
-	ProxyConfig  *ProxyConfig  // 指向 Config.Proxy
+	LLMConfig      *LLMConfig      // 指向 Config.LLM
+	GitHubConfig   *GitHubConfig   // 指向 Config.GitHub
+	GitLabConfig   *GitLabConfig   // 指向 Config.GitLab
+	PlatformConfig *PlatformConfig // 指向 Config.Platform
+	JiraConfig     *JiraConfig     // 指向 Config.Jira
+	LogConfig      *LogConfig      // 指向 Config.Log
+	ProxyConfig    *ProxyConfig    // 指向 Config.Proxy
 }
 
 // newGlobalManager 创建全局配置管理器（私有函数）
@@ -80,6 +82,8 @@ func newGlobalManager() (*GlobalManager, error) {
 	// 初始化便捷字段，指向 Config 中的对应字段
 	manager.LLMConfig = &config.LLM
 	manager.GitHubConfig = &config.GitHub
+	manager.GitLabConfig = &config.GitLab
+	manager.PlatformConfig = &config.Platform
 	manager.JiraConfig = &config.Jira
 	manager.LogConfig = &config.Log
 	manager.ProxyConfig = &config.Proxy
@@ -103,6 +107,8 @@ func (m *GlobalManager) Load() error {
 		// 更新便捷字段的指针（指向零值 Config）
 		m.LLMConfig = &m.Config.LLM
 		m.GitHubConfig = &m.Config.GitHub
+		m.GitLabConfig = &m.Config.GitLab
+		m.PlatformConfig = &m.Config.Platform
 		m.JiraConfig = &m.Config.Jira
 		m.LogConfig = &m.Config.Log
 		m.ProxyConfig = &m.Config.Proxy
@@ -122,6 +128,8 @@ func (m *GlobalManager) Load() error {
 	// 更新便捷字段的指针（确保指向最新的 Config）
 	m.LLMConfig = &m.Config.LLM
 	m.GitHubConfig = &m.Config.GitHub
+	m.GitLabConfig = &m.Config.GitLab
+	m.PlatformConfig = &m.Config.Platform
 	m.JiraConfig = &m.Config.Jira
 	m.LogConfig = &m.Config.Log
 	m.ProxyConfig = &m.Config.Proxy
@@ -232,6 +240,34 @@ func (m *GlobalManager) GetCurrentGitHubAccount() (*GitHubAccount, error) {
 	return nil, fmt.Errorf("未找到当前 GitHub 账号: %s", githubConfig.Current)
 }
 
+// GetGitLabConfig 获取 GitLab 配置
+//
+// 返回 Config 字段中的 GitLab 配置的引用。
+// 可以直接使用 manager.Config.GitLab 或 manager.GitLabConfig 访问。
+//
+// 返回:
+//   - *GitLabConfig: GitLab 配置结构（指向 Config.GitLab）
+func (m *GlobalManager) GetGitLabConfig() *GitLabConfig {
+	if m.Config == nil {
+		return &GitLabConfig{}
+	}
+	return &m.Config.GitLab
+}
+
+// GetPlatformConfig 获取代码托管平台配置
+//
+// 返回 Config 字段中的平台配置的引用。
+// 可以直接使用 manager.Config.Platform 或 manager.PlatformConfig 访问。
+//
+// 返回:
+//   - *PlatformConfig: 平台配置结构（指向 Config.Platform）
+func (m *GlobalManager) GetPlatformConfig() *PlatformConfig {
+	if m.Config == nil {
+		return &PlatformConfig{}
+	}
+	return &m.Config.Platform
+}
+
 // GetJiraConfig 获取 Jira 配置
 //
 // 返回 Config 字段中的 Jira 配置的引用。
@@ -342,6 +378,13 @@ func (m *GlobalManager) getGlobalConfig() *GlobalConfig {
 					if apiToken, ok := accMap["api_token"].(string); ok {
 						account.APIToken = apiToken
 					}
+					if rules, ok := accMap["rules"].([]interface{}); ok {
+						for _, rule := range rules {
+							if str, ok := rule.(string); ok {
+								account.Rules = append(account.Rules, str)
+							}
+						}
+					}
 					if account.Name != "" || account.APIToken != "" {
 						cfg.GitHub.Accounts = append(cfg.GitHub.Accounts, account)
 					}
@@ -350,6 +393,15 @@ func (m *GlobalManager) getGlobalConfig() *GlobalConfig {
 		}
 	}
 
+	// 读取 GitLab 配置
+	cfg.GitLab.BaseURL = m.viper.GetString("gitlab.base_url")
+	cfg.GitLab.APIToken = m.viper.GetString("gitlab.api_token")
+
+	// 读取平台配置（主机名映射，viper 会将键转为小写）
+	if hosts := m.viper.GetStringMapString("platform.hosts"); len(hosts) > 0 {
+		cfg.Platform.Hosts = hosts
+	}
+
 	// 读取 Jira 配置
 	cfg.Jira.Email = m.viper.GetString("jira.email")
 	cfg.Jira.APIToken = m.viper.GetString("jira.api_token")
@@ -365,6 +417,90 @@ func (m *GlobalManager) getGlobalConfig() *GlobalConfig {
 	cfg.LLM.Proxy.URL = m.viper.GetString("llm.proxy.url")
 	cfg.LLM.Proxy.APIKey = m.viper.GetString("llm.proxy.api_key")
 	cfg.LLM.Proxy.Model = m.viper.GetString("llm.proxy.model")
+	cfg.LLM.Anthropic.APIKey = m.viper.GetString("llm.anthropic.api_key")
+	cfg.LLM.Anthropic.Model = m.viper.GetString("llm.anthropic.model")
+	cfg.LLM.Anthropic.URL = m.viper.GetString("llm.anthropic.url")
+	cfg.LLM.Ollama.URL = m.viper.GetString("llm.ollama.url")
+	cfg.LLM.Ollama.Model = m.viper.GetString("llm.ollama.model")
+	cfg.LLM.MaxDiffTokens = m.viper.GetInt("llm.max_diff_tokens")
+	// 读取 token 预算列表
+	if budgetsVal := m.viper.Get("llm.budgets"); budgetsVal != nil {
+		if budgets, ok := budgetsVal.([]interface{}); ok {
+			for _, b := range budgets {
+				if budgetMap, ok := b.(map[string]interface{}); ok {
+					budget := LLMTokenBudget{}
+					if provider, ok := budgetMap["provider"].(string); ok {
+						budget.Provider = provider
+					}
+					if model, ok := budgetMap["model"].(string); ok {
+						budget.Model = model
+					}
+					switch maxTokens := budgetMap["max_tokens"].(type) {
+					case int64:
+						budget.MaxTokens = int(maxTokens)
+					case int:
+						budget.MaxTokens = maxTokens
+					}
+					if budget.MaxTokens > 0 && (budget.Provider != "" || budget.Model != "") {
+						cfg.LLM.Budgets = append(cfg.LLM.Budgets, budget)
+					}
+				}
+			}
+		}
+	}
+
+	// 读取响应缓存配置
+	cfg.LLM.Cache.Disabled = m.viper.GetBool("llm.cache.disabled")
+	cfg.LLM.Cache.TTLHours = m.viper.GetInt("llm.cache.ttl_hours")
+	cfg.LLM.Cache.MaxSizeMB = m.viper.GetInt("llm.cache.max_size_mb")
+
+	// 读取用量统计配置和价格表
+	cfg.LLM.Usage.Disabled = m.viper.GetBool("llm.usage.disabled")
+	cfg.LLM.Usage.MonthlyBudget = m.viper.GetFloat64("llm.usage.monthly_budget")
+	if pricesVal := m.viper.Get("llm.prices"); pricesVal != nil {
+		if prices, ok := pricesVal.([]interface{}); ok {
+			for _, p := range prices {
+				if priceMap, ok := p.(map[string]interface{}); ok {
+					price := LLMPrice{}
+					if provider, ok := priceMap["provider"].(string); ok {
+						price.Provider = provider
+					}
+					if model, ok := priceMap["model"].(string); ok {
+						price.Model = model
+					}
+					price.Input = toFloat64(priceMap["input"])
+					price.Output = toFloat64(priceMap["output"])
+					if price.Provider != "" || price.Model != "" {
+						cfg.LLM.Prices = append(cfg.LLM.Prices, price)
+					}
+				}
+			}
+		}
+	}
+
+	// 读取回退提供商和按功能路由
+	cfg.LLM.Fallbacks = m.viper.GetStringSlice("llm.fallbacks")
+	if routesVal := m.viper.Get("llm.routes"); routesVal != nil {
+		if routes, ok := routesVal.([]interface{}); ok {
+			for _, r := range routes {
+				if routeMap, ok := r.(map[string]interface{}); ok {
+					route := LLMRoute{}
+					if feature, ok := routeMap["feature"].(string); ok {
+						route.Feature = feature
+					}
+					if provider, ok := routeMap["provider"].(string); ok {
+						route.Provider = provider
+					}
+					if model, ok := routeMap["model"].(string); ok {
+						route.Model = model
+					}
+					if route.Feature != "" && (route.Provider != "" || route.Model != "") {
+						cfg.LLM.Routes = append(cfg.LLM.Routes, route)
+					}
+				}
+			}
+		}
+	}
 
 	// 读取代理配置
 	if enabled := m.viper.Get("proxy.enabled"); enabled != nil {
@@ -377,3 +513,16 @@ func (m *GlobalManager) getGlobalConfig() *GlobalConfig {
 
 	return cfg
 }
+
+// toFloat64 将 TOML 数值（整数或浮点数）转换为 float64，其他类型返回 0
+func toFloat64(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case int64:
+		return float64(v)
+	case int:
+		return float64(v)
+	}
+	return 0
+}
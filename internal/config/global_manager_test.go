@@ -102,6 +102,74 @@ level = "debug"
 	assert.Equal(t, "debug", manager.LogConfig.Level)
 }
 
+func TestGlobalManager_Load_GitLabConfig(t *testing.T) {
+	// Arrange: 创建包含 [gitlab] 配置段的配置文件
+	tempDir := t.TempDir()
+	configDir := filepath.Join(tempDir, ".config", "Workflow")
+	configPath := filepath.Join(configDir, "config.toml")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+
+	configContent := `[gitlab]
+base_url = "https://gitlab.example.com"
+api_token = "glpat-test"
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	manager := &GlobalManager{
+		viper:  viper.New(),
+		path:   configPath,
+		Config: &GlobalConfig{},
+	}
+	manager.viper.SetConfigName("config")
+	manager.viper.SetConfigType("toml")
+	manager.viper.AddConfigPath(configDir)
+
+	// Act: 加载配置
+	err := manager.Load()
+
+	// Assert: 验证 GitLab 配置已加载，便捷字段指向 Config.GitLab
+	require.NoError(t, err)
+	assert.Equal(t, "https://gitlab.example.com", manager.GitLabConfig.BaseURL)
+	assert.Equal(t, "glpat-test", manager.GitLabConfig.APIToken)
+	assert.Same(t, &manager.Config.GitLab, manager.GitLabConfig)
+	assert.Same(t, manager.GitLabConfig, manager.GetGitLabConfig())
+}
+
+func TestGlobalManager_Load_PlatformHosts(t *testing.T) {
+	// Arrange: 创建包含 [platform.hosts] 配置段的配置文件（键包含点号和大写）
+	tempDir := t.TempDir()
+	configDir := filepath.Join(tempDir, ".config", "Workflow")
+	configPath := filepath.Join(configDir, "config.toml")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+
+	configContent := `[platform.hosts]
+"github.example.com" = "github"
+"Git.Example.com" = "gitlab"
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	manager := &GlobalManager{
+		viper:  viper.New(),
+		path:   configPath,
+		Config: &GlobalConfig{},
+	}
+	manager.viper.SetConfigName("config")
+	manager.viper.SetConfigType("toml")
+	manager.viper.AddConfigPath(configDir)
+
+	// Act: 加载配置
+	err := manager.Load()
+
+	// Assert: 验证主机名映射已加载（键统一为小写）
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"github.example.com": "github",
+		"git.example.com":    "gitlab",
+	}, manager.PlatformConfig.Hosts)
+	assert.Same(t, &manager.Config.Platform, manager.PlatformConfig)
+	assert.Same(t, manager.PlatformConfig, manager.GetPlatformConfig())
+}
+
 func TestGlobalManager_Load_FileNotExists(t *testing.T) {
 	// Arrange: 设置测试环境，但不创建配置文件
 	tempDir := t.TempDir()
@@ -268,6 +336,14 @@ model = "deepseek-chat"
 url = "https://api.example.com/v1"
 api_key = "proxy-key"
 model = "custom-model"
+
+[llm.anthropic]
+api_key = "sk-ant-key"
+model = "claude-3-5-haiku-latest"
+
+[llm.ollama]
+url = "http://localhost:11434/v1"
+model = "llama3.2"
 `
 	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
 
@@ -289,6 +365,11 @@ model = "custom-model"
 	assert.Equal(t, "https://api.example.com/v1", llmConfig.Proxy.URL)
 	assert.Equal(t, "proxy-key", llmConfig.Proxy.APIKey)
 	assert.Equal(t, "custom-model", llmConfig.Proxy.Model)
+	assert.Equal(t, "sk-ant-key", llmConfig.Anthropic.APIKey)
+	assert.Equal(t, "claude-3-5-haiku-latest", llmConfig.Anthropic.Model)
+	assert.Empty(t, llmConfig.Anthropic.URL)
+	assert.Equal(t, "http://localhost:11434/v1", llmConfig.Ollama.URL)
+	assert.Equal(t, "llama3.2", llmConfig.Ollama.Model)
 }
 
 func TestGlobalManager_GetLLMConfig_Empty(t *testing.T) {
@@ -536,3 +617,120 @@ func TestGlobalManager_GetConfigPath(t *testing.T) {
 	assert.Contains(t, path, "Workflow")
 	assert.Contains(t, path, "config.toml")
 }
+
+func TestGlobalManager_Load_LLMBudgets(t *testing.T) {
+	// Arrange: 创建包含 token 预算的配置文件（模型名包含点号）
+	tempDir := t.TempDir()
+	configDir := filepath.Join(tempDir, ".config", "Workflow")
+	configPath := filepath.Join(configDir, "config.toml")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+
+	configContent := `[llm]
+provider = "openai"
+max_diff_tokens = 8000
+
+[llm.openai]
+model = "gpt-4.1"
+
+[[llm.budgets]]
+provider = "openai"
+model = "gpt-4.1"
+max_tokens = 100000
+
+[[llm.budgets]]
+model = "deepseek-chat"
+max_tokens = 30000
+
+[[llm.budgets]]
+provider = "deepseek"
+
+[llm.cache]
+ttl_hours = 24
+max_size_mb = 10
+
+[llm.usage]
+monthly_budget = 20.5
+
+[[llm.prices]]
+model = "gpt-4.1"
+input = 2
+output = 8.0
+
+[[llm.prices]]
+input = 1.0
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	manager := &GlobalManager{
+		viper:  viper.New(),
+		path:   configPath,
+		Config: &GlobalConfig{},
+	}
+	manager.viper.SetConfigName("config")
+	manager.viper.SetConfigType("toml")
+	manager.viper.AddConfigPath(configDir)
+
+	// Act: 加载配置
+	err := manager.Load()
+
+	// Assert: 验证预算已加载，缺少 max_tokens 的条目被忽略
+	require.NoError(t, err)
+	assert.Equal(t, 8000, manager.LLMConfig.MaxDiffTokens)
+	assert.Equal(t, []LLMTokenBudget{
+		{Provider: "openai", Model: "gpt-4.1", MaxTokens: 100000},
+		{Model: "deepseek-chat", MaxTokens: 30000},
+	}, manager.LLMConfig.Budgets)
+	assert.Equal(t, 100000, manager.LLMConfig.DiffTokenBudget(""))
+	assert.False(t, manager.LLMConfig.Cache.Disabled)
+	assert.Equal(t, 24, manager.LLMConfig.Cache.TTLHours)
+	assert.Equal(t, 10, manager.LLMConfig.Cache.MaxSizeMB)
+	assert.False(t, manager.LLMConfig.Usage.Disabled)
+	assert.Equal(t, 20.5, manager.LLMConfig.Usage.MonthlyBudget)
+	// 价格可以写成整数，缺少 provider 和 model 的条目被忽略
+	assert.Equal(t, []LLMPrice{{Model: "gpt-4.1", Input: 2, Output: 8}}, manager.LLMConfig.Prices)
+}
+
+func TestGlobalManager_Load_LLMRoutes(t *testing.T) {
+	// Arrange: 创建包含回退提供商和按功能路由的配置文件
+	tempDir := t.TempDir()
+	configDir := filepath.Join(tempDir, ".config", "Workflow")
+	configPath := filepath.Join(configDir, "config.toml")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+
+	configContent := `[llm]
+provider = "openai"
+fallbacks = ["deepseek", "ollama"]
+
+[[llm.routes]]
+feature = "translate"
+model = "gpt-4o-mini"
+
+[[llm.routes]]
+feature = "pr-summarize"
+provider = "anthropic"
+
+[[llm.routes]]
+feature = "commit"
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	manager := &GlobalManager{
+		viper:  viper.New(),
+		path:   configPath,
+		Config: &GlobalConfig{},
+	}
+	manager.viper.SetConfigName("config")
+	manager.viper.SetConfigType("toml")
+	manager.viper.AddConfigPath(configDir)
+
+	// Act: 加载配置
+	err := manager.Load()
+
+	// Assert: 缺少 provider 和 model 的路由被忽略
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deepseek", "ollama"}, manager.LLMConfig.Fallbacks)
+	assert.Equal(t, []LLMRoute{
+		{Feature: "translate", Model: "gpt-4o-mini"},
+		{Feature: "pr-summarize", Provider: "anthropic"},
+	}, manager.LLMConfig.Routes)
+}
@@ -1,6 +1,9 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // LLMConfig LLM configuration
 type LLMConfig struct {
@@ -19,6 +22,105 @@ type LLMConfig struct {
 		APIKey string `toml:"api_key,omitempty"`
 		Model  string `toml:"model,omitempty"`
 	} `toml:"proxy,omitempty"`
+	Anthropic struct {
+		APIKey string `toml:"api_key,omitempty"`
+		Model  string `toml:"model,omitempty"`
+		// URL overrides the default Anthropic API URL (optional)
+		URL string `toml:"url,omitempty"`
+	} `toml:"anthropic,omitempty"`
+	Ollama struct {
+		// URL Ollama API URL (optional, defaults to DefaultOllamaURL)
+		URL   string `toml:"url,omitempty"`
+		Model string `toml:"model,omitempty"`
+	} `toml:"ollama,omitempty"`
+	// MaxDiffTokens default token budget for diffs sent to the LLM (0 uses DefaultDiffTokenBudget)
+	MaxDiffTokens int `toml:"max_diff_tokens,omitempty"`
+	// Budgets token budgets for specific providers or models, overriding MaxDiffTokens
+	Budgets []LLMTokenBudget `toml:"budgets,omitempty"`
+	Cache   struct {
+		// Disabled turns the response cache off
+		Disabled bool `toml:"disabled,omitempty"`
+		// TTLHours how long cached responses are kept (0 uses DefaultCacheTTL)
+		TTLHours int `toml:"ttl_hours,omitempty"`
+		// MaxSizeMB size cap of the cache directory (0 uses DefaultCacheMaxSizeMB)
+		MaxSizeMB int `toml:"max_size_mb,omitempty"`
+	} `toml:"cache,omitempty"`
+	Usage struct {
+		// Disabled turns usage recording off
+		Disabled bool `toml:"disabled,omitempty"`
+		// MonthlyBudget soft monthly budget in USD, calls warn once it is reached (0 disables it)
+		MonthlyBudget float64 `toml:"monthly_budget,omitempty"`
+	} `toml:"usage,omitempty"`
+	// Prices prices of providers or models, overriding DefaultLLMPrices
+	Prices []LLMPrice `toml:"prices,omitempty"`
+	// Fallbacks providers tried in order when a request fails with a server error, rate limit or timeout
+	Fallbacks []string `toml:"fallbacks,omitempty"`
+	// Routes providers or models of specific features, overriding Provider
+	Routes []LLMRoute `toml:"routes,omitempty"`
+}
+
+// LLMProviders supported LLM providers
+var LLMProviders = []string{"openai", "deepseek", "proxy", "anthropic", "ollama"}
+
+// DefaultDiffTokenBudget default token budget for diffs sent to the LLM
+const DefaultDiffTokenBudget = 12000
+
+const (
+	// DefaultCacheTTL default time LLM responses are kept in the response cache
+	DefaultCacheTTL = 7 * 24 * time.Hour
+	// DefaultCacheMaxSizeMB default size cap of the LLM response cache in MB
+	DefaultCacheMaxSizeMB = 50
+)
+
+const (
+	// DefaultAnthropicURL default Anthropic API URL
+	DefaultAnthropicURL = "https://api.anthropic.com/v1"
+	// DefaultOllamaURL default URL of a local Ollama server (OpenAI-compatible API)
+	DefaultOllamaURL = "http://localhost:11434/v1"
+)
+
+// LLMTokenBudget diff token budget for a provider, a model, or a model of a provider
+type LLMTokenBudget struct {
+	Provider  string `toml:"provider,omitempty"`
+	Model     string `toml:"model,omitempty"`
+	MaxTokens int    `toml:"max_tokens"`
+}
+
+// LLMPrice price of a provider, a model, or a model of a provider in USD per million tokens
+type LLMPrice struct {
+	Provider string  `toml:"provider,omitempty"`
+	Model    string  `toml:"model,omitempty"`
+	Input    float64 `toml:"input"`
+	Output   float64 `toml:"output"`
+}
+
+// LLMRoute provider and model of a feature (e.g. "translate" or "pr-summarize")
+//
+// An empty Provider uses LLMConfig.Provider, an empty Model uses the model of the provider.
+type LLMRoute struct {
+	Feature  string `toml:"feature"`
+	Provider string `toml:"provider,omitempty"`
+	Model    string `toml:"model,omitempty"`
+}
+
+// LLMEndpoint resolved configuration of a provider that serves LLM requests
+type LLMEndpoint struct {
+	Provider string
+	APIKey   string
+	Model    string
+	URL      string
+}
+
+// DefaultLLMPrices approximate list prices of the default models, used when [[llm.prices]] has no match
+//
+// Ollama runs locally and is free.
+var DefaultLLMPrices = []LLMPrice{
+	{Provider: "ollama"},
+	{Model: "gpt-3.5-turbo", Input: 0.50, Output: 1.50},
+	{Model: "gpt-4o", Input: 2.50, Output: 10.00},
+	{Model: "gpt-4o-mini", Input: 0.15, Output: 0.60},
+	{Model: "deepseek-chat", Input: 0.27, Output: 1.10},
+	{Model: "claude-3-5-haiku-latest", Input: 0.80, Output: 4.00},
 }
 
 // CurrentProvider gets current provider configuration
@@ -29,10 +131,28 @@ type LLMConfig struct {
 // Returns:
 //   - APIKey: API key
 //   - Model: Model name (if not set, returns default value)
-//   - URL: API URL (openai/deepseek use default URL, proxy needs configuration)
+//   - URL: API URL (openai/deepseek use default URL, anthropic/ollama default URL can be overridden, proxy needs configuration)
 //   - error: Returns error if provider is not configured or invalid
+//
+// Ollama runs locally and does not need an API key, so apiKey is empty for it.
 func (c *LLMConfig) CurrentProvider() (apiKey, model, url string, err error) {
-	switch c.Provider {
+	return c.ProviderSettings(c.Provider)
+}
+
+// ProviderSettings gets the configuration of a provider
+//
+// Same as CurrentProvider, for any provider (e.g. a fallback or the provider of a route).
+//
+// Parameters:
+//   - provider: Provider name
+//
+// Returns:
+//   - APIKey: API key
+//   - Model: Model name (if not set, returns default value)
+//   - URL: API URL
+//   - error: Returns error if provider is invalid or its configuration is incomplete
+func (c *LLMConfig) ProviderSettings(provider string) (apiKey, model, url string, err error) {
+	switch provider {
 	case "openai":
 		apiKey = c.OpenAI.APIKey
 		model = c.OpenAI.Model
@@ -55,9 +175,94 @@ func (c *LLMConfig) CurrentProvider() (apiKey, model, url string, err error) {
 			return "", "", "", fmt.Errorf("model, URL and API key are required for proxy provider")
 		}
 		return apiKey, model, url, nil
+	case "anthropic":
+		apiKey = c.Anthropic.APIKey
+		model = c.Anthropic.Model
+		if model == "" {
+			model = "claude-3-5-haiku-latest"
+		}
+		url = c.Anthropic.URL
+		if url == "" {
+			url = DefaultAnthropicURL
+		}
+		return apiKey, model, url, nil
+	case "ollama":
+		model = c.Ollama.Model
+		url = c.Ollama.URL
+		if url == "" {
+			url = DefaultOllamaURL
+		}
+		if model == "" {
+			return "", "", "", fmt.Errorf("model is required for ollama provider")
+		}
+		return "", model, url, nil
 	default:
-		return "", "", "", fmt.Errorf("unsupported LLM provider: %s", c.Provider)
+		return "", "", "", fmt.Errorf("unsupported LLM provider: %s", provider)
+	}
+}
+
+// Route gets the provider and model of a feature
+//
+// Parameters:
+//   - feature: Feature name (empty for requests without a feature)
+//
+// Returns:
+//   - provider: Provider of the matching route, or Provider
+//   - model: Model of the matching route (empty uses the model of the provider)
+func (c *LLMConfig) Route(feature string) (provider, model string) {
+	provider = c.Provider
+	if feature == "" {
+		return provider, ""
+	}
+	for _, route := range c.Routes {
+		if route.Feature != feature {
+			continue
+		}
+		if route.Provider != "" {
+			provider = route.Provider
+		}
+		return provider, route.Model
+	}
+	return provider, ""
+}
+
+// Endpoints gets the providers that serve requests of a feature, in fallback order
+//
+// The first endpoint is the provider and model of the feature (see Route), followed by
+// Fallbacks with their configured models. Fallbacks equal to the first provider and
+// duplicates are skipped.
+//
+// Parameters:
+//   - feature: Feature name (empty for requests without a feature)
+//
+// Returns:
+//   - []LLMEndpoint: Endpoints, at least one
+//   - error: Returns error if any of the providers is invalid or incomplete
+func (c *LLMConfig) Endpoints(feature string) ([]LLMEndpoint, error) {
+	provider, routeModel := c.Route(feature)
+	apiKey, model, url, err := c.ProviderSettings(provider)
+	if err != nil {
+		return nil, err
 	}
+	if routeModel != "" {
+		model = routeModel
+	}
+	endpoints := []LLMEndpoint{{Provider: provider, APIKey: apiKey, Model: model, URL: url}}
+
+	seen := map[string]bool{provider: true}
+	for _, fallback := range c.Fallbacks {
+		if seen[fallback] {
+			continue
+		}
+		seen[fallback] = true
+
+		apiKey, model, url, err := c.ProviderSettings(fallback)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fallback provider %s: %w", fallback, err)
+		}
+		endpoints = append(endpoints, LLMEndpoint{Provider: fallback, APIKey: apiKey, Model: model, URL: url})
+	}
+	return endpoints, nil
 }
 
 // CurrentLanguage gets current language configuration
@@ -87,3 +292,116 @@ func (c *LLMConfig) CurrentLanguage() (*SupportedLanguage, error) {
 
 	return lang, nil
 }
+
+// DiffTokenBudget gets the token budget for diffs sent to the provider and model of a feature
+//
+// The provider and model are resolved the same way as Endpoints (see Route). Budgets
+// are matched from the most to the least specific: an entry with both provider and
+// model, an entry with only the model, an entry with only the provider. Without a
+// match, MaxDiffTokens is used, then DefaultDiffTokenBudget.
+//
+// Parameters:
+//   - feature: Feature name (empty uses Provider and its model)
+//
+// Returns:
+//   - int: Token budget
+func (c *LLMConfig) DiffTokenBudget(feature string) int {
+	provider, model := c.Route(feature)
+	if model == "" {
+		if _, current, _, err := c.ProviderSettings(provider); err == nil {
+			model = current
+		}
+	}
+
+	best, bestRank := 0, 0
+	for _, budget := range c.Budgets {
+		if budget.MaxTokens <= 0 {
+			continue
+		}
+		if rank := matchRank(budget.Provider, budget.Model, provider, model); rank > bestRank {
+			best, bestRank = budget.MaxTokens, rank
+		}
+	}
+	if bestRank > 0 {
+		return best
+	}
+
+	if c.MaxDiffTokens > 0 {
+		return c.MaxDiffTokens
+	}
+	return DefaultDiffTokenBudget
+}
+
+// CacheTTL gets how long LLM responses are kept in the response cache
+//
+// Returns:
+//   - time.Duration: Cache.TTLHours, or DefaultCacheTTL when it is not set
+func (c *LLMConfig) CacheTTL() time.Duration {
+	if c.Cache.TTLHours > 0 {
+		return time.Duration(c.Cache.TTLHours) * time.Hour
+	}
+	return DefaultCacheTTL
+}
+
+// CacheMaxSize gets the size cap of the LLM response cache
+//
+// Returns:
+//   - int64: Size cap in bytes, from Cache.MaxSizeMB or DefaultCacheMaxSizeMB
+func (c *LLMConfig) CacheMaxSize() int64 {
+	sizeMB := c.Cache.MaxSizeMB
+	if sizeMB <= 0 {
+		sizeMB = DefaultCacheMaxSizeMB
+	}
+	return int64(sizeMB) * 1024 * 1024
+}
+
+// Price gets the price of a provider and model
+//
+// Entries of Prices are matched like Budgets, from the most to the least specific.
+// Without a match, DefaultLLMPrices is used.
+//
+// Parameters:
+//   - provider: Provider name
+//   - model: Model name
+//
+// Returns:
+//   - LLMPrice: Matching price
+//   - bool: Whether a price was found
+func (c *LLMConfig) Price(provider, model string) (LLMPrice, bool) {
+	for _, prices := range [][]LLMPrice{c.Prices, DefaultLLMPrices} {
+		var best LLMPrice
+		bestRank := 0
+		for _, price := range prices {
+			if rank := matchRank(price.Provider, price.Model, provider, model); rank > bestRank {
+				best, bestRank = price, rank
+			}
+		}
+		if bestRank > 0 {
+			return best, true
+		}
+	}
+	return LLMPrice{}, false
+}
+
+// matchRank ranks how specifically an entry with entryProvider and entryModel matches provider and model
+//
+// Returns 3 for an entry with both provider and model, 2 for only the model,
+// 1 for only the provider, and 0 if the entry does not match or is empty.
+func matchRank(entryProvider, entryModel, provider, model string) int {
+	if entryProvider != "" && entryProvider != provider {
+		return 0
+	}
+	if entryModel != "" && entryModel != model {
+		return 0
+	}
+
+	switch {
+	case entryProvider != "" && entryModel != "":
+		return 3
+	case entryModel != "":
+		return 2
+	case entryProvider != "":
+		return 1
+	}
+	return 0
+}
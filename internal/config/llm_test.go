@@ -2,8 +2,10 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // ==================== CurrentProvider Tests ====================
@@ -256,6 +258,64 @@ func TestLLMConfig_CurrentProvider_EdgeCases(t *testing.T) {
 	})
 }
 
+func TestLLMConfig_CurrentProvider_Anthropic(t *testing.T) {
+	t.Run("default model and URL", func(t *testing.T) {
+		config := LLMConfig{Provider: "anthropic"}
+		config.Anthropic.APIKey = "sk-ant-key"
+
+		apiKey, model, url, err := config.CurrentProvider()
+		assert.NoError(t, err)
+		assert.Equal(t, "sk-ant-key", apiKey)
+		assert.Equal(t, "claude-3-5-haiku-latest", model)
+		assert.Equal(t, DefaultAnthropicURL, url)
+	})
+
+	t.Run("custom model and URL", func(t *testing.T) {
+		config := LLMConfig{Provider: "anthropic"}
+		config.Anthropic.APIKey = "sk-ant-key"
+		config.Anthropic.Model = "claude-3-opus-latest"
+		config.Anthropic.URL = "https://gateway.example.com/anthropic/v1"
+
+		apiKey, model, url, err := config.CurrentProvider()
+		assert.NoError(t, err)
+		assert.Equal(t, "sk-ant-key", apiKey)
+		assert.Equal(t, "claude-3-opus-latest", model)
+		assert.Equal(t, "https://gateway.example.com/anthropic/v1", url)
+	})
+}
+
+func TestLLMConfig_CurrentProvider_Ollama(t *testing.T) {
+	t.Run("default URL and no API key", func(t *testing.T) {
+		config := LLMConfig{Provider: "ollama"}
+		config.Ollama.Model = "llama3.2"
+
+		apiKey, model, url, err := config.CurrentProvider()
+		assert.NoError(t, err)
+		assert.Empty(t, apiKey)
+		assert.Equal(t, "llama3.2", model)
+		assert.Equal(t, DefaultOllamaURL, url)
+	})
+
+	t.Run("custom URL", func(t *testing.T) {
+		config := LLMConfig{Provider: "ollama"}
+		config.Ollama.Model = "qwen2.5-coder"
+		config.Ollama.URL = "http://gpu-box:11434/v1"
+
+		_, model, url, err := config.CurrentProvider()
+		assert.NoError(t, err)
+		assert.Equal(t, "qwen2.5-coder", model)
+		assert.Equal(t, "http://gpu-box:11434/v1", url)
+	})
+
+	t.Run("model is required", func(t *testing.T) {
+		config := LLMConfig{Provider: "ollama"}
+
+		_, _, _, err := config.CurrentProvider()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "model is required for ollama provider")
+	})
+}
+
 // ==================== CurrentLanguage Tests ====================
 
 func TestLLMConfig_CurrentLanguage(t *testing.T) {
@@ -362,3 +422,260 @@ func TestLLMConfig_CurrentLanguage_DefaultEnglish(t *testing.T) {
 	assert.Equal(t, "English", lang.Name)
 	assert.Equal(t, "English", lang.NativeName)
 }
+
+// ==================== DiffTokenBudget Tests ====================
+
+func TestLLMConfig_DiffTokenBudget(t *testing.T) {
+	budgets := []LLMTokenBudget{
+		{Provider: "openai", MaxTokens: 20000},
+		{Model: "gpt-4o", MaxTokens: 60000},
+		{Provider: "openai", Model: "gpt-4.1", MaxTokens: 100000},
+		{Provider: "deepseek", Model: "gpt-4.1", MaxTokens: 1},
+	}
+
+	tests := []struct {
+		name   string
+		config LLMConfig
+		want   int
+	}{
+		{
+			name:   "Default budget",
+			config: LLMConfig{Provider: "openai"},
+			want:   DefaultDiffTokenBudget,
+		},
+		{
+			name:   "Configured default budget",
+			config: LLMConfig{Provider: "openai", MaxDiffTokens: 8000},
+			want:   8000,
+		},
+		{
+			name:   "Provider budget",
+			config: LLMConfig{Provider: "openai", MaxDiffTokens: 8000, Budgets: budgets},
+			want:   20000,
+		},
+		{
+			name:   "Unconfigured provider falls back to default budget",
+			config: LLMConfig{Provider: "unknown", MaxDiffTokens: 8000, Budgets: budgets},
+			want:   8000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.config.DiffTokenBudget(""))
+		})
+	}
+}
+
+func TestLLMConfig_DiffTokenBudget_Model(t *testing.T) {
+	config := LLMConfig{
+		Provider: "openai",
+		Budgets: []LLMTokenBudget{
+			{Provider: "openai", MaxTokens: 20000},
+			{Model: "gpt-4o", MaxTokens: 60000},
+			{Provider: "openai", Model: "gpt-4.1", MaxTokens: 100000},
+			{Provider: "deepseek", Model: "gpt-4.1", MaxTokens: 1},
+		},
+	}
+
+	// Model-only entry is more specific than the provider entry
+	config.OpenAI.Model = "gpt-4o"
+	assert.Equal(t, 60000, config.DiffTokenBudget(""))
+
+	// Provider and model entry is the most specific
+	config.OpenAI.Model = "gpt-4.1"
+	assert.Equal(t, 100000, config.DiffTokenBudget(""))
+
+	// Default model (gpt-3.5-turbo) only matches the provider entry
+	config.OpenAI.Model = ""
+	assert.Equal(t, 20000, config.DiffTokenBudget(""))
+}
+
+func TestLLMConfig_DiffTokenBudget_Route(t *testing.T) {
+	config := LLMConfig{
+		Provider:      "openai",
+		MaxDiffTokens: 8000,
+		Budgets: []LLMTokenBudget{
+			{Provider: "openai", MaxTokens: 20000},
+			{Model: "gpt-4.1", MaxTokens: 100000},
+			{Provider: "deepseek", MaxTokens: 30000},
+		},
+		Routes: []LLMRoute{
+			{Feature: "commit", Provider: "deepseek"},
+			{Feature: "pr-create", Model: "gpt-4.1"},
+			{Feature: "translate", Provider: "ollama"},
+		},
+	}
+
+	// Features without a route use the primary provider
+	assert.Equal(t, 20000, config.DiffTokenBudget("pr-summarize"))
+
+	// Route provider with its configured model
+	assert.Equal(t, 30000, config.DiffTokenBudget("commit"))
+
+	// Route model on the primary provider
+	assert.Equal(t, 100000, config.DiffTokenBudget("pr-create"))
+
+	// Route provider without a budget falls back to the default budget
+	assert.Equal(t, 8000, config.DiffTokenBudget("translate"))
+}
+
+// ==================== Cache Tests ====================
+
+func TestLLMConfig_CacheDefaults(t *testing.T) {
+	config := LLMConfig{}
+
+	assert.Equal(t, DefaultCacheTTL, config.CacheTTL())
+	assert.Equal(t, int64(DefaultCacheMaxSizeMB)*1024*1024, config.CacheMaxSize())
+}
+
+func TestLLMConfig_CacheConfigured(t *testing.T) {
+	config := LLMConfig{}
+	config.Cache.TTLHours = 12
+	config.Cache.MaxSizeMB = 5
+
+	assert.Equal(t, 12*time.Hour, config.CacheTTL())
+	assert.Equal(t, int64(5*1024*1024), config.CacheMaxSize())
+}
+
+// ==================== Price Tests ====================
+
+func TestLLMConfig_Price(t *testing.T) {
+	config := LLMConfig{
+		Prices: []LLMPrice{
+			{Provider: "proxy", Input: 1, Output: 2},
+			{Model: "gpt-4o", Input: 3, Output: 4},
+			{Provider: "proxy", Model: "gpt-4o", Input: 5, Output: 6},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		provider string
+		model    string
+		want     LLMPrice
+		found    bool
+	}{
+		{
+			name:     "Provider and model entry is the most specific",
+			provider: "proxy",
+			model:    "gpt-4o",
+			want:     LLMPrice{Provider: "proxy", Model: "gpt-4o", Input: 5, Output: 6},
+			found:    true,
+		},
+		{
+			name:     "Model entry matches any provider",
+			provider: "openai",
+			model:    "gpt-4o",
+			want:     LLMPrice{Model: "gpt-4o", Input: 3, Output: 4},
+			found:    true,
+		},
+		{
+			name:     "Provider entry",
+			provider: "proxy",
+			model:    "qwen",
+			want:     LLMPrice{Provider: "proxy", Input: 1, Output: 2},
+			found:    true,
+		},
+		{
+			name:     "Falls back to default prices",
+			provider: "openai",
+			model:    "gpt-4o-mini",
+			want:     LLMPrice{Model: "gpt-4o-mini", Input: 0.15, Output: 0.60},
+			found:    true,
+		},
+		{
+			name:     "Ollama is free by default",
+			provider: "ollama",
+			model:    "llama3.2",
+			want:     LLMPrice{Provider: "ollama"},
+			found:    true,
+		},
+		{
+			name:     "Unknown model",
+			provider: "openai",
+			model:    "o3",
+			found:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, found := config.Price(tt.provider, tt.model)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.want, price)
+		})
+	}
+}
+
+// ==================== Route Tests ====================
+
+func TestLLMConfig_Route(t *testing.T) {
+	config := LLMConfig{
+		Provider: "openai",
+		Routes: []LLMRoute{
+			{Feature: "translate", Model: "gpt-4o-mini"},
+			{Feature: "pr-summarize", Provider: "anthropic", Model: "claude-3-5-sonnet-latest"},
+			{Feature: "commit", Provider: "deepseek"},
+		},
+	}
+
+	tests := []struct {
+		feature  string
+		provider string
+		model    string
+	}{
+		{"translate", "openai", "gpt-4o-mini"},
+		{"pr-summarize", "anthropic", "claude-3-5-sonnet-latest"},
+		{"commit", "deepseek", ""},
+		{"pr-create", "openai", ""},
+		{"", "openai", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.feature, func(t *testing.T) {
+			provider, model := config.Route(tt.feature)
+			assert.Equal(t, tt.provider, provider)
+			assert.Equal(t, tt.model, model)
+		})
+	}
+}
+
+// ==================== Endpoints Tests ====================
+
+func TestLLMConfig_Endpoints(t *testing.T) {
+	// Arrange: openai 为主提供商，deepseek 和 ollama 为回退（重复和主提供商被跳过）
+	config := LLMConfig{
+		Provider:  "openai",
+		Fallbacks: []string{"deepseek", "openai", "ollama", "deepseek"},
+		Routes:    []LLMRoute{{Feature: "translate", Model: "gpt-4o-mini"}},
+	}
+	config.OpenAI.APIKey = "sk-openai"
+	config.DeepSeek.APIKey = "sk-deepseek"
+	config.Ollama.Model = "llama3.2"
+
+	// Act
+	endpoints, err := config.Endpoints("translate")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []LLMEndpoint{
+		{Provider: "openai", APIKey: "sk-openai", Model: "gpt-4o-mini", URL: "https://api.openai.com/v1"},
+		{Provider: "deepseek", APIKey: "sk-deepseek", Model: "deepseek-chat", URL: "https://api.deepseek.com/v1"},
+		{Provider: "ollama", Model: "llama3.2", URL: DefaultOllamaURL},
+	}, endpoints)
+
+	// 没有路由的功能使用提供商的模型
+	endpoints, err = config.Endpoints("commit")
+	require.NoError(t, err)
+	assert.Equal(t, "gpt-3.5-turbo", endpoints[0].Model)
+}
+
+func TestLLMConfig_Endpoints_InvalidFallback(t *testing.T) {
+	config := LLMConfig{Provider: "openai", Fallbacks: []string{"ollama"}}
+
+	_, err := config.Endpoints("")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid fallback provider ollama")
+}
@@ -0,0 +1,11 @@
+package config
+
+// MergeConfig PR 合并配置
+//
+// 项目级别的合并策略配置，提交到 Git。
+type MergeConfig struct {
+	// Method 默认合并方法（"merge"、"squash" 或 "rebase"）
+	Method string `toml:"method,omitempty"`
+	// DeleteBranch 合并后是否删除远程源分支（默认保留）
+	DeleteBranch bool `toml:"delete_branch,omitempty"`
+}
@@ -0,0 +1,8 @@
+package config
+
+// PlatformConfig 代码托管平台配置
+type PlatformConfig struct {
+	// Hosts 自定义主机名到平台的映射（如 "github.example.com" = "github"、"git.example.com" = "gitlab"）
+	// 用于 GitHub Enterprise、自建 GitLab 等无法从域名识别平台的实例
+	Hosts map[string]string `toml:"hosts,omitempty"`
+}
@@ -254,6 +254,32 @@ func (r *RepoManager) GetTemplateConfig() *TemplateConfig {
 	return r.TemplateConfig
 }
 
+// GetMergeMethod 获取默认合并方法
+//
+// 从项目公共配置（[merge] method）中读取。
+//
+// 返回:
+//   - string: 合并方法（"merge"、"squash" 或 "rebase"），如果未配置则返回空字符串
+func (r *RepoManager) GetMergeMethod() string {
+	if r.Config == nil {
+		return ""
+	}
+	return r.Config.Merge.Method
+}
+
+// GetMergeDeleteBranch 获取合并后是否删除远程源分支
+//
+// 从项目公共配置（[merge] delete_branch）中读取。
+//
+// 返回:
+//   - bool: 是否删除远程源分支，如果未配置则返回 false
+func (r *RepoManager) GetMergeDeleteBranch() bool {
+	if r.Config == nil {
+		return false
+	}
+	return r.Config.Merge.DeleteBranch
+}
+
 // GetBranchPrefix 获取分支前缀（个人偏好）
 //
 // 从项目私有配置中读取分支前缀。
@@ -329,6 +355,27 @@ func (r *RepoManager) GetAutoAcceptChangeType() bool {
 	return false
 }
 
+// GetGitHubAccount 获取仓库绑定的 GitHub 账号（个人偏好）
+//
+// 从项目私有配置中读取绑定的账号名称。
+//
+// 返回:
+//   - string: 账号名称，如果未绑定则返回空字符串
+func (r *RepoManager) GetGitHubAccount() string {
+	cfg := r.loadPrivateConfig()
+	if cfg == nil {
+		return ""
+	}
+
+	// 查找当前 repo_id 的配置
+	repoSection, ok := cfg.Repositories[r.repoID]
+	if !ok || repoSection.GitHubAccount == nil {
+		return ""
+	}
+
+	return *repoSection.GitHubAccount
+}
+
 // Save 保存配置到文件
 //
 // 保存当前 Config 字段的内容到文件。
@@ -417,6 +464,10 @@ func (r *RepoManager) getRepoConfig() *RepoConfig {
 		}
 	}
 
+	// 读取 merge
+	cfg.Merge.Method = r.publicViper.GetString("merge.method")
+	cfg.Merge.DeleteBranch = r.publicViper.GetBool("merge.delete_branch")
+
 	return cfg
 }
 
@@ -541,6 +592,7 @@ func extractRepoNameFromURL(url string) string {
 //
 //	[${repo_id}]
 //	auto_accept_change_type = true
+//	github_account = "work"
 type PrivateRepoConfig struct {
 	// Repositories 按 repo_id 组织的配置
 	Repositories map[string]PrivateRepoSection `toml:",inline"`
@@ -552,6 +604,8 @@ type PrivateRepoSection struct {
 	Branch *BranchConfig `toml:"branch,omitempty"`
 	// AutoAcceptChangeType 自动接受变更类型
 	AutoAcceptChangeType *bool `toml:"auto_accept_change_type,omitempty"`
+	// GitHubAccount 仓库绑定的 GitHub 账号名称（优先于账号规则和全局当前账号）
+	GitHubAccount *string `toml:"github_account,omitempty"`
 }
 
 // loadPrivateConfig 加载私有配置（延迟加载，带缓存）
@@ -587,53 +641,66 @@ func (r *RepoManager) loadPrivateConfig() *PrivateRepoConfig {
 	}
 
 	// 转换为结构化配置
+	// 解析所有仓库的配置段，保存时不会丢失其他仓库的配置
 	privateConfig := &PrivateRepoConfig{
 		Repositories: make(map[string]PrivateRepoSection),
 	}
 
-	// 初始化仓库配置段
-	repoSection := PrivateRepoSection{}
-
 	// TOML 解析器会将 [repo_id.branch] 解析为嵌套结构：
 	// config["repo_id"] = map[string]interface{}{
 	//     "branch": map[string]interface{}{...}
 	// }
-	// 所以需要先检查 repo_id 是否存在
-	if repoValue, ok := config[r.repoID]; ok {
-		if repoMap, ok := repoValue.(map[string]interface{}); ok {
-			// 解析 branch 配置
-			if branchValue, ok := repoMap["branch"]; ok {
-				if branchMap, ok := branchValue.(map[string]interface{}); ok {
-					branchConfig := &BranchConfig{}
-					if prefix, ok := branchMap["prefix"].(string); ok {
-						branchConfig.Prefix = &prefix
-					}
-					if ignore, ok := branchMap["ignore"].([]interface{}); ok {
-						branchConfig.Ignore = make([]string, 0, len(ignore))
-						for _, item := range ignore {
-							if str, ok := item.(string); ok {
-								branchConfig.Ignore = append(branchConfig.Ignore, str)
-							}
-						}
+	for repoID, repoValue := range config {
+		repoMap, ok := repoValue.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		// 如果有任何配置，保存到结果中
+		repoSection := parsePrivateRepoSection(repoMap)
+		if repoSection.Branch != nil || repoSection.AutoAcceptChangeType != nil || repoSection.GitHubAccount != nil {
+			privateConfig.Repositories[repoID] = repoSection
+		}
+	}
+
+	// 缓存配置
+	r.privateConfig = privateConfig
+
+	return privateConfig
+}
+
+// parsePrivateRepoSection 解析单个仓库的私有配置段
+func parsePrivateRepoSection(repoMap map[string]interface{}) PrivateRepoSection {
+	repoSection := PrivateRepoSection{}
+
+	// 解析 branch 配置
+	if branchValue, ok := repoMap["branch"]; ok {
+		if branchMap, ok := branchValue.(map[string]interface{}); ok {
+			branchConfig := &BranchConfig{}
+			if prefix, ok := branchMap["prefix"].(string); ok {
+				branchConfig.Prefix = &prefix
+			}
+			if ignore, ok := branchMap["ignore"].([]interface{}); ok {
+				branchConfig.Ignore = make([]string, 0, len(ignore))
+				for _, item := range ignore {
+					if str, ok := item.(string); ok {
+						branchConfig.Ignore = append(branchConfig.Ignore, str)
 					}
-					repoSection.Branch = branchConfig
 				}
 			}
-
-			// 解析 auto_accept_change_type
-			if autoAccept, ok := repoMap["auto_accept_change_type"].(bool); ok {
-				repoSection.AutoAcceptChangeType = &autoAccept
-			}
+			repoSection.Branch = branchConfig
 		}
 	}
 
-	// 如果有任何配置，保存到结果中
-	if repoSection.Branch != nil || repoSection.AutoAcceptChangeType != nil {
-		privateConfig.Repositories[r.repoID] = repoSection
+	// 解析 auto_accept_change_type
+	if autoAccept, ok := repoMap["auto_accept_change_type"].(bool); ok {
+		repoSection.AutoAcceptChangeType = &autoAccept
 	}
 
-	// 缓存配置
-	r.privateConfig = privateConfig
+	// 解析 github_account
+	if account, ok := repoMap["github_account"].(string); ok && account != "" {
+		repoSection.GitHubAccount = &account
+	}
 
-	return privateConfig
+	return repoSection
 }
@@ -321,6 +321,59 @@ func TestRepoManager_GetTemplateConfig_Empty(t *testing.T) {
 	assert.Empty(t, templateConfig.PullRequests)
 }
 
+// ==================== GetMergeMethod 测试 ====================
+
+func TestRepoManager_GetMergeMethod(t *testing.T) {
+	// Arrange: 设置测试环境并创建包含 [merge] 的公共配置文件
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+
+	configDir := filepath.Join(tempDir, ".workflow")
+	publicConfigPath := filepath.Join(configDir, "config.toml")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+
+	configContent := `[merge]
+method = "rebase"
+delete_branch = true
+`
+	require.NoError(t, os.WriteFile(publicConfigPath, []byte(configContent), 0644))
+
+	mockGitRepo := &mockGitRepository{
+		repoPath:  tempDir,
+		isGitRepo: true,
+		remoteURL: "https://github.com/owner/repo.git",
+	}
+
+	manager, err := newRepoManager(mockGitRepo)
+	require.NoError(t, err)
+	require.NoError(t, manager.Load())
+
+	// Act & Assert: 验证合并方法
+	assert.Equal(t, "rebase", manager.GetMergeMethod())
+	assert.Equal(t, "rebase", manager.Config.Merge.Method)
+	assert.True(t, manager.GetMergeDeleteBranch())
+}
+
+func TestRepoManager_GetMergeMethod_NotConfigured(t *testing.T) {
+	// Arrange: 设置测试环境，不创建配置文件
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+
+	mockGitRepo := &mockGitRepository{
+		repoPath:  tempDir,
+		isGitRepo: true,
+		remoteURL: "https://github.com/owner/repo.git",
+	}
+
+	manager, err := newRepoManager(mockGitRepo)
+	require.NoError(t, err)
+	require.NoError(t, manager.Load())
+
+	// Act & Assert: 未配置时返回空字符串
+	assert.Equal(t, "", manager.GetMergeMethod())
+	assert.False(t, manager.GetMergeDeleteBranch())
+}
+
 // ==================== GetBranchPrefix 测试 ====================
 
 func TestRepoManager_GetBranchPrefix(t *testing.T) {
@@ -521,6 +574,57 @@ func TestRepoManager_GetAutoAcceptChangeType_NotConfigured(t *testing.T) {
 	assert.False(t, autoAccept)
 }
 
+// ==================== GetGitHubAccount 测试 ====================
+
+func TestRepoManager_GetGitHubAccount(t *testing.T) {
+	// Arrange: 创建包含多个仓库配置段的私有配置文件
+	tempDir := t.TempDir()
+
+	mockGitRepo := &mockGitRepository{
+		repoPath:  tempDir,
+		isGitRepo: true,
+		remoteURL: "https://github.com/acme/repo.git",
+	}
+
+	manager, err := newRepoManager(mockGitRepo)
+	require.NoError(t, err)
+	manager.privatePath = filepath.Join(tempDir, "repository.toml")
+	repoID := manager.GetRepoID()
+
+	configContent := fmt.Sprintf(`[%s]
+github_account = "work"
+
+[other_12345678.branch]
+prefix = "feature"
+`, repoID)
+	require.NoError(t, os.WriteFile(manager.privatePath, []byte(configContent), 0644))
+
+	// Act & Assert: 读取当前仓库绑定的账号
+	assert.Equal(t, "work", manager.GetGitHubAccount())
+
+	// 其他仓库的配置段也会被加载，保存时不会丢失
+	privateConfig := manager.LoadPrivateConfig()
+	require.NotNil(t, privateConfig)
+	require.Contains(t, privateConfig.Repositories, "other_12345678")
+	assert.Equal(t, "feature", *privateConfig.Repositories["other_12345678"].Branch.Prefix)
+}
+
+func TestRepoManager_GetGitHubAccount_NotConfigured(t *testing.T) {
+	tempDir := t.TempDir()
+
+	mockGitRepo := &mockGitRepository{
+		repoPath:  tempDir,
+		isGitRepo: true,
+		remoteURL: "https://github.com/acme/repo.git",
+	}
+
+	manager, err := newRepoManager(mockGitRepo)
+	require.NoError(t, err)
+	manager.privatePath = filepath.Join(tempDir, "repository.toml")
+
+	assert.Empty(t, manager.GetGitHubAccount())
+}
+
 // ==================== SaveTemplateConfig 测试 ====================
 
 func TestRepoManager_SaveTemplateConfig(t *testing.T) {
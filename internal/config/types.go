@@ -6,11 +6,13 @@ package config
 // 用于全局配置：遵循 XDG Base Directory Specification
 // 配置文件位置：$XDG_CONFIG_HOME/Workflow/config.toml（默认：~/.config/Workflow/config.toml）
 type GlobalConfig struct {
-	Jira   JiraConfig   `toml:"jira,omitempty"`
-	GitHub GitHubConfig `toml:"github,omitempty"`
-	Log    LogConfig    `toml:"log,omitempty"`
-	LLM    LLMConfig    `toml:"llm,omitempty"`
-	Proxy  ProxyConfig  `toml:"proxy,omitempty"`
+	Jira     JiraConfig     `toml:"jira,omitempty"`
+	GitHub   GitHubConfig   `toml:"github,omitempty"`
+	GitLab   GitLabConfig   `toml:"gitlab,omitempty"`
+	Platform PlatformConfig `toml:"platform,omitempty"`
+	Log      LogConfig      `toml:"log,omitempty"`
+	LLM      LLMConfig      `toml:"llm,omitempty"`
+	Proxy    ProxyConfig    `toml:"proxy,omitempty"`
 }
 
 // RepoConfig 仓库配置结构
@@ -19,4 +21,5 @@ type GlobalConfig struct {
 // 用于仓库公共配置：.workflow/config.toml（项目根目录，提交到 Git）
 type RepoConfig struct {
 	Template TemplateConfig `toml:"template,omitempty"`
+	Merge    MergeConfig    `toml:"merge,omitempty"`
 }
@@ -42,6 +42,30 @@ func (r *Repository) CreateBranch(name string) error {
 	return nil
 }
 
+// CreateBranchAt 在指定提交创建分支
+//
+// 分支已存在时返回错误，不会移动已有分支。
+func (r *Repository) CreateBranchAt(name string, hash plumbing.Hash) error {
+	exists, err := r.BranchExists(name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("branch %s already exists", name)
+	}
+
+	if _, err := r.repo.CommitObject(hash); err != nil {
+		return fmt.Errorf("commit %s not found: %w", hash, err)
+	}
+
+	branchRef := plumbing.NewHashReference(plumbing.NewBranchReferenceName(name), hash)
+	if err := r.repo.Storer.SetReference(branchRef); err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", name, err)
+	}
+
+	return nil
+}
+
 // CheckoutBranch 切换到指定分支
 func (r *Repository) CheckoutBranch(name string) error {
 	branchRef := plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", name))
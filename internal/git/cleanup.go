@@ -0,0 +1,310 @@
+package git
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// 分支可以清理的原因
+const (
+	CleanupReasonMerged = "merged" // 已合并到默认分支
+	CleanupReasonGone   = "gone"   // 上游分支已在远程删除
+	CleanupReasonStale  = "stale"  // 最后一次提交早于阈值
+)
+
+// CleanupOptions 查找可清理分支的选项
+type CleanupOptions struct {
+	// DefaultBranch 默认分支，合并检查以它为目标，且自身不会被清理
+	DefaultBranch string
+	// StaleAfter 最后一次提交早于该时长的分支视为过期，0 表示不检查
+	StaleAfter time.Duration
+	// Ignore 忽略的分支（支持 path.Match 通配符，如 "release/*"）
+	Ignore []string
+	// RemoteBranches 远程实际存在的分支（remote 名称 -> 分支名称集合）
+	// 为 nil 时使用本地的远程跟踪分支判断上游是否已删除
+	RemoteBranches map[string]map[string]bool
+	// Now 当前时间，为零值时使用 time.Now()
+	Now time.Time
+}
+
+// CleanupCandidate 可清理的分支
+type CleanupCandidate struct {
+	// Name 分支名称
+	Name string
+	// Hash 分支指向的提交
+	Hash string
+	// Remote 上游所在的远程（未设置上游时为空）
+	Remote string
+	// UpstreamBranch 上游分支名称（未设置上游时为空）
+	UpstreamBranch string
+	// LastCommit 最后一次提交的时间
+	LastCommit time.Time
+	// Reasons 可清理的原因（CleanupReason* 常量）
+	Reasons []string
+}
+
+// HasReason 判断是否包含指定原因
+func (c *CleanupCandidate) HasReason(reason string) bool {
+	for _, r := range c.Reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// FindCleanupCandidates 查找可以清理的本地分支
+//
+// 当前分支、默认分支和忽略的分支不会被返回。分支满足以下任一条件即可清理：
+// 已合并到默认分支（本地或远程跟踪分支）、上游分支已删除、最后一次提交早于 StaleAfter。
+//
+// 参数:
+//   - opts: 查找选项
+//
+// 返回:
+//   - []CleanupCandidate: 按分支名称排序的可清理分支
+//   - error: 读取仓库失败时返回错误
+func (r *Repository) FindCleanupCandidates(opts CleanupOptions) ([]CleanupCandidate, error) {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	targets, err := r.mergeTargets(opts.DefaultBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := r.repo.Config()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git config: %w", err)
+	}
+
+	branches, err := r.ListBranches()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []CleanupCandidate{}
+	for _, branch := range branches {
+		if branch.IsHead || branch.Name == opts.DefaultBranch || matchesAny(opts.Ignore, branch.Name) {
+			continue
+		}
+
+		ref, err := r.repo.Reference(plumbing.NewBranchReferenceName(branch.Name), true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve branch %s: %w", branch.Name, err)
+		}
+		commit, err := r.repo.CommitObject(ref.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commit of branch %s: %w", branch.Name, err)
+		}
+
+		candidate := CleanupCandidate{
+			Name:       branch.Name,
+			Hash:       ref.Hash().String(),
+			LastCommit: commit.Committer.When,
+		}
+		if upstream, ok := cfg.Branches[branch.Name]; ok && upstream.Remote != "" && upstream.Merge.IsBranch() {
+			candidate.Remote = upstream.Remote
+			candidate.UpstreamBranch = upstream.Merge.Short()
+		}
+
+		if isMerged(commit, targets) {
+			candidate.Reasons = append(candidate.Reasons, CleanupReasonMerged)
+		}
+		if candidate.UpstreamBranch != "" && !r.upstreamExists(candidate.Remote, candidate.UpstreamBranch, opts.RemoteBranches) {
+			candidate.Reasons = append(candidate.Reasons, CleanupReasonGone)
+		}
+		if opts.StaleAfter > 0 && now.Sub(candidate.LastCommit) > opts.StaleAfter {
+			candidate.Reasons = append(candidate.Reasons, CleanupReasonStale)
+		}
+
+		if len(candidate.Reasons) > 0 {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates, nil
+}
+
+// mergeTargets 返回默认分支的本地提交和各远程跟踪提交
+func (r *Repository) mergeTargets(defaultBranch string) ([]*object.Commit, error) {
+	names := []plumbing.ReferenceName{plumbing.NewBranchReferenceName(defaultBranch)}
+
+	remotes, err := r.repo.Remotes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remotes: %w", err)
+	}
+	for _, remote := range remotes {
+		names = append(names, plumbing.NewRemoteReferenceName(remote.Config().Name, defaultBranch))
+	}
+
+	targets := []*object.Commit{}
+	for _, name := range names {
+		ref, err := r.repo.Reference(name, true)
+		if err != nil {
+			continue
+		}
+		commit, err := r.repo.CommitObject(ref.Hash())
+		if err != nil {
+			continue
+		}
+		targets = append(targets, commit)
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("default branch %s not found", defaultBranch)
+	}
+	return targets, nil
+}
+
+// upstreamExists 判断上游分支是否仍然存在
+func (r *Repository) upstreamExists(remote, branch string, remoteBranches map[string]map[string]bool) bool {
+	if branches, ok := remoteBranches[remote]; ok {
+		return branches[branch]
+	}
+
+	_, err := r.repo.Reference(plumbing.NewRemoteReferenceName(remote, branch), true)
+	return err == nil
+}
+
+// isMerged 判断提交是否已包含在任一目标提交中
+func isMerged(commit *object.Commit, targets []*object.Commit) bool {
+	for _, target := range targets {
+		if commit.Hash == target.Hash {
+			return true
+		}
+		if ok, err := commit.IsAncestor(target); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny 判断名称是否匹配任一模式（精确匹配或 path.Match 通配符）
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if pattern == name {
+			return true
+		}
+		if ok, err := path.Match(pattern, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// FindLocalOnlyTags 查找只存在于本地的 tag
+//
+// 参数:
+//   - remoteTags: 远程存在的 tag 名称集合
+//
+// 返回:
+//   - []TagInfo: 按名称排序的本地 tag（CommitHash 为 tag 引用指向的对象）
+//   - error: 读取 tag 失败时返回错误
+func (r *Repository) FindLocalOnlyTags(remoteTags map[string]bool) ([]TagInfo, error) {
+	tags, err := r.ListTags()
+	if err != nil {
+		return nil, err
+	}
+
+	localOnly := []TagInfo{}
+	for _, tag := range tags {
+		if !remoteTags[tag.Name] {
+			localOnly = append(localOnly, tag)
+		}
+	}
+
+	sort.Slice(localOnly, func(i, j int) bool {
+		return localOnly[i].Name < localOnly[j].Name
+	})
+	return localOnly, nil
+}
+
+// CleanupRecord 一次清理删除的引用，用于撤销
+type CleanupRecord struct {
+	// Time 清理时间
+	Time time.Time `json:"time"`
+	// Branches 删除的分支
+	Branches []DeletedRef `json:"branches,omitempty"`
+	// Tags 删除的 tag
+	Tags []DeletedRef `json:"tags,omitempty"`
+}
+
+// DeletedRef 被删除的分支或 tag
+type DeletedRef struct {
+	// Name 名称
+	Name string `json:"name"`
+	// Hash 删除前指向的对象
+	Hash string `json:"hash"`
+	// Remote 同时从该远程删除（为空表示只删除了本地）
+	Remote string `json:"remote,omitempty"`
+}
+
+// ReadCleanupRecords 读取清理记录文件
+//
+// 参数:
+//   - path: 记录文件路径
+//
+// 返回:
+//   - []CleanupRecord: 清理记录（从旧到新），文件不存在时返回空列表
+//   - error: 读取或解析失败时返回错误
+func ReadCleanupRecords(path string) ([]CleanupRecord, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []CleanupRecord{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cleanup records: %w", err)
+	}
+
+	var records []CleanupRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse cleanup records %s: %w", path, err)
+	}
+	return records, nil
+}
+
+// WriteCleanupRecords 写入清理记录文件
+//
+// 记录为空时删除文件。
+//
+// 参数:
+//   - path: 记录文件路径
+//   - records: 清理记录（从旧到新）
+//
+// 返回:
+//   - error: 写入失败时返回错误
+func WriteCleanupRecords(path string, records []CleanupRecord) error {
+	if len(records) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove cleanup records: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for cleanup records: %w", err)
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cleanup records: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cleanup records: %w", err)
+	}
+	return nil
+}
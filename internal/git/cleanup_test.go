@@ -0,0 +1,242 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commitFile 写入文件并提交，返回提交哈希
+func commitFile(t *testing.T, repo *Repository, dir, name, content string) plumbing.Hash {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	require.NoError(t, repo.Add(name))
+	hash, err := repo.Commit("Update "+name, &object.Signature{
+		Name:  "Test User",
+		Email: "test@example.com",
+		When:  time.Now(),
+	})
+	require.NoError(t, err)
+	return hash
+}
+
+// setUpstream 为分支配置上游
+func setUpstream(t *testing.T, repo *Repository, branch, remote string) {
+	t.Helper()
+
+	cfg, err := repo.repo.Config()
+	require.NoError(t, err)
+	cfg.Branches[branch] = &config.Branch{
+		Name:   branch,
+		Remote: remote,
+		Merge:  plumbing.NewBranchReferenceName(branch),
+	}
+	require.NoError(t, repo.repo.SetConfig(cfg))
+}
+
+// candidateNames 返回候选分支名称
+func candidateNames(candidates []CleanupCandidate) []string {
+	names := []string{}
+	for _, candidate := range candidates {
+		names = append(names, candidate.Name)
+	}
+	return names
+}
+
+// ==================== FindCleanupCandidates 测试 ====================
+
+func TestRepository_FindCleanupCandidates_Merged(t *testing.T) {
+	repo, dir := setupTestRepoWithCommit(t)
+
+	// merged 指向旧提交，main 继续前进
+	require.NoError(t, repo.CreateBranch("merged"))
+	commitFile(t, repo, dir, "main.txt", "main")
+
+	// feature 有 main 没有的提交
+	require.NoError(t, repo.CreateAndCheckoutBranch("feature"))
+	commitFile(t, repo, dir, "feature.txt", "feature")
+	require.NoError(t, repo.CheckoutBranch("main"))
+
+	candidates, err := repo.FindCleanupCandidates(CleanupOptions{DefaultBranch: "main"})
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, "merged", candidates[0].Name)
+	assert.Equal(t, []string{CleanupReasonMerged}, candidates[0].Reasons)
+}
+
+func TestRepository_FindCleanupCandidates_Stale(t *testing.T) {
+	repo, dir := setupTestRepoWithCommit(t)
+
+	require.NoError(t, repo.CreateAndCheckoutBranch("old"))
+	commitFile(t, repo, dir, "old.txt", "old")
+	require.NoError(t, repo.CheckoutBranch("main"))
+
+	// 未到阈值
+	candidates, err := repo.FindCleanupCandidates(CleanupOptions{DefaultBranch: "main", StaleAfter: 24 * time.Hour})
+	require.NoError(t, err)
+	assert.Empty(t, candidates)
+
+	// 模拟 30 天后
+	candidates, err = repo.FindCleanupCandidates(CleanupOptions{
+		DefaultBranch: "main",
+		StaleAfter:    24 * time.Hour,
+		Now:           time.Now().Add(30 * 24 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, "old", candidates[0].Name)
+	assert.True(t, candidates[0].HasReason(CleanupReasonStale))
+}
+
+func TestRepository_FindCleanupCandidates_Gone(t *testing.T) {
+	repo, dir := setupTestRepoWithCommit(t)
+
+	for _, name := range []string{"gone", "alive"} {
+		require.NoError(t, repo.CreateAndCheckoutBranch(name))
+		commitFile(t, repo, dir, name+".txt", name)
+		require.NoError(t, repo.CheckoutBranch("main"))
+		setUpstream(t, repo, name, "origin")
+	}
+
+	candidates, err := repo.FindCleanupCandidates(CleanupOptions{
+		DefaultBranch:  "main",
+		RemoteBranches: map[string]map[string]bool{"origin": {"alive": true}},
+	})
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, "gone", candidates[0].Name)
+	assert.Equal(t, "origin", candidates[0].Remote)
+	assert.Equal(t, "gone", candidates[0].UpstreamBranch)
+	assert.Equal(t, []string{CleanupReasonGone}, candidates[0].Reasons)
+}
+
+func TestRepository_FindCleanupCandidates_Ignore(t *testing.T) {
+	repo, dir := setupTestRepoWithCommit(t)
+
+	for _, name := range []string{"release/1.0", "develop", "topic"} {
+		require.NoError(t, repo.CreateBranch(name))
+	}
+	commitFile(t, repo, dir, "main.txt", "main")
+
+	candidates, err := repo.FindCleanupCandidates(CleanupOptions{
+		DefaultBranch: "main",
+		Ignore:        []string{"release/*", "develop"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"topic"}, candidateNames(candidates))
+}
+
+func TestRepository_FindCleanupCandidates_SkipsCurrentAndDefault(t *testing.T) {
+	repo, _ := setupTestRepoWithCommit(t)
+
+	// current 与 main 指向同一提交，但它是当前分支
+	require.NoError(t, repo.CreateAndCheckoutBranch("current"))
+
+	candidates, err := repo.FindCleanupCandidates(CleanupOptions{DefaultBranch: "main"})
+	require.NoError(t, err)
+	assert.Empty(t, candidates)
+}
+
+func TestRepository_FindCleanupCandidates_UnknownDefault(t *testing.T) {
+	repo, _ := setupTestRepoWithCommit(t)
+
+	_, err := repo.FindCleanupCandidates(CleanupOptions{DefaultBranch: "trunk"})
+	assert.Error(t, err)
+}
+
+// ==================== FindLocalOnlyTags 测试 ====================
+
+func TestRepository_FindLocalOnlyTags(t *testing.T) {
+	repo, _ := setupTestRepoWithCommit(t)
+
+	require.NoError(t, repo.CreateTagAtHead("v1.0.0"))
+	require.NoError(t, repo.CreateTagAtHead("local-test"))
+
+	tags, err := repo.FindLocalOnlyTags(map[string]bool{"v1.0.0": true})
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "local-test", tags[0].Name)
+}
+
+// ==================== CreateBranchAt 测试 ====================
+
+func TestRepository_CreateBranchAt(t *testing.T) {
+	repo, dir := setupTestRepoWithCommit(t)
+
+	first, err := repo.GetHead()
+	require.NoError(t, err)
+	commitFile(t, repo, dir, "second.txt", "second")
+
+	require.NoError(t, repo.CreateBranchAt("restored", first))
+
+	hash, err := repo.ResolveRevision("restored")
+	require.NoError(t, err)
+	assert.Equal(t, first, hash)
+
+	// 已存在的分支不会被移动
+	head, err := repo.GetHead()
+	require.NoError(t, err)
+	assert.Error(t, repo.CreateBranchAt("restored", head))
+
+	// 不存在的提交
+	assert.Error(t, repo.CreateBranchAt("missing", plumbing.NewHash("1111111111111111111111111111111111111111")))
+}
+
+// ==================== DeleteRemoteBranch 测试 ====================
+
+func TestRepository_DeleteRemoteBranch(t *testing.T) {
+	_, remoteDir, _ := setupMockRemoteRepoWithCommit(t, "main")
+	repo, dir := setupTestRepoWithCommit(t)
+	require.NoError(t, repo.AddRemote("origin", remoteDir))
+
+	require.NoError(t, repo.CreateAndCheckoutBranch("feature"))
+	commitFile(t, repo, dir, "feature.txt", "feature")
+	require.NoError(t, repo.Push("origin", "feature", nil))
+
+	refs, err := repo.ListRemoteRefs("origin")
+	require.NoError(t, err)
+	assert.Contains(t, refs, "refs/heads/feature")
+
+	require.NoError(t, repo.DeleteRemoteBranch("origin", "feature", nil))
+
+	refs, err = repo.ListRemoteRefs("origin")
+	require.NoError(t, err)
+	assert.NotContains(t, refs, "refs/heads/feature")
+}
+
+// ==================== CleanupRecords 测试 ====================
+
+func TestCleanupRecords_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "records.json")
+
+	// 文件不存在时返回空列表
+	records, err := ReadCleanupRecords(path)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	want := []CleanupRecord{
+		{
+			Time:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Branches: []DeletedRef{{Name: "feature", Hash: "abc", Remote: "origin"}},
+			Tags:     []DeletedRef{{Name: "v0.1", Hash: "def"}},
+		},
+	}
+	require.NoError(t, WriteCleanupRecords(path, want))
+
+	records, err = ReadCleanupRecords(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, records)
+
+	// 写入空记录时删除文件
+	require.NoError(t, WriteCleanupRecords(path, nil))
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
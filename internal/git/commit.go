@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/storer"
@@ -72,12 +73,12 @@ func (r *Repository) AddAll() error {
 // Commit 提交更改
 func (r *Repository) Commit(message string, author *object.Signature) (plumbing.Hash, error) {
 	if author == nil {
-		// 尝试从配置获取作者信息
-		config, err := r.repo.Config()
-		if err == nil {
+		// 尝试从配置获取作者信息（包括全局配置 ~/.gitconfig）
+		cfg, err := r.repo.ConfigScoped(config.GlobalScope)
+		if err == nil && cfg.User.Name != "" {
 			author = &object.Signature{
-				Name:  config.User.Name,
-				Email: config.User.Email,
+				Name:  cfg.User.Name,
+				Email: cfg.User.Email,
 				When:  time.Now(),
 			}
 		} else {
@@ -0,0 +1,687 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/binary"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// 文件变更状态
+const (
+	DiffStatusAdded    = "added"
+	DiffStatusModified = "modified"
+	DiffStatusDeleted  = "deleted"
+	DiffStatusRenamed  = "renamed"
+)
+
+// renameSimilarityThreshold 删除和新增的文件内容相似度达到该百分比时视为重命名（与 git 默认值一致）
+const renameSimilarityThreshold = 50
+
+// Diff 差异结果
+type Diff struct {
+	// Patch unified diff 文本，二进制文件输出 "Binary files ... differ" 占位
+	Patch string
+	// Files 每个文件的变更统计，按路径排序
+	Files []DiffFileStat
+}
+
+// DiffFileStat 单个文件的变更统计
+type DiffFileStat struct {
+	// Path 文件路径（删除的文件为删除前的路径）
+	Path string
+	// OldPath 重命名前的路径（仅 Status 为 DiffStatusRenamed 时有值）
+	OldPath string
+	// Status 变更状态（DiffStatus* 常量）
+	Status string
+	// Added 新增行数
+	Added int
+	// Deleted 删除行数
+	Deleted int
+	// Binary 是否为二进制文件（二进制文件没有行数统计）
+	Binary bool
+}
+
+// IsEmpty 判断是否没有任何变更
+func (d *Diff) IsEmpty() bool {
+	return len(d.Files) == 0
+}
+
+// Totals 返回所有文件的新增和删除行数合计
+func (d *Diff) Totals() (added, deleted int) {
+	for _, file := range d.Files {
+		added += file.Added
+		deleted += file.Deleted
+	}
+	return added, deleted
+}
+
+// DiffWorktree 获取工作区相对于 HEAD 的差异
+//
+// 包含已暂存和未暂存的修改、删除以及未跟踪的新文件，与 `git add -A && git diff --cached` 的结果一致。
+//
+// 返回:
+//   - *Diff: 差异结果，无变更时 Files 为空
+//   - error: 错误信息
+func (r *Repository) DiffWorktree() (*Diff, error) {
+	headTree, err := r.headTree()
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := r.changedPaths(func(fileStatus *git.FileStatus) bool {
+		return fileStatus.Staging != git.Unmodified || fileStatus.Worktree != git.Unmodified
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]filePair, 0, len(paths))
+	for _, path := range paths {
+		from, err := r.treeFileContent(headTree, path)
+		if err != nil {
+			return nil, err
+		}
+		to, err := r.worktreeFileContent(path)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, filePair{from: from, to: to})
+	}
+
+	return buildDiff(pairs)
+}
+
+// DiffStaged 获取暂存区相对于 HEAD 的差异
+//
+// 只包含已暂存（git add）的变更，与 `git diff --cached` 一致。
+//
+// 返回:
+//   - *Diff: 差异结果，没有暂存的变更时 Files 为空
+//   - error: 错误信息
+func (r *Repository) DiffStaged() (*Diff, error) {
+	headTree, err := r.headTree()
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := r.repo.Storer.Index()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+
+	paths, err := r.changedPaths(func(fileStatus *git.FileStatus) bool {
+		return fileStatus.Staging != git.Unmodified && fileStatus.Staging != git.Untracked
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]filePair, 0, len(paths))
+	for _, path := range paths {
+		from, err := r.treeFileContent(headTree, path)
+		if err != nil {
+			return nil, err
+		}
+		to, err := r.indexFileContent(idx, path)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, filePair{from: from, to: to})
+	}
+
+	return buildDiff(pairs)
+}
+
+// DiffRange 获取 head 相对于 base 的差异
+//
+// 以两者的合并基（merge-base）为起点计算差异，与 `git diff base...head` 一致。
+//
+// 参数:
+//   - base: 基准分支或修订版本
+//   - head: 目标分支或修订版本（为空时使用 HEAD）
+//
+// 返回:
+//   - *Diff: 差异结果
+//   - error: 修订版本不存在或没有合并基时返回错误
+func (r *Repository) DiffRange(base, head string) (*Diff, error) {
+	if head == "" {
+		head = "HEAD"
+	}
+
+	baseHash, err := r.ResolveRevision(base)
+	if err != nil {
+		return nil, err
+	}
+	headHash, err := r.ResolveRevision(head)
+	if err != nil {
+		return nil, err
+	}
+
+	baseCommit, err := r.repo.CommitObject(baseHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", base, err)
+	}
+	headCommit, err := r.repo.CommitObject(headHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", head, err)
+	}
+
+	bases, err := baseCommit.MergeBase(headCommit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base: %w", err)
+	}
+	if len(bases) == 0 {
+		return nil, fmt.Errorf("no merge base between %s and %s", base, head)
+	}
+
+	fromTree, err := bases[0].Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree of merge base: %w", err)
+	}
+	toTree, err := headCommit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree of %s: %w", head, err)
+	}
+
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute diff: %w", err)
+	}
+
+	pairs := make([]filePair, 0, len(changes))
+	for _, change := range changes {
+		var pair filePair
+		if change.From.Name != "" {
+			if pair.from, err = r.treeFileContent(fromTree, change.From.Name); err != nil {
+				return nil, err
+			}
+		}
+		if change.To.Name != "" {
+			if pair.to, err = r.treeFileContent(toTree, change.To.Name); err != nil {
+				return nil, err
+			}
+		}
+		pairs = append(pairs, pair)
+	}
+
+	return buildDiff(pairs)
+}
+
+// WorktreeDiff 获取工作区相对于 HEAD 的差异（unified diff 格式）
+//
+// 等价于 DiffWorktree 的 Patch。
+//
+// 返回:
+//   - string: unified diff 文本，无变更时为空字符串
+//   - error: 错误信息
+func (r *Repository) WorktreeDiff() (string, error) {
+	diff, err := r.DiffWorktree()
+	if err != nil {
+		return "", err
+	}
+	return diff.Patch, nil
+}
+
+// StagedDiff 获取暂存区相对于 HEAD 的差异（unified diff 格式）
+//
+// 等价于 DiffStaged 的 Patch。
+//
+// 返回:
+//   - string: unified diff 文本，没有暂存的变更时为空字符串
+//   - error: 错误信息
+func (r *Repository) StagedDiff() (string, error) {
+	diff, err := r.DiffStaged()
+	if err != nil {
+		return "", err
+	}
+	return diff.Patch, nil
+}
+
+// BranchDiff 获取当前 HEAD 相对于指定分支的差异（unified diff 格式）
+//
+// 等价于 DiffRange(base, "HEAD") 的 Patch。
+//
+// 参数:
+//   - base: 基准分支或修订版本
+//
+// 返回:
+//   - string: unified diff 文本
+//   - error: 错误信息
+func (r *Repository) BranchDiff(base string) (string, error) {
+	diff, err := r.DiffRange(base, "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return diff.Patch, nil
+}
+
+// DiffContents 比较两段文件内容，生成 unified diff
+//
+// 用于比较不在仓库中的文件（如嵌入的默认模板和覆盖后的模板）。
+//
+// 参数:
+//   - fromPath: 变更前的文件路径（用于 diff 文件头）
+//   - toPath: 变更后的文件路径（用于 diff 文件头）
+//   - from: 变更前的内容
+//   - to: 变更后的内容
+//
+// 返回:
+//   - string: unified diff 文本，内容相同时为空字符串
+//   - error: 错误信息
+func DiffContents(fromPath, toPath string, from, to []byte) (string, error) {
+	if bytes.Equal(from, to) {
+		return "", nil
+	}
+
+	fp := newContentFilePatch(
+		&contentFile{path: fromPath, mode: filemode.Regular, hash: plumbing.ComputeHash(plumbing.BlobObject, from), content: from},
+		&contentFile{path: toPath, mode: filemode.Regular, hash: plumbing.ComputeHash(plumbing.BlobObject, to), content: to},
+	)
+	return encodePatch(&contentPatch{filePatches: []fdiff.FilePatch{fp}})
+}
+
+// changedPaths 返回工作区状态中满足条件的文件路径（已排序）
+func (r *Repository) changedPaths(include func(*git.FileStatus) bool) ([]string, error) {
+	status, err := r.worktree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status: %w", err)
+	}
+
+	paths := make([]string, 0, len(status))
+	for path, fileStatus := range status {
+		if include(fileStatus) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// filePair 同一文件变更前后的内容，新增文件的 from 和删除文件的 to 为 nil
+type filePair struct {
+	from, to *contentFile
+}
+
+// path 返回变更后的路径，删除的文件返回删除前的路径
+func (p filePair) path() string {
+	if p.to != nil {
+		return p.to.path
+	}
+	return p.from.path
+}
+
+// buildDiff 识别重命名后生成补丁和文件统计
+func buildDiff(pairs []filePair) (*Diff, error) {
+	pairs = detectRenames(pairs)
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].path() < pairs[j].path()
+	})
+
+	patches := make([]fdiff.FilePatch, 0, len(pairs))
+	files := make([]DiffFileStat, 0, len(pairs))
+	for _, pair := range pairs {
+		fp := newContentFilePatch(pair.from, pair.to)
+		patches = append(patches, fp)
+		files = append(files, fp.stat())
+	}
+
+	patch, err := encodePatch(&contentPatch{filePatches: patches})
+	if err != nil {
+		return nil, err
+	}
+	return &Diff{Patch: patch, Files: files}, nil
+}
+
+// detectRenames 将删除和新增的文件配对为重命名
+//
+// 内容完全相同的文件优先配对，其次选择相似度最高且不低于 renameSimilarityThreshold 的文件。
+// 内容未变的文件以及没有实际变化的条目会被移除。
+func detectRenames(pairs []filePair) []filePair {
+	result := make([]filePair, 0, len(pairs))
+	var deleted, added []*contentFile
+	for _, pair := range pairs {
+		switch {
+		case pair.from == nil && pair.to == nil:
+			continue
+		case pair.from == nil:
+			added = append(added, pair.to)
+		case pair.to == nil:
+			deleted = append(deleted, pair.from)
+		default:
+			if pair.from.hash == pair.to.hash && pair.from.mode == pair.to.mode && pair.from.path == pair.to.path {
+				continue
+			}
+			result = append(result, pair)
+		}
+	}
+
+	used := make([]bool, len(added))
+	for _, from := range deleted {
+		best, bestScore := -1, 0
+		for i, to := range added {
+			if used[i] {
+				continue
+			}
+			score := similarity(from, to)
+			if score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+
+		if best >= 0 && bestScore >= renameSimilarityThreshold {
+			used[best] = true
+			result = append(result, filePair{from: from, to: added[best]})
+			continue
+		}
+		result = append(result, filePair{from: from})
+	}
+	for i, to := range added {
+		if !used[i] {
+			result = append(result, filePair{to: to})
+		}
+	}
+
+	return result
+}
+
+// similarity 计算两个文件内容的相似度（0-100）
+//
+// 内容相同返回 100；二进制文件只识别完全相同的内容；文本文件按相同行数计算。
+func similarity(a, b *contentFile) int {
+	if a.hash == b.hash {
+		return 100
+	}
+	if isBinaryContent(a.content) || isBinaryContent(b.content) {
+		return 0
+	}
+
+	linesA, linesB := splitLines(a.content), splitLines(b.content)
+	if len(linesA)+len(linesB) == 0 {
+		return 0
+	}
+
+	counts := make(map[string]int, len(linesA))
+	for _, line := range linesA {
+		counts[line]++
+	}
+	common := 0
+	for _, line := range linesB {
+		if counts[line] > 0 {
+			counts[line]--
+			common++
+		}
+	}
+
+	return common * 2 * 100 / (len(linesA) + len(linesB))
+}
+
+// headTree 获取 HEAD 提交的文件树，空仓库返回 nil
+func (r *Repository) headTree() (*object.Tree, error) {
+	ref, err := r.repo.Head()
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	commit, err := r.repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD commit: %w", err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD tree: %w", err)
+	}
+	return tree, nil
+}
+
+// treeFileContent 读取文件树中的文件内容，文件不存在时返回 nil
+func (r *Repository) treeFileContent(tree *object.Tree, path string) (*contentFile, error) {
+	if tree == nil {
+		return nil, nil
+	}
+
+	file, err := tree.File(path)
+	if err != nil {
+		if err == object.ErrFileNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s from HEAD: %w", path, err)
+	}
+
+	reader, err := file.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s from HEAD: %w", path, err)
+	}
+	defer reader.Close()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(reader); err != nil {
+		return nil, fmt.Errorf("failed to read %s from HEAD: %w", path, err)
+	}
+
+	return &contentFile{path: path, mode: file.Mode, hash: file.Hash, content: buf.Bytes()}, nil
+}
+
+// indexFileContent 读取暂存区中的文件内容，文件不存在时返回 nil
+func (r *Repository) indexFileContent(idx *index.Index, path string) (*contentFile, error) {
+	entry, err := idx.Entry(path)
+	if err != nil {
+		if err == index.ErrEntryNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s from index: %w", path, err)
+	}
+
+	blob, err := r.repo.BlobObject(entry.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s from index: %w", path, err)
+	}
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s from index: %w", path, err)
+	}
+	defer reader.Close()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(reader); err != nil {
+		return nil, fmt.Errorf("failed to read %s from index: %w", path, err)
+	}
+
+	return &contentFile{path: path, mode: entry.Mode, hash: entry.Hash, content: buf.Bytes()}, nil
+}
+
+// worktreeFileContent 读取工作区中的文件内容，文件不存在时返回 nil
+func (r *Repository) worktreeFileContent(path string) (*contentFile, error) {
+	fullPath := filepath.Join(r.path, filepath.FromSlash(path))
+
+	info, err := os.Lstat(fullPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	if info.IsDir() {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	mode, err := filemode.NewFromOSFileMode(info.Mode())
+	if err != nil {
+		mode = filemode.Regular
+	}
+
+	return &contentFile{
+		path:    path,
+		mode:    mode,
+		hash:    plumbing.ComputeHash(plumbing.BlobObject, content),
+		content: content,
+	}, nil
+}
+
+// encodePatch 将补丁编码为 unified diff 文本
+func encodePatch(patch fdiff.Patch) (string, error) {
+	var buf bytes.Buffer
+	encoder := fdiff.NewUnifiedEncoder(&buf, fdiff.DefaultContextLines)
+	if err := encoder.Encode(patch); err != nil {
+		return "", fmt.Errorf("failed to encode diff: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// contentPatch 基于文件内容构建的补丁，实现 fdiff.Patch 接口
+type contentPatch struct {
+	filePatches []fdiff.FilePatch
+}
+
+func (p *contentPatch) FilePatches() []fdiff.FilePatch { return p.filePatches }
+func (p *contentPatch) Message() string                { return "" }
+
+// contentFile 补丁中的文件，实现 fdiff.File 接口
+type contentFile struct {
+	path    string
+	mode    filemode.FileMode
+	hash    plumbing.Hash
+	content []byte
+}
+
+func (f *contentFile) Hash() plumbing.Hash     { return f.hash }
+func (f *contentFile) Mode() filemode.FileMode { return f.mode }
+func (f *contentFile) Path() string            { return f.path }
+
+// contentChunk 补丁中的变更块，实现 fdiff.Chunk 接口
+type contentChunk struct {
+	content string
+	op      fdiff.Operation
+}
+
+func (c *contentChunk) Content() string       { return c.content }
+func (c *contentChunk) Type() fdiff.Operation { return c.op }
+
+// contentFilePatch 单个文件的补丁，实现 fdiff.FilePatch 接口
+type contentFilePatch struct {
+	from, to *contentFile
+	binary   bool
+	chunks   []fdiff.Chunk
+}
+
+// newContentFilePatch 根据新旧文件内容计算补丁
+func newContentFilePatch(from, to *contentFile) *contentFilePatch {
+	fp := &contentFilePatch{from: from, to: to}
+
+	var src, dst []byte
+	if from != nil {
+		src = from.content
+	}
+	if to != nil {
+		dst = to.content
+	}
+
+	if isBinaryContent(src) || isBinaryContent(dst) {
+		fp.binary = true
+		return fp
+	}
+
+	for _, d := range diff.Do(string(src), string(dst)) {
+		var op fdiff.Operation
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			op = fdiff.Equal
+		case diffmatchpatch.DiffDelete:
+			op = fdiff.Delete
+		case diffmatchpatch.DiffInsert:
+			op = fdiff.Add
+		}
+		fp.chunks = append(fp.chunks, &contentChunk{content: d.Text, op: op})
+	}
+
+	return fp
+}
+
+func (p *contentFilePatch) IsBinary() bool { return p.binary }
+
+func (p *contentFilePatch) Files() (fdiff.File, fdiff.File) {
+	// 必须返回无类型的 nil，否则 encoder 无法识别新增/删除的文件
+	var from, to fdiff.File
+	if p.from != nil {
+		from = p.from
+	}
+	if p.to != nil {
+		to = p.to
+	}
+	return from, to
+}
+
+func (p *contentFilePatch) Chunks() []fdiff.Chunk { return p.chunks }
+
+// stat 返回补丁的文件变更统计
+func (p *contentFilePatch) stat() DiffFileStat {
+	stat := DiffFileStat{Binary: p.binary}
+	switch {
+	case p.from == nil:
+		stat.Path, stat.Status = p.to.path, DiffStatusAdded
+	case p.to == nil:
+		stat.Path, stat.Status = p.from.path, DiffStatusDeleted
+	case p.from.path != p.to.path:
+		stat.Path, stat.OldPath, stat.Status = p.to.path, p.from.path, DiffStatusRenamed
+	default:
+		stat.Path, stat.Status = p.to.path, DiffStatusModified
+	}
+
+	for _, chunk := range p.chunks {
+		switch chunk.Type() {
+		case fdiff.Add:
+			stat.Added += countLines(chunk.Content())
+		case fdiff.Delete:
+			stat.Deleted += countLines(chunk.Content())
+		}
+	}
+	return stat
+}
+
+// splitLines 按行拆分内容，保留换行符
+func splitLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// countLines 统计文本的行数（最后一行没有换行符时也计为一行）
+func countLines(text string) int {
+	lines := strings.Count(text, "\n")
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		lines++
+	}
+	return lines
+}
+
+// isBinaryContent 判断内容是否为二进制
+func isBinaryContent(content []byte) bool {
+	if len(content) == 0 {
+		return false
+	}
+	isBinary, err := binary.IsBinary(bytes.NewReader(content))
+	return err == nil && isBinary
+}
@@ -0,0 +1,289 @@
+//go:build test
+
+package git_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zevwings/workflow/internal/git"
+	"github.com/zevwings/workflow/internal/testutils"
+)
+
+// writeFile 在仓库中写入文件
+func writeFile(t *testing.T, testRepo *testutils.GitTestRepo, name, content string) {
+	t.Helper()
+
+	path := filepath.Join(testRepo.Path(), name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+// commitAll 暂存所有变更并提交
+func commitAll(t *testing.T, repo *git.Repository, message string) {
+	t.Helper()
+
+	require.NoError(t, repo.AddAll())
+	_, err := repo.Commit(message, &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Now()})
+	require.NoError(t, err)
+}
+
+// statsByPath 按路径索引文件统计
+func statsByPath(diff *git.Diff) map[string]git.DiffFileStat {
+	stats := map[string]git.DiffFileStat{}
+	for _, file := range diff.Files {
+		stats[file.Path] = file
+	}
+	return stats
+}
+
+// numberedLines 生成 n 行文本
+func numberedLines(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		b.WriteString("line ")
+		b.WriteString(string(rune('a' + i)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// ==================== DiffStaged 测试 ====================
+
+func TestRepository_DiffStaged_Stats(t *testing.T) {
+	testRepo := testutils.NewGitTestRepo().
+		WithFileString("a.txt", "one\ntwo\nthree\n").
+		WithFileString("b.txt", "remove me\n").
+		WithCommit("Initial commit").
+		Build(t)
+	repo := testRepo.Repository()
+
+	writeFile(t, testRepo, "a.txt", "one\n2\nthree\nfour\n")
+	require.NoError(t, os.Remove(filepath.Join(testRepo.Path(), "b.txt")))
+	writeFile(t, testRepo, "c.txt", "new\nfile\n")
+	require.NoError(t, repo.AddAll())
+
+	// 未暂存的修改不应出现
+	writeFile(t, testRepo, "untracked.txt", "untracked\n")
+
+	diff, err := repo.DiffStaged()
+	require.NoError(t, err)
+
+	assert.Equal(t, []git.DiffFileStat{
+		{Path: "a.txt", Status: git.DiffStatusModified, Added: 2, Deleted: 1},
+		{Path: "b.txt", Status: git.DiffStatusDeleted, Deleted: 1},
+		{Path: "c.txt", Status: git.DiffStatusAdded, Added: 2},
+	}, diff.Files)
+
+	added, deleted := diff.Totals()
+	assert.Equal(t, 4, added)
+	assert.Equal(t, 2, deleted)
+
+	assert.Contains(t, diff.Patch, "diff --git a/a.txt b/a.txt")
+	assert.Contains(t, diff.Patch, "+four")
+	assert.Contains(t, diff.Patch, "deleted file mode")
+	assert.Contains(t, diff.Patch, "new file mode")
+	assert.NotContains(t, diff.Patch, "untracked.txt")
+}
+
+func TestRepository_DiffStaged_Rename(t *testing.T) {
+	testRepo := testutils.NewGitTestRepo().
+		WithFileString("old.txt", numberedLines(10)).
+		WithCommit("Initial commit").
+		Build(t)
+	repo := testRepo.Repository()
+
+	// 重命名并修改一行，相似度仍高于阈值
+	require.NoError(t, os.Remove(filepath.Join(testRepo.Path(), "old.txt")))
+	writeFile(t, testRepo, "dir/new.txt", strings.Replace(numberedLines(10), "line c\n", "line C\n", 1))
+	require.NoError(t, repo.AddAll())
+
+	diff, err := repo.DiffStaged()
+	require.NoError(t, err)
+
+	require.Len(t, diff.Files, 1)
+	assert.Equal(t, git.DiffFileStat{
+		Path:    "dir/new.txt",
+		OldPath: "old.txt",
+		Status:  git.DiffStatusRenamed,
+		Added:   1,
+		Deleted: 1,
+	}, diff.Files[0])
+	assert.Contains(t, diff.Patch, "rename from old.txt")
+	assert.Contains(t, diff.Patch, "rename to dir/new.txt")
+	assert.Contains(t, diff.Patch, "-line c")
+	assert.Contains(t, diff.Patch, "+line C")
+}
+
+func TestRepository_DiffStaged_DissimilarIsNotRename(t *testing.T) {
+	testRepo := testutils.NewGitTestRepo().
+		WithFileString("old.txt", numberedLines(4)).
+		WithCommit("Initial commit").
+		Build(t)
+	repo := testRepo.Repository()
+
+	require.NoError(t, os.Remove(filepath.Join(testRepo.Path(), "old.txt")))
+	writeFile(t, testRepo, "new.txt", "completely\ndifferent\ncontent\n")
+	require.NoError(t, repo.AddAll())
+
+	diff, err := repo.DiffStaged()
+	require.NoError(t, err)
+
+	stats := statsByPath(diff)
+	assert.Equal(t, git.DiffStatusDeleted, stats["old.txt"].Status)
+	assert.Equal(t, git.DiffStatusAdded, stats["new.txt"].Status)
+}
+
+func TestRepository_DiffStaged_Binary(t *testing.T) {
+	testRepo := testutils.NewGitTestRepo().
+		WithFileString("README.md", "readme\n").
+		WithCommit("Initial commit").
+		Build(t)
+	repo := testRepo.Repository()
+
+	writeFile(t, testRepo, "image.png", "\x89PNG\x00\x01\x02\x03")
+	require.NoError(t, repo.Add("image.png"))
+
+	diff, err := repo.DiffStaged()
+	require.NoError(t, err)
+
+	require.Len(t, diff.Files, 1)
+	assert.Equal(t, git.DiffFileStat{Path: "image.png", Status: git.DiffStatusAdded, Binary: true}, diff.Files[0])
+	assert.Contains(t, diff.Patch, "Binary files /dev/null and b/image.png differ")
+	assert.NotContains(t, diff.Patch, "PNG")
+}
+
+func TestRepository_DiffStaged_Empty(t *testing.T) {
+	testRepo := testutils.NewGitTestRepo().
+		WithFileString("README.md", "readme\n").
+		WithCommit("Initial commit").
+		Build(t)
+
+	writeFile(t, testRepo, "README.md", "changed\n")
+
+	diff, err := testRepo.Repository().DiffStaged()
+	require.NoError(t, err)
+	assert.True(t, diff.IsEmpty())
+	assert.Empty(t, diff.Patch)
+}
+
+// ==================== DiffWorktree 测试 ====================
+
+func TestRepository_DiffWorktree(t *testing.T) {
+	testRepo := testutils.NewGitTestRepo().
+		WithFileString("a.txt", "one\n").
+		WithFileString("b.txt", "two\n").
+		WithCommit("Initial commit").
+		Build(t)
+	repo := testRepo.Repository()
+
+	// 已暂存、未暂存和未跟踪的变更都应出现
+	writeFile(t, testRepo, "a.txt", "one\nstaged\n")
+	require.NoError(t, repo.Add("a.txt"))
+	writeFile(t, testRepo, "b.txt", "two\nunstaged\n")
+	writeFile(t, testRepo, "c.txt", "untracked\n")
+
+	diff, err := repo.DiffWorktree()
+	require.NoError(t, err)
+
+	stats := statsByPath(diff)
+	assert.Equal(t, git.DiffFileStat{Path: "a.txt", Status: git.DiffStatusModified, Added: 1}, stats["a.txt"])
+	assert.Equal(t, git.DiffFileStat{Path: "b.txt", Status: git.DiffStatusModified, Added: 1}, stats["b.txt"])
+	assert.Equal(t, git.DiffFileStat{Path: "c.txt", Status: git.DiffStatusAdded, Added: 1}, stats["c.txt"])
+	assert.Contains(t, diff.Patch, "+unstaged")
+}
+
+func TestRepository_DiffWorktree_UntrackedRename(t *testing.T) {
+	testRepo := testutils.NewGitTestRepo().
+		WithFileString("old.txt", numberedLines(6)).
+		WithCommit("Initial commit").
+		Build(t)
+	repo := testRepo.Repository()
+
+	require.NoError(t, os.Rename(filepath.Join(testRepo.Path(), "old.txt"), filepath.Join(testRepo.Path(), "new.txt")))
+
+	diff, err := repo.DiffWorktree()
+	require.NoError(t, err)
+
+	require.Len(t, diff.Files, 1)
+	assert.Equal(t, git.DiffFileStat{Path: "new.txt", OldPath: "old.txt", Status: git.DiffStatusRenamed}, diff.Files[0])
+	assert.Contains(t, diff.Patch, "rename from old.txt")
+	assert.NotContains(t, diff.Patch, "@@")
+}
+
+// ==================== DiffRange 测试 ====================
+
+func TestRepository_DiffRange_MergeBase(t *testing.T) {
+	testRepo := testutils.NewGitTestRepo().
+		WithFileString("shared.txt", "shared\n").
+		WithCommit("Initial commit").
+		Build(t)
+	repo := testRepo.Repository()
+
+	require.NoError(t, repo.CreateAndCheckoutBranch("feature"))
+	writeFile(t, testRepo, "feature.txt", "feature\n")
+	commitAll(t, repo, "Add feature")
+
+	// main 在分叉后继续前进，这些变更不应出现在 main...feature 中
+	require.NoError(t, repo.CheckoutBranch("main"))
+	writeFile(t, testRepo, "main.txt", "main\n")
+	writeFile(t, testRepo, "shared.txt", "shared\nchanged on main\n")
+	commitAll(t, repo, "Advance main")
+
+	diff, err := repo.DiffRange("main", "feature")
+	require.NoError(t, err)
+
+	assert.Equal(t, []git.DiffFileStat{
+		{Path: "feature.txt", Status: git.DiffStatusAdded, Added: 1},
+	}, diff.Files)
+	assert.Contains(t, diff.Patch, "+feature")
+	assert.NotContains(t, diff.Patch, "main.txt")
+
+	// head 为空时使用 HEAD（当前为 main，相对于 feature 的合并基）
+	diff, err = repo.DiffRange("feature", "")
+	require.NoError(t, err)
+	stats := statsByPath(diff)
+	assert.Contains(t, stats, "main.txt")
+	assert.Contains(t, stats, "shared.txt")
+	assert.NotContains(t, stats, "feature.txt")
+}
+
+func TestRepository_DiffRange_Rename(t *testing.T) {
+	testRepo := testutils.NewGitTestRepo().
+		WithFileString("docs/guide.md", numberedLines(8)).
+		WithCommit("Initial commit").
+		Build(t)
+	repo := testRepo.Repository()
+
+	require.NoError(t, repo.CreateAndCheckoutBranch("feature"))
+	require.NoError(t, os.Remove(filepath.Join(testRepo.Path(), "docs/guide.md")))
+	writeFile(t, testRepo, "docs/usage.md", numberedLines(8))
+	commitAll(t, repo, "Rename guide")
+
+	diff, err := repo.DiffRange("main", "feature")
+	require.NoError(t, err)
+
+	require.Len(t, diff.Files, 1)
+	assert.Equal(t, git.DiffStatusRenamed, diff.Files[0].Status)
+	assert.Equal(t, "docs/guide.md", diff.Files[0].OldPath)
+	assert.Equal(t, "docs/usage.md", diff.Files[0].Path)
+}
+
+func TestRepository_DiffRange_InvalidRevision(t *testing.T) {
+	testRepo := testutils.NewGitTestRepo().
+		WithFileString("README.md", "readme\n").
+		WithCommit("Initial commit").
+		Build(t)
+
+	_, err := testRepo.Repository().DiffRange("missing", "")
+	assert.Error(t, err)
+
+	_, err = testRepo.Repository().DiffRange("main", "missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,146 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==================== WorktreeDiff 测试 ====================
+
+func TestRepository_WorktreeDiff(t *testing.T) {
+	repo, tempDir := setupTestRepoWithCommit(t)
+
+	// 修改已跟踪文件
+	err := os.WriteFile(filepath.Join(tempDir, "test.txt"), []byte("modified content\n"), 0644)
+	require.NoError(t, err)
+
+	// 创建未跟踪文件
+	err = os.WriteFile(filepath.Join(tempDir, "new.txt"), []byte("new file\n"), 0644)
+	require.NoError(t, err)
+
+	diff, err := repo.WorktreeDiff()
+	require.NoError(t, err)
+
+	assert.Contains(t, diff, "diff --git a/test.txt b/test.txt")
+	assert.Contains(t, diff, "-test content")
+	assert.Contains(t, diff, "+modified content")
+	assert.Contains(t, diff, "diff --git a/new.txt b/new.txt")
+	assert.Contains(t, diff, "new file mode")
+	assert.Contains(t, diff, "+new file")
+}
+
+func TestRepository_WorktreeDiff_DeletedFile(t *testing.T) {
+	repo, tempDir := setupTestRepoWithCommit(t)
+
+	err := os.Remove(filepath.Join(tempDir, "test.txt"))
+	require.NoError(t, err)
+
+	diff, err := repo.WorktreeDiff()
+	require.NoError(t, err)
+
+	assert.Contains(t, diff, "deleted file mode")
+	assert.Contains(t, diff, "-test content")
+}
+
+func TestRepository_WorktreeDiff_Clean(t *testing.T) {
+	repo, _ := setupTestRepoWithCommit(t)
+
+	diff, err := repo.WorktreeDiff()
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+}
+
+// ==================== StagedDiff 测试 ====================
+
+func TestRepository_StagedDiff(t *testing.T) {
+	repo, tempDir := setupTestRepoWithCommit(t)
+
+	// 暂存修改后再次修改工作区，只应出现暂存的内容
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "test.txt"), []byte("staged content\n"), 0644))
+	require.NoError(t, repo.Add("test.txt"))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "test.txt"), []byte("unstaged content\n"), 0644))
+
+	// 已暂存的新文件
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "new.txt"), []byte("new file\n"), 0644))
+	require.NoError(t, repo.Add("new.txt"))
+
+	// 未暂存的文件
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "untracked.txt"), []byte("untracked\n"), 0644))
+
+	diff, err := repo.StagedDiff()
+	require.NoError(t, err)
+
+	assert.Contains(t, diff, "-test content")
+	assert.Contains(t, diff, "+staged content")
+	assert.NotContains(t, diff, "unstaged content")
+	assert.Contains(t, diff, "diff --git a/new.txt b/new.txt")
+	assert.Contains(t, diff, "+new file")
+	assert.NotContains(t, diff, "untracked.txt")
+}
+
+func TestRepository_StagedDiff_Nothing(t *testing.T) {
+	repo, tempDir := setupTestRepoWithCommit(t)
+
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "test.txt"), []byte("modified content\n"), 0644))
+
+	diff, err := repo.StagedDiff()
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+}
+
+// ==================== BranchDiff 测试 ====================
+
+func TestRepository_BranchDiff(t *testing.T) {
+	repo, tempDir := setupTestRepoWithCommit(t)
+
+	baseBranch, err := repo.CurrentBranch()
+	require.NoError(t, err)
+
+	err = repo.CreateAndCheckoutBranch("feature")
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(tempDir, "feature.txt"), []byte("feature content\n"), 0644)
+	require.NoError(t, err)
+	require.NoError(t, repo.Add("feature.txt"))
+
+	author := &object.Signature{Name: "Test User", Email: "test@example.com"}
+	_, err = repo.Commit("Add feature", author)
+	require.NoError(t, err)
+
+	diff, err := repo.BranchDiff(baseBranch)
+	require.NoError(t, err)
+
+	assert.Contains(t, diff, "feature.txt")
+	assert.Contains(t, diff, "+feature content")
+	assert.NotContains(t, diff, "test.txt")
+}
+
+func TestRepository_BranchDiff_InvalidBase(t *testing.T) {
+	repo, _ := setupTestRepoWithCommit(t)
+
+	_, err := repo.BranchDiff("nonexistent")
+	assert.Error(t, err)
+}
+
+// ==================== DiffContents 测试 ====================
+
+func TestDiffContents(t *testing.T) {
+	patch, err := DiffContents("prompt.md", "prompt.md", []byte("line1\nline2\n"), []byte("line1\nchanged\n"))
+	require.NoError(t, err)
+
+	assert.Contains(t, patch, "--- a/prompt.md")
+	assert.Contains(t, patch, "+++ b/prompt.md")
+	assert.Contains(t, patch, "-line2")
+	assert.Contains(t, patch, "+changed")
+}
+
+func TestDiffContents_Identical(t *testing.T) {
+	patch, err := DiffContents("prompt.md", "prompt.md", []byte("same\n"), []byte("same\n"))
+	require.NoError(t, err)
+	assert.Empty(t, patch)
+}
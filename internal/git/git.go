@@ -4,9 +4,12 @@
 // - 仓库操作（打开、初始化、检查）
 // - 分支操作（创建、切换、删除、列出）
 // - 提交操作（状态、添加、提交、历史）
+// - 差异操作（暂存区、工作区、base...head，含文件统计、重命名识别和二进制文件占位）
 // - Tag 操作（创建、列出、删除）
 // - 远程操作（添加、删除、获取、推送、拉取）
+// - Stash 操作（列出、保存、应用、删除）
 //
-// 注意：本包只包含 go-git v5 原生支持的核心功能，不包含需要额外实现的高级操作。
+// 注意：本包以 go-git v5 原生支持的核心功能为主。go-git 不支持的 stash
+// 通过 CommandRunner 接口调用 git 可执行文件实现。
 package git
 
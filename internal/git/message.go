@@ -0,0 +1,283 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/zevwings/workflow/internal/util"
+)
+
+// ConventionalCommitTypes 默认允许的 Conventional Commits 提交类型
+var ConventionalCommitTypes = []string{
+	"feat", "fix", "docs", "style", "refactor", "test", "chore", "perf", "ci", "build", "revert",
+}
+
+const (
+	// DefaultCommitHeaderLength 提交消息首行的默认最大长度
+	DefaultCommitHeaderLength = 72
+
+	// defaultScopedCommitTemplate use_scope 为 true 时的默认首行模板
+	defaultScopedCommitTemplate = "{type}({scope}): {subject}"
+	// defaultCommitTemplate use_scope 为 false 时的默认首行模板
+	defaultCommitTemplate = "{type}: {subject}"
+)
+
+var (
+	// commitPlaceholderPattern 提交模板中的占位符，"({scope})" 作为整体处理以便 scope 为空时省略括号
+	commitPlaceholderPattern = regexp.MustCompile(`\(\{scope\}\)|\{(type|scope|subject|jira_ticket)\}`)
+	// commitTicketPattern Jira ticket key
+	commitTicketPattern = `[A-Z][A-Z0-9_]*-[0-9]+`
+	// emptyScopePattern scope 为空时残留的括号
+	emptyScopePattern = regexp.MustCompile(`\(\s*\)`)
+)
+
+// CommitMessage 提交消息的组成部分
+type CommitMessage struct {
+	// Type 提交类型（如 "feat"）
+	Type string
+	// Scope 变更涉及的模块（可选）
+	Scope string
+	// Subject 提交主题
+	Subject string
+	// Body 提交正文（可选）
+	Body string
+	// JiraTicket 关联的 Jira ticket（可选）
+	JiraTicket string
+}
+
+// CommitRules 仓库的提交消息规则
+//
+// 来自 .workflow/config.toml 的 [template.commit] 配置。
+type CommitRules struct {
+	// Template 首行模板，支持 {type}、{scope}、{subject}、{jira_ticket} 占位符
+	Template string
+	// UseScope 是否要求 scope
+	UseScope bool
+	// Types 允许的提交类型
+	Types []string
+	// MaxHeaderLength 首行最大长度
+	MaxHeaderLength int
+}
+
+// CommitRulesFromTemplate 根据 [template.commit] 配置创建提交消息规则
+//
+// 支持的配置项:
+//   - use_scope: 是否要求 scope（默认 false）
+//   - default: 首行模板（默认 "{type}({scope}): {subject}" 或 "{type}: {subject}"）
+//   - types: 允许的提交类型（默认 ConventionalCommitTypes）
+//   - max_header_length: 首行最大长度（默认 72）
+//
+// 参数:
+//   - commitConfig: [template.commit] 配置，可以为 nil
+//
+// 返回:
+//   - CommitRules: 提交消息规则
+func CommitRulesFromTemplate(commitConfig map[string]interface{}) CommitRules {
+	rules := CommitRules{
+		Types:           ConventionalCommitTypes,
+		MaxHeaderLength: DefaultCommitHeaderLength,
+	}
+
+	if useScope, ok := commitConfig["use_scope"].(bool); ok {
+		rules.UseScope = useScope
+	}
+
+	if tmpl, ok := commitConfig["default"].(string); ok && strings.TrimSpace(tmpl) != "" {
+		rules.Template = strings.TrimSpace(tmpl)
+	} else if rules.UseScope {
+		rules.Template = defaultScopedCommitTemplate
+	} else {
+		rules.Template = defaultCommitTemplate
+	}
+
+	if rawTypes, ok := commitConfig["types"].([]interface{}); ok {
+		types := []string{}
+		for _, raw := range rawTypes {
+			if value, ok := raw.(string); ok && strings.TrimSpace(value) != "" {
+				types = append(types, strings.ToLower(strings.TrimSpace(value)))
+			}
+		}
+		if len(types) > 0 {
+			rules.Types = types
+		}
+	}
+
+	switch length := commitConfig["max_header_length"].(type) {
+	case int64:
+		rules.MaxHeaderLength = int(length)
+	case int:
+		rules.MaxHeaderLength = length
+	}
+
+	return rules
+}
+
+// Render 按首行模板渲染提交消息
+//
+// scope 为空时省略 "({scope})" 的括号；模板中没有 {jira_ticket} 时，
+// ticket 以 " (PROJ-123)" 的形式追加到首行末尾。正文与首行之间空一行。
+//
+// 参数:
+//   - message: 提交消息的组成部分
+//
+// 返回:
+//   - string: 完整的提交消息
+func (r CommitRules) Render(message CommitMessage) string {
+	header := util.RenderTemplate(r.Template, map[string]string{
+		"type":        message.Type,
+		"scope":       message.Scope,
+		"subject":     message.Subject,
+		"jira_ticket": message.JiraTicket,
+	})
+	header = emptyScopePattern.ReplaceAllString(header, "")
+	header = strings.Join(strings.Fields(header), " ")
+
+	if message.JiraTicket != "" && !strings.Contains(r.Template, "{jira_ticket}") {
+		header = fmt.Sprintf("%s (%s)", header, message.JiraTicket)
+	}
+
+	body := strings.TrimSpace(message.Body)
+	if body == "" {
+		return header
+	}
+	return header + "\n\n" + body
+}
+
+// Validate 校验提交消息是否符合规则
+//
+// 首行必须匹配首行模板（允许末尾追加的 ticket），type 必须是允许的类型，
+// UseScope 为 true 时必须有 scope，subject 不能以句号结尾，首行不能超过最大长度，
+// 正文与首行之间必须空一行。
+//
+// 参数:
+//   - message: 完整的提交消息
+//
+// 返回:
+//   - error: 不符合规则时返回描述原因的错误
+func (r CommitRules) Validate(message string) error {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return fmt.Errorf("commit message is empty")
+	}
+
+	lines := strings.Split(message, "\n")
+	header := strings.TrimSpace(lines[0])
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		return fmt.Errorf("commit header and body must be separated by a blank line")
+	}
+
+	if length := utf8.RuneCountInString(header); r.MaxHeaderLength > 0 && length > r.MaxHeaderLength {
+		return fmt.Errorf("commit header is %d characters long, the limit is %d", length, r.MaxHeaderLength)
+	}
+
+	pattern, err := commitHeaderPattern(r.Template)
+	if err != nil {
+		return err
+	}
+	match := pattern.FindStringSubmatch(header)
+	if match == nil {
+		return fmt.Errorf("commit header %q does not match the template %q", header, r.Template)
+	}
+	parts := map[string]string{}
+	for i, name := range pattern.SubexpNames() {
+		if name != "" && parts[name] == "" {
+			parts[name] = match[i]
+		}
+	}
+
+	if _, ok := parts["type"]; ok && !containsString(r.Types, parts["type"]) {
+		return fmt.Errorf("commit type %q is not allowed, use one of: %s", parts["type"], strings.Join(r.Types, ", "))
+	}
+	if r.UseScope && parts["scope"] == "" {
+		return fmt.Errorf("commit scope is required")
+	}
+	if subject, ok := parts["subject"]; ok {
+		if strings.TrimSpace(subject) == "" {
+			return fmt.Errorf("commit subject is empty")
+		}
+		if strings.HasSuffix(subject, ".") {
+			return fmt.Errorf("commit subject must not end with a period")
+		}
+	}
+
+	return nil
+}
+
+// commitHeaderPattern 将首行模板转换为正则表达式
+//
+// 模板中的空白匹配任意空白（占位符为空时允许省略），末尾允许追加 " (PROJ-123)"。
+func commitHeaderPattern(tmpl string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString(`^\s*`)
+
+	seen := map[string]bool{}
+	group := func(name, expr string) string {
+		if seen[name] {
+			return "(?:" + expr + ")"
+		}
+		seen[name] = true
+		return "(?P<" + name + ">" + expr + ")"
+	}
+
+	last := 0
+	for _, loc := range commitPlaceholderPattern.FindAllStringSubmatchIndex(tmpl, -1) {
+		b.WriteString(templateLiteralPattern(tmpl[last:loc[0]]))
+		last = loc[1]
+
+		if loc[2] < 0 {
+			// "({scope})"：scope 为空时整体省略
+			b.WriteString(`(?:\(` + group("scope", `[^()\s]+`) + `\))?`)
+			continue
+		}
+		switch tmpl[loc[2]:loc[3]] {
+		case "type":
+			b.WriteString(group("type", `[a-z]+`))
+		case "scope":
+			b.WriteString(group("scope", `[^()\s]*`))
+		case "subject":
+			b.WriteString(group("subject", `.+?`))
+		case "jira_ticket":
+			b.WriteString(group("jira_ticket", commitTicketPattern) + "?")
+		}
+	}
+	b.WriteString(templateLiteralPattern(tmpl[last:]))
+	b.WriteString(`(?:\s+\(` + commitTicketPattern + `\))?\s*$`)
+
+	pattern, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid commit template %q: %w", tmpl, err)
+	}
+	return pattern, nil
+}
+
+// templateLiteralPattern 转义模板中的普通文本，空白匹配任意数量的空白
+func templateLiteralPattern(literal string) string {
+	fields := strings.Fields(literal)
+	for i, field := range fields {
+		fields[i] = regexp.QuoteMeta(field)
+	}
+
+	pattern := strings.Join(fields, `\s*`)
+	if literal != "" && strings.TrimSpace(literal) == "" {
+		return `\s*`
+	}
+	if strings.HasPrefix(literal, " ") {
+		pattern = `\s*` + pattern
+	}
+	if strings.HasSuffix(literal, " ") {
+		pattern += `\s*`
+	}
+	return pattern
+}
+
+// containsString 判断切片中是否包含指定字符串
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==================== CommitRulesFromTemplate 测试 ====================
+
+func TestCommitRulesFromTemplate_Defaults(t *testing.T) {
+	rules := CommitRulesFromTemplate(nil)
+	assert.Equal(t, "{type}: {subject}", rules.Template)
+	assert.False(t, rules.UseScope)
+	assert.Equal(t, ConventionalCommitTypes, rules.Types)
+	assert.Equal(t, DefaultCommitHeaderLength, rules.MaxHeaderLength)
+
+	rules = CommitRulesFromTemplate(map[string]interface{}{"use_scope": true})
+	assert.Equal(t, "{type}({scope}): {subject}", rules.Template)
+	assert.True(t, rules.UseScope)
+}
+
+func TestCommitRulesFromTemplate_Custom(t *testing.T) {
+	// TOML 解析后数组为 []interface{}，整数为 int64
+	rules := CommitRulesFromTemplate(map[string]interface{}{
+		"default":           "{jira_ticket} {type}: {subject}",
+		"types":             []interface{}{"Feat", "fix", ""},
+		"max_header_length": int64(50),
+	})
+	assert.Equal(t, "{jira_ticket} {type}: {subject}", rules.Template)
+	assert.Equal(t, []string{"feat", "fix"}, rules.Types)
+	assert.Equal(t, 50, rules.MaxHeaderLength)
+}
+
+// ==================== Render 测试 ====================
+
+func TestCommitRules_Render(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		message  CommitMessage
+		want     string
+	}{
+		{
+			name:     "scope 和 ticket",
+			template: "{type}({scope}): {subject}",
+			message:  CommitMessage{Type: "feat", Scope: "jira", Subject: "add login", JiraTicket: "PROJ-1"},
+			want:     "feat(jira): add login (PROJ-1)",
+		},
+		{
+			name:     "scope 为空时省略括号",
+			template: "{type}({scope}): {subject}",
+			message:  CommitMessage{Type: "fix", Subject: "handle nil"},
+			want:     "fix: handle nil",
+		},
+		{
+			name:     "模板中包含 ticket",
+			template: "{jira_ticket} {type}: {subject}",
+			message:  CommitMessage{Type: "fix", Subject: "handle nil", JiraTicket: "PROJ-1"},
+			want:     "PROJ-1 fix: handle nil",
+		},
+		{
+			name:     "模板中的 ticket 为空",
+			template: "{jira_ticket} {type}: {subject}",
+			message:  CommitMessage{Type: "fix", Subject: "handle nil"},
+			want:     "fix: handle nil",
+		},
+		{
+			name:     "正文",
+			template: "{type}: {subject}",
+			message:  CommitMessage{Type: "docs", Subject: "update readme", Body: "\nExplain the setup.\n"},
+			want:     "docs: update readme\n\nExplain the setup.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := CommitRules{Template: tt.template}
+			assert.Equal(t, tt.want, rules.Render(tt.message))
+		})
+	}
+}
+
+// ==================== Validate 测试 ====================
+
+func TestCommitRules_Validate(t *testing.T) {
+	scoped := CommitRulesFromTemplate(map[string]interface{}{"use_scope": true})
+	plain := CommitRulesFromTemplate(nil)
+	ticketFirst := CommitRulesFromTemplate(map[string]interface{}{"default": "{jira_ticket} {type}: {subject}"})
+
+	tests := []struct {
+		name    string
+		rules   CommitRules
+		message string
+		wantErr string
+	}{
+		{name: "scope", rules: scoped, message: "feat(jira): add login"},
+		{name: "追加的 ticket", rules: scoped, message: "feat(jira): add login (PROJ-1)"},
+		{name: "正文", rules: plain, message: "fix: handle nil\n\nDetails."},
+		{name: "模板中的 ticket", rules: ticketFirst, message: "PROJ-1 fix: handle nil"},
+		{name: "模板中的 ticket 可省略", rules: ticketFirst, message: "fix: handle nil"},
+		{name: "缺少 scope", rules: scoped, message: "feat: add login", wantErr: "scope is required"},
+		{name: "不允许的 type", rules: plain, message: "feature: add login", wantErr: "not allowed"},
+		{name: "不匹配模板", rules: plain, message: "add login", wantErr: "does not match"},
+		{name: "不允许 scope", rules: plain, message: "feat(jira): add login", wantErr: "does not match"},
+		{name: "句号结尾", rules: plain, message: "fix: handle nil.", wantErr: "period"},
+		{name: "缺少空行", rules: plain, message: "fix: handle nil\nDetails.", wantErr: "blank line"},
+		{name: "空消息", rules: plain, message: "  ", wantErr: "empty"},
+		{
+			name:    "首行过长",
+			rules:   CommitRules{Template: "{type}: {subject}", Types: ConventionalCommitTypes, MaxHeaderLength: 20},
+			message: "fix: handle nil pointer in config",
+			wantErr: "limit is 20",
+		},
+		{
+			name:    "首行长度按字符计算",
+			rules:   CommitRules{Template: "{type}: {subject}", Types: ConventionalCommitTypes, MaxHeaderLength: 20},
+			message: "fix: 修复配置中的空指针问题",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rules.Validate(tt.message)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCommitRules_RenderThenValidate(t *testing.T) {
+	rules := CommitRulesFromTemplate(map[string]interface{}{"use_scope": true})
+
+	message := rules.Render(CommitMessage{
+		Type:       "feat",
+		Scope:      "commit",
+		Subject:    "add commit command",
+		Body:       "Generate the message with the LLM.",
+		JiraTicket: "PROJ-42",
+	})
+	assert.NoError(t, rules.Validate(message))
+}
@@ -86,6 +86,23 @@ func (r *Repository) Fetch(remoteName string, auth transport.AuthMethod) error {
 	return nil
 }
 
+// Pull 从远程拉取指定分支并快进当前分支
+//
+// 当前分支必须是 branchName。只支持快进合并，本地分支包含远程没有的提交时返回错误。
+func (r *Repository) Pull(remoteName string, branchName string, auth transport.AuthMethod) error {
+	err := r.worktree.Pull(&git.PullOptions{
+		RemoteName:    remoteName,
+		ReferenceName: plumbing.NewBranchReferenceName(branchName),
+		SingleBranch:  true,
+		Auth:          auth,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("failed to pull %s from %s: %w", branchName, remoteName, err)
+	}
+
+	return nil
+}
+
 // Push 推送到远程
 func (r *Repository) Push(remoteName string, branchName string, auth transport.AuthMethod) error {
 	remote, err := r.repo.Remote(remoteName)
@@ -105,6 +122,25 @@ func (r *Repository) Push(remoteName string, branchName string, auth transport.A
 	return nil
 }
 
+// DeleteRemoteBranch 删除远程分支
+func (r *Repository) DeleteRemoteBranch(remoteName string, branchName string, auth transport.AuthMethod) error {
+	remote, err := r.repo.Remote(remoteName)
+	if err != nil {
+		return fmt.Errorf("failed to get remote %s: %w", remoteName, err)
+	}
+
+	refSpec := fmt.Sprintf(":refs/heads/%s", branchName)
+	err = remote.Push(&git.PushOptions{
+		RefSpecs: []config.RefSpec{config.RefSpec(refSpec)},
+		Auth:     auth,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("failed to delete branch %s from %s: %w", branchName, remoteName, err)
+	}
+
+	return nil
+}
+
 // PushWithUpstream 推送并设置上游分支
 // 注意：go-git v5 不直接支持设置上游分支，此方法只执行推送
 // 如果需要设置上游，可以使用 git 命令：git branch --set-upstream-to=origin/branch branch
@@ -183,3 +219,20 @@ func NewGitHubTokenAuth(token string) transport.AuthMethod {
 		Password: token,
 	}
 }
+
+// NewGitLabTokenAuth 创建 GitLab token 认证
+//
+// 用于 GitLab 仓库的 HTTPS 认证。
+// GitLab 不接受 "token" 用户名，Personal Access Token 需要使用 "oauth2" 作为用户名。
+//
+// 参数:
+//   - token: GitLab Personal Access Token
+//
+// 返回:
+//   - transport.AuthMethod: 认证方法
+func NewGitLabTokenAuth(token string) transport.AuthMethod {
+	return &http.BasicAuth{
+		Username: "oauth2",
+		Password: token,
+	}
+}
@@ -1,8 +1,12 @@
 package git
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -183,6 +187,50 @@ func TestRepository_Fetch(t *testing.T) {
 	}
 }
 
+// ==================== Pull 测试 ====================
+
+func TestRepository_Pull(t *testing.T) {
+	// 上游仓库
+	upstream, upstreamDir := setupTestRepoWithCommit(t)
+	branch, err := upstream.CurrentBranch()
+	require.NoError(t, err)
+
+	// 从上游克隆本地仓库
+	cloneDir := t.TempDir()
+	_, err = gogit.PlainClone(cloneDir, false, &gogit.CloneOptions{URL: upstreamDir})
+	require.NoError(t, err)
+	local, err := Open(cloneDir)
+	require.NoError(t, err)
+
+	// 上游新增提交
+	require.NoError(t, os.WriteFile(filepath.Join(upstreamDir, "new.txt"), []byte("new"), 0644))
+	require.NoError(t, upstream.Add("new.txt"))
+	upstreamHead, err := upstream.Commit("Add new file", &object.Signature{Name: "Test User", Email: "test@example.com"})
+	require.NoError(t, err)
+
+	// 快进本地分支
+	err = local.Pull("origin", branch, nil)
+	require.NoError(t, err)
+
+	localHead, err := local.GetHead()
+	require.NoError(t, err)
+	assert.Equal(t, upstreamHead, localHead)
+	assert.FileExists(t, filepath.Join(cloneDir, "new.txt"))
+
+	// 已是最新时不返回错误
+	assert.NoError(t, local.Pull("origin", branch, nil))
+}
+
+func TestRepository_Pull_NonExistentRemote(t *testing.T) {
+	repo, _ := setupTestRepoWithCommit(t)
+
+	branch, err := repo.CurrentBranch()
+	require.NoError(t, err)
+
+	err = repo.Pull("origin", branch, nil)
+	assert.Error(t, err)
+}
+
 // ==================== Push 测试 ====================
 
 func TestRepository_Push(t *testing.T) {
@@ -120,3 +120,34 @@ func (r *Repository) Repo() *git.Repository {
 func (r *Repository) Worktree() *git.Worktree {
 	return r.worktree
 }
+
+// GetLocalUser 获取仓库本地配置（.git/config）中的 user.name 和 user.email
+func (r *Repository) GetLocalUser() (name, email string, err error) {
+	config, err := r.repo.Config()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read config: %w", err)
+	}
+	return config.User.Name, config.User.Email, nil
+}
+
+// SetLocalUser 设置仓库本地配置（.git/config）中的 user.name 和 user.email
+//
+// 空值不会覆盖已有配置。
+func (r *Repository) SetLocalUser(name, email string) error {
+	config, err := r.repo.Config()
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	if name != "" {
+		config.User.Name = name
+	}
+	if email != "" {
+		config.User.Email = email
+	}
+
+	if err := r.repo.SetConfig(config); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
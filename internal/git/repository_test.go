@@ -432,3 +432,20 @@ func TestRepository_Worktree(t *testing.T) {
 	assert.NotNil(t, worktree)
 	assert.IsType(t, &git.Worktree{}, worktree)
 }
+
+func TestRepository_SetLocalUser(t *testing.T) {
+	repo, _ := setupTestRepo(t)
+
+	require.NoError(t, repo.SetLocalUser("octocat", "octocat@example.com"))
+	name, email, err := repo.GetLocalUser()
+	require.NoError(t, err)
+	assert.Equal(t, "octocat", name)
+	assert.Equal(t, "octocat@example.com", email)
+
+	// 空值不覆盖已有配置
+	require.NoError(t, repo.SetLocalUser("", "new@example.com"))
+	name, email, err = repo.GetLocalUser()
+	require.NoError(t, err)
+	assert.Equal(t, "octocat", name)
+	assert.Equal(t, "new@example.com", email)
+}
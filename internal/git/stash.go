@@ -0,0 +1,374 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zevwings/workflow/internal/logging"
+)
+
+// ErrNoLocalChanges 没有可保存到 stash 的更改
+var ErrNoLocalChanges = errors.New("no local changes to save")
+
+// CommandRunner 执行 git 命令行的接口
+//
+// go-git 不支持 stash，stash 操作通过调用 git 可执行文件实现。
+// 测试时可以替换为 fake 实现。
+type CommandRunner interface {
+	// Run 在 dir 目录下执行 git 命令，返回标准输出和标准错误
+	Run(dir string, args ...string) (stdout string, stderr string, err error)
+}
+
+// execRunner 调用系统 git 可执行文件的 CommandRunner
+type execRunner struct{}
+
+// Run 在 dir 目录下执行 git 命令
+func (execRunner) Run(dir string, args ...string) (string, string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return stdout.String(), stderr.String(), err
+}
+
+// StashEntry stash 条目
+type StashEntry struct {
+	// Index stash 序号（stash@{Index}）
+	Index int
+	// Ref stash 引用名称（如 "stash@{0}"）
+	Ref string
+	// Branch 创建 stash 时所在的分支
+	Branch string
+	// Message stash 描述
+	Message string
+	// Date 创建时间
+	Date time.Time
+	// Files 每个文件的变更统计（仅在请求 stat 时填充）
+	Files []StashFileStat
+}
+
+// StashFileStat stash 中单个文件的变更统计
+type StashFileStat struct {
+	Path    string
+	Added   int
+	Deleted int
+	// Binary 是否为二进制文件（二进制文件没有行数统计）
+	Binary bool
+	// Untracked 是否为 stash 时未跟踪的文件（使用 --include-untracked 保存）
+	Untracked bool
+}
+
+// StashPushOptions stash push 选项
+type StashPushOptions struct {
+	// Message stash 描述（可选）
+	Message string
+	// IncludeUntracked 是否包含未跟踪的文件
+	IncludeUntracked bool
+	// Pathspecs 只保存匹配的路径（可选）
+	Pathspecs []string
+}
+
+// StashConflictError 应用 stash 时发生冲突
+//
+// 冲突时 git 不会删除 stash，即使执行的是 pop。
+type StashConflictError struct {
+	// Ref 发生冲突的 stash
+	Ref string
+	// Files 存在冲突的文件
+	Files []string
+}
+
+// Error 实现 error 接口
+func (e *StashConflictError) Error() string {
+	if len(e.Files) == 0 {
+		return fmt.Sprintf("conflicts while applying %s", e.Ref)
+	}
+	return fmt.Sprintf("conflicts while applying %s: %s", e.Ref, strings.Join(e.Files, ", "))
+}
+
+// Stash 基于 git 命令行的 stash 操作封装
+type Stash struct {
+	runner CommandRunner
+	dir    string
+}
+
+// NewStash 创建 stash 操作封装
+//
+// 参数:
+//   - dir: 仓库工作目录
+//
+// 返回:
+//   - *Stash: 调用系统 git 可执行文件的 stash 封装
+func NewStash(dir string) *Stash {
+	return NewStashWithRunner(dir, execRunner{})
+}
+
+// NewStashWithRunner 使用指定的命令执行器创建 stash 操作封装
+//
+// 参数:
+//   - dir: 仓库工作目录
+//   - runner: git 命令执行器
+//
+// 返回:
+//   - *Stash: stash 封装
+func NewStashWithRunner(dir string, runner CommandRunner) *Stash {
+	return &Stash{runner: runner, dir: dir}
+}
+
+// Stash 返回当前仓库的 stash 操作封装
+func (r *Repository) Stash() *Stash {
+	return NewStash(r.path)
+}
+
+// List 列出所有 stash，最新的在前
+//
+// 参数:
+//   - withStat: 是否同时获取每个 stash 的文件变更统计
+//
+// 返回:
+//   - []StashEntry: stash 列表
+//   - error: 执行 git 失败时返回错误
+func (s *Stash) List(withStat bool) ([]StashEntry, error) {
+	stdout, err := s.run("stash", "list", "--format=%gd%x00%ct%x00%gs")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stashes: %w", err)
+	}
+
+	entries := []StashEntry{}
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		if line == "" {
+			continue
+		}
+		entry, ok := parseStashLine(line)
+		if !ok {
+			continue
+		}
+		if withStat {
+			files, err := s.Stat(entry.Ref)
+			if err != nil {
+				return nil, err
+			}
+			entry.Files = files
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Stat 获取 stash 的文件变更统计
+//
+// 使用 --include-untracked 保存的未跟踪文件存放在 stash 的第三个父提交中，
+// 一并统计并标记为 Untracked。
+//
+// 参数:
+//   - ref: stash 引用名称（如 "stash@{0}"）
+//
+// 返回:
+//   - []StashFileStat: 文件变更统计
+//   - error: 执行 git 失败时返回错误
+func (s *Stash) Stat(ref string) ([]StashFileStat, error) {
+	stdout, err := s.run("stash", "show", "--numstat", ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to show stash %s: %w", ref, err)
+	}
+	files := parseNumstat(stdout)
+
+	untrackedRef := ref + "^3"
+	if _, err := s.run("rev-parse", "--verify", "--quiet", untrackedRef); err != nil {
+		return files, nil
+	}
+	stdout, err = s.run("show", "--format=", "--numstat", untrackedRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to show untracked files of stash %s: %w", ref, err)
+	}
+	for _, file := range parseNumstat(stdout) {
+		file.Untracked = true
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// Push 保存当前更改到新的 stash
+//
+// 参数:
+//   - opts: push 选项
+//
+// 返回:
+//   - error: 没有可保存的更改时返回 ErrNoLocalChanges
+func (s *Stash) Push(opts StashPushOptions) error {
+	args := []string{"stash", "push"}
+	if opts.Message != "" {
+		args = append(args, "-m", opts.Message)
+	}
+	if opts.IncludeUntracked {
+		args = append(args, "--include-untracked")
+	}
+	if len(opts.Pathspecs) > 0 {
+		args = append(args, "--")
+		args = append(args, opts.Pathspecs...)
+	}
+
+	stdout, err := s.run(args...)
+	if err != nil {
+		return fmt.Errorf("failed to push stash: %w", err)
+	}
+	// git stash push 在没有更改时返回 0，只能通过输出判断
+	if strings.Contains(stdout, "No local changes to save") {
+		return ErrNoLocalChanges
+	}
+	return nil
+}
+
+// Apply 应用 stash 并保留条目
+//
+// 参数:
+//   - ref: stash 引用名称
+//
+// 返回:
+//   - error: 发生冲突时返回 *StashConflictError
+func (s *Stash) Apply(ref string) error {
+	return s.apply("apply", ref)
+}
+
+// Pop 应用 stash 并删除条目
+//
+// 参数:
+//   - ref: stash 引用名称
+//
+// 返回:
+//   - error: 发生冲突时返回 *StashConflictError，此时 stash 不会被删除
+func (s *Stash) Pop(ref string) error {
+	return s.apply("pop", ref)
+}
+
+// Drop 删除 stash
+//
+// 参数:
+//   - ref: stash 引用名称
+//
+// 返回:
+//   - error: 执行 git 失败时返回错误
+func (s *Stash) Drop(ref string) error {
+	if _, err := s.run("stash", "drop", ref); err != nil {
+		return fmt.Errorf("failed to drop stash %s: %w", ref, err)
+	}
+	return nil
+}
+
+// apply 执行 stash apply 或 pop，并识别冲突
+func (s *Stash) apply(action, ref string) error {
+	stdout, stderr, err := s.runner.Run(s.dir, "stash", action, ref)
+	if err == nil {
+		return nil
+	}
+
+	if strings.Contains(stdout, "CONFLICT") || strings.Contains(stderr, "CONFLICT") {
+		return &StashConflictError{Ref: ref, Files: s.conflictedFiles()}
+	}
+	return fmt.Errorf("failed to %s stash %s: %w", action, ref, commandError(err, stderr))
+}
+
+// conflictedFiles 返回存在未解决冲突的文件
+func (s *Stash) conflictedFiles() []string {
+	stdout, err := s.run("diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return nil
+	}
+
+	var files []string
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files
+}
+
+// run 执行 git 命令，失败时把标准错误附加到错误信息中
+func (s *Stash) run(args ...string) (string, error) {
+	logging.GetLogger().WithField("args", args).Debug("Running git")
+
+	stdout, stderr, err := s.runner.Run(s.dir, args...)
+	if err != nil {
+		return stdout, commandError(err, stderr)
+	}
+	return stdout, nil
+}
+
+// commandError 组合命令错误和标准错误输出
+func commandError(err error, stderr string) error {
+	stderr = strings.TrimSpace(stderr)
+	if stderr == "" {
+		return err
+	}
+	return fmt.Errorf("%w: %s", err, stderr)
+}
+
+// parseStashLine 解析 "stash list --format=%gd%x00%ct%x00%gs" 的一行
+//
+// %gs 的格式为 "WIP on <branch>: <hash> <subject>" 或 "On <branch>: <message>"。
+func parseStashLine(line string) (StashEntry, bool) {
+	parts := strings.SplitN(line, "\x00", 3)
+	if len(parts) != 3 {
+		return StashEntry{}, false
+	}
+
+	entry := StashEntry{Ref: parts[0], Message: parts[2]}
+
+	index := strings.TrimSuffix(strings.TrimPrefix(parts[0], "stash@{"), "}")
+	n, err := strconv.Atoi(index)
+	if err != nil {
+		return StashEntry{}, false
+	}
+	entry.Index = n
+
+	if unix, err := strconv.ParseInt(parts[1], 10, 64); err == nil {
+		entry.Date = time.Unix(unix, 0)
+	}
+
+	subject := parts[2]
+	for _, prefix := range []string{"WIP on ", "On "} {
+		if rest, ok := strings.CutPrefix(subject, prefix); ok {
+			if branch, message, ok := strings.Cut(rest, ": "); ok {
+				entry.Branch = branch
+				entry.Message = message
+			}
+			break
+		}
+	}
+
+	return entry, true
+}
+
+// parseNumstat 解析 "--numstat" 输出
+//
+// 每行格式为 "<added>\t<deleted>\t<path>"，二进制文件的行数为 "-"。
+func parseNumstat(output string) []StashFileStat {
+	files := []StashFileStat{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		stat := StashFileStat{Path: parts[2]}
+		if parts[0] == "-" && parts[1] == "-" {
+			stat.Binary = true
+		} else {
+			stat.Added, _ = strconv.Atoi(parts[0])
+			stat.Deleted, _ = strconv.Atoi(parts[1])
+		}
+		files = append(files, stat)
+	}
+	return files
+}
@@ -0,0 +1,233 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRunner 记录调用参数并按命令返回预设输出的 CommandRunner
+type fakeRunner struct {
+	calls   [][]string
+	outputs map[string]fakeOutput
+}
+
+// fakeOutput 单条命令的预设输出
+type fakeOutput struct {
+	stdout string
+	stderr string
+	err    error
+}
+
+func newFakeRunner() *fakeRunner {
+	return &fakeRunner{outputs: map[string]fakeOutput{}}
+}
+
+func (f *fakeRunner) on(command string, output fakeOutput) {
+	f.outputs[command] = output
+}
+
+func (f *fakeRunner) Run(dir string, args ...string) (string, string, error) {
+	f.calls = append(f.calls, args)
+	output := f.outputs[strings.Join(args, " ")]
+	return output.stdout, output.stderr, output.err
+}
+
+// ==================== parseStashLine 测试 ====================
+
+func TestParseStashLine(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		want   StashEntry
+		wantOK bool
+	}{
+		{
+			name:   "wip entry",
+			line:   "stash@{0}\x001700000000\x00WIP on main: abc1234 Add feature",
+			want:   StashEntry{Index: 0, Ref: "stash@{0}", Branch: "main", Message: "abc1234 Add feature", Date: time.Unix(1700000000, 0)},
+			wantOK: true,
+		},
+		{
+			name:   "entry with message",
+			line:   "stash@{3}\x001700000000\x00On feature/PROJ-1: half done",
+			want:   StashEntry{Index: 3, Ref: "stash@{3}", Branch: "feature/PROJ-1", Message: "half done", Date: time.Unix(1700000000, 0)},
+			wantOK: true,
+		},
+		{
+			name:   "unknown subject keeps message",
+			line:   "stash@{1}\x001700000000\x00autostash",
+			want:   StashEntry{Index: 1, Ref: "stash@{1}", Message: "autostash", Date: time.Unix(1700000000, 0)},
+			wantOK: true,
+		},
+		{
+			name:   "malformed line",
+			line:   "stash@{0} WIP on main",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseStashLine(tt.line)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+// ==================== parseNumstat 测试 ====================
+
+func TestParseNumstat(t *testing.T) {
+	output := "3\t1\tmain.go\n-\t-\tlogo.png\n10\t0\tdocs/README.md\n"
+
+	files := parseNumstat(output)
+
+	assert.Equal(t, []StashFileStat{
+		{Path: "main.go", Added: 3, Deleted: 1},
+		{Path: "logo.png", Binary: true},
+		{Path: "docs/README.md", Added: 10},
+	}, files)
+	assert.Empty(t, parseNumstat(""))
+}
+
+// ==================== Stash（fake runner）测试 ====================
+
+func TestStash_List_WithStat(t *testing.T) {
+	runner := newFakeRunner()
+	runner.on("stash list --format=%gd%x00%ct%x00%gs", fakeOutput{
+		stdout: "stash@{0}\x001700000000\x00On main: first\nstash@{1}\x001700000000\x00WIP on dev: abc1234 second\n",
+	})
+	runner.on("stash show --numstat stash@{0}", fakeOutput{stdout: "1\t2\ta.go\n"})
+	runner.on("stash show --numstat stash@{1}", fakeOutput{stdout: "4\t0\tb.go\n"})
+	runner.on("rev-parse --verify --quiet stash@{0}^3", fakeOutput{err: errors.New("exit status 1")})
+	runner.on("show --format= --numstat stash@{1}^3", fakeOutput{stdout: "2\t0\tnew.txt\n"})
+
+	entries, err := NewStashWithRunner("/repo", runner).List(true)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "main", entries[0].Branch)
+	assert.Equal(t, []StashFileStat{{Path: "a.go", Added: 1, Deleted: 2}}, entries[0].Files)
+	assert.Equal(t, "dev", entries[1].Branch)
+	assert.Equal(t, []StashFileStat{{Path: "b.go", Added: 4}, {Path: "new.txt", Added: 2, Untracked: true}}, entries[1].Files)
+}
+
+func TestStash_List_Empty(t *testing.T) {
+	entries, err := NewStashWithRunner("/repo", newFakeRunner()).List(false)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestStash_Push_Args(t *testing.T) {
+	tests := []struct {
+		name string
+		opts StashPushOptions
+		want []string
+	}{
+		{
+			name: "no options",
+			opts: StashPushOptions{},
+			want: []string{"stash", "push"},
+		},
+		{
+			name: "message, untracked and pathspecs",
+			opts: StashPushOptions{Message: "wip", IncludeUntracked: true, Pathspecs: []string{"src/", "-weird"}},
+			want: []string{"stash", "push", "-m", "wip", "--include-untracked", "--", "src/", "-weird"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := newFakeRunner()
+			require.NoError(t, NewStashWithRunner("/repo", runner).Push(tt.opts))
+			require.Len(t, runner.calls, 1)
+			assert.Equal(t, tt.want, runner.calls[0])
+		})
+	}
+}
+
+func TestStash_Push_NoLocalChanges(t *testing.T) {
+	runner := newFakeRunner()
+	runner.on("stash push", fakeOutput{stdout: "No local changes to save\n"})
+
+	err := NewStashWithRunner("/repo", runner).Push(StashPushOptions{})
+	assert.ErrorIs(t, err, ErrNoLocalChanges)
+}
+
+func TestStash_Pop_Conflict(t *testing.T) {
+	runner := newFakeRunner()
+	runner.on("stash pop stash@{0}", fakeOutput{
+		stdout: "Auto-merging a.go\nCONFLICT (content): Merge conflict in a.go\n",
+		err:    errors.New("exit status 1"),
+	})
+	runner.on("diff --name-only --diff-filter=U", fakeOutput{stdout: "a.go\n"})
+
+	err := NewStashWithRunner("/repo", runner).Pop("stash@{0}")
+
+	var conflict *StashConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, "stash@{0}", conflict.Ref)
+	assert.Equal(t, []string{"a.go"}, conflict.Files)
+}
+
+func TestStash_Apply_Error(t *testing.T) {
+	runner := newFakeRunner()
+	runner.on("stash apply stash@{5}", fakeOutput{
+		stderr: "error: stash@{5} is not a valid reference\n",
+		err:    errors.New("exit status 1"),
+	})
+
+	err := NewStashWithRunner("/repo", runner).Apply("stash@{5}")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not a valid reference")
+
+	var conflict *StashConflictError
+	assert.False(t, errors.As(err, &conflict))
+}
+
+// ==================== Stash（git 可执行文件）测试 ====================
+
+func TestRepository_Stash_PushListPop(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git 可执行文件不可用")
+	}
+
+	repo, tempDir := setupTestRepoWithCommit(t)
+	stash := repo.Stash()
+
+	// 没有更改时不创建 stash
+	assert.ErrorIs(t, stash.Push(StashPushOptions{}), ErrNoLocalChanges)
+
+	// 修改已跟踪文件并保存
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "test.txt"), []byte("changed content"), 0644))
+	require.NoError(t, stash.Push(StashPushOptions{Message: "test stash"}))
+
+	entries, err := stash.List(true)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "stash@{0}", entries[0].Ref)
+	assert.Equal(t, "main", entries[0].Branch)
+	assert.Equal(t, "test stash", entries[0].Message)
+	require.Len(t, entries[0].Files, 1)
+	assert.Equal(t, "test.txt", entries[0].Files[0].Path)
+
+	// pop 后恢复更改并删除条目
+	require.NoError(t, stash.Pop("stash@{0}"))
+	content, err := os.ReadFile(filepath.Join(tempDir, "test.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "changed content", string(content))
+
+	entries, err = stash.List(false)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
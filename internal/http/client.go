@@ -401,6 +401,7 @@ func (c *httpClient) PatchWithConfig(url string, config *RequestConfig) (*HttpRe
 // Stream streaming request
 //
 // Sends request and returns response stream, used for handling large files or streaming data.
+// The client timeout does not apply to streams, use RequestConfig.WithContext to cancel them.
 //
 // Parameters:
 //   - method: HTTP method
@@ -409,13 +410,13 @@ func (c *httpClient) PatchWithConfig(url string, config *RequestConfig) (*HttpRe
 //
 // Returns:
 //   - io.ReadCloser: Response stream
-//   - error: Returns error if request fails
+//   - error: Returns error if request fails, or *StatusError if the server responds with an error status
 func (c *httpClient) Stream(method HttpMethod, url string, config *RequestConfig) (io.ReadCloser, error) {
 	if config == nil {
 		config = NewRequestConfig()
 	}
 
-	req := c.client.R()
+	req := c.streamClient().R()
 	req = config.applyToRequest(req)
 	// Set not to automatically parse response to support streaming read
 	req.SetDoNotParseResponse(true)
@@ -442,7 +443,48 @@ func (c *httpClient) Stream(method HttpMethod, url string, config *RequestConfig
 		return nil, err
 	}
 
-	return resp.RawBody(), nil
+	body := resp.RawBody()
+	if resp.IsError() {
+		// Error responses are short, read them so the caller can report the status
+		defer body.Close()
+		data, _ := io.ReadAll(io.LimitReader(body, maxStreamErrorBodySize))
+		return nil, &StatusError{Status: resp.StatusCode(), Body: string(data)}
+	}
+
+	return body, nil
+}
+
+// maxStreamErrorBodySize maximum size of an error response body read by Stream
+const maxStreamErrorBodySize = 64 * 1024
+
+// streamClient returns a copy of the client without the overall timeout
+//
+// http.Client.Timeout also limits reading the response body, which would cut off
+// long-running streams such as LLM completions. Authentication, headers, proxy
+// and retry settings are shared with the base client.
+func (c *httpClient) streamClient() *resty.Client {
+	base := c.client.GetClient()
+	if base.Timeout == 0 {
+		return c.client
+	}
+
+	httpClient := *base
+	httpClient.Timeout = 0
+
+	client := resty.NewWithClient(&httpClient)
+	client.SetLogger(adapterhttp.NewLogrusLogger())
+	client.Token = c.client.Token
+	client.AuthScheme = c.client.AuthScheme
+	client.UserInfo = c.client.UserInfo
+	client.Header = c.client.Header.Clone()
+	client.SetRetryCount(c.client.RetryCount)
+	client.SetRetryWaitTime(c.client.RetryWaitTime)
+	client.SetRetryMaxWaitTime(c.client.RetryMaxWaitTime)
+	client.AddRetryCondition(DefaultRetryCondition)
+	client.SetRetryAfter(DefaultRetryAfter)
+	setupLoggingHooks(client)
+
+	return client
 }
 
 // PostMultipart POST Multipart request
@@ -502,3 +544,13 @@ type ConfigError struct {
 func (e *ConfigError) Error() string {
 	return "config error: " + e.Message
 }
+
+// StatusError error status returned by the server for a streaming request
+type StatusError struct {
+	Status int
+	Body   string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.Status, e.Body)
+}
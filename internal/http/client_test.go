@@ -3,8 +3,11 @@
 package http
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -288,6 +291,81 @@ func TestClient_Stream(t *testing.T) {
 	}
 }
 
+// TestClient_Stream_ErrorStatus 测试错误状态码返回 StatusError
+func TestClient_Stream_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error": "invalid api key"}`)
+	}))
+	defer server.Close()
+
+	client := newClient()
+	stream, err := client.Stream(MethodGet, server.URL, nil)
+
+	require.Error(t, err)
+	assert.Nil(t, stream)
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusUnauthorized, statusErr.Status)
+	assert.Equal(t, `{"error": "invalid api key"}`, statusErr.Body)
+}
+
+// slowStreamServer 先写入 first，等待 delay 后再写入 second
+func slowStreamServer(t *testing.T, first, second string, delay time.Duration) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, first)
+		w.(http.Flusher).Flush()
+		select {
+		case <-time.After(delay):
+			fmt.Fprint(w, second)
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestClient_Stream_IgnoresClientTimeout 测试流式读取不受客户端超时限制
+func TestClient_Stream_IgnoresClientTimeout(t *testing.T) {
+	server := slowStreamServer(t, "first ", "second", 300*time.Millisecond)
+
+	client := newClient()
+	client.client.SetTimeout(100 * time.Millisecond)
+
+	stream, err := client.Stream(MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	defer stream.Close()
+
+	data, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	assert.Equal(t, "first second", string(data))
+}
+
+// TestClient_Stream_ContextCancel 测试取消上下文后流式读取中止
+func TestClient_Stream_ContextCancel(t *testing.T) {
+	server := slowStreamServer(t, "first ", "second", 10*time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newClient()
+	stream, err := client.Stream(MethodGet, server.URL, NewRequestConfig().WithContext(ctx))
+	require.NoError(t, err)
+	defer stream.Close()
+
+	buf := make([]byte, 6)
+	_, err = io.ReadFull(stream, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "first ", string(buf))
+
+	cancel()
+	_, err = io.ReadAll(stream)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 // TestClient_Stream_AllMethods 测试所有 HTTP 方法的流式请求
 func TestClient_Stream_AllMethods(t *testing.T) {
 	methods := []HttpMethod{MethodGet, MethodPost, MethodPut, MethodDelete, MethodPatch}
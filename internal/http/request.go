@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -20,6 +21,8 @@ type baseRequestConfig struct {
 	Timeout time.Duration
 	// Retry 可选的重试配置（如果为 nil，使用 Client 的默认重试配置）
 	Retry *RetryConfig
+	// Context 可选的请求上下文（取消后请求和流式读取会中止）
+	Context context.Context
 }
 
 // RequestConfig HTTP 请求配置
@@ -136,6 +139,18 @@ func (c *RequestConfig) WithRetry(retry *RetryConfig) *RequestConfig {
 	return c
 }
 
+// WithContext 设置请求上下文
+//
+// 参数:
+//   - ctx: 请求上下文，取消后请求（包括流式请求的读取）会中止
+//
+// 返回:
+//   - *RequestConfig: 返回自身，支持链式调用
+func (c *RequestConfig) WithContext(ctx context.Context) *RequestConfig {
+	c.Context = ctx
+	return c
+}
+
 // ensureHeaders 确保 Headers map 已初始化
 func (c *RequestConfig) ensureHeaders() {
 	if c.Headers == nil {
@@ -170,6 +185,11 @@ func (c *RequestConfig) applyToRequest(req *resty.Request) *resty.Request {
 		}
 	}
 
+	// 添加上下文
+	if c.Context != nil {
+		req = req.SetContext(c.Context)
+	}
+
 	// 注意：重试配置需要在 doRequest 中应用，因为需要在 Client 级别设置
 
 	// 注意：resty.Request 不支持单独设置超时，超时在 Client 级别设置
@@ -3,6 +3,7 @@
 package http
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -127,6 +128,21 @@ func TestRequestConfig_WithRetry(t *testing.T) {
 	assert.Equal(t, retry, config.Retry)
 }
 
+// ==================== WithContext 测试 ====================
+
+func TestRequestConfig_WithContext(t *testing.T) {
+	// Arrange: 创建配置
+	config := NewRequestConfig()
+	ctx := context.Background()
+
+	// Act: 设置上下文
+	result := config.WithContext(ctx)
+
+	// Assert: 验证链式调用和上下文设置
+	assert.Equal(t, config, result)
+	assert.Equal(t, ctx, config.Context)
+}
+
 // ==================== ensureHeaders 测试 ====================
 
 func TestRequestConfig_ensureHeaders(t *testing.T) {
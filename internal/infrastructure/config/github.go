@@ -0,0 +1,28 @@
+package config
+
+import (
+	"github.com/zevwings/workflow/internal/config"
+)
+
+// ResolveGitHubAccount selects the GitHub account for the repository in the current directory
+//
+// The account bound to the repository in its private configuration wins,
+// then the first account whose rules match the remote owner, then the
+// current global account. Outside a git repository only the rules and the
+// current account are considered. A binding to an account that was renamed
+// or removed is skipped and reported in the match's StaleBinding.
+//
+// Parameters:
+//   - manager: Global configuration manager
+//   - owner: Owner of the remote repository (empty to skip rule matching)
+//
+// Returns:
+//   - *config.GitHubAccountMatch: Selected account and why it was selected
+//   - error: Returns error if no account is configured
+func ResolveGitHubAccount(manager *config.GlobalManager, owner string) (*config.GitHubAccountMatch, error) {
+	boundName := ""
+	if repoManager, err := NewRepoManagerWithDefaultGit(""); err == nil {
+		boundName = repoManager.GetGitHubAccount()
+	}
+	return manager.GetGitHubConfig().ResolveAccount(boundName, owner)
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+	"github.com/zevwings/workflow/internal/config"
+)
+
+// LoadGlobalConfig loads the global configuration, tolerating a missing config file
+//
+// Without a config file the manager keeps its zero-value configuration, so
+// commands can report what is missing themselves.
+//
+// Returns:
+//   - *config.GlobalManager: Loaded global configuration manager
+//   - error: Returns error if the manager cannot be created or the config file cannot be read
+func LoadGlobalConfig() (*config.GlobalManager, error) {
+	manager, err := config.Global()
+	if err != nil {
+		return nil, fmt.Errorf("初始化全局配置失败: %w", err)
+	}
+
+	if err := manager.Load(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("加载全局配置失败: %w", err)
+		}
+	}
+
+	return manager, nil
+}
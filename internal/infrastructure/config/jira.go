@@ -0,0 +1,34 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/zevwings/workflow/internal/config"
+	"github.com/zevwings/workflow/internal/jira"
+)
+
+// NewJiraClient creates a Jira client from the global configuration
+//
+// Parameters:
+//   - manager: Global configuration manager
+//
+// Returns:
+//   - *jira.JiraClient: Jira client
+//   - error: Returns error if Jira is not configured or the client cannot be created
+func NewJiraClient(manager *config.GlobalManager) (*jira.JiraClient, error) {
+	jiraConfig := manager.JiraConfig
+	if jiraConfig == nil || jiraConfig.ServiceAddress == "" || jiraConfig.Email == "" || jiraConfig.APIToken == "" {
+		return nil, fmt.Errorf("jira 未配置（请先运行 'workflow setup'）")
+	}
+
+	client, err := jira.NewJiraClient(&jira.Config{
+		ServiceAddress: jiraConfig.ServiceAddress,
+		Email:          jiraConfig.Email,
+		APIToken:       jiraConfig.APIToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("创建 Jira 客户端失败: %w", err)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/zevwings/workflow/internal/config"
+	"github.com/zevwings/workflow/internal/git"
+	"github.com/zevwings/workflow/internal/logging"
+	"github.com/zevwings/workflow/internal/pr/provider"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+// PlatformToken returns the API token configured for the detected platform
+//
+// For GitHub, the account is selected per repository (see ResolveGitHubAccount).
+// A binding to an account that no longer exists is reported as a warning.
+//
+// Parameters:
+//   - manager: Global configuration manager
+//   - info: Platform detected from the git remote
+//
+// Returns:
+//   - string: API token
+//   - error: Returns error if no token is configured for the platform
+func PlatformToken(manager *config.GlobalManager, info *provider.PlatformInfo) (string, error) {
+	if info.Platform == provider.PlatformGitLab {
+		if manager.GitLabConfig == nil || manager.GitLabConfig.APIToken == "" {
+			return "", fmt.Errorf("GitLab 未配置 API Token（请在配置文件的 [gitlab] 中设置 api_token）")
+		}
+		return manager.GitLabConfig.APIToken, nil
+	}
+
+	match, err := ResolveGitHubAccount(manager, info.Owner)
+	if err != nil {
+		return "", fmt.Errorf("获取 GitHub 账号失败（请先运行 'workflow setup'）: %w", err)
+	}
+	account := match.Account
+	if match.StaleBinding != "" {
+		prompt.GetMessage().Warning("GitHub account %s bound to this repository no longer exists, using %s. Run 'workflow repo setup' to rebind.",
+			match.StaleBinding, account.Name)
+	}
+	if account.APIToken == "" {
+		return "", fmt.Errorf("GitHub 账号 %s 未配置 API Token", account.Name)
+	}
+
+	logging.GetLogger().WithFields(logging.Fields{
+		"account": account.Name,
+		"source":  match.Source,
+	}).Debug("Selected GitHub account")
+	return account.APIToken, nil
+}
+
+// RemoteAuth returns the auth method for pushing to or fetching from a remote
+//
+// HTTPS remotes use the API token with the username the platform expects
+// ("oauth2" for GitLab, "token" for GitHub); SSH remotes return nil so that
+// go-git falls back to the SSH agent.
+//
+// Parameters:
+//   - gitRepo: Git repository
+//   - remote: Remote name
+//   - platformName: Platform of the remote (provider.PlatformGitHub or provider.PlatformGitLab)
+//   - token: API token of the platform (empty returns nil)
+//
+// Returns:
+//   - transport.AuthMethod: Auth method, nil for SSH remotes
+func RemoteAuth(gitRepo *git.Repository, remote, platformName, token string) transport.AuthMethod {
+	if token == "" {
+		return nil
+	}
+	remoteURL, err := gitRepo.GetRemoteURL(remote)
+	if err != nil {
+		return nil
+	}
+	if !strings.HasPrefix(remoteURL, "http://") && !strings.HasPrefix(remoteURL, "https://") {
+		return nil
+	}
+	if platformName == provider.PlatformGitLab {
+		return git.NewGitLabTokenAuth(token)
+	}
+	return git.NewGitHubTokenAuth(token)
+}
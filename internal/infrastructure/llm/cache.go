@@ -0,0 +1,62 @@
+package llm
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/zevwings/workflow/internal/config"
+	"github.com/zevwings/workflow/internal/llm"
+	"github.com/zevwings/workflow/internal/logging"
+)
+
+// cacheDirName name of the response cache directory inside the cache directory
+const cacheDirName = "llm"
+
+var (
+	configureCacheOnce sync.Once
+	// cacheDisabled set by DisableCache (e.g. --no-cache)
+	cacheDisabled bool
+)
+
+// DisableCache turns the response cache off for this process
+//
+// Used by --no-cache. Must be called before the first LLM client is created.
+func DisableCache() {
+	cacheDisabled = true
+}
+
+// ConfigureCache sets up the response cache from the [llm.cache] configuration
+//
+// Runs once per process. The cache is skipped when it is disabled in the
+// configuration or by DisableCache, or when the cache directory cannot be determined.
+func ConfigureCache() {
+	configureCacheOnce.Do(func() {
+		llmConfig := getLLMConfig()
+		if cacheDisabled || llmConfig.Cache.Disabled {
+			return
+		}
+
+		responses, err := NewResponseCache()
+		if err != nil {
+			logging.GetLogger().WithError(err).Debug("Failed to create LLM response cache")
+			return
+		}
+		llm.SetResponseCache(responses)
+	})
+}
+
+// NewResponseCache creates the response cache from the [llm.cache] configuration
+//
+// Returns:
+//   - *llm.ResponseCache: Response cache in <cache dir>/llm
+//   - error: Returns error if the cache directory cannot be determined
+func NewResponseCache() (*llm.ResponseCache, error) {
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cache directory: %w", err)
+	}
+
+	llmConfig := getLLMConfig()
+	return llm.NewResponseCache(filepath.Join(cacheDir, cacheDirName), llmConfig.CacheTTL(), llmConfig.CacheMaxSize()), nil
+}
@@ -11,7 +11,7 @@ import (
 // Type Definitions
 // ============================================================================
 
-// llmConfigProvider implements the llm.LLMConfigProvider interface
+// llmConfigProvider implements the llm.LLMConfigProvider and llm.LLMEndpointsProvider interfaces
 //
 // Wraps config.LLMConfig to provide interface implementation.
 // Encapsulates LLM configuration conversion logic into the infrastructure layer to avoid other packages directly depending on config package's concrete types.
@@ -25,11 +25,11 @@ type llmConfigProvider struct {
 
 // GetProviderConfig gets provider configuration
 //
-// Gets the current provider's configuration information (APIKey, Model, URL) from config.LLMConfig,
+// Gets the current provider's configuration information (Provider, APIKey, Model, URL) from config.LLMConfig,
 // and converts it to llm.ProviderConfig format for return.
 //
 // Returns:
-//   - *llm.ProviderConfig: LLM provider configuration (APIKey, Model, URL)
+//   - *llm.ProviderConfig: LLM provider configuration (Provider, APIKey, Model, URL)
 //   - error: Returns error if configuration is invalid or retrieval fails
 func (p *llmConfigProvider) GetProviderConfig() (*llm.ProviderConfig, error) {
 	apiKey, model, url, err := p.llmConfig.CurrentProvider()
@@ -38,12 +38,42 @@ func (p *llmConfigProvider) GetProviderConfig() (*llm.ProviderConfig, error) {
 	}
 
 	return &llm.ProviderConfig{
-		APIKey: apiKey,
-		Model:  model,
-		URL:    url,
+		Provider: p.llmConfig.Provider,
+		APIKey:   apiKey,
+		Model:    model,
+		URL:      url,
 	}, nil
 }
 
+// GetProviderConfigs gets the providers that serve requests of a feature, in fallback order
+//
+// Implements llm.LLMEndpointsProvider from the [[llm.routes]] and fallbacks configuration,
+// see config.LLMConfig.Endpoints.
+//
+// Parameters:
+//   - feature: Feature name (empty for requests without a feature)
+//
+// Returns:
+//   - []*llm.ProviderConfig: LLM provider configurations, at least one
+//   - error: Returns error if any of the providers is invalid
+func (p *llmConfigProvider) GetProviderConfigs(feature string) ([]*llm.ProviderConfig, error) {
+	endpoints, err := p.llmConfig.Endpoints(feature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LLM provider configuration: %w", err)
+	}
+
+	configs := make([]*llm.ProviderConfig, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		configs = append(configs, &llm.ProviderConfig{
+			Provider: endpoint.Provider,
+			APIKey:   endpoint.APIKey,
+			Model:    endpoint.Model,
+			URL:      endpoint.URL,
+		})
+	}
+	return configs, nil
+}
+
 // GetLanguage gets language configuration
 //
 // Gets the current language configuration information from config.LLMConfig,
@@ -59,18 +89,23 @@ func (p *llmConfigProvider) GetLanguage() (*llm.SupportedLanguage, error) {
 		return nil, fmt.Errorf("failed to get language configuration: %w", err)
 	}
 
+	return toLLMLanguage(lang), nil
+}
+
+// ============================================================================
+// Private Helper Methods
+// ============================================================================
+
+// toLLMLanguage converts config.SupportedLanguage to llm.SupportedLanguage
+func toLLMLanguage(lang *config.SupportedLanguage) *llm.SupportedLanguage {
 	return &llm.SupportedLanguage{
 		Code:                lang.Code,
 		Name:                lang.Name,
 		NativeName:          lang.NativeName,
 		InstructionTemplate: lang.InstructionTemplate,
-	}, nil
+	}
 }
 
-// ============================================================================
-// Private Helper Methods
-// ============================================================================
-
 // getLLMConfig gets LLM configuration from global config manager
 //
 // Internal helper method to get global config manager and return LLM configuration.
@@ -118,6 +153,24 @@ func NewLLMConfigProvider() llm.LLMConfigProvider {
 	return &llmConfigProvider{llmConfig: llmConfig}
 }
 
+// LanguageFromCode gets the LLM language configuration for a language code
+//
+// Used by commands that let the user override the configured language (e.g. --language).
+//
+// Parameters:
+//   - code: Language code (e.g. "en", "zh-CN")
+//
+// Returns:
+//   - *llm.SupportedLanguage: Language configuration information
+//   - error: Returns error if the language code is not supported
+func LanguageFromCode(code string) (*llm.SupportedLanguage, error) {
+	lang := config.FindLanguage(code)
+	if lang == nil {
+		return nil, fmt.Errorf("unsupported language code: %s", code)
+	}
+	return toLLMLanguage(lang), nil
+}
+
 // NewBranchLLMClient creates branch LLM client
 //
 // Creates and returns branch LLM client instance from global configuration.
@@ -128,12 +181,17 @@ func NewLLMConfigProvider() llm.LLMConfigProvider {
 //
 // Note:
 //   - Function will panic if configuration is invalid or creation fails
+//   - Prompt template lookup, the response cache and usage recording are configured on first use,
+//     see ConfigurePrompts, ConfigureCache and ConfigureUsage
 //
 // Usage example:
 //
 //	branchClient := infrastructurellm.NewBranchLLMClient()
 //	translated, err := branchClient.TranslateToEnglish("Hello")
 func NewBranchLLMClient() *llm.BranchLLMClient {
+	ConfigurePrompts()
+	ConfigureCache()
+	ConfigureUsage()
 	provider := NewLLMConfigProvider()
 	return llm.NewBranchLLMClient(provider)
 }
@@ -148,6 +206,8 @@ func NewBranchLLMClient() *llm.BranchLLMClient {
 //
 // Note:
 //   - Function will panic if configuration is invalid or creation fails
+//   - Prompt template lookup, the response cache and usage recording are configured on first use,
+//     see ConfigurePrompts, ConfigureCache and ConfigureUsage
 //
 // Usage example:
 //
@@ -155,6 +215,33 @@ func NewBranchLLMClient() *llm.BranchLLMClient {
 //	content, err := prClient.GenerateContent("fix: bug", nil, "")
 //	summary, err := prClient.Summarize("PR Title", "PR Diff")
 func NewPullRequestLLMClient() *llm.PullRequestLLMClient {
+	ConfigurePrompts()
+	ConfigureCache()
+	ConfigureUsage()
 	provider := NewLLMConfigProvider()
 	return llm.NewPullRequestLLMClient(provider)
 }
+
+// NewCommitLLMClient creates commit LLM client
+//
+// Creates and returns commit LLM client instance from global configuration.
+//
+// Returns:
+//   - *llm.CommitLLMClient: Commit LLM client instance
+//
+// Note:
+//   - Function will panic if configuration is invalid or creation fails
+//   - Prompt template lookup, the response cache and usage recording are configured on first use,
+//     see ConfigurePrompts, ConfigureCache and ConfigureUsage
+//
+// Usage example:
+//
+//	commitClient := infrastructurellm.NewCommitLLMClient()
+//	content, err := commitClient.Generate(stagedDiff, "", nil)
+func NewCommitLLMClient() *llm.CommitLLMClient {
+	ConfigurePrompts()
+	ConfigureCache()
+	ConfigureUsage()
+	provider := NewLLMConfigProvider()
+	return llm.NewCommitLLMClient(provider)
+}
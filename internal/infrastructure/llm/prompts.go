@@ -0,0 +1,85 @@
+package llm
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/zevwings/workflow/internal/config"
+	"github.com/zevwings/workflow/internal/git"
+	"github.com/zevwings/workflow/internal/jira"
+	"github.com/zevwings/workflow/internal/llm"
+	"github.com/zevwings/workflow/internal/logging"
+)
+
+// userPromptsDirName name of the prompt override directory inside the config directory
+const userPromptsDirName = "prompts"
+
+var configurePromptsOnce sync.Once
+
+// ConfigurePrompts sets up prompt template lookup for the current directory
+//
+// Templates are looked up in <repository>/.workflow/prompts/, then <config dir>/prompts/,
+// then the embedded defaults. The repository name, current branch and the Jira ticket
+// found in the branch name become the default template variables.
+// Runs once per process; outside a Git repository only the user directory is used.
+func ConfigurePrompts() {
+	configurePromptsOnce.Do(configurePrompts)
+}
+
+// UserPromptsDir gets the user prompt override directory
+//
+// Returns:
+//   - string: <config dir>/prompts
+//   - error: Returns error if the config directory cannot be determined
+func UserPromptsDir() (string, error) {
+	configDir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, userPromptsDirName), nil
+}
+
+// RepoPromptsDir gets the repository prompt override directory of a repository
+//
+// Parameters:
+//   - gitRepo: Git repository
+//
+// Returns:
+//   - string: <repository>/.workflow/prompts
+func RepoPromptsDir(gitRepo *git.Repository) string {
+	return filepath.Join(gitRepo.Path(), filepath.FromSlash(llm.RepoPromptsDir))
+}
+
+// configurePrompts resolves the override directories and default variables
+func configurePrompts() {
+	logger := logging.GetLogger()
+
+	userDir, err := UserPromptsDir()
+	if err != nil {
+		logger.WithError(err).Debug("Failed to get user prompts directory")
+		userDir = ""
+	}
+
+	var repoDir string
+	var vars llm.PromptVars
+	if gitRepo, err := git.OpenCurrent(); err == nil {
+		repoDir = RepoPromptsDir(gitRepo)
+		vars.Repo = repoName(gitRepo)
+		if branch, err := gitRepo.CurrentBranch(); err == nil {
+			vars.Branch = branch
+			vars.Ticket = jira.ExtractTicketKey(branch)
+		}
+	}
+
+	llm.ConfigurePrompts(repoDir, userDir, vars)
+}
+
+// repoName gets the repository name from the origin remote, falling back to the directory name
+func repoName(gitRepo *git.Repository) string {
+	if url, err := gitRepo.GetRemoteURL("origin"); err == nil {
+		if name, err := git.ExtractRepoName(url); err == nil {
+			return name
+		}
+	}
+	return filepath.Base(gitRepo.Path())
+}
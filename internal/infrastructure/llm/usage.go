@@ -0,0 +1,86 @@
+package llm
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/zevwings/workflow/internal/config"
+	"github.com/zevwings/workflow/internal/git"
+	"github.com/zevwings/workflow/internal/llm"
+	"github.com/zevwings/workflow/internal/logging"
+	"github.com/zevwings/workflow/internal/prompt"
+)
+
+const (
+	// usageDirName name of the usage directory inside the data directory
+	usageDirName = "llm"
+	// usageFileName name of the usage file
+	usageFileName = "usage.jsonl"
+)
+
+var configureUsageOnce sync.Once
+
+// ConfigureUsage sets up usage recording from the [llm.usage] and [[llm.prices]] configuration
+//
+// Runs once per process. Every API call is appended to <data dir>/llm/usage.jsonl with
+// the current repository. When [llm.usage] monthly_budget is reached, a warning is shown
+// before the next call. Recording is skipped when it is disabled in the configuration,
+// or when the data directory cannot be determined.
+func ConfigureUsage() {
+	configureUsageOnce.Do(func() {
+		llmConfig := getLLMConfig()
+		if llmConfig.Usage.Disabled {
+			return
+		}
+
+		store, err := NewUsageStore()
+		if err != nil {
+			logging.GetLogger().WithError(err).Debug("Failed to create LLM usage store")
+			return
+		}
+
+		// The providers of fallback chains and routes share the budget state of the store
+		opts := llm.UsageOptions{
+			Pricer:        NewUsagePricer(llmConfig),
+			MonthlyBudget: llmConfig.Usage.MonthlyBudget,
+			Warn: func(spent, budget float64) {
+				prompt.GetMessage().Warning("LLM usage this month is %s, over the monthly budget of %s", llm.FormatUsageCost(spent), llm.FormatUsageCost(budget))
+			},
+		}
+		if gitRepo, err := git.OpenCurrent(); err == nil {
+			opts.Repo = repoName(gitRepo)
+		}
+		llm.SetUsageStore(store, opts)
+	})
+}
+
+// NewUsageStore creates the usage store
+//
+// Returns:
+//   - *llm.UsageStore: Usage file <data dir>/llm/usage.jsonl
+//   - error: Returns error if the data directory cannot be determined
+func NewUsageStore() (*llm.UsageStore, error) {
+	dataDir, err := config.DataDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data directory: %w", err)
+	}
+	return llm.NewUsageStore(filepath.Join(dataDir, usageDirName, usageFileName)), nil
+}
+
+// NewUsagePricer creates a price lookup from [[llm.prices]] and the default prices
+//
+// Parameters:
+//   - llmConfig: LLM configuration
+//
+// Returns:
+//   - llm.UsagePricer: Price lookup, see config.LLMConfig.Price
+func NewUsagePricer(llmConfig *config.LLMConfig) llm.UsagePricer {
+	return func(provider, model string) (llm.UsagePrice, bool) {
+		price, ok := llmConfig.Price(provider, model)
+		if !ok {
+			return llm.UsagePrice{}, false
+		}
+		return llm.UsagePrice{Input: price.Input, Output: price.Output}, true
+	}
+}
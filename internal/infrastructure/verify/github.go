@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	"github.com/zevwings/workflow/internal/config"
+	infrastructureconfig "github.com/zevwings/workflow/internal/infrastructure/config"
 	"github.com/zevwings/workflow/internal/pr/github"
+	"github.com/zevwings/workflow/internal/pr/provider"
 	"github.com/zevwings/workflow/internal/prompt"
 	"github.com/zevwings/workflow/internal/util"
 )
@@ -90,3 +92,39 @@ func VerifyGitHubConfig(githubConfig *config.GitHubConfig) bool {
 
 	return allValid
 }
+
+// VerifyRepoGitHubAccount reports which GitHub account applies to the current repository and why
+// Skipped when there are no accounts or the current directory has no GitHub remote
+func VerifyRepoGitHubAccount(manager *config.GlobalManager) bool {
+	if len(manager.GetGitHubConfig().Accounts) == 0 {
+		return true
+	}
+
+	info, err := provider.AutoDetect(provider.WithHostMapping(manager.GetPlatformConfig().Hosts))
+	if err != nil || info.Platform != provider.PlatformGitHub {
+		return true
+	}
+
+	msg := prompt.GetMessage()
+	msg.Info("Repository GitHub Account")
+	table := prompt.NewTable([]string{"Repository", "Account", "Reason"})
+
+	repository := fmt.Sprintf("%s/%s", info.Owner, info.Repo)
+	match, err := infrastructureconfig.ResolveGitHubAccount(manager, info.Owner)
+	if err != nil {
+		table.AddRow([]string{repository, "", err.Error()})
+		table.Render()
+		msg.Warning("No GitHub account applies to this repository. Please check the configuration.")
+		msg.Break()
+		return false
+	}
+
+	table.AddRow([]string{repository, match.Account.Name, match.Reason()})
+	table.Render()
+	if match.StaleBinding != "" {
+		msg.Warning("The bound account %s no longer exists. Run 'workflow repo setup' to rebind this repository.", match.StaleBinding)
+	}
+	msg.Break()
+
+	return true
+}
@@ -52,6 +52,16 @@ func VerifyLLMConfig(llmConfig *config.LLMConfig) {
 		// For proxy type, don't display URL in table to avoid table being too wide
 		// URL information can be displayed in detailed output
 		key = util.MaskSensitiveValue(llmConfig.Proxy.APIKey)
+	case "anthropic":
+		model = llmConfig.Anthropic.Model
+		if model == "" {
+			model = "claude-3-5-haiku-latest"
+		}
+		key = util.MaskSensitiveValue(llmConfig.Anthropic.APIKey)
+	case "ollama":
+		model = llmConfig.Ollama.Model
+		// Ollama runs locally without an API key
+		key = "-"
 	default:
 		model = "-"
 		key = "-"
@@ -59,12 +69,15 @@ func VerifyLLMConfig(llmConfig *config.LLMConfig) {
 
 	// 1. Configuration completeness verification
 	apiKey, _, _, err := llmConfig.CurrentProvider()
+	if err == nil {
+		err = verifyLLMEndpoints(llmConfig)
+	}
 	var status string
 	var systemPrompt, userPrompt, testResponse string
 
 	if err != nil {
 		status = fmt.Sprintf("✗ Configuration error: %v", err)
-	} else if apiKey == "" {
+	} else if apiKey == "" && llmConfig.Provider != "ollama" {
 		status = "✗ API Key not configured"
 	} else {
 		// 2. Create LLM client and send test request
@@ -115,6 +128,8 @@ func VerifyLLMConfig(llmConfig *config.LLMConfig) {
 				status = "✗ API Key invalid or not configured"
 			} else if strings.Contains(errorMsg, "timeout") {
 				status = "✗ Connection timeout"
+			} else if llmConfig.Provider == "ollama" && strings.Contains(errorMsg, "connection refused") {
+				status = "✗ Ollama is not running (start it with 'ollama serve')"
 			} else if strings.Contains(errorMsg, "network") {
 				status = "✗ Network connection failed"
 			} else {
@@ -131,6 +146,12 @@ func VerifyLLMConfig(llmConfig *config.LLMConfig) {
 	table.AddRow([]string{"Model", model})
 	table.AddRow([]string{"Key", key})
 	table.AddRow([]string{"Output Language", language})
+	if len(llmConfig.Fallbacks) > 0 {
+		table.AddRow([]string{"Fallbacks", strings.Join(llmConfig.Fallbacks, ", ")})
+	}
+	for _, route := range llmConfig.Routes {
+		table.AddRow([]string{"Route: " + route.Feature, formatRoute(llmConfig, route.Feature)})
+	}
 	table.AddRow([]string{"Status", status})
 	table.Render()
 
@@ -144,3 +165,29 @@ func VerifyLLMConfig(llmConfig *config.LLMConfig) {
 
 	msg.Break()
 }
+
+// formatRoute formats the provider and model used for a feature, e.g. "openai / gpt-4o-mini"
+func formatRoute(llmConfig *config.LLMConfig, feature string) string {
+	provider, model := llmConfig.Route(feature)
+	if model == "" {
+		if _, providerModel, _, err := llmConfig.ProviderSettings(provider); err == nil {
+			model = providerModel
+		} else {
+			model = "-"
+		}
+	}
+	return provider + " / " + model
+}
+
+// verifyLLMEndpoints checks that the fallback providers and the providers of the routes are configured
+func verifyLLMEndpoints(llmConfig *config.LLMConfig) error {
+	if _, err := llmConfig.Endpoints(""); err != nil {
+		return err
+	}
+	for _, route := range llmConfig.Routes {
+		if _, err := llmConfig.Endpoints(route.Feature); err != nil {
+			return fmt.Errorf("route of %s: %w", route.Feature, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,390 @@
+package jira
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+	"github.com/zevwings/workflow/internal/config"
+)
+
+// ManifestFile 下载目录中的清单文件名
+const ManifestFile = "manifest.json"
+
+// DefaultDownloadWorkers 默认的并发下载数
+const DefaultDownloadWorkers = 4
+
+// partSuffix 下载中的临时文件后缀，下载完成后重命名为正式文件名
+const partSuffix = ".part"
+
+// 下载结果状态
+const (
+	DownloadStatusDownloaded = "downloaded"
+	DownloadStatusSkipped    = "skipped"
+	DownloadStatusFailed     = "failed"
+)
+
+// AttachmentDownloader 附件下载接口
+//
+// JiraClient 实现了此接口，测试中可以替换为假实现。
+type AttachmentDownloader interface {
+	DownloadAttachment(attachment *cloud.Attachment) (io.ReadCloser, error)
+}
+
+// Manifest 下载目录中的附件清单
+type Manifest struct {
+	Ticket       string          `json:"ticket"`
+	DownloadedAt time.Time       `json:"downloadedAt"`
+	Attachments  []ManifestEntry `json:"attachments"`
+}
+
+// ManifestEntry 清单中的单个附件
+type ManifestEntry struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+	SHA256   string `json:"sha256"`
+}
+
+// DownloadOptions 附件下载选项
+type DownloadOptions struct {
+	Workers  int                  // 并发下载数（<= 0 时使用 DefaultDownloadWorkers）
+	Progress func(DownloadResult) // 每个附件处理完成后调用（串行调用，可为 nil）
+}
+
+// DownloadResult 单个附件的下载结果
+type DownloadResult struct {
+	Attachment *cloud.Attachment
+	Path       string // 本地文件路径
+	Status     string // downloaded、skipped 或 failed
+	Size       int64
+	SHA256     string
+	Err        error
+}
+
+// DownloadDirInfo 附件下载目录信息
+type DownloadDirInfo struct {
+	Ticket  string
+	Path    string
+	Files   int
+	Size    int64
+	ModTime time.Time
+}
+
+// AttachmentsRoot 获取附件下载根目录
+//
+// 返回:
+//   - string: 下载根目录路径（如 ~/.local/share/Workflow/jira）
+//   - error: 如果获取失败，返回错误
+func AttachmentsRoot() (string, error) {
+	dataDir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "jira"), nil
+}
+
+// AttachmentsDir 获取 ticket 的附件下载目录
+//
+// 参数:
+//   - ticket: Ticket Key（如 "PROJ-123"）
+//
+// 返回:
+//   - string: 下载目录路径（如 ~/.local/share/Workflow/jira/PROJ-123）
+//   - error: 如果获取失败，返回错误
+func AttachmentsDir(ticket string) (string, error) {
+	root, err := AttachmentsRoot()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, NormalizeTicketKey(ticket)), nil
+}
+
+// FilterAttachments 按文件名通配符过滤附件
+//
+// 匹配不区分大小写，通配符语法与 path.Match 相同（如 "*.png"）。
+//
+// 参数:
+//   - attachments: 附件列表
+//   - pattern: 文件名通配符（为空时不过滤）
+//
+// 返回:
+//   - []*cloud.Attachment: 匹配的附件列表
+//   - error: 如果通配符无效，返回错误
+func FilterAttachments(attachments []*cloud.Attachment, pattern string) ([]*cloud.Attachment, error) {
+	if pattern == "" {
+		return attachments, nil
+	}
+
+	pattern = strings.ToLower(pattern)
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("无效的通配符 %q: %w", pattern, err)
+	}
+
+	result := make([]*cloud.Attachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		if matched, _ := path.Match(pattern, strings.ToLower(attachment.Filename)); matched {
+			result = append(result, attachment)
+		}
+	}
+	return result, nil
+}
+
+// DownloadAttachments 并发下载附件到指定目录
+//
+// 目录中已存在且大小相同的文件会被跳过。附件先写入临时文件，
+// 下载完成后再重命名，因此中断的下载不会被误认为已完成。
+// 同名附件会在文件名后追加附件 ID 以避免覆盖。
+// 单个附件失败不会中断其他附件的下载，失败信息记录在结果的 Err 中。
+//
+// 参数:
+//   - downloader: 附件下载接口
+//   - attachments: 待下载的附件列表
+//   - dir: 下载目录（不存在时自动创建）
+//   - opts: 下载选项
+//
+// 返回:
+//   - []DownloadResult: 下载结果（与 attachments 顺序一致）
+//   - error: 如果下载目录无法创建，返回错误
+func DownloadAttachments(downloader AttachmentDownloader, attachments []*cloud.Attachment, dir string, opts DownloadOptions) ([]DownloadResult, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("创建下载目录失败: %w", err)
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultDownloadWorkers
+	}
+
+	filenames := localFilenames(attachments)
+	results := make([]DownloadResult, len(attachments))
+	jobs := make(chan int)
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result := downloadAttachment(downloader, attachments[i], filepath.Join(dir, filenames[i]))
+				results[i] = result
+				if opts.Progress != nil {
+					mu.Lock()
+					opts.Progress(result)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := range attachments {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, nil
+}
+
+// WriteManifest 将下载结果写入目录中的 manifest.json
+//
+// 失败的附件不会写入清单。
+//
+// 参数:
+//   - dir: 下载目录
+//   - ticket: Ticket Key
+//   - results: 下载结果
+//
+// 返回:
+//   - string: 清单文件路径
+//   - error: 如果写入失败，返回错误
+func WriteManifest(dir, ticket string, results []DownloadResult) (string, error) {
+	manifest := Manifest{
+		Ticket:       NormalizeTicketKey(ticket),
+		DownloadedAt: time.Now().UTC(),
+		Attachments:  make([]ManifestEntry, 0, len(results)),
+	}
+	for _, result := range results {
+		if result.Status == DownloadStatusFailed {
+			continue
+		}
+		manifest.Attachments = append(manifest.Attachments, ManifestEntry{
+			ID:       result.Attachment.ID,
+			Filename: filepath.Base(result.Path),
+			Size:     result.Size,
+			SHA256:   result.SHA256,
+		})
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("序列化清单失败: %w", err)
+	}
+
+	manifestPath := filepath.Join(dir, ManifestFile)
+	if err := os.WriteFile(manifestPath, append(data, '\n'), 0644); err != nil {
+		return "", fmt.Errorf("写入清单失败: %w", err)
+	}
+	return manifestPath, nil
+}
+
+// ListAttachmentDirs 列出下载根目录下的所有附件目录
+//
+// 参数:
+//   - root: 下载根目录
+//
+// 返回:
+//   - []DownloadDirInfo: 目录信息（按 Ticket 排序），根目录不存在时返回空列表
+//   - error: 如果读取失败，返回错误
+func ListAttachmentDirs(root string) ([]DownloadDirInfo, error) {
+	entries, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return []DownloadDirInfo{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取下载目录失败: %w", err)
+	}
+
+	result := make([]DownloadDirInfo, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		info := DownloadDirInfo{Ticket: entry.Name(), Path: filepath.Join(root, entry.Name())}
+		if stat, err := entry.Info(); err == nil {
+			info.ModTime = stat.ModTime()
+		}
+		_ = filepath.WalkDir(info.Path, func(_ string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			if stat, err := d.Info(); err == nil {
+				info.Files++
+				info.Size += stat.Size()
+			}
+			return nil
+		})
+		result = append(result, info)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Ticket < result[j].Ticket })
+	return result, nil
+}
+
+// downloadAttachment 下载单个附件，已存在且大小相同时跳过
+func downloadAttachment(downloader AttachmentDownloader, attachment *cloud.Attachment, target string) DownloadResult {
+	result := DownloadResult{Attachment: attachment, Path: target}
+
+	if stat, err := os.Stat(target); err == nil && stat.Mode().IsRegular() && stat.Size() == int64(attachment.Size) {
+		sum, err := fileSHA256(target)
+		if err == nil {
+			result.Status = DownloadStatusSkipped
+			result.Size = stat.Size()
+			result.SHA256 = sum
+			return result
+		}
+	}
+
+	size, sum, err := fetchToFile(downloader, attachment, target)
+	if err != nil {
+		result.Status = DownloadStatusFailed
+		result.Err = err
+		return result
+	}
+
+	result.Status = DownloadStatusDownloaded
+	result.Size = size
+	result.SHA256 = sum
+	return result
+}
+
+// fetchToFile 将附件写入临时文件并计算 SHA-256，成功后重命名为目标文件
+func fetchToFile(downloader AttachmentDownloader, attachment *cloud.Attachment, target string) (int64, string, error) {
+	body, err := downloader.DownloadAttachment(attachment)
+	if err != nil {
+		return 0, "", err
+	}
+	defer body.Close()
+
+	partPath := target + partSuffix
+	file, err := os.Create(partPath)
+	if err != nil {
+		return 0, "", fmt.Errorf("创建文件 %s 失败: %w", partPath, err)
+	}
+
+	hash := sha256.New()
+	size, copyErr := io.Copy(io.MultiWriter(file, hash), body)
+	closeErr := file.Close()
+	if copyErr != nil || closeErr != nil {
+		_ = os.Remove(partPath)
+		if copyErr != nil {
+			return 0, "", fmt.Errorf("下载附件 %s 失败: %w", attachment.Filename, copyErr)
+		}
+		return 0, "", fmt.Errorf("写入文件 %s 失败: %w", partPath, closeErr)
+	}
+
+	if err := os.Rename(partPath, target); err != nil {
+		_ = os.Remove(partPath)
+		return 0, "", fmt.Errorf("重命名文件 %s 失败: %w", partPath, err)
+	}
+
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// fileSHA256 计算文件的 SHA-256
+func fileSHA256(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// localFilenames 为附件生成本地文件名
+//
+// 去除路径部分，并为重名的附件追加附件 ID（如 "log-10002.txt"）。
+func localFilenames(attachments []*cloud.Attachment) []string {
+	counts := make(map[string]int, len(attachments))
+	names := make([]string, len(attachments))
+	for i, attachment := range attachments {
+		names[i] = safeFilename(attachment)
+		counts[strings.ToLower(names[i])]++
+	}
+
+	for i, attachment := range attachments {
+		if counts[strings.ToLower(names[i])] > 1 {
+			ext := filepath.Ext(names[i])
+			names[i] = strings.TrimSuffix(names[i], ext) + "-" + attachment.ID + ext
+		}
+	}
+	return names
+}
+
+// safeFilename 返回可安全用作本地文件名的附件名
+func safeFilename(attachment *cloud.Attachment) string {
+	name := filepath.Base(strings.ReplaceAll(attachment.Filename, "\\", "/"))
+	if name == "." || name == "/" || name == ".." || name == "" || name == ManifestFile {
+		return "attachment-" + attachment.ID
+	}
+	return name
+}
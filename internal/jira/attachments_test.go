@@ -0,0 +1,197 @@
+package jira
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDownloader 按附件 ID 返回预设内容的下载器
+type fakeDownloader struct {
+	mu       sync.Mutex
+	contents map[string]string
+	calls    []string
+}
+
+func (f *fakeDownloader) DownloadAttachment(attachment *cloud.Attachment) (io.ReadCloser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, attachment.ID)
+	content, ok := f.contents[attachment.ID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
+func sha256Hex(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// ==================== FilterAttachments 测试 ====================
+
+func TestFilterAttachments(t *testing.T) {
+	attachments := []*cloud.Attachment{
+		{ID: "1", Filename: "screenshot.PNG"},
+		{ID: "2", Filename: "app.log"},
+		{ID: "3", Filename: "diagram.png"},
+	}
+
+	tests := []struct {
+		name     string
+		pattern  string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "empty pattern", pattern: "", expected: []string{"1", "2", "3"}},
+		{name: "extension case insensitive", pattern: "*.png", expected: []string{"1", "3"}},
+		{name: "no match", pattern: "*.zip", expected: []string{}},
+		{name: "invalid pattern", pattern: "[", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FilterAttachments(attachments, tt.pattern)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			ids := make([]string, 0, len(result))
+			for _, attachment := range result {
+				ids = append(ids, attachment.ID)
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+}
+
+// ==================== DownloadAttachments 测试 ====================
+
+func TestDownloadAttachments(t *testing.T) {
+	dir := t.TempDir()
+	downloader := &fakeDownloader{contents: map[string]string{
+		"1": "hello",
+		"2": "world!",
+		"3": "other log",
+	}}
+	attachments := []*cloud.Attachment{
+		{ID: "1", Filename: "a.txt", Size: 5},
+		{ID: "2", Filename: "log.txt", Size: 6},
+		{ID: "3", Filename: "log.txt", Size: 9},
+		{ID: "4", Filename: "missing.txt", Size: 1},
+	}
+
+	var progress []string
+	results, err := DownloadAttachments(downloader, attachments, dir, DownloadOptions{
+		Workers:  2,
+		Progress: func(result DownloadResult) { progress = append(progress, result.Attachment.ID) },
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	assert.Len(t, progress, 4)
+
+	assert.Equal(t, DownloadStatusDownloaded, results[0].Status)
+	assert.Equal(t, filepath.Join(dir, "a.txt"), results[0].Path)
+	assert.Equal(t, int64(5), results[0].Size)
+	assert.Equal(t, sha256Hex("hello"), results[0].SHA256)
+
+	// 同名附件追加附件 ID
+	assert.Equal(t, filepath.Join(dir, "log-2.txt"), results[1].Path)
+	assert.Equal(t, filepath.Join(dir, "log-3.txt"), results[2].Path)
+
+	assert.Equal(t, DownloadStatusFailed, results[3].Status)
+	assert.Error(t, results[3].Err)
+	_, statErr := os.Stat(filepath.Join(dir, "missing.txt"+partSuffix))
+	assert.True(t, os.IsNotExist(statErr))
+
+	content, err := os.ReadFile(filepath.Join(dir, "log-3.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "other log", string(content))
+}
+
+func TestDownloadAttachments_SkipsExistingFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "same.txt"), []byte("hello"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "changed.txt"), []byte("old"), 0644))
+
+	downloader := &fakeDownloader{contents: map[string]string{
+		"1": "hello",
+		"2": "updated",
+	}}
+	attachments := []*cloud.Attachment{
+		{ID: "1", Filename: "same.txt", Size: 5},
+		{ID: "2", Filename: "changed.txt", Size: 7},
+	}
+
+	results, err := DownloadAttachments(downloader, attachments, dir, DownloadOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, DownloadStatusSkipped, results[0].Status)
+	assert.Equal(t, sha256Hex("hello"), results[0].SHA256)
+	assert.Equal(t, DownloadStatusDownloaded, results[1].Status)
+	assert.Equal(t, []string{"2"}, downloader.calls)
+}
+
+// ==================== WriteManifest 测试 ====================
+
+func TestWriteManifest(t *testing.T) {
+	dir := t.TempDir()
+	results := []DownloadResult{
+		{Attachment: &cloud.Attachment{ID: "1"}, Path: filepath.Join(dir, "a.txt"), Status: DownloadStatusDownloaded, Size: 5, SHA256: "abc"},
+		{Attachment: &cloud.Attachment{ID: "2"}, Path: filepath.Join(dir, "b.txt"), Status: DownloadStatusSkipped, Size: 3, SHA256: "def"},
+		{Attachment: &cloud.Attachment{ID: "3"}, Path: filepath.Join(dir, "c.txt"), Status: DownloadStatusFailed, Err: errors.New("boom")},
+	}
+
+	manifestPath, err := WriteManifest(dir, "proj-1", results)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, ManifestFile), manifestPath)
+
+	data, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+
+	var manifest Manifest
+	require.NoError(t, json.Unmarshal(data, &manifest))
+	assert.Equal(t, "PROJ-1", manifest.Ticket)
+	assert.Equal(t, []ManifestEntry{
+		{ID: "1", Filename: "a.txt", Size: 5, SHA256: "abc"},
+		{ID: "2", Filename: "b.txt", Size: 3, SHA256: "def"},
+	}, manifest.Attachments)
+}
+
+// ==================== ListAttachmentDirs 测试 ====================
+
+func TestListAttachmentDirs(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "PROJ-2"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "PROJ-1"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "PROJ-1", "a.txt"), []byte("hello"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "PROJ-1", ManifestFile), []byte("{}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "stray.txt"), []byte("x"), 0644))
+
+	dirs, err := ListAttachmentDirs(root)
+	require.NoError(t, err)
+	require.Len(t, dirs, 2)
+	assert.Equal(t, "PROJ-1", dirs[0].Ticket)
+	assert.Equal(t, 2, dirs[0].Files)
+	assert.Equal(t, int64(7), dirs[0].Size)
+	assert.Equal(t, "PROJ-2", dirs[1].Ticket)
+	assert.Equal(t, 0, dirs[1].Files)
+
+	dirs, err = ListAttachmentDirs(filepath.Join(root, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, dirs)
+}
@@ -0,0 +1,66 @@
+package jira
+
+import (
+	"strings"
+	"time"
+)
+
+// CommentFilter 评论过滤条件
+type CommentFilter struct {
+	Author string    // 作者（匹配显示名、邮箱或 Account ID，不区分大小写）
+	Since  time.Time // 只保留此时间之后创建的评论（零值表示不限制）
+	Offset int       // 跳过的评论数
+	Limit  int       // 最多返回的评论数（0 表示不限制）
+}
+
+// FilterComments 按条件过滤评论
+//
+// 先按作者和时间过滤，再应用 Offset 和 Limit。
+// 创建时间无法解析的评论在指定 Since 时会被排除。
+//
+// 参数:
+//   - comments: 评论列表
+//   - filter: 过滤条件
+//
+// 返回:
+//   - []*JiraComment: 过滤后的评论列表
+func FilterComments(comments []*JiraComment, filter CommentFilter) []*JiraComment {
+	result := make([]*JiraComment, 0, len(comments))
+	for _, comment := range comments {
+		if filter.Author != "" && !matchesUser(comment.Author, filter.Author) {
+			continue
+		}
+		if !filter.Since.IsZero() {
+			created, err := ParseTime(comment.Created)
+			if err != nil || created.Before(filter.Since) {
+				continue
+			}
+		}
+		result = append(result, comment)
+	}
+
+	if filter.Offset > 0 {
+		if filter.Offset >= len(result) {
+			return []*JiraComment{}
+		}
+		result = result[filter.Offset:]
+	}
+	if filter.Limit > 0 && filter.Limit < len(result) {
+		result = result[:filter.Limit]
+	}
+
+	return result
+}
+
+// matchesUser 判断用户的显示名、邮箱或 Account ID 是否包含查询内容
+func matchesUser(user *JiraUser, query string) bool {
+	if user == nil {
+		return false
+	}
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	if strings.Contains(strings.ToLower(user.DisplayName), query) || strings.EqualFold(user.AccountID, query) {
+		return true
+	}
+	return user.EmailAddress != nil && strings.Contains(strings.ToLower(*user.EmailAddress), query)
+}
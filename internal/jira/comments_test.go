@@ -0,0 +1,93 @@
+package jira
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ==================== FilterComments 测试 ====================
+
+func TestFilterComments(t *testing.T) {
+	email := "bob@example.com"
+	comments := []*JiraComment{
+		{ID: "1", Created: "2024-01-01T10:00:00.000+0000", Author: &JiraUser{AccountID: "a1", DisplayName: "Alice"}},
+		{ID: "2", Created: "2024-01-02T10:00:00.000+0000", Author: &JiraUser{AccountID: "b2", DisplayName: "Bob", EmailAddress: &email}},
+		{ID: "3", Created: "2024-01-03T10:00:00.000+0000", Author: &JiraUser{AccountID: "a1", DisplayName: "Alice"}},
+		{ID: "4", Created: "invalid", Author: nil},
+	}
+
+	tests := []struct {
+		name     string
+		filter   CommentFilter
+		expected []string
+	}{
+		{
+			name:     "no filter",
+			filter:   CommentFilter{},
+			expected: []string{"1", "2", "3", "4"},
+		},
+		{
+			name:     "author by display name",
+			filter:   CommentFilter{Author: "alice"},
+			expected: []string{"1", "3"},
+		},
+		{
+			name:     "author by email",
+			filter:   CommentFilter{Author: "BOB@example.com"},
+			expected: []string{"2"},
+		},
+		{
+			name:     "author by account id",
+			filter:   CommentFilter{Author: "b2"},
+			expected: []string{"2"},
+		},
+		{
+			name:     "since excludes older and unparsable",
+			filter:   CommentFilter{Since: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+			expected: []string{"2", "3"},
+		},
+		{
+			name:     "offset and limit",
+			filter:   CommentFilter{Offset: 1, Limit: 2},
+			expected: []string{"2", "3"},
+		},
+		{
+			name:     "offset past end",
+			filter:   CommentFilter{Offset: 10},
+			expected: []string{},
+		},
+		{
+			name:     "limit after author filter",
+			filter:   CommentFilter{Author: "alice", Limit: 1},
+			expected: []string{"1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FilterComments(comments, tt.filter)
+			ids := make([]string, 0, len(result))
+			for _, comment := range result {
+				ids = append(ids, comment.ID)
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+}
+
+// ==================== ParseTime 测试 ====================
+
+func TestParseTime(t *testing.T) {
+	parsed, err := ParseTime("2024-01-02T03:04:05.000+0800")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 19, 4, 5, 0, time.UTC), parsed.UTC())
+
+	parsed, err = ParseTime("2024-01-02T03:04:05Z")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), parsed.UTC())
+
+	_, err = ParseTime("yesterday")
+	assert.Error(t, err)
+}
@@ -0,0 +1,240 @@
+package jira
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+)
+
+// TimeLayout Jira REST API 返回的时间格式
+const TimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// ToJiraIssue 将 go-jira 的 Issue 转换为 JiraIssue
+//
+// JiraIssue 只保留命令输出需要的字段，用于 JSON 和 Markdown 渲染。
+//
+// 参数:
+//   - issue: go-jira 返回的 Issue（不能为 nil）
+//
+// 返回:
+//   - *JiraIssue: 转换后的 Issue
+func ToJiraIssue(issue *cloud.Issue) *JiraIssue {
+	result := &JiraIssue{
+		Key:     issue.Key,
+		ID:      issue.ID,
+		SelfURL: issue.Self,
+	}
+
+	fields := issue.Fields
+	if fields == nil {
+		return result
+	}
+
+	result.Fields = JiraIssueFields{
+		Summary:     fields.Summary,
+		Description: optionalString(fields.Description),
+		Status:      toJiraStatus(fields.Status),
+		Priority:    toJiraPriority(fields.Priority),
+		Created:     formatTime(fields.Created),
+		Updated:     formatTime(fields.Updated),
+		Reporter:    toJiraUser(fields.Reporter),
+		Assignee:    toJiraUser(fields.Assignee),
+		Labels:      fields.Labels,
+		IssueLinks:  toJiraIssueLinks(fields.IssueLinks),
+		Subtasks:    toJiraSubtasks(fields.Subtasks),
+	}
+
+	if fields.Type.Name != "" {
+		result.Fields.IssueType = &JiraIssueType{ID: fields.Type.ID, Name: fields.Type.Name, Subtask: fields.Type.Subtask}
+	}
+	if fields.Parent != nil && fields.Parent.Key != "" {
+		result.Fields.Parent = &JiraIssueRef{Key: fields.Parent.Key}
+	}
+	if fields.Epic != nil && fields.Epic.Key != "" {
+		result.Fields.Epic = &JiraIssueRef{Key: fields.Epic.Key}
+		result.Fields.Epic.Fields.Summary = fields.Epic.Summary
+	}
+	for _, component := range fields.Components {
+		result.Fields.Components = append(result.Fields.Components, &JiraComponent{
+			ID:          component.ID,
+			Name:        component.Name,
+			Description: optionalString(component.Description),
+		})
+	}
+	for _, version := range fields.FixVersions {
+		released := version.Released != nil && *version.Released
+		result.Fields.FixVersions = append(result.Fields.FixVersions, &JiraVersion{
+			ID:          version.ID,
+			Name:        version.Name,
+			Released:    released,
+			ReleaseDate: optionalString(version.ReleaseDate),
+		})
+	}
+	for _, attachment := range fields.Attachments {
+		size := int64(attachment.Size)
+		result.Fields.Attachment = append(result.Fields.Attachment, &JiraAttachment{
+			Filename:   attachment.Filename,
+			ContentURL: attachment.Content,
+			MimeType:   optionalString(attachment.MimeType),
+			Size:       &size,
+		})
+	}
+	if tt := fields.TimeTracking; tt != nil {
+		result.Fields.TimeTracking = &JiraTimeTracking{
+			OriginalEstimate:  optionalString(tt.OriginalEstimate),
+			RemainingEstimate: optionalString(tt.RemainingEstimate),
+			TimeSpent:         optionalString(tt.TimeSpent),
+		}
+	}
+
+	return result
+}
+
+// ToJiraComments 将 go-jira 的评论列表转换为 JiraComment 列表
+func ToJiraComments(comments []*cloud.Comment) []*JiraComment {
+	result := make([]*JiraComment, 0, len(comments))
+	for _, comment := range comments {
+		result = append(result, &JiraComment{
+			ID:           comment.ID,
+			Body:         comment.Body,
+			Created:      comment.Created,
+			Updated:      optionalString(comment.Updated),
+			Author:       toJiraUser(comment.Author),
+			UpdateAuthor: toJiraUser(comment.UpdateAuthor),
+		})
+	}
+	return result
+}
+
+// ToJiraChangelog 将 go-jira 的变更历史转换为 JiraChangelog 列表
+func ToJiraChangelog(changelog *cloud.Changelog) []*JiraChangelog {
+	if changelog == nil {
+		return []*JiraChangelog{}
+	}
+
+	result := make([]*JiraChangelog, 0, len(changelog.Histories))
+	for i := range changelog.Histories {
+		history := &changelog.Histories[i]
+		entry := &JiraChangelog{
+			ID:      history.Id,
+			Author:  toJiraUser(&history.Author),
+			Created: history.Created,
+			Items:   make([]*JiraChangelogItem, 0, len(history.Items)),
+		}
+		for _, item := range history.Items {
+			entry.Items = append(entry.Items, &JiraChangelogItem{
+				Field:      item.Field,
+				FieldType:  item.FieldType,
+				From:       optionalValue(item.From),
+				FromString: optionalString(item.FromString),
+				To:         optionalValue(item.To),
+				ToString:   optionalString(item.ToString),
+			})
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// ParseTime 解析 Jira 返回的时间字符串
+func ParseTime(value string) (time.Time, error) {
+	t, err := time.Parse(TimeLayout, value)
+	if err != nil {
+		return time.Parse(time.RFC3339, value)
+	}
+	return t, nil
+}
+
+func toJiraIssueLinks(links []*cloud.IssueLink) []*JiraIssueLink {
+	result := make([]*JiraIssueLink, 0, len(links))
+	for _, link := range links {
+		result = append(result, &JiraIssueLink{
+			ID: link.ID,
+			Type: JiraIssueLinkType{
+				ID:      link.Type.ID,
+				Name:    link.Type.Name,
+				Inward:  link.Type.Inward,
+				Outward: link.Type.Outward,
+			},
+			InwardIssue:  toJiraIssueRef(link.InwardIssue),
+			OutwardIssue: toJiraIssueRef(link.OutwardIssue),
+		})
+	}
+	return result
+}
+
+func toJiraSubtasks(subtasks []*cloud.Subtasks) []*JiraSubtask {
+	result := make([]*JiraSubtask, 0, len(subtasks))
+	for _, subtask := range subtasks {
+		result = append(result, &JiraSubtask{
+			ID:  subtask.ID,
+			Key: subtask.Key,
+			Fields: JiraSubtaskFields{
+				Summary: subtask.Fields.Summary,
+				Status:  toJiraStatus(subtask.Fields.Status),
+			},
+		})
+	}
+	return result
+}
+
+func toJiraIssueRef(issue *cloud.Issue) *JiraIssueRef {
+	if issue == nil {
+		return nil
+	}
+
+	ref := &JiraIssueRef{Key: issue.Key}
+	if issue.Fields != nil {
+		ref.Fields.Summary = issue.Fields.Summary
+		ref.Fields.Status = toJiraStatus(issue.Fields.Status)
+	}
+	return ref
+}
+
+func toJiraStatus(status *cloud.Status) JiraStatus {
+	if status == nil {
+		return JiraStatus{}
+	}
+	return JiraStatus{ID: status.ID, Name: status.Name, SelfURL: optionalString(status.Self)}
+}
+
+func toJiraPriority(priority *cloud.Priority) *JiraPriority {
+	if priority == nil {
+		return nil
+	}
+	return &JiraPriority{ID: priority.ID, Name: priority.Name, IconURL: optionalString(priority.IconURL)}
+}
+
+func toJiraUser(user *cloud.User) *JiraUser {
+	if user == nil || (user.AccountID == "" && user.DisplayName == "") {
+		return nil
+	}
+	return &JiraUser{
+		AccountID:    user.AccountID,
+		DisplayName:  user.DisplayName,
+		EmailAddress: optionalString(user.EmailAddress),
+	}
+}
+
+func formatTime(t cloud.Time) *string {
+	if time.Time(t).IsZero() {
+		return nil
+	}
+	value := time.Time(t).Format(TimeLayout)
+	return &value
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func optionalValue(value interface{}) *string {
+	if value == nil {
+		return nil
+	}
+	return optionalString(fmt.Sprint(value))
+}
@@ -0,0 +1,85 @@
+package jira
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==================== ToJiraIssue 测试 ====================
+
+func TestToJiraIssue(t *testing.T) {
+	created := cloud.Time(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	issue := &cloud.Issue{
+		ID:  "10001",
+		Key: "PROJ-1",
+		Fields: &cloud.IssueFields{
+			Summary:     "Login page",
+			Description: "Add a login page",
+			Type:        cloud.IssueType{Name: "Story"},
+			Status:      &cloud.Status{Name: "In Progress"},
+			Priority:    &cloud.Priority{Name: "High"},
+			Assignee:    &cloud.User{AccountID: "a1", DisplayName: "Alice"},
+			Created:     created,
+			Labels:      []string{"frontend"},
+			Parent:      &cloud.Parent{Key: "PROJ-0"},
+			Subtasks:    []*cloud.Subtasks{{Key: "PROJ-2", Fields: cloud.IssueFields{Summary: "Form", Status: &cloud.Status{Name: "To Do"}}}},
+		},
+	}
+
+	result := ToJiraIssue(issue)
+
+	assert.Equal(t, "PROJ-1", result.Key)
+	assert.Equal(t, "Login page", result.Fields.Summary)
+	require.NotNil(t, result.Fields.Description)
+	assert.Equal(t, "Add a login page", *result.Fields.Description)
+	assert.Equal(t, "Story", result.Fields.IssueType.Name)
+	assert.Equal(t, "In Progress", result.Fields.Status.Name)
+	assert.Equal(t, "High", result.Fields.Priority.Name)
+	assert.Equal(t, "Alice", result.Fields.Assignee.DisplayName)
+	assert.Nil(t, result.Fields.Reporter)
+	require.NotNil(t, result.Fields.Created)
+	assert.Equal(t, "2024-01-02T03:04:05.000+0000", *result.Fields.Created)
+	assert.Nil(t, result.Fields.Updated)
+	assert.Equal(t, []string{"frontend"}, result.Fields.Labels)
+	assert.Equal(t, "PROJ-0", result.Fields.Parent.Key)
+	require.Len(t, result.Fields.Subtasks, 1)
+	assert.Equal(t, "To Do", result.Fields.Subtasks[0].Fields.Status.Name)
+}
+
+func TestToJiraIssue_NilFields(t *testing.T) {
+	result := ToJiraIssue(&cloud.Issue{Key: "PROJ-1"})
+
+	assert.Equal(t, "PROJ-1", result.Key)
+	assert.Empty(t, result.Fields.Summary)
+}
+
+// ==================== ToJiraChangelog 测试 ====================
+
+func TestToJiraChangelog(t *testing.T) {
+	changelog := &cloud.Changelog{
+		Histories: []cloud.ChangelogHistory{
+			{
+				Id:      "1",
+				Author:  cloud.User{DisplayName: "Alice"},
+				Created: "2024-01-02T03:04:05.000+0000",
+				Items:   []cloud.ChangelogItems{{Field: "status", FromString: "To Do", ToString: "In Progress"}},
+			},
+		},
+	}
+
+	result := ToJiraChangelog(changelog)
+
+	require.Len(t, result, 1)
+	assert.Equal(t, "Alice", result[0].Author.DisplayName)
+	require.Len(t, result[0].Items, 1)
+	assert.Equal(t, "status", result[0].Items[0].Field)
+	assert.Equal(t, "To Do", *result[0].Items[0].FromString)
+	assert.Equal(t, "In Progress", *result[0].Items[0].ToString)
+	assert.Nil(t, result[0].Items[0].From)
+
+	assert.Empty(t, ToJiraChangelog(nil))
+}
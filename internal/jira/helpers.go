@@ -2,9 +2,13 @@ package jira
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 )
 
+// ticketKeyPattern 匹配文本中的 Ticket Key（如 "feature/PROJ-123-login" 中的 "PROJ-123"）
+var ticketKeyPattern = regexp.MustCompile(`(?i)(?:^|[^A-Za-z0-9])([A-Za-z][A-Za-z0-9]*-[0-9]+)(?:[^0-9]|$)`)
+
 // ValidateTicketKey 验证 Ticket Key 格式
 //
 // Jira Ticket Key 格式：PROJECT-NUMBER（如 "PROJ-123"）
@@ -72,3 +76,17 @@ func ExtractTicketNumber(ticket string) string {
 	return ""
 }
 
+// ExtractTicketKey 从文本（如分支名）中提取第一个 Ticket Key
+//
+// 参数:
+//   - text: 待提取的文本（如 "feature/PROJ-123-add-login"）
+//
+// 返回:
+//   - string: 规范化后的 Ticket Key（如 "PROJ-123"），未找到时返回空字符串
+func ExtractTicketKey(text string) string {
+	match := ticketKeyPattern.FindStringSubmatch(text)
+	if match == nil {
+		return ""
+	}
+	return NormalizeTicketKey(match[1])
+}
@@ -267,3 +267,54 @@ func TestExtractTicketNumber(t *testing.T) {
 	}
 }
 
+// ==================== ExtractTicketKey 测试 ====================
+
+func TestExtractTicketKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "plain ticket key",
+			input:    "PROJ-123",
+			expected: "PROJ-123",
+		},
+		{
+			name:     "branch with prefix and slug",
+			input:    "feature/PROJ-123-add-login",
+			expected: "PROJ-123",
+		},
+		{
+			name:     "lowercase branch",
+			input:    "bugfix/proj-42_fix-crash",
+			expected: "PROJ-42",
+		},
+		{
+			name:     "first key wins",
+			input:    "ABC-1-and-DEF-2",
+			expected: "ABC-1",
+		},
+		{
+			name:     "no ticket key",
+			input:    "feature/add-login",
+			expected: "",
+		},
+		{
+			name:     "number prefix is not a project",
+			input:    "release/1-2",
+			expected: "",
+		},
+		{
+			name:     "empty string",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExtractTicketKey(tt.input))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package jira
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/andygrunwald/go-jira/v2/cloud"
 	"github.com/zevwings/workflow/internal/jira/api"
@@ -198,6 +199,18 @@ func (c *JiraClient) UploadAttachment(ticket, filePath string) ([]*cloud.Attachm
 	return c.issueAPI.UploadAttachment(ticket, filePath)
 }
 
+// DownloadAttachment 下载附件
+//
+// 参数:
+//   - attachment: 附件对象
+//
+// 返回:
+//   - io.ReadCloser: 附件内容流（调用方负责关闭）
+//   - error: 如果下载失败，返回错误
+func (c *JiraClient) DownloadAttachment(attachment *cloud.Attachment) (io.ReadCloser, error) {
+	return c.issueAPI.DownloadAttachment(attachment)
+}
+
 // GetTransitions 获取 ticket 的可用状态转换
 //
 // 参数:
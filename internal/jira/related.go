@@ -0,0 +1,138 @@
+package jira
+
+import (
+	"strings"
+)
+
+// 关联关系名称
+const (
+	RelationSubtask = "subtask"
+	RelationParent  = "parent"
+	RelationEpic    = "epic"
+)
+
+// epicIssueType Epic 的 Issue 类型名称
+const epicIssueType = "epic"
+
+// maxParentDepth 向上查找父级 Issue 的最大层数（子任务 -> 故事 -> Epic）
+const maxParentDepth = 3
+
+// RelatedIssues 从 Issue 链接和子任务中整理关联 Issue
+//
+// 链接的关联关系取链接类型的方向描述（如 "blocks"、"is blocked by"），
+// 子任务的关联关系为 "subtask"。
+//
+// 参数:
+//   - links: Issue 链接列表
+//   - subtasks: 子任务列表
+//
+// 返回:
+//   - []*JiraRelatedIssue: 关联 Issue 列表
+func RelatedIssues(links []*JiraIssueLink, subtasks []*JiraSubtask) []*JiraRelatedIssue {
+	result := make([]*JiraRelatedIssue, 0, len(links)+len(subtasks))
+
+	for _, link := range links {
+		switch {
+		case link.OutwardIssue != nil:
+			result = append(result, relatedFromRef(link.OutwardIssue, linkRelation(link.Type.Outward, link.Type.Name)))
+		case link.InwardIssue != nil:
+			result = append(result, relatedFromRef(link.InwardIssue, linkRelation(link.Type.Inward, link.Type.Name)))
+		}
+	}
+
+	for _, subtask := range subtasks {
+		result = append(result, &JiraRelatedIssue{
+			Key:      subtask.Key,
+			Summary:  subtask.Fields.Summary,
+			Status:   subtask.Fields.Status.Name,
+			Relation: RelationSubtask,
+		})
+	}
+
+	return result
+}
+
+// GetRelated 获取 ticket 的关联 Issue
+//
+// 包括 Issue 链接、子任务，以及沿父级向上查找到的父 Issue 和所属 Epic。
+//
+// 参数:
+//   - ticket: Ticket Key（如 "PROJ-123"）
+//
+// 返回:
+//   - []*JiraRelatedIssue: 关联 Issue 列表
+//   - error: 如果获取失败，返回错误
+func (c *JiraClient) GetRelated(ticket string) ([]*JiraRelatedIssue, error) {
+	issue, err := c.GetTicketInfo(ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := ToJiraIssue(issue)
+	result := RelatedIssues(converted.Fields.IssueLinks, converted.Fields.Subtasks)
+	return append(result, c.parentChain(converted)...), nil
+}
+
+// parentChain 沿父级向上查找，直到找到 Epic
+//
+// 父级 Issue 获取失败时停止查找，不视为错误。
+// Jira 未返回父级时，回退到 Issue 自带的 Epic 字段。
+func (c *JiraClient) parentChain(issue *JiraIssue) []*JiraRelatedIssue {
+	var result []*JiraRelatedIssue
+
+	current := issue
+	for depth := 0; depth < maxParentDepth && current.Fields.Parent != nil; depth++ {
+		parent, err := c.GetTicketInfo(current.Fields.Parent.Key)
+		if err != nil {
+			break
+		}
+
+		current = ToJiraIssue(parent)
+		relation := RelationParent
+		if isEpic(current) {
+			relation = RelationEpic
+		}
+		result = append(result, &JiraRelatedIssue{
+			Key:      current.Key,
+			Summary:  current.Fields.Summary,
+			Status:   current.Fields.Status.Name,
+			Relation: relation,
+		})
+		if relation == RelationEpic {
+			return result
+		}
+	}
+
+	if result == nil && issue.Fields.Epic != nil && issue.Fields.Epic.Key != "" {
+		result = append(result, &JiraRelatedIssue{
+			Key:      issue.Fields.Epic.Key,
+			Summary:  issue.Fields.Epic.Fields.Summary,
+			Relation: RelationEpic,
+		})
+	}
+
+	return result
+}
+
+// isEpic 判断 Issue 是否为 Epic
+func isEpic(issue *JiraIssue) bool {
+	return issue.Fields.IssueType != nil && strings.EqualFold(issue.Fields.IssueType.Name, epicIssueType)
+}
+
+// linkRelation 返回链接的方向描述，缺失时使用链接类型名称
+func linkRelation(direction, name string) string {
+	if direction != "" {
+		return direction
+	}
+	return strings.ToLower(name)
+}
+
+// relatedFromRef 将链接引用的 Issue 转换为关联 Issue
+func relatedFromRef(ref *JiraIssueRef, relation string) *JiraRelatedIssue {
+	return &JiraRelatedIssue{
+		Key:      ref.Key,
+		Summary:  ref.Fields.Summary,
+		Status:   ref.Fields.Status.Name,
+		Relation: relation,
+	}
+}
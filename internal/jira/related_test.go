@@ -0,0 +1,75 @@
+package jira
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zevwings/workflow/internal/jira/api"
+)
+
+// ==================== RelatedIssues 测试 ====================
+
+func TestRelatedIssues(t *testing.T) {
+	blocks := JiraIssueLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"}
+
+	outward := &JiraIssueRef{Key: "PROJ-2"}
+	outward.Fields.Summary = "Outward"
+	outward.Fields.Status.Name = "Done"
+	inward := &JiraIssueRef{Key: "PROJ-3"}
+	inward.Fields.Summary = "Inward"
+
+	links := []*JiraIssueLink{
+		{Type: blocks, OutwardIssue: outward},
+		{Type: blocks, InwardIssue: inward},
+		{Type: JiraIssueLinkType{Name: "Relates"}, OutwardIssue: &JiraIssueRef{Key: "PROJ-4"}},
+		{Type: blocks},
+	}
+	subtasks := []*JiraSubtask{
+		{Key: "PROJ-5", Fields: JiraSubtaskFields{Summary: "Subtask", Status: JiraStatus{Name: "To Do"}}},
+	}
+
+	result := RelatedIssues(links, subtasks)
+
+	require.Len(t, result, 4)
+	assert.Equal(t, &JiraRelatedIssue{Key: "PROJ-2", Summary: "Outward", Status: "Done", Relation: "blocks"}, result[0])
+	assert.Equal(t, "is blocked by", result[1].Relation)
+	assert.Equal(t, "relates", result[2].Relation)
+	assert.Equal(t, &JiraRelatedIssue{Key: "PROJ-5", Summary: "Subtask", Status: "To Do", Relation: RelationSubtask}, result[3])
+}
+
+// ==================== GetRelated 测试 ====================
+
+func TestJiraClient_GetRelated(t *testing.T) {
+	issues := map[string]string{
+		"PROJ-3": `{"key":"PROJ-3","fields":{"summary":"Subtask","issuetype":{"name":"Sub-task","subtask":true},"status":{"name":"To Do"},"parent":{"key":"PROJ-2"},
+			"issuelinks":[{"type":{"name":"Blocks","inward":"is blocked by","outward":"blocks"},"outwardIssue":{"key":"PROJ-9","fields":{"summary":"Blocked","status":{"name":"Open"}}}}]}}`,
+		"PROJ-2": `{"key":"PROJ-2","fields":{"summary":"Story","issuetype":{"name":"Story"},"status":{"name":"In Progress"},"parent":{"key":"PROJ-1"}}}`,
+		"PROJ-1": `{"key":"PROJ-1","fields":{"summary":"Epic","issuetype":{"name":"Epic"},"status":{"name":"Open"}}}`,
+	}
+
+	server := api.SetupMockJiraServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		body, ok := issues[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client, err := NewJiraClient(&Config{ServiceAddress: server.URL, Email: "test@example.com", APIToken: "test-token"})
+	require.NoError(t, err)
+
+	result, err := client.GetRelated("PROJ-3")
+	require.NoError(t, err)
+
+	require.Len(t, result, 3)
+	assert.Equal(t, &JiraRelatedIssue{Key: "PROJ-9", Summary: "Blocked", Status: "Open", Relation: "blocks"}, result[0])
+	assert.Equal(t, &JiraRelatedIssue{Key: "PROJ-2", Summary: "Story", Status: "In Progress", Relation: RelationParent}, result[1])
+	assert.Equal(t, &JiraRelatedIssue{Key: "PROJ-1", Summary: "Epic", Status: "Open", Relation: RelationEpic}, result[2])
+}
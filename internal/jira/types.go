@@ -12,6 +12,7 @@ type JiraIssue struct {
 type JiraIssueFields struct {
 	Summary      string            `json:"summary"`
 	Description  *string           `json:"description,omitempty"`
+	IssueType    *JiraIssueType    `json:"issuetype,omitempty"`
 	Status       JiraStatus        `json:"status"`
 	Attachment   []*JiraAttachment `json:"attachment,omitempty"`
 	Comment      *JiraComments     `json:"comment,omitempty"`
@@ -25,9 +26,18 @@ type JiraIssueFields struct {
 	FixVersions  []*JiraVersion    `json:"fixVersions,omitempty"`
 	IssueLinks   []*JiraIssueLink  `json:"issuelinks,omitempty"`
 	Subtasks     []*JiraSubtask    `json:"subtasks,omitempty"`
+	Parent       *JiraIssueRef     `json:"parent,omitempty"`
+	Epic         *JiraIssueRef     `json:"epic,omitempty"`
 	TimeTracking *JiraTimeTracking `json:"timeTracking,omitempty"`
 }
 
+// JiraIssueType Issue 类型
+type JiraIssueType struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Subtask bool   `json:"subtask"`
+}
+
 // JiraAttachment 附件信息
 type JiraAttachment struct {
 	Filename   string  `json:"filename"`
@@ -167,6 +177,14 @@ type JiraChangelogHistory struct {
 	Total      int              `json:"total"`
 }
 
+// JiraRelatedIssue 关联的 Issue
+type JiraRelatedIssue struct {
+	Key      string `json:"key"`
+	Summary  string `json:"summary"`
+	Status   string `json:"status"`
+	Relation string `json:"relation"` // 关联关系，如 "blocks"、"is blocked by"、"subtask"、"parent"、"epic"
+}
+
 // JiraProject 项目信息
 type JiraProject struct {
 	ID          string  `json:"id"`
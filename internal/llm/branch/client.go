@@ -114,12 +114,18 @@ func TranslateToEnglish(text string, llmClient client.LLMClient) (string, error)
 
 	userPrompt := fmt.Sprintf("Translate this text to English: %s", text)
 
+	systemPrompt, err := prompt.TranslateSystemPrompt(prompt.NewVars(nil, ""))
+	if err != nil {
+		return "", fmt.Errorf("构建 system prompt 失败: %w", err)
+	}
+
 	maxTokens := 100
 	params := &client.LLMRequestParams{
-		SystemPrompt: prompt.TranslateSystemPrompt,
+		SystemPrompt: systemPrompt,
 		UserPrompt:   userPrompt,
 		MaxTokens:    &maxTokens,
 		Temperature:  0.3,
+		Feature:      client.FeatureTranslate,
 	}
 
 	translated, err := llmClient.Call(params)
@@ -0,0 +1,350 @@
+// Package cache 在磁盘上缓存 LLM 响应
+//
+// 缓存键是 provider、模型、system prompt、user prompt、temperature 和 max_tokens 的哈希，
+// 相同的请求会直接返回上次的响应。每个响应保存为缓存目录下的一个 JSON 文件，
+// 超过 TTL 的条目在读取和写入时被删除，目录大小超过上限时按最近使用时间淘汰。
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zevwings/workflow/internal/llm/client"
+	"github.com/zevwings/workflow/internal/logging"
+)
+
+// keyVersion 缓存键的版本，键的组成变化时递增，使旧条目失效
+const keyVersion = 1
+
+// entryExt 缓存条目文件的扩展名
+const entryExt = ".json"
+
+// Options 缓存选项
+type Options struct {
+	// TTL 条目的有效期（<= 0 表示不过期）
+	TTL time.Duration
+	// MaxSize 缓存目录的大小上限，单位字节（<= 0 表示不限制）
+	MaxSize int64
+}
+
+// Cache 磁盘上的 LLM 响应缓存
+type Cache struct {
+	dir  string
+	opts Options
+	now  func() time.Time
+}
+
+// entry 缓存条目
+type entry struct {
+	// CreatedAt 写入时间
+	CreatedAt time.Time `json:"created_at"`
+	// Provider 提供商
+	Provider string `json:"provider"`
+	// Model 模型名称
+	Model string `json:"model"`
+	// Response LLM 响应
+	Response string `json:"response"`
+}
+
+// Stats 缓存统计
+type Stats struct {
+	// Dir 缓存目录
+	Dir string
+	// Entries 条目数量（包括已过期但尚未删除的条目）
+	Entries int
+	// Expired 已过期的条目数量
+	Expired int
+	// Size 条目占用的字节数
+	Size int64
+	// Oldest、Newest 最早和最近写入的时间（没有条目时为零值）
+	Oldest time.Time
+	Newest time.Time
+	// TTL 条目的有效期
+	TTL time.Duration
+	// MaxSize 大小上限
+	MaxSize int64
+}
+
+// New 创建响应缓存
+//
+// 目录在第一次写入时创建。
+//
+// 参数:
+//   - dir: 缓存目录
+//   - opts: 缓存选项
+//
+// 返回:
+//   - *Cache: 响应缓存
+func New(dir string, opts Options) *Cache {
+	return &Cache{dir: dir, opts: opts, now: time.Now}
+}
+
+// Dir 获取缓存目录
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Key 计算请求的缓存键
+//
+// 参数:
+//   - provider: 提供商
+//   - model: 模型名称（params.Model 不为空时使用 params.Model）
+//   - params: LLM 请求参数
+//
+// 返回:
+//   - string: 十六进制的 SHA-256 哈希
+func Key(provider, model string, params *client.LLMRequestParams) string {
+	if params.Model != "" {
+		model = params.Model
+	}
+	maxTokens := 0
+	if params.MaxTokens != nil {
+		maxTokens = *params.MaxTokens
+	}
+
+	data, _ := json.Marshal(struct {
+		Version      int     `json:"version"`
+		Provider     string  `json:"provider"`
+		Model        string  `json:"model"`
+		SystemPrompt string  `json:"system_prompt"`
+		UserPrompt   string  `json:"user_prompt"`
+		Temperature  float32 `json:"temperature"`
+		MaxTokens    int     `json:"max_tokens"`
+	}{keyVersion, provider, model, params.SystemPrompt, params.UserPrompt, params.Temperature, maxTokens})
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Get 读取缓存的响应
+//
+// 过期的条目会被删除。命中时更新条目的修改时间，用于按最近使用时间淘汰。
+//
+// 参数:
+//   - key: 缓存键
+//
+// 返回:
+//   - string: 缓存的响应
+//   - bool: 是否命中
+func (c *Cache) Get(key string) (string, bool) {
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil || c.expired(e.CreatedAt) {
+		_ = os.Remove(path)
+		return "", false
+	}
+
+	now := c.now()
+	_ = os.Chtimes(path, now, now)
+	return e.Response, true
+}
+
+// Put 写入响应并淘汰过期和超出大小上限的条目
+//
+// 参数:
+//   - key: 缓存键
+//   - provider: 提供商
+//   - model: 模型名称
+//   - response: LLM 响应
+//
+// 返回:
+//   - error: 如果写入失败，返回错误
+func (c *Cache) Put(key, provider, model, response string) error {
+	data, err := json.Marshal(entry{CreatedAt: c.now(), Provider: provider, Model: model, Response: response})
+	if err != nil {
+		return fmt.Errorf("编码缓存条目失败: %w", err)
+	}
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("创建缓存目录失败: %w", err)
+	}
+
+	// 先写入临时文件再重命名，避免并发读取到不完整的条目
+	tmp, err := os.CreateTemp(c.dir, key+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("写入缓存条目失败: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("写入缓存条目失败: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("写入缓存条目失败: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("写入缓存条目失败: %w", err)
+	}
+
+	return c.evict()
+}
+
+// Stats 统计缓存条目
+//
+// 返回:
+//   - Stats: 缓存统计（缓存目录不存在时条目数为 0）
+//   - error: 如果读取缓存目录失败，返回错误
+func (c *Cache) Stats() (Stats, error) {
+	stats := Stats{Dir: c.dir, TTL: c.opts.TTL, MaxSize: c.opts.MaxSize}
+
+	files, err := c.files()
+	if err != nil {
+		return stats, err
+	}
+	for _, file := range files {
+		stats.Entries++
+		stats.Size += file.size
+
+		data, err := os.ReadFile(file.path)
+		if err != nil {
+			continue
+		}
+		var e entry
+		if err := json.Unmarshal(data, &e); err != nil {
+			continue
+		}
+		if c.expired(e.CreatedAt) {
+			stats.Expired++
+		}
+		if stats.Oldest.IsZero() || e.CreatedAt.Before(stats.Oldest) {
+			stats.Oldest = e.CreatedAt
+		}
+		if e.CreatedAt.After(stats.Newest) {
+			stats.Newest = e.CreatedAt
+		}
+	}
+	return stats, nil
+}
+
+// Clear 删除所有缓存条目
+//
+// 返回:
+//   - int: 删除的条目数量
+//   - error: 如果删除失败，返回错误
+func (c *Cache) Clear() (int, error) {
+	files, err := c.files()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, file := range files {
+		if err := os.Remove(file.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return removed, fmt.Errorf("删除缓存条目失败: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// cacheFile 缓存目录中的条目文件
+type cacheFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// files 列出缓存目录中的条目文件，目录不存在时返回 nil
+func (c *Cache) files() ([]cacheFile, error) {
+	entries, err := os.ReadDir(c.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取缓存目录失败: %w", err)
+	}
+
+	var files []cacheFile
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), entryExt) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, cacheFile{
+			path:    filepath.Join(c.dir, e.Name()),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+	}
+	return files, nil
+}
+
+// evict 删除过期的条目，并在超出大小上限时从最久未使用的条目开始删除
+//
+// 条目的写入时间保存在文件内容中，这里使用文件修改时间判断过期：
+// 命中会刷新修改时间，因此按修改时间删除的条目一定已超过 TTL。
+func (c *Cache) evict() error {
+	logger := logging.GetLogger()
+
+	files, err := c.files()
+	if err != nil {
+		return err
+	}
+
+	var kept []cacheFile
+	var size int64
+	for _, file := range files {
+		if c.expired(file.modTime) {
+			_ = os.Remove(file.path)
+			continue
+		}
+		kept = append(kept, file)
+		size += file.size
+	}
+
+	if c.opts.MaxSize <= 0 || size <= c.opts.MaxSize {
+		return nil
+	}
+
+	sort.Slice(kept, func(i, j int) bool {
+		return kept[i].modTime.Before(kept[j].modTime)
+	})
+	evicted := 0
+	for _, file := range kept {
+		if size <= c.opts.MaxSize {
+			break
+		}
+		if err := os.Remove(file.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("删除缓存条目失败: %w", err)
+		}
+		size -= file.size
+		evicted++
+	}
+
+	logger.WithFields(logging.Fields{
+		"evicted":  evicted,
+		"size":     size,
+		"max_size": c.opts.MaxSize,
+	}).Debug("LLM response cache evicted entries over the size cap")
+
+	return nil
+}
+
+// expired 判断写入时间是否已超过 TTL
+func (c *Cache) expired(t time.Time) bool {
+	return c.opts.TTL > 0 && c.now().Sub(t) > c.opts.TTL
+}
+
+// path 返回缓存键对应的条目文件路径
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+entryExt)
+}
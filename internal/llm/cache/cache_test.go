@@ -0,0 +1,209 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zevwings/workflow/internal/llm/client"
+)
+
+// ==================== Key 测试 ====================
+
+func TestKey(t *testing.T) {
+	maxTokens := 100
+	base := &client.LLMRequestParams{SystemPrompt: "system", UserPrompt: "user", Temperature: 0.3}
+
+	tests := []struct {
+		name     string
+		provider string
+		model    string
+		params   *client.LLMRequestParams
+		same     bool
+	}{
+		{"相同请求", "openai", "gpt-4o", &client.LLMRequestParams{SystemPrompt: "system", UserPrompt: "user", Temperature: 0.3}, true},
+		{"params.Model 与默认模型相同", "openai", "", &client.LLMRequestParams{SystemPrompt: "system", UserPrompt: "user", Temperature: 0.3, Model: "gpt-4o"}, true},
+		{"不同 provider", "deepseek", "gpt-4o", base, false},
+		{"不同模型", "openai", "gpt-4.1", base, false},
+		{"不同 system prompt", "openai", "gpt-4o", &client.LLMRequestParams{SystemPrompt: "other", UserPrompt: "user", Temperature: 0.3}, false},
+		{"不同 user prompt", "openai", "gpt-4o", &client.LLMRequestParams{SystemPrompt: "system", UserPrompt: "other", Temperature: 0.3}, false},
+		{"不同 temperature", "openai", "gpt-4o", &client.LLMRequestParams{SystemPrompt: "system", UserPrompt: "user", Temperature: 0.5}, false},
+		{"不同 max_tokens", "openai", "gpt-4o", &client.LLMRequestParams{SystemPrompt: "system", UserPrompt: "user", Temperature: 0.3, MaxTokens: &maxTokens}, false},
+	}
+
+	want := Key("openai", "gpt-4o", base)
+	assert.Len(t, want, 64, "键应该是十六进制的 SHA-256")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Key(tt.provider, tt.model, tt.params)
+			if tt.same {
+				assert.Equal(t, want, got)
+			} else {
+				assert.NotEqual(t, want, got)
+			}
+		})
+	}
+}
+
+// ==================== Get / Put 测试 ====================
+
+func TestCache_PutGet(t *testing.T) {
+	// Arrange: 空缓存
+	c := New(t.TempDir(), Options{TTL: time.Hour})
+
+	// Act & Assert: 写入前未命中，写入后命中
+	_, ok := c.Get("key")
+	assert.False(t, ok)
+
+	require.NoError(t, c.Put("key", "openai", "gpt-4o", "response"))
+	response, ok := c.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, "response", response)
+}
+
+func TestCache_PutCreatesDir(t *testing.T) {
+	// Arrange: 缓存目录不存在
+	dir := filepath.Join(t.TempDir(), "llm")
+	c := New(dir, Options{})
+
+	// Act: 写入
+	require.NoError(t, c.Put("key", "openai", "gpt-4o", "response"))
+
+	// Assert: 目录被创建，且不留下临时文件
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "key.json", entries[0].Name())
+}
+
+func TestCache_TTL(t *testing.T) {
+	// Arrange: 写入一条条目
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New(t.TempDir(), Options{TTL: time.Hour})
+	c.now = func() time.Time { return now }
+	require.NoError(t, c.Put("key", "openai", "gpt-4o", "response"))
+
+	// Act & Assert: TTL 内命中
+	now = now.Add(30 * time.Minute)
+	_, ok := c.Get("key")
+	assert.True(t, ok)
+
+	// 超过 TTL 后未命中，且条目被删除
+	now = now.Add(time.Hour)
+	_, ok = c.Get("key")
+	assert.False(t, ok)
+	_, err := os.Stat(c.path("key"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestCache_CorruptEntry(t *testing.T) {
+	// Arrange: 条目文件内容损坏
+	c := New(t.TempDir(), Options{})
+	require.NoError(t, os.WriteFile(c.path("key"), []byte("not json"), 0644))
+
+	// Act: 读取
+	_, ok := c.Get("key")
+
+	// Assert: 未命中并删除条目
+	assert.False(t, ok)
+	_, err := os.Stat(c.path("key"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+// ==================== 淘汰测试 ====================
+
+func TestCache_EvictOverSizeCap(t *testing.T) {
+	// Arrange: 每条条目约 1 KB，上限约 2.5 KB
+	dir := t.TempDir()
+	c := New(dir, Options{MaxSize: 2500})
+	response := strings.Repeat("x", 900)
+
+	require.NoError(t, c.Put("a", "openai", "gpt-4o", response))
+	require.NoError(t, c.Put("b", "openai", "gpt-4o", response))
+	// 让 a 比 b 更早被使用
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(c.path("a"), old, old))
+	require.NoError(t, os.Chtimes(c.path("b"), old.Add(time.Minute), old.Add(time.Minute)))
+
+	// Act: 写入第三条，超出上限
+	require.NoError(t, c.Put("c", "openai", "gpt-4o", response))
+
+	// Assert: 最久未使用的 a 被淘汰
+	_, ok := c.Get("a")
+	assert.False(t, ok, "最久未使用的条目应该被淘汰")
+	_, ok = c.Get("b")
+	assert.True(t, ok)
+	_, ok = c.Get("c")
+	assert.True(t, ok)
+}
+
+func TestCache_EvictExpired(t *testing.T) {
+	// Arrange: 一条修改时间超过 TTL 的条目
+	c := New(t.TempDir(), Options{TTL: time.Hour})
+	require.NoError(t, c.Put("old", "openai", "gpt-4o", "response"))
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(c.path("old"), old, old))
+
+	// Act: 写入新的条目
+	require.NoError(t, c.Put("new", "openai", "gpt-4o", "response"))
+
+	// Assert: 过期的条目被删除
+	_, err := os.Stat(c.path("old"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+// ==================== Stats / Clear 测试 ====================
+
+func TestCache_StatsAndClear(t *testing.T) {
+	// Arrange: 两条条目，其中一条已过期
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+	c := New(dir, Options{TTL: time.Hour, MaxSize: 1024 * 1024})
+	c.now = func() time.Time { return now }
+	require.NoError(t, c.Put("a", "openai", "gpt-4o", "first"))
+	now = now.Add(2 * time.Hour)
+	require.NoError(t, c.Put("b", "openai", "gpt-4o", "second"))
+
+	// Act: 统计
+	stats, err := c.Stats()
+
+	// Assert: 统计条目数、过期数和时间范围
+	require.NoError(t, err)
+	assert.Equal(t, dir, stats.Dir)
+	assert.Equal(t, 2, stats.Entries)
+	assert.Equal(t, 1, stats.Expired)
+	assert.Greater(t, stats.Size, int64(0))
+	assert.Equal(t, now.Add(-2*time.Hour), stats.Oldest.UTC())
+	assert.Equal(t, now, stats.Newest.UTC())
+	assert.Equal(t, time.Hour, stats.TTL)
+
+	// Act: 清空
+	removed, err := c.Clear()
+
+	// Assert: 所有条目被删除
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	stats, err = c.Stats()
+	require.NoError(t, err)
+	assert.Zero(t, stats.Entries)
+}
+
+func TestCache_StatsMissingDir(t *testing.T) {
+	// Arrange: 缓存目录不存在
+	c := New(filepath.Join(t.TempDir(), "missing"), Options{})
+
+	// Act: 统计和清空
+	stats, err := c.Stats()
+	require.NoError(t, err)
+	removed, err := c.Clear()
+	require.NoError(t, err)
+
+	// Assert: 没有条目
+	assert.Zero(t, stats.Entries)
+	assert.Zero(t, removed)
+}
@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zevwings/workflow/internal/llm/client"
+	"github.com/zevwings/workflow/internal/logging"
+)
+
+// cachingClient 带响应缓存的 LLM 客户端，实现 client.LLMClient 接口
+type cachingClient struct {
+	llmClient client.LLMClient
+	cache     *Cache
+	provider  string
+	model     string
+	// refresh 为 true 时跳过读取缓存，但仍写入新的响应
+	refresh bool
+}
+
+// NewClient 为 LLM 客户端添加响应缓存
+//
+// Call 和 Stream 命中缓存时直接返回缓存的响应，不调用 API；Stream 命中时
+// 将完整的响应作为一个片段传给 onDelta。失败的请求和空响应不会被缓存。
+//
+// 参数:
+//   - llmClient: 被装饰的 LLM 客户端
+//   - cache: 响应缓存
+//   - provider: 提供商（用于缓存键）
+//   - model: 默认模型名称（用于缓存键）
+//
+// 返回:
+//   - client.LLMClient: 带响应缓存的 LLM 客户端
+func NewClient(llmClient client.LLMClient, cache *Cache, provider, model string) client.LLMClient {
+	if llmClient == nil {
+		panic(fmt.Errorf("llm/cache.NewClient: llmClient cannot be nil"))
+	}
+	if cache == nil {
+		panic(fmt.Errorf("llm/cache.NewClient: cache cannot be nil"))
+	}
+	return &cachingClient{llmClient: llmClient, cache: cache, provider: provider, model: model}
+}
+
+// Refresher 包含带响应缓存的客户端的装饰器（如按功能路由的客户端）实现的接口
+type Refresher interface {
+	// Refresh 返回跳过缓存读取的副本，通常对包含的每个客户端调用 cache.Refresh
+	Refresh() client.LLMClient
+}
+
+// Refresh 返回跳过缓存读取的客户端，用于重新生成
+//
+// 新的响应仍会写入缓存，替换之前的条目。llmClient 实现 Refresher 时调用它的
+// Refresh 方法；llmClient 没有响应缓存时原样返回。
+//
+// 参数:
+//   - llmClient: LLM 客户端
+//
+// 返回:
+//   - client.LLMClient: 跳过缓存读取的 LLM 客户端
+func Refresh(llmClient client.LLMClient) client.LLMClient {
+	switch c := llmClient.(type) {
+	case *cachingClient:
+		refreshed := *c
+		refreshed.refresh = true
+		return &refreshed
+	case Refresher:
+		return c.Refresh()
+	default:
+		return llmClient
+	}
+}
+
+// Call 调用 LLM API，命中缓存时返回缓存的响应
+func (c *cachingClient) Call(params *client.LLMRequestParams) (string, error) {
+	key := Key(c.provider, c.model, params)
+	if response, ok := c.lookup(key); ok {
+		return response, nil
+	}
+
+	response, err := c.llmClient.Call(params)
+	if err != nil {
+		return "", err
+	}
+	c.store(key, params, response)
+	return response, nil
+}
+
+// Stream 以流式方式调用 LLM API，命中缓存时将缓存的响应一次性传给 onDelta
+func (c *cachingClient) Stream(ctx context.Context, params *client.LLMRequestParams, onDelta func(delta string)) (string, error) {
+	key := Key(c.provider, c.model, params)
+	if response, ok := c.lookup(key); ok {
+		if onDelta != nil {
+			onDelta(response)
+		}
+		return response, nil
+	}
+
+	response, err := c.llmClient.Stream(ctx, params, onDelta)
+	if err != nil {
+		return "", err
+	}
+	c.store(key, params, response)
+	return response, nil
+}
+
+// lookup 读取缓存（refresh 时跳过）
+func (c *cachingClient) lookup(key string) (string, bool) {
+	if c.refresh {
+		return "", false
+	}
+	response, ok := c.cache.Get(key)
+	if ok {
+		logging.GetLogger().WithField("key", key).Debug("LLM response cache hit")
+	}
+	return response, ok
+}
+
+// store 写入缓存，写入失败只记录日志，不影响本次调用
+func (c *cachingClient) store(key string, params *client.LLMRequestParams, response string) {
+	if response == "" {
+		return
+	}
+	model := c.model
+	if params.Model != "" {
+		model = params.Model
+	}
+	if err := c.cache.Put(key, c.provider, model, response); err != nil {
+		logging.GetLogger().WithError(err).Warn("Failed to write LLM response cache")
+	}
+}
@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zevwings/workflow/internal/llm/client"
+)
+
+// fakeClient 记录调用次数的 LLM 客户端
+type fakeClient struct {
+	calls    int
+	response string
+	err      error
+}
+
+func (f *fakeClient) Call(params *client.LLMRequestParams) (string, error) {
+	f.calls++
+	return f.response, f.err
+}
+
+func (f *fakeClient) Stream(ctx context.Context, params *client.LLMRequestParams, onDelta func(delta string)) (string, error) {
+	f.calls++
+	if f.err == nil && onDelta != nil {
+		onDelta(f.response)
+	}
+	return f.response, f.err
+}
+
+func testParams() *client.LLMRequestParams {
+	return &client.LLMRequestParams{SystemPrompt: "system", UserPrompt: "user", Temperature: 0.3}
+}
+
+// ==================== NewClient 测试 ====================
+
+func TestClient_Call(t *testing.T) {
+	// Arrange: 带缓存的客户端
+	inner := &fakeClient{response: "response"}
+	llmClient := NewClient(inner, New(t.TempDir(), Options{}), "openai", "gpt-4o")
+
+	// Act: 两次相同的请求
+	first, err := llmClient.Call(testParams())
+	require.NoError(t, err)
+	second, err := llmClient.Call(testParams())
+	require.NoError(t, err)
+
+	// Assert: 只调用一次 API
+	assert.Equal(t, "response", first)
+	assert.Equal(t, "response", second)
+	assert.Equal(t, 1, inner.calls)
+}
+
+func TestClient_Stream(t *testing.T) {
+	// Arrange: 带缓存的客户端
+	inner := &fakeClient{response: "response"}
+	llmClient := NewClient(inner, New(t.TempDir(), Options{}), "openai", "gpt-4o")
+
+	// Act: 先流式请求写入缓存，再流式请求命中缓存
+	_, err := llmClient.Stream(context.Background(), testParams(), nil)
+	require.NoError(t, err)
+
+	var deltas []string
+	response, err := llmClient.Stream(context.Background(), testParams(), func(delta string) {
+		deltas = append(deltas, delta)
+	})
+
+	// Assert: 命中时整个响应作为一个片段传给 onDelta
+	require.NoError(t, err)
+	assert.Equal(t, "response", response)
+	assert.Equal(t, []string{"response"}, deltas)
+	assert.Equal(t, 1, inner.calls)
+}
+
+func TestClient_SharedBetweenCallAndStream(t *testing.T) {
+	// Arrange: 带缓存的客户端
+	inner := &fakeClient{response: "response"}
+	llmClient := NewClient(inner, New(t.TempDir(), Options{}), "openai", "gpt-4o")
+
+	// Act: Call 写入的响应可以被 Stream 读取
+	_, err := llmClient.Call(testParams())
+	require.NoError(t, err)
+	_, err = llmClient.Stream(context.Background(), testParams(), nil)
+	require.NoError(t, err)
+
+	// Assert: 只调用一次 API
+	assert.Equal(t, 1, inner.calls)
+}
+
+func TestClient_ErrorsNotCached(t *testing.T) {
+	// Arrange: 第一次调用失败
+	inner := &fakeClient{err: errors.New("api error")}
+	llmClient := NewClient(inner, New(t.TempDir(), Options{}), "openai", "gpt-4o")
+
+	// Act: 失败后重试
+	_, err := llmClient.Call(testParams())
+	require.Error(t, err)
+	inner.err, inner.response = nil, "response"
+	response, err := llmClient.Call(testParams())
+
+	// Assert: 失败不会被缓存
+	require.NoError(t, err)
+	assert.Equal(t, "response", response)
+	assert.Equal(t, 2, inner.calls)
+}
+
+func TestClient_EmptyResponseNotCached(t *testing.T) {
+	// Arrange: 返回空响应
+	inner := &fakeClient{}
+	llmClient := NewClient(inner, New(t.TempDir(), Options{}), "openai", "gpt-4o")
+
+	// Act: 两次相同的请求
+	_, _ = llmClient.Call(testParams())
+	_, _ = llmClient.Call(testParams())
+
+	// Assert: 空响应不会被缓存
+	assert.Equal(t, 2, inner.calls)
+}
+
+func TestNewClient_NilPanics(t *testing.T) {
+	assert.Panics(t, func() { NewClient(nil, New(t.TempDir(), Options{}), "openai", "gpt-4o") })
+	assert.Panics(t, func() { NewClient(&fakeClient{}, nil, "openai", "gpt-4o") })
+}
+
+// ==================== Refresh 测试 ====================
+
+func TestRefresh(t *testing.T) {
+	// Arrange: 缓存中已有响应
+	inner := &fakeClient{response: "first"}
+	llmClient := NewClient(inner, New(t.TempDir(), Options{}), "openai", "gpt-4o")
+	_, err := llmClient.Call(testParams())
+	require.NoError(t, err)
+
+	// Act: 跳过缓存重新生成
+	inner.response = "second"
+	refreshed, err := Refresh(llmClient).Call(testParams())
+	require.NoError(t, err)
+
+	// Assert: 调用 API，新的响应替换缓存中的条目
+	assert.Equal(t, "second", refreshed)
+	assert.Equal(t, 2, inner.calls)
+	cached, err := llmClient.Call(testParams())
+	require.NoError(t, err)
+	assert.Equal(t, "second", cached)
+	assert.Equal(t, 2, inner.calls)
+}
+
+func TestRefresh_WithoutCache(t *testing.T) {
+	// 没有响应缓存的客户端原样返回
+	inner := &fakeClient{}
+	assert.Same(t, inner, Refresh(inner))
+}
@@ -0,0 +1,166 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/zevwings/workflow/internal/logging"
+)
+
+const (
+	// anthropicVersion Anthropic API version sent in the anthropic-version header
+	anthropicVersion = "2023-06-01"
+	// anthropicDefaultMaxTokens max_tokens used when the request does not set one (required by the Messages API)
+	anthropicDefaultMaxTokens = 4096
+	// anthropicMaxTemperature maximum temperature accepted by the Messages API
+	anthropicMaxTemperature = 1.0
+)
+
+// buildAnthropicHeaders builds request headers for the Anthropic Messages API
+//
+// Returns:
+//   - map[string]string: Request header map
+//   - error: Returns error if API key is not configured
+func (c *llmClient) buildAnthropicHeaders() (map[string]string, error) {
+	if c.config.APIKey == "" {
+		return nil, fmt.Errorf("LLM API key not configured")
+	}
+
+	return map[string]string{
+		"x-api-key":         c.config.APIKey,
+		"anthropic-version": anthropicVersion,
+		"Content-Type":      "application/json",
+	}, nil
+}
+
+// buildAnthropicPayload builds request body for the Anthropic Messages API
+//
+// The system prompt is a top-level field instead of a message, the user prompt
+// is sent as a text content block, and max_tokens is required.
+//
+// Parameters:
+//   - model: Model name
+//   - params: LLM request parameters
+//
+// Returns:
+//   - map[string]interface{}: Request body data
+func buildAnthropicPayload(model string, params *LLMRequestParams) map[string]interface{} {
+	maxTokens := anthropicDefaultMaxTokens
+	if params.MaxTokens != nil {
+		maxTokens = *params.MaxTokens
+	}
+
+	temperature := params.Temperature
+	if temperature > anthropicMaxTemperature {
+		temperature = anthropicMaxTemperature
+	}
+
+	payload := map[string]interface{}{
+		"model":  model,
+		"stream": false,
+		"messages": []map[string]interface{}{
+			{
+				"role": "user",
+				"content": []map[string]interface{}{
+					{"type": "text", "text": params.UserPrompt},
+				},
+			},
+		},
+		"max_tokens":  maxTokens,
+		"temperature": temperature,
+	}
+	if params.SystemPrompt != "" {
+		payload["system"] = params.SystemPrompt
+	}
+
+	return payload
+}
+
+// extractAnthropicContent extracts content from an Anthropic Messages API response
+//
+// The text of all "text" content blocks is joined.
+//
+// Parameters:
+//   - response: JSON response data
+//
+// Returns:
+//   - string: Extracted content (trimmed of leading and trailing whitespace)
+//   - Usage: Token usage of the response
+//   - error: Returns error if response format is incorrect or content is empty
+func extractAnthropicContent(response map[string]interface{}) (string, Usage, error) {
+	logger := logging.GetLogger()
+
+	jsonBytes, err := json.Marshal(response)
+	if err != nil {
+		logger.WithError(err).Error("Failed to serialize LLM response to JSON string")
+		return "", Usage{}, fmt.Errorf("failed to serialize response to JSON string: %w", err)
+	}
+
+	var message MessagesResponse
+	if err := json.Unmarshal(jsonBytes, &message); err != nil {
+		logger.WithError(err).Error("Failed to parse LLM response as Anthropic Messages format")
+		return "", Usage{}, fmt.Errorf("failed to parse response as Anthropic Messages format: %w", err)
+	}
+
+	var content strings.Builder
+	for _, block := range message.Content {
+		if block.Type == "text" {
+			content.WriteString(block.Text)
+		}
+	}
+
+	result := strings.TrimSpace(content.String())
+	if result == "" {
+		logger.WithField("response", response).Error("LLM response content is empty string")
+		return "", Usage{}, fmt.Errorf("response content is empty string")
+	}
+
+	return result, message.Usage.toUsage(), nil
+}
+
+// decodeAnthropicEvent decodes an event of an Anthropic Messages API stream
+//
+// Parameters:
+//   - event: SSE event name
+//   - payload: SSE event data
+//   - usage: Token usage, updated from message_start (input tokens) and message_delta (output tokens) events
+//
+// Returns:
+//   - string: Generated text carried by the event
+//   - bool: Whether the stream has ended
+//   - error: Returns error if the event is an error or cannot be parsed
+func decodeAnthropicEvent(event, payload string, usage *Usage) (string, bool, error) {
+	var data MessagesStreamEvent
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return "", false, fmt.Errorf("failed to parse stream event %q: %w", payload, err)
+	}
+	if event == "" {
+		event = data.Type
+	}
+
+	switch event {
+	case "error":
+		message := payload
+		if data.Error != nil && data.Error.Message != "" {
+			message = data.Error.Message
+		}
+		return "", false, fmt.Errorf("LLM API stream error: %s", message)
+	case "message_stop":
+		return "", true, nil
+	case "message_start":
+		if data.Message != nil {
+			*usage = data.Message.Usage.toUsage()
+		}
+	case "message_delta":
+		if data.Usage != nil {
+			usage.CompletionTokens = data.Usage.OutputTokens
+			usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+		}
+	case "content_block_delta":
+		if data.Delta != nil && data.Delta.Type == "text_delta" {
+			return data.Delta.Text, false, nil
+		}
+	}
+	return "", false, nil
+}
@@ -0,0 +1,230 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// anthropicEvent 生成 Anthropic Messages API 的 SSE 事件
+func anthropicEvent(event string, data interface{}) string {
+	payload, _ := json.Marshal(data)
+	return fmt.Sprintf("event: %s\ndata: %s\n\n", event, payload)
+}
+
+// anthropicTextDelta 生成包含文本增量的 content_block_delta 事件
+func anthropicTextDelta(text string) string {
+	return anthropicEvent("content_block_delta", map[string]interface{}{
+		"type":  "content_block_delta",
+		"index": 0,
+		"delta": map[string]interface{}{"type": "text_delta", "text": text},
+	})
+}
+
+// ==================== Anthropic Call 测试 ====================
+
+func TestLLMClient_Call_Anthropic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 验证请求路径和请求头
+		assert.Equal(t, "/v1/messages", r.URL.Path)
+		assert.Equal(t, "sk-ant-key", r.Header.Get("x-api-key"))
+		assert.Equal(t, anthropicVersion, r.Header.Get("anthropic-version"))
+		assert.Empty(t, r.Header.Get("Authorization"))
+
+		// 验证请求体：system 为顶层字段，用户消息为内容块，max_tokens 必填
+		var payload map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "claude-3-5-haiku-latest", payload["model"])
+		assert.Equal(t, "You are a helpful assistant.", payload["system"])
+		assert.Equal(t, float64(anthropicDefaultMaxTokens), payload["max_tokens"])
+		assert.Equal(t, float64(1), payload["temperature"])
+		messages := payload["messages"].([]interface{})
+		require.Len(t, messages, 1)
+		message := messages[0].(map[string]interface{})
+		assert.Equal(t, "user", message["role"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"type": "text", "text": "What is Go?"},
+		}, message["content"])
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"id": "msg_123",
+			"type": "message",
+			"role": "assistant",
+			"model": "claude-3-5-haiku-latest",
+			"content": [
+				{"type": "text", "text": "Go is a programming language."},
+				{"type": "text", "text": " It is fast."}
+			],
+			"stop_reason": "end_turn",
+			"usage": {"input_tokens": 12, "output_tokens": 9}
+		}`)
+	}))
+	defer server.Close()
+
+	client := newClient(&ProviderConfig{
+		Provider: ProviderAnthropic,
+		APIKey:   "sk-ant-key",
+		Model:    "claude-3-5-haiku-latest",
+		URL:      server.URL + "/v1",
+	})
+
+	content, usage, err := client.(UsageReporter).CallWithUsage(&LLMRequestParams{
+		SystemPrompt: "You are a helpful assistant.",
+		UserPrompt:   "What is Go?",
+		Temperature:  1.5, // 超出 Anthropic 的范围，应被限制为 1
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Go is a programming language. It is fast.", content)
+	assert.Equal(t, Usage{PromptTokens: 12, CompletionTokens: 9, TotalTokens: 21}, usage)
+}
+
+func TestLLMClient_Call_Anthropic_MaxTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, float64(100), payload["max_tokens"])
+		_, hasSystem := payload["system"]
+		assert.False(t, hasSystem, "空的 system prompt 不应发送")
+
+		fmt.Fprint(w, `{"type": "message", "content": [{"type": "text", "text": "ok"}]}`)
+	}))
+	defer server.Close()
+
+	client := newClient(&ProviderConfig{Provider: ProviderAnthropic, APIKey: "sk-ant-key", Model: "claude", URL: server.URL})
+
+	maxTokens := 100
+	content, err := client.Call(&LLMRequestParams{UserPrompt: "hi", MaxTokens: &maxTokens})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", content)
+}
+
+func TestLLMClient_Call_Anthropic_Errors(t *testing.T) {
+	t.Run("API 错误", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"type": "error", "error": {"type": "authentication_error", "message": "invalid x-api-key"}}`)
+		}))
+		defer server.Close()
+
+		client := newClient(&ProviderConfig{Provider: ProviderAnthropic, APIKey: "bad-key", Model: "claude", URL: server.URL})
+
+		_, err := client.Call(&LLMRequestParams{UserPrompt: "hi"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid x-api-key")
+	})
+
+	t.Run("没有文本内容", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"type": "message", "content": []}`)
+		}))
+		defer server.Close()
+
+		client := newClient(&ProviderConfig{Provider: ProviderAnthropic, APIKey: "sk-ant-key", Model: "claude", URL: server.URL})
+
+		_, err := client.Call(&LLMRequestParams{UserPrompt: "hi"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "response content is empty string")
+	})
+
+	t.Run("未配置 API 密钥", func(t *testing.T) {
+		client := newClient(&ProviderConfig{Provider: ProviderAnthropic, Model: "claude", URL: "http://127.0.0.1:1"})
+
+		_, err := client.Call(&LLMRequestParams{UserPrompt: "hi"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "LLM API key not configured")
+	})
+}
+
+// ==================== Anthropic Stream 测试 ====================
+
+func TestLLMClient_Stream_Anthropic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/messages", r.URL.Path)
+		var payload map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, true, payload["stream"])
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, anthropicEvent("message_start", map[string]interface{}{"type": "message_start", "message": map[string]interface{}{"id": "msg_123", "usage": map[string]interface{}{"input_tokens": 20, "output_tokens": 1}}}))
+		fmt.Fprint(w, anthropicEvent("content_block_start", map[string]interface{}{"type": "content_block_start", "index": 0, "content_block": map[string]interface{}{"type": "text", "text": ""}}))
+		fmt.Fprint(w, anthropicEvent("ping", map[string]interface{}{"type": "ping"}))
+		fmt.Fprint(w, anthropicTextDelta("Go is"))
+		fmt.Fprint(w, anthropicTextDelta(" fast"))
+		fmt.Fprint(w, anthropicEvent("content_block_stop", map[string]interface{}{"type": "content_block_stop", "index": 0}))
+		fmt.Fprint(w, anthropicEvent("message_delta", map[string]interface{}{"type": "message_delta", "delta": map[string]interface{}{"stop_reason": "end_turn"}, "usage": map[string]interface{}{"output_tokens": 4}}))
+		fmt.Fprint(w, anthropicEvent("message_stop", map[string]interface{}{"type": "message_stop"}))
+		fmt.Fprint(w, anthropicTextDelta("ignored after stop"))
+	}))
+	defer server.Close()
+
+	client := newClient(&ProviderConfig{Provider: ProviderAnthropic, APIKey: "sk-ant-key", Model: "claude", URL: server.URL})
+
+	var deltas []string
+	content, usage, err := client.(UsageReporter).StreamWithUsage(context.Background(), &LLMRequestParams{UserPrompt: "What is Go?"}, func(delta string) {
+		deltas = append(deltas, delta)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Go is fast", content)
+	assert.Equal(t, []string{"Go is", " fast"}, deltas)
+	assert.Equal(t, Usage{PromptTokens: 20, CompletionTokens: 4, TotalTokens: 24}, usage)
+}
+
+func TestDecodeAnthropicEvent_Error(t *testing.T) {
+	stream := anthropicTextDelta("partial") +
+		anthropicEvent("error", map[string]interface{}{
+			"type":  "error",
+			"error": map[string]interface{}{"type": "overloaded_error", "message": "Overloaded"},
+		})
+
+	_, _, err := readStream(strings.NewReader(stream), decodeAnthropicEvent, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "LLM API stream error: Overloaded")
+}
+
+// ==================== Ollama 测试 ====================
+
+func TestLLMClient_Call_Ollama(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Ollama 使用 OpenAI 兼容 API，不需要 API 密钥
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Empty(t, r.Header.Get("Authorization"))
+
+		var payload map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "llama3.2", payload["model"])
+
+		fmt.Fprint(w, `{"choices": [{"index": 0, "message": {"role": "assistant", "content": "Hello from Ollama"}, "finish_reason": "stop"}]}`)
+	}))
+	defer server.Close()
+
+	client := newClient(&ProviderConfig{Provider: ProviderOllama, Model: "llama3.2", URL: server.URL + "/v1"})
+
+	content, err := client.Call(&LLMRequestParams{SystemPrompt: "You are a helpful assistant.", UserPrompt: "Say hello"})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello from Ollama", content)
+}
+
+func TestLLMClient_Stream_Ollama(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		fmt.Fprint(w, sseChunk("Hello"))
+		fmt.Fprint(w, sseChunk(" from Ollama"))
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	client := newClient(&ProviderConfig{Provider: ProviderOllama, Model: "llama3.2", URL: server.URL})
+
+	content, err := client.Stream(context.Background(), &LLMRequestParams{UserPrompt: "Say hello"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello from Ollama", content)
+}
@@ -1,4 +1,4 @@
-// Package client provides unified configuration-driven LLM client implementation, supporting OpenAI, DeepSeek, and proxy APIs.
+// Package client provides unified configuration-driven LLM client implementation, supporting OpenAI, DeepSeek, Anthropic, Ollama, and proxy APIs.
 //
 // Main features:
 //   - LLMClient: LLM client supporting multiple providers
@@ -42,6 +42,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -72,6 +73,40 @@ type LLMClient interface {
 	//   - string: LLM generated text content (trimmed of leading and trailing whitespace)
 	//   - error: Returns corresponding error message if API call fails or response format is incorrect
 	Call(params *LLMRequestParams) (string, error)
+
+	// Stream calls LLM API with a streaming response
+	//
+	// Parameters:
+	//   - ctx: Request context, cancelling it aborts the request and the stream
+	//   - params: LLM request parameters
+	//   - onDelta: Called with each piece of generated text as it arrives (can be nil)
+	//
+	// Returns:
+	//   - string: Complete generated text (trimmed of leading and trailing whitespace)
+	//   - error: Returns error if API call fails, the stream reports an error, or ctx is cancelled
+	Stream(ctx context.Context, params *LLMRequestParams, onDelta func(delta string)) (string, error)
+}
+
+// UsageReporter is implemented by LLM clients that report the token usage of their calls
+//
+// Decorators that record usage use it when the wrapped client implements it,
+// and estimate the token counts otherwise.
+type UsageReporter interface {
+	// CallWithUsage calls LLM API like Call
+	//
+	// Returns:
+	//   - string: LLM generated text content
+	//   - Usage: Token usage reported by the API (zero if the API does not report it)
+	//   - error: Same errors as Call
+	CallWithUsage(params *LLMRequestParams) (string, Usage, error)
+
+	// StreamWithUsage calls LLM API with a streaming response like Stream
+	//
+	// Returns:
+	//   - string: Complete generated text
+	//   - Usage: Token usage reported in the stream (zero if the API does not report it)
+	//   - error: Same errors as Stream
+	StreamWithUsage(ctx context.Context, params *LLMRequestParams, onDelta func(delta string)) (string, Usage, error)
 }
 
 // llmClient LLM client implementation
@@ -102,6 +137,24 @@ func newClient(config *ProviderConfig) LLMClient {
 	}
 }
 
+// New creates an LLM client that is not shared
+//
+// Unlike Global, every call returns a new client. Used when requests are served by
+// several providers, e.g. a fallback chain or per-feature routing.
+// Automatically uses http.Global() to get global HTTP client.
+//
+// Parameters:
+//   - config: LLM configuration struct (cannot be nil)
+//
+// Returns:
+//   - LLMClient: LLM client instance
+func New(config *ProviderConfig) LLMClient {
+	if config == nil {
+		panic(fmt.Errorf("llm/client.New: config cannot be nil"))
+	}
+	return newClient(config)
+}
+
 // Global gets global LLMClient singleton
 //
 // Returns process-level LLMClient singleton.
@@ -145,13 +198,27 @@ func Global(config *ProviderConfig) LLMClient {
 //   - string: LLM generated text content (trimmed of leading and trailing whitespace)
 //   - error: Returns corresponding error message if API call fails or response format is incorrect
 func (c *llmClient) Call(params *LLMRequestParams) (string, error) {
+	content, _, err := c.CallWithUsage(params)
+	return content, err
+}
+
+// CallWithUsage calls LLM API and returns the token usage reported by the API
+//
+// Parameters:
+//   - params: LLM request parameters
+//
+// Returns:
+//   - string: LLM generated text content (trimmed of leading and trailing whitespace)
+//   - Usage: Token usage from the "usage" field of the response
+//   - error: Returns corresponding error message if API call fails or response format is incorrect
+func (c *llmClient) CallWithUsage(params *LLMRequestParams) (string, Usage, error) {
 	logger := logging.GetLogger()
 
 	// Build URL (unified format)
 	url, err := c.buildURL()
 	if err != nil {
 		logger.WithError(err).Error("Failed to build LLM API URL: URL not configured")
-		return "", fmt.Errorf("failed to build URL: %w", err)
+		return "", Usage{}, fmt.Errorf("failed to build URL: %w", err)
 	}
 
 	// Record LLM API call start
@@ -164,14 +231,14 @@ func (c *llmClient) Call(params *LLMRequestParams) (string, error) {
 	payload, err := c.buildPayload(params)
 	if err != nil {
 		logger.WithError(err).Error("Failed to build LLM request payload")
-		return "", fmt.Errorf("failed to build request body: %w", err)
+		return "", Usage{}, fmt.Errorf("failed to build request body: %w", err)
 	}
 
 	// Build request headers (unified format)
 	headers, err := c.buildHeaders()
 	if err != nil {
 		logger.WithError(err).Error("Failed to build LLM request headers: API key not configured")
-		return "", fmt.Errorf("failed to build request headers: %w", err)
+		return "", Usage{}, fmt.Errorf("failed to build request headers: %w", err)
 	}
 
 	// Record request parameter details (Debug level)
@@ -210,7 +277,7 @@ func (c *llmClient) Call(params *LLMRequestParams) (string, error) {
 	resp, err := c.httpClient.PostWithConfig(url, reqConfig)
 	if err != nil {
 		logger.WithError(err).WithField("url", url).Error("LLM HTTP request failed")
-		return "", fmt.Errorf("failed to send LLM request to %s: %w", url, err)
+		return "", Usage{}, &RequestError{URL: url, Err: err}
 	}
 
 	// Check error (use EnsureSuccessWith for unified handling)
@@ -218,10 +285,10 @@ func (c *llmClient) Call(params *LLMRequestParams) (string, error) {
 		errorMessage := r.ExtractErrorMessage()
 		logger.Warnf("LLM API returned error status: url=%s, status=%d, error=%s",
 			url, r.Status, errorMessage)
-		return fmt.Errorf("LLM API request failed (%s): %d - %s", url, r.Status, errorMessage)
+		return &RequestError{URL: url, Status: r.Status, Message: errorMessage}
 	})
 	if err != nil {
-		return "", err
+		return "", Usage{}, err
 	}
 
 	// Parse JSON response
@@ -229,14 +296,14 @@ func (c *llmClient) Call(params *LLMRequestParams) (string, error) {
 	data, err = http.AsJSON[map[string]interface{}](resp)
 	if err != nil {
 		logger.WithError(err).Error("Failed to parse LLM response as JSON")
-		return "", fmt.Errorf("failed to parse response JSON: %w", err)
+		return "", Usage{}, fmt.Errorf("failed to parse response JSON: %w", err)
 	}
 
 	// Extract content based on configured response format
-	content, err := c.extractContent(data)
+	content, usage, err := c.extractContent(data)
 	if err != nil {
 		logger.WithError(err).Error("Failed to extract content from LLM response")
-		return "", fmt.Errorf("failed to extract response content: %w", err)
+		return "", Usage{}, fmt.Errorf("failed to extract response content: %w", err)
 	}
 
 	// Record response content summary (Debug level)
@@ -248,17 +315,20 @@ func (c *llmClient) Call(params *LLMRequestParams) (string, error) {
 
 	// Record LLM API call success
 	logger.WithFields(logging.Fields{
-		"model":          c.config.Model,
-		"url":            url,
-		"content_length": len(content),
+		"model":             c.config.Model,
+		"url":               url,
+		"content_length":    len(content),
+		"prompt_tokens":     usage.PromptTokens,
+		"completion_tokens": usage.CompletionTokens,
 	}).Info("LLM API call succeeded")
 
-	return content, nil
+	return content, usage, nil
 }
 
 // buildURL builds API URL
 //
-// Gets URL directly from configuration struct.
+// Gets URL directly from configuration struct. Anthropic uses the Messages
+// endpoint, all other providers use the OpenAI Chat Completions endpoint.
 //
 // Returns:
 //   - string: API URL
@@ -268,24 +338,36 @@ func (c *llmClient) buildURL() (string, error) {
 	if url == "" {
 		return "", fmt.Errorf("URL not configured")
 	}
+	if c.config.isAnthropic() {
+		return fmt.Sprintf("%s/messages", url), nil
+	}
 	return fmt.Sprintf("%s/chat/completions", url), nil
 }
 
 // buildHeaders builds request headers
 //
+// Providers that do not require an API key (Ollama) only send the
+// Authorization header when a key is configured.
+//
 // Returns:
 //   - map[string]string: Request header map
 //   - error: Returns error if API key is not configured
 func (c *llmClient) buildHeaders() (map[string]string, error) {
+	if c.config.isAnthropic() {
+		return c.buildAnthropicHeaders()
+	}
+
 	headers := make(map[string]string)
 
 	var apiKey string = c.config.APIKey
 
-	if apiKey == "" {
+	if apiKey == "" && c.config.requiresAPIKey() {
 		return nil, fmt.Errorf("LLM API key not configured")
 	}
 
-	headers["Authorization"] = fmt.Sprintf("Bearer %s", apiKey)
+	if apiKey != "" {
+		headers["Authorization"] = fmt.Sprintf("Bearer %s", apiKey)
+	}
 	headers["Content-Type"] = "application/json"
 
 	return headers, nil
@@ -325,6 +407,10 @@ func (c *llmClient) buildPayload(params *LLMRequestParams) (map[string]interface
 		model = params.Model
 	}
 
+	if c.config.isAnthropic() {
+		return buildAnthropicPayload(model, params), nil
+	}
+
 	payload := map[string]interface{}{
 		"model":  model,
 		"stream": false, // Explicitly set to false to avoid proxy server using streaming response causing timeout
@@ -347,21 +433,32 @@ func (c *llmClient) buildPayload(params *LLMRequestParams) (map[string]interface
 		payload["max_tokens"] = *params.MaxTokens
 	}
 
+	// Ask for a JSON response if the provider supports response_format
+	if format := c.config.responseFormat(params.ResponseFormat); format != nil {
+		payload["response_format"] = format
+	}
+
 	return payload, nil
 }
 
 // extractContent extracts content from response
 //
 // Uses OpenAI standard format to parse response and extract message content.
-// Supports all response formats that follow OpenAI Chat Completions API standard.
+// Supports all response formats that follow OpenAI Chat Completions API standard;
+// Anthropic responses are parsed as Messages API content blocks.
 //
 // Parameters:
 //   - response: JSON response data (map[string]interface{})
 //
 // Returns:
 //   - string: Extracted content (trimmed of leading and trailing whitespace)
+//   - Usage: Token usage of the response
 //   - error: Returns error if response format is incorrect or content is empty
-func (c *llmClient) extractContent(response map[string]interface{}) (string, error) {
+func (c *llmClient) extractContent(response map[string]interface{}) (string, Usage, error) {
+	if c.config.isAnthropic() {
+		return extractAnthropicContent(response)
+	}
+
 	logger := logging.GetLogger()
 
 	// Parse as standard struct
@@ -369,32 +466,32 @@ func (c *llmClient) extractContent(response map[string]interface{}) (string, err
 	jsonBytes, err := json.Marshal(response)
 	if err != nil {
 		logger.WithError(err).Error("Failed to serialize LLM response to JSON string")
-		return "", fmt.Errorf("failed to serialize response to JSON string: %w", err)
+		return "", Usage{}, fmt.Errorf("failed to serialize response to JSON string: %w", err)
 	}
 
 	var completion ChatCompletionResponse
 	if err := json.Unmarshal(jsonBytes, &completion); err != nil {
 		logger.WithError(err).Error("Failed to parse LLM response as OpenAI ChatCompletion format")
-		return "", fmt.Errorf("failed to parse response as OpenAI ChatCompletion format: %w", err)
+		return "", Usage{}, fmt.Errorf("failed to parse response as OpenAI ChatCompletion format: %w", err)
 	}
 
 	// Extract content
 	if len(completion.Choices) == 0 {
 		logger.WithField("response", response).Error("LLM response has no choices")
-		return "", fmt.Errorf("response has no choices array or array is empty")
+		return "", Usage{}, fmt.Errorf("response has no choices array or array is empty")
 	}
 
 	choice := completion.Choices[0]
 	if choice.Message.Content == nil {
 		logger.WithField("choice", choice).Error("LLM response content is empty")
-		return "", fmt.Errorf("response content is empty")
+		return "", Usage{}, fmt.Errorf("response content is empty")
 	}
 
 	content := strings.TrimSpace(*choice.Message.Content)
 	if content == "" {
 		logger.WithField("choice", choice).Error("LLM response content is empty string")
-		return "", fmt.Errorf("response content is empty string")
+		return "", Usage{}, fmt.Errorf("response content is empty string")
 	}
 
-	return content, nil
+	return content, completion.Usage, nil
 }
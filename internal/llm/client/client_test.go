@@ -2,6 +2,7 @@ package client
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -240,6 +241,43 @@ func TestLLMClient_Call_WithCustomModel(t *testing.T) {
 	assert.Equal(t, "Response", response)
 }
 
+func TestLLMClient_Call_ResponseFormat(t *testing.T) {
+	format := &ResponseFormat{
+		Name:   "pr_summary",
+		Schema: map[string]interface{}{"type": "object"},
+	}
+
+	tests := []struct {
+		provider string
+		want     interface{}
+	}{
+		{ProviderOpenAI, map[string]interface{}{
+			"type":        "json_schema",
+			"json_schema": map[string]interface{}{"name": "pr_summary", "schema": map[string]interface{}{"type": "object"}},
+		}},
+		{ProviderDeepSeek, map[string]interface{}{"type": "json_object"}},
+		{ProviderOllama, map[string]interface{}{"type": "json_object"}},
+		{"proxy", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			var payload map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+				fmt.Fprint(w, `{"choices": [{"index": 0, "message": {"role": "assistant", "content": "{}"}, "finish_reason": "stop"}]}`)
+			}))
+			defer server.Close()
+
+			client := newClient(&ProviderConfig{Provider: tt.provider, APIKey: "test-api-key", Model: "gpt-4o-mini", URL: server.URL})
+
+			_, err := client.Call(&LLMRequestParams{UserPrompt: "Summarize", ResponseFormat: format})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, payload["response_format"])
+		})
+	}
+}
+
 func TestLLMClient_Call_EmptyResponse(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response := map[string]interface{}{
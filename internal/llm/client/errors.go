@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// RequestError error of an LLM API request that failed before a response was generated
+//
+// Either the request could not be sent (Err is set) or the API responded with an
+// error status (Status is set).
+type RequestError struct {
+	// URL request URL
+	URL string
+	// Status HTTP status code (0 if no response was received)
+	Status int
+	// Message error message extracted from the response body
+	Message string
+	// Err underlying error if the request could not be sent
+	Err error
+}
+
+func (e *RequestError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("failed to send LLM request to %s: %v", e.URL, e.Err)
+	}
+	return fmt.Sprintf("LLM API request failed (%s): %d - %s", e.URL, e.Status, e.Message)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// IsTransient reports whether err is a failure that another provider may not have
+//
+// Server errors (5xx), rate limits (429), timeouts and requests that could not
+// reach the API are transient. Client errors such as an invalid API key,
+// malformed responses and cancelled requests are not.
+//
+// Parameters:
+//   - err: Error returned by LLMClient.Call or LLMClient.Stream
+//
+// Returns:
+//   - bool: Whether the request can be retried with another provider
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var requestErr *RequestError
+	if !errors.As(err, &requestErr) {
+		return false
+	}
+	if requestErr.Err != nil {
+		// The API could not be reached (timeout, refused connection, DNS failure)
+		return true
+	}
+	return requestErr.Status >= http.StatusInternalServerError || requestErr.Status == http.StatusTooManyRequests
+}
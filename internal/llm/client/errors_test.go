@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==================== IsTransient 测试 ====================
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"服务器错误", &RequestError{Status: http.StatusServiceUnavailable}, true},
+		{"限流", &RequestError{Status: http.StatusTooManyRequests}, true},
+		{"客户端错误", &RequestError{Status: http.StatusUnauthorized}, false},
+		{"无法连接", &RequestError{Err: errors.New("dial tcp: connection refused")}, true},
+		{"包装的错误", fmt.Errorf("failed: %w", &RequestError{Status: http.StatusBadGateway}), true},
+		{"超时", context.DeadlineExceeded, true},
+		{"取消", &RequestError{Err: context.Canceled}, false},
+		{"响应格式错误", errors.New("failed to extract response content"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsTransient(tt.err))
+		})
+	}
+}
+
+func TestLLMClient_Stream_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"error": {"message": "Rate limit reached"}}`)
+	}))
+	defer server.Close()
+
+	client := newClient(&ProviderConfig{APIKey: "test-api-key", Model: "gpt-3.5-turbo", URL: server.URL})
+
+	_, err := client.Stream(context.Background(), &LLMRequestParams{UserPrompt: "hi"}, nil)
+
+	require.Error(t, err)
+	var requestErr *RequestError
+	require.ErrorAs(t, err, &requestErr)
+	assert.Equal(t, http.StatusTooManyRequests, requestErr.Status)
+	assert.Equal(t, "Rate limit reached", requestErr.Message)
+	assert.True(t, IsTransient(err))
+}
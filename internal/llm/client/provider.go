@@ -1,9 +1,24 @@
 package client
 
+// 需要特殊处理的提供商
+const (
+	// ProviderOpenAI OpenAI
+	ProviderOpenAI = "openai"
+	// ProviderDeepSeek DeepSeek，使用 OpenAI 兼容 API
+	ProviderDeepSeek = "deepseek"
+	// ProviderAnthropic Anthropic，使用 Messages API（而不是 OpenAI Chat Completions API）
+	ProviderAnthropic = "anthropic"
+	// ProviderOllama Ollama，本地运行，使用 OpenAI 兼容 API，不需要 API 密钥
+	ProviderOllama = "ollama"
+)
+
 // ProviderConfig 提供商配置
 //
-// 用于 LLM 客户端的基础配置，包含 API 密钥、模型名称和 URL。
+// 用于 LLM 客户端的基础配置，包含提供商、API 密钥、模型名称和 URL。
 type ProviderConfig struct {
+	// Provider 提供商名称（决定请求格式：ProviderAnthropic 使用 Messages API，
+	// 其他提供商使用 OpenAI Chat Completions API；ProviderOllama 不需要 API 密钥）
+	Provider string
 	// APIKey API 密钥
 	APIKey string
 	// Model 模型名称
@@ -11,3 +26,44 @@ type ProviderConfig struct {
 	// URL API URL（仅 proxy provider 需要，openai/deepseek 使用固定 URL）
 	URL string
 }
+
+// isAnthropic 判断是否使用 Anthropic Messages API
+func (c *ProviderConfig) isAnthropic() bool {
+	return c.Provider == ProviderAnthropic
+}
+
+// requiresAPIKey 判断提供商是否需要 API 密钥
+func (c *ProviderConfig) requiresAPIKey() bool {
+	return c.Provider != ProviderOllama
+}
+
+// reportsStreamUsage 判断提供商是否支持通过 stream_options.include_usage 在流式响应中返回 token 使用统计
+func (c *ProviderConfig) reportsStreamUsage() bool {
+	return c.Provider == ProviderOpenAI || c.Provider == ProviderDeepSeek
+}
+
+// responseFormat 返回请求体中的 response_format（提供商不支持时返回 nil）
+//
+// OpenAI 支持 JSON Schema（json_schema），DeepSeek 和 Ollama 只支持 JSON 模式（json_object）。
+// proxy 背后的服务不一定支持 response_format，Anthropic 没有对应的参数，都不发送，
+// 依赖 prompt 中的格式说明。
+func (c *ProviderConfig) responseFormat(format *ResponseFormat) map[string]interface{} {
+	if format == nil {
+		return nil
+	}
+
+	switch c.Provider {
+	case ProviderOpenAI:
+		return map[string]interface{}{
+			"type": "json_schema",
+			"json_schema": map[string]interface{}{
+				"name":   format.Name,
+				"schema": format.Schema,
+			},
+		}
+	case ProviderDeepSeek, ProviderOllama:
+		return map[string]interface{}{"type": "json_object"}
+	default:
+		return nil
+	}
+}
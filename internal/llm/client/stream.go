@@ -0,0 +1,334 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/zevwings/workflow/internal/http"
+	"github.com/zevwings/workflow/internal/logging"
+)
+
+// streamDone data of the SSE event that ends an OpenAI-style stream
+const streamDone = "[DONE]"
+
+// Stream calls LLM API with a streaming response
+//
+// Sends the request with "stream": true and parses the SSE response
+// (OpenAI Chat Completions chunks, or Anthropic Messages events).
+// onDelta is called with each piece of generated text as it arrives.
+// Cancelling ctx aborts the request and the stream.
+//
+// Parameters:
+//   - ctx: Request context
+//   - params: LLM request parameters
+//   - onDelta: Called with each piece of generated text (can be nil)
+//
+// Returns:
+//   - string: Complete generated text (trimmed of leading and trailing whitespace)
+//   - error: Returns error if the request fails, the stream contains an error event, or ctx is cancelled
+func (c *llmClient) Stream(ctx context.Context, params *LLMRequestParams, onDelta func(delta string)) (string, error) {
+	content, _, err := c.StreamWithUsage(ctx, params, onDelta)
+	return content, err
+}
+
+// StreamWithUsage calls LLM API with a streaming response and returns the token usage reported in the stream
+//
+// OpenAI and DeepSeek are asked to send the usage in the last chunk
+// ("stream_options": {"include_usage": true}); Anthropic reports it in the
+// message_start and message_delta events. Other providers may not report it,
+// in which case the returned usage is zero.
+//
+// Parameters:
+//   - ctx: Request context
+//   - params: LLM request parameters
+//   - onDelta: Called with each piece of generated text (can be nil)
+//
+// Returns:
+//   - string: Complete generated text (trimmed of leading and trailing whitespace)
+//   - Usage: Token usage reported in the stream
+//   - error: Returns error if the request fails, the stream contains an error event, or ctx is cancelled
+func (c *llmClient) StreamWithUsage(ctx context.Context, params *LLMRequestParams, onDelta func(delta string)) (string, Usage, error) {
+	logger := logging.GetLogger()
+
+	url, err := c.buildURL()
+	if err != nil {
+		logger.WithError(err).Error("Failed to build LLM API URL: URL not configured")
+		return "", Usage{}, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	payload, err := c.buildPayload(params)
+	if err != nil {
+		logger.WithError(err).Error("Failed to build LLM request payload")
+		return "", Usage{}, fmt.Errorf("failed to build request body: %w", err)
+	}
+	payload["stream"] = true
+	if c.config.reportsStreamUsage() {
+		payload["stream_options"] = map[string]interface{}{"include_usage": true}
+	}
+
+	headers, err := c.buildHeaders()
+	if err != nil {
+		logger.WithError(err).Error("Failed to build LLM request headers: API key not configured")
+		return "", Usage{}, fmt.Errorf("failed to build request headers: %w", err)
+	}
+	headers["Accept"] = "text/event-stream"
+
+	logger.WithFields(logging.Fields{
+		"model": c.config.Model,
+		"url":   url,
+	}).Info("Starting streaming LLM API call")
+
+	reqConfig := http.NewRequestConfig().
+		WithHeaders(headers).
+		WithBody(payload).
+		WithContext(ctx)
+
+	body, err := c.httpClient.Stream(http.MethodPost, url, reqConfig)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", Usage{}, ctx.Err()
+		}
+		var statusErr *http.StatusError
+		if errors.As(err, &statusErr) {
+			message := errorMessage(statusErr.Body)
+			logger.Warnf("LLM API returned error status: url=%s, status=%d, error=%s", url, statusErr.Status, message)
+			return "", Usage{}, &RequestError{URL: url, Status: statusErr.Status, Message: message}
+		}
+		logger.WithError(err).WithField("url", url).Error("LLM HTTP stream request failed")
+		return "", Usage{}, &RequestError{URL: url, Err: err}
+	}
+	defer body.Close()
+
+	decode := decodeChatCompletionChunk
+	if c.config.isAnthropic() {
+		decode = decodeAnthropicEvent
+	}
+
+	content, usage, err := readStream(body, decode, onDelta)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", Usage{}, ctx.Err()
+		}
+		logger.WithError(err).WithField("url", url).Error("Failed to read LLM response stream")
+		return "", Usage{}, err
+	}
+
+	logger.WithFields(logging.Fields{
+		"model":             c.config.Model,
+		"url":               url,
+		"content_length":    len(content),
+		"prompt_tokens":     usage.PromptTokens,
+		"completion_tokens": usage.CompletionTokens,
+	}).Info("Streaming LLM API call succeeded")
+
+	return content, usage, nil
+}
+
+// streamDecoder decodes the data of an SSE event
+//
+// Returns the generated text carried by the event, whether the stream has
+// ended, and an error if the event reports one. Token usage carried by the
+// event is written to usage.
+type streamDecoder func(event, payload string, usage *Usage) (delta string, done bool, err error)
+
+// readStream reads an SSE stream
+//
+// Events are separated by blank lines and their "data:" lines carry JSON that
+// is passed to decode. Errors are reported by decode, or, when the request
+// failed, as a plain JSON body without any SSE framing.
+//
+// Parameters:
+//   - r: Response body
+//   - decode: Decoder of the event data
+//   - onDelta: Called with each piece of generated text (can be nil)
+//
+// Returns:
+//   - string: Complete generated text (trimmed of leading and trailing whitespace)
+//   - Usage: Token usage reported in the stream (zero if it is not reported)
+//   - error: Returns error if the stream contains an error or no content
+func readStream(r io.Reader, decode streamDecoder, onDelta func(delta string)) (string, Usage, error) {
+	reader := bufio.NewReader(r)
+
+	var content strings.Builder
+	var usage Usage
+	var event string
+	var data []string
+	var raw strings.Builder
+	done := false
+
+	// dispatch handles the event collected so far
+	dispatch := func() error {
+		defer func() {
+			event = ""
+			data = nil
+		}()
+		if len(data) == 0 {
+			return nil
+		}
+
+		delta, end, err := decode(event, strings.Join(data, "\n"), &usage)
+		if err != nil {
+			return err
+		}
+		done = end
+		if delta != "" {
+			content.WriteString(delta)
+			if onDelta != nil {
+				onDelta(delta)
+			}
+		}
+		return nil
+	}
+
+	for !done {
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return "", Usage{}, fmt.Errorf("failed to read response stream: %w", readErr)
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "":
+			if err := dispatch(); err != nil {
+				return "", Usage{}, err
+			}
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, ":"), strings.HasPrefix(line, "id:"), strings.HasPrefix(line, "retry:"):
+			// Comments (keep-alive) and fields that are not used
+		default:
+			raw.WriteString(line)
+			raw.WriteString("\n")
+		}
+
+		if errors.Is(readErr, io.EOF) {
+			if err := dispatch(); err != nil {
+				return "", Usage{}, err
+			}
+			break
+		}
+	}
+
+	if content.Len() == 0 && strings.TrimSpace(raw.String()) != "" {
+		// Not an SSE stream, usually the JSON error body of a failed request
+		return "", Usage{}, fmt.Errorf("LLM API request failed: %s", errorMessage(raw.String()))
+	}
+
+	result := strings.TrimSpace(content.String())
+	if result == "" {
+		return "", Usage{}, fmt.Errorf("response content is empty string")
+	}
+	return result, usage, nil
+}
+
+// decodeChatCompletionChunk decodes an event of an OpenAI-style stream
+//
+// Chunks carry the generated text in choices[0].delta.content and the stream
+// ends with "data: [DONE]". Errors are reported either as an "error" event or
+// as a chunk with an "error" field. The usage is sent in the last chunk when
+// it is requested with stream_options.
+func decodeChatCompletionChunk(event, payload string, usage *Usage) (string, bool, error) {
+	if payload == streamDone {
+		return "", true, nil
+	}
+	if event == "error" {
+		return "", false, fmt.Errorf("LLM API stream error: %s", errorMessage(payload))
+	}
+
+	var chunk ChatCompletionChunk
+	if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+		return "", false, fmt.Errorf("failed to parse stream chunk %q: %w", payload, err)
+	}
+	if chunk.Error != nil {
+		return "", false, fmt.Errorf("LLM API stream error: %s", chunk.Error.Message)
+	}
+	if chunk.Usage != nil {
+		*usage = *chunk.Usage
+	}
+
+	var delta strings.Builder
+	for _, choice := range chunk.Choices {
+		if choice.Index == 0 && choice.Delta.Content != nil {
+			delta.WriteString(*choice.Delta.Content)
+		}
+	}
+	return delta.String(), false, nil
+}
+
+// errorMessage extracts the error message from an error payload
+//
+// Supports {"error": {"message": "..."}}, {"error": "..."} and {"message": "..."},
+// and falls back to the payload itself.
+func errorMessage(payload string) string {
+	payload = strings.TrimSpace(payload)
+
+	var body struct {
+		Error   *APIError `json:"error"`
+		Message string    `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(payload), &body); err == nil {
+		if body.Error != nil && body.Error.Message != "" {
+			return body.Error.Message
+		}
+		if body.Message != "" {
+			return body.Message
+		}
+	}
+	return payload
+}
+
+// RetryObserver optional interface of LLM clients that need to know when a request is sent again
+//
+// structured.Call calls OnRetry before each repair attempt, so that a streaming client
+// can discard what it rendered of the rejected response.
+type RetryObserver interface {
+	// OnRetry is called before the request is sent again
+	OnRetry()
+}
+
+// streamingClient LLMClient whose Call streams the response
+type streamingClient struct {
+	LLMClient
+	ctx     context.Context
+	onDelta func(delta string)
+	onRetry func()
+}
+
+// NewStreamingClient wraps an LLMClient so that Call streams the response
+//
+// Lets features built on Call (PR summary, reword, ...) render the response
+// live without changing their signatures.
+//
+// Parameters:
+//   - ctx: Request context used for every call
+//   - llmClient: LLM client to wrap (cannot be nil)
+//   - onDelta: Called with each piece of generated text as it arrives
+//   - onRetry: Called before a request is sent again, e.g. after its response was rejected (can be nil)
+//
+// Returns:
+//   - LLMClient: LLM client whose Call uses Stream, implements RetryObserver
+func NewStreamingClient(ctx context.Context, llmClient LLMClient, onDelta func(delta string), onRetry func()) LLMClient {
+	if llmClient == nil {
+		panic(fmt.Errorf("llm/client.NewStreamingClient: llmClient cannot be nil"))
+	}
+	return &streamingClient{LLMClient: llmClient, ctx: ctx, onDelta: onDelta, onRetry: onRetry}
+}
+
+// Call calls LLM API through Stream
+func (c *streamingClient) Call(params *LLMRequestParams) (string, error) {
+	return c.LLMClient.Stream(c.ctx, params, c.onDelta)
+}
+
+// OnRetry forwards the retry to onRetry
+func (c *streamingClient) OnRetry() {
+	if c.onRetry != nil {
+		c.onRetry()
+	}
+}
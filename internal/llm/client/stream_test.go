@@ -0,0 +1,251 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sseChunk 生成包含增量内容的 SSE 事件
+func sseChunk(content string) string {
+	chunk, _ := json.Marshal(map[string]interface{}{
+		"id":     "chatcmpl-123",
+		"object": "chat.completion.chunk",
+		"choices": []map[string]interface{}{
+			{"index": 0, "delta": map[string]interface{}{"content": content}, "finish_reason": nil},
+		},
+	})
+	return fmt.Sprintf("data: %s\n\n", chunk)
+}
+
+// ==================== readStream 测试 ====================
+
+func TestReadStream(t *testing.T) {
+	stream := ": keep-alive\n\n" +
+		"data: {\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\"}}]}\n\n" +
+		sseChunk(" Hello") +
+		sseChunk(", world") +
+		"data: {\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"stop\"}]}\n\n" +
+		"data: [DONE]\n\n" +
+		sseChunk("ignored after done")
+
+	var deltas []string
+	content, _, err := readStream(strings.NewReader(stream), decodeChatCompletionChunk, func(delta string) {
+		deltas = append(deltas, delta)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, world", content)
+	assert.Equal(t, []string{" Hello", ", world"}, deltas)
+}
+
+func TestReadStream_CRLFAndMissingDone(t *testing.T) {
+	stream := strings.ReplaceAll(sseChunk("partial")+sseChunk(" answer"), "\n", "\r\n")
+
+	content, _, err := readStream(strings.NewReader(stream), decodeChatCompletionChunk, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "partial answer", content)
+}
+
+func TestReadStream_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		stream  string
+		wantErr string
+	}{
+		{
+			name:    "error 事件",
+			stream:  sseChunk("Hel") + "event: error\ndata: {\"error\": {\"message\": \"overloaded\"}}\n\n",
+			wantErr: "LLM API stream error: overloaded",
+		},
+		{
+			name:    "数据块中的错误",
+			stream:  "data: {\"error\": {\"message\": \"context length exceeded\", \"code\": 400}}\n\n",
+			wantErr: "LLM API stream error: context length exceeded",
+		},
+		{
+			name:    "字符串形式的错误",
+			stream:  "data: {\"error\": \"invalid api key\"}\n\n",
+			wantErr: "LLM API stream error: invalid api key",
+		},
+		{
+			name:    "非 SSE 的错误响应",
+			stream:  "{\n  \"error\": {\"message\": \"Incorrect API key provided\", \"type\": \"invalid_request_error\"}\n}\n",
+			wantErr: "LLM API request failed: Incorrect API key provided",
+		},
+		{
+			name:    "无法解析的数据块",
+			stream:  "data: {not json}\n\n",
+			wantErr: "failed to parse stream chunk",
+		},
+		{
+			name:    "没有内容",
+			stream:  "data: [DONE]\n\n",
+			wantErr: "response content is empty string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := readStream(strings.NewReader(tt.stream), decodeChatCompletionChunk, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+// ==================== Stream 测试 ====================
+
+func TestLLMClient_Stream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, true, payload["stream"])
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+		assert.Equal(t, "Bearer test-api-key", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, part := range []string{"Go is", " a programming", " language"} {
+			fmt.Fprint(w, sseChunk(part))
+			w.(http.Flusher).Flush()
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	client := newClient(&ProviderConfig{APIKey: "test-api-key", Model: "gpt-3.5-turbo", URL: server.URL})
+
+	var deltas []string
+	content, err := client.Stream(context.Background(), &LLMRequestParams{UserPrompt: "What is Go?"}, func(delta string) {
+		deltas = append(deltas, delta)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Go is a programming language", content)
+	assert.Equal(t, []string{"Go is", " a programming", " language"}, deltas)
+}
+
+func TestLLMClient_StreamWithUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// OpenAI 需要通过 stream_options 请求在最后一个数据块中返回 token 使用统计
+		var payload map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, map[string]interface{}{"include_usage": true}, payload["stream_options"])
+
+		fmt.Fprint(w, sseChunk("Hello"))
+		fmt.Fprint(w, "data: {\"choices\":[],\"usage\":{\"prompt_tokens\":12,\"completion_tokens\":3,\"total_tokens\":15}}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	client := newClient(&ProviderConfig{Provider: ProviderOpenAI, APIKey: "test-api-key", Model: "gpt-4o-mini", URL: server.URL})
+
+	content, usage, err := client.(UsageReporter).StreamWithUsage(context.Background(), &LLMRequestParams{UserPrompt: "Say hello"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", content)
+	assert.Equal(t, Usage{PromptTokens: 12, CompletionTokens: 3, TotalTokens: 15}, usage)
+}
+
+func TestLLMClient_Stream_NoStreamOptionsForProxy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.NotContains(t, payload, "stream_options")
+
+		fmt.Fprint(w, sseChunk("Hello"))
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	client := newClient(&ProviderConfig{Provider: "proxy", APIKey: "test-api-key", Model: "gpt-4o-mini", URL: server.URL})
+
+	_, usage, err := client.(UsageReporter).StreamWithUsage(context.Background(), &LLMRequestParams{UserPrompt: "Say hello"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, Usage{}, usage)
+}
+
+func TestLLMClient_Stream_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error": {"message": "Incorrect API key provided"}}`)
+	}))
+	defer server.Close()
+
+	client := newClient(&ProviderConfig{APIKey: "bad-key", Model: "gpt-3.5-turbo", URL: server.URL})
+
+	_, err := client.Stream(context.Background(), &LLMRequestParams{UserPrompt: "hi"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Incorrect API key provided")
+}
+
+func TestLLMClient_Stream_Cancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, sseChunk("first"))
+		w.(http.Flusher).Flush()
+		select {
+		case <-time.After(10 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	client := newClient(&ProviderConfig{APIKey: "test-api-key", Model: "gpt-3.5-turbo", URL: server.URL})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Stream(ctx, &LLMRequestParams{UserPrompt: "hi"}, func(delta string) {
+		// 收到第一段内容后取消（模拟 Ctrl-C）
+		cancel()
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+// ==================== NewStreamingClient 测试 ====================
+
+func TestNewStreamingClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, sseChunk("streamed"))
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	base := newClient(&ProviderConfig{APIKey: "test-api-key", Model: "gpt-3.5-turbo", URL: server.URL})
+
+	var deltas []string
+	retries := 0
+	streaming := NewStreamingClient(context.Background(), base, func(delta string) {
+		deltas = append(deltas, delta)
+	}, func() {
+		retries++
+	})
+
+	content, err := streaming.Call(&LLMRequestParams{UserPrompt: "hi"})
+	require.NoError(t, err)
+	assert.Equal(t, "streamed", content)
+	assert.Equal(t, []string{"streamed"}, deltas)
+
+	// OnRetry 转发给 onRetry
+	observer, ok := streaming.(RetryObserver)
+	require.True(t, ok)
+	observer.OnRetry()
+	assert.Equal(t, 1, retries)
+
+	// onRetry 可以为 nil
+	assert.NotPanics(t, func() {
+		NewStreamingClient(context.Background(), base, nil, nil).(RetryObserver).OnRetry()
+	})
+
+	assert.Panics(t, func() {
+		NewStreamingClient(context.Background(), nil, nil, nil)
+	})
+}
@@ -1,5 +1,7 @@
 package client
 
+import "encoding/json"
+
 // LLMRequestParams LLM 请求参数
 //
 // 包含调用 LLM API 所需的所有参数。
@@ -14,8 +16,41 @@ type LLMRequestParams struct {
 	Temperature float32 `json:"temperature"`
 	// Model 模型名称（可选，如果为空则从配置获取）
 	Model string `json:"model,omitempty"`
+	// Feature 发起请求的功能（如 FeatureCommit，用于用量统计和路由，不发送给 API）
+	Feature string `json:"-"`
+	// ResponseFormat 要求以 JSON 对象响应（可选，提供商支持时发送 response_format）
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+}
+
+// ResponseFormat 要求 LLM 以符合 JSON Schema 的 JSON 对象响应
+//
+// 只是对提供商的提示：不支持 response_format 的提供商会忽略它，调用方仍需校验响应。
+type ResponseFormat struct {
+	// Name schema 名称（只能包含字母、数字、下划线和连字符）
+	Name string `json:"name"`
+	// Schema JSON Schema（顶层为 object）
+	Schema map[string]interface{} `json:"schema"`
 }
 
+// 发起请求的功能（LLMRequestParams.Feature），用于用量统计和按功能路由
+const (
+	// FeaturePRCreate 生成 PR 内容（pr create）
+	FeaturePRCreate = "pr-create"
+	// FeaturePRSummarize 总结 PR（pr summarize）
+	FeaturePRSummarize = "pr-summarize"
+	// FeaturePRReword 重写 PR 标题和描述（pr reword）
+	FeaturePRReword = "pr-reword"
+	// FeatureFileSummary 逐个文件总结变更（diff 超出预算时）
+	FeatureFileSummary = "file-summary"
+	// FeatureCommit 生成提交消息（commit）
+	FeatureCommit = "commit"
+	// FeatureTranslate 翻译为英文（分支名等）
+	FeatureTranslate = "translate"
+)
+
+// Features 所有功能，可以在配置中为它们指定提供商和模型
+var Features = []string{FeaturePRCreate, FeaturePRSummarize, FeaturePRReword, FeatureFileSummary, FeatureCommit, FeatureTranslate}
+
 // ChatCompletionResponse OpenAI Chat Completions API 响应
 //
 // 完整的 OpenAI 标准响应格式，支持所有标准字段和扩展字段。
@@ -65,3 +100,129 @@ type Usage struct {
 	// TotalTokens 总 token 数
 	TotalTokens int `json:"total_tokens"`
 }
+
+// ChatCompletionChunk OpenAI Chat Completions API 流式响应中的单个数据块
+//
+// 流式响应以 SSE 的 "data:" 行返回，每个数据块包含一段增量内容。
+type ChatCompletionChunk struct {
+	// ID 响应唯一标识符
+	ID string `json:"id"`
+	// Object 对象类型，固定为 "chat.completion.chunk"
+	Object string `json:"object"`
+	// Created 创建时间戳（Unix 时间戳）
+	Created int64 `json:"created"`
+	// Model 使用的模型名称
+	Model string `json:"model"`
+	// Choices 选择列表
+	Choices []ChatCompletionChunkChoice `json:"choices"`
+	// Error 流中返回的错误（部分服务在流中以数据块的形式返回错误）
+	Error *APIError `json:"error,omitempty"`
+	// Usage Token 使用统计（请求 stream_options.include_usage 时在最后一个数据块中返回）
+	Usage *Usage `json:"usage,omitempty"`
+}
+
+// ChatCompletionChunkChoice 流式数据块的选择项
+type ChatCompletionChunkChoice struct {
+	// Index 选择索引
+	Index int `json:"index"`
+	// Delta 增量消息
+	Delta ChatMessage `json:"delta"`
+	// FinishReason 完成原因（最后一个数据块之前为 null）
+	FinishReason *string `json:"finish_reason"`
+}
+
+// APIError LLM API 返回的错误
+type APIError struct {
+	// Message 错误信息
+	Message string `json:"message"`
+	// Type 错误类型
+	Type string `json:"type,omitempty"`
+	// Code 错误码（可能是字符串或数字）
+	Code interface{} `json:"code,omitempty"`
+}
+
+// UnmarshalJSON 解析错误，兼容以字符串形式返回的错误（如 {"error": "invalid api key"}）
+func (e *APIError) UnmarshalJSON(data []byte) error {
+	var message string
+	if err := json.Unmarshal(data, &message); err == nil {
+		e.Message = message
+		return nil
+	}
+
+	type apiError APIError
+	var parsed apiError
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+	*e = APIError(parsed)
+	return nil
+}
+
+// MessagesResponse Anthropic Messages API 响应
+type MessagesResponse struct {
+	// ID 响应唯一标识符
+	ID string `json:"id"`
+	// Type 对象类型，固定为 "message"
+	Type string `json:"type"`
+	// Role 消息角色，固定为 "assistant"
+	Role string `json:"role"`
+	// Model 使用的模型名称
+	Model string `json:"model"`
+	// Content 内容块列表
+	Content []ContentBlock `json:"content"`
+	// StopReason 停止原因
+	StopReason string `json:"stop_reason"`
+	// Usage Token 使用统计
+	Usage MessagesUsage `json:"usage"`
+}
+
+// ContentBlock Anthropic Messages API 内容块
+type ContentBlock struct {
+	// Type 内容块类型（如 "text"）
+	Type string `json:"type"`
+	// Text 文本内容（仅 "text" 类型）
+	Text string `json:"text,omitempty"`
+}
+
+// MessagesUsage Anthropic Messages API Token 使用统计
+type MessagesUsage struct {
+	// InputTokens 输入 token 数
+	InputTokens int `json:"input_tokens"`
+	// OutputTokens 输出 token 数
+	OutputTokens int `json:"output_tokens"`
+}
+
+// toUsage 转换为 OpenAI 格式的 Token 使用统计
+func (u MessagesUsage) toUsage() Usage {
+	return Usage{
+		PromptTokens:     u.InputTokens,
+		CompletionTokens: u.OutputTokens,
+		TotalTokens:      u.InputTokens + u.OutputTokens,
+	}
+}
+
+// MessagesStreamEvent Anthropic Messages API 流式响应中的单个事件
+//
+// 文本增量在 "content_block_delta" 事件的 Delta 中返回，流以 "message_stop" 事件结束。
+type MessagesStreamEvent struct {
+	// Type 事件类型（message_start、content_block_delta、message_stop、error 等）
+	Type string `json:"type"`
+	// Index 内容块索引
+	Index int `json:"index"`
+	// Delta 增量内容（content_block_delta 事件）
+	Delta *MessagesStreamDelta `json:"delta,omitempty"`
+	// Error 错误（error 事件）
+	Error *APIError `json:"error,omitempty"`
+	// Message 消息（message_start 事件，包含输入 token 数）
+	Message *MessagesResponse `json:"message,omitempty"`
+	// Usage Token 使用统计（message_delta 事件，包含输出 token 数）
+	Usage *MessagesUsage `json:"usage,omitempty"`
+}
+
+// MessagesStreamDelta Anthropic Messages API 流式增量
+type MessagesStreamDelta struct {
+	// Type 增量类型（如 "text_delta"）
+	Type string `json:"type"`
+	// Text 文本增量（仅 "text_delta" 类型）
+	Text string `json:"text,omitempty"`
+}
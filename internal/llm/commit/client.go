@@ -0,0 +1,250 @@
+package commit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/zevwings/workflow/internal/llm/cache"
+	"github.com/zevwings/workflow/internal/llm/client"
+	"github.com/zevwings/workflow/internal/llm/compact"
+	"github.com/zevwings/workflow/internal/llm/pr"
+	"github.com/zevwings/workflow/internal/llm/prompt"
+	"github.com/zevwings/workflow/internal/llm/utils"
+	"github.com/zevwings/workflow/internal/logging"
+)
+
+var (
+	// globalCommitClient 全局提交 LLM 客户端单例
+	globalCommitClient *CommitLLMClient
+	commitOnce         sync.Once
+)
+
+// CommitLLMClient 提交 LLM 客户端
+//
+// 封装所有提交相关的 LLM 操作，包括生成提交消息。
+// 提供统一的接口和配置管理。
+type CommitLLMClient struct {
+	llmClient client.LLMClient
+}
+
+// newCommitLLMClient 创建新的提交 LLM 客户端（内部函数，不导出）
+//
+// 参数:
+//   - llmClient: LLM 客户端实例（不能为 nil）
+//
+// 返回:
+//   - *CommitLLMClient: 提交 LLM 客户端实例
+func newCommitLLMClient(llmClient client.LLMClient) *CommitLLMClient {
+	if llmClient == nil {
+		panic(fmt.Errorf("commit.newCommitLLMClient: llmClient cannot be nil"))
+	}
+	return &CommitLLMClient{
+		llmClient: llmClient,
+	}
+}
+
+// Global 获取全局 CommitLLMClient 单例
+//
+// 返回进程级别的 CommitLLMClient 单例。
+// 单例会在首次调用时初始化，后续调用会复用同一个实例。
+//
+// 参数:
+//   - llmClient: LLM 客户端实例（必须，不能为 nil）
+//
+// 返回:
+//   - *CommitLLMClient: 提交 LLM 客户端实例
+//
+// 注意:
+//   - LLM 客户端必须由调用者提供，提交模块不负责它的创建和生命周期
+//   - 首次调用时传入的参数会被保存，后续调用会忽略参数
+//   - 如果传入 nil，会在首次调用时 panic
+func Global(llmClient client.LLMClient) *CommitLLMClient {
+	if llmClient == nil {
+		panic(fmt.Errorf("commit.Global: llmClient cannot be nil"))
+	}
+	commitOnce.Do(func() {
+		globalCommitClient = newCommitLLMClient(llmClient)
+	})
+	return globalCommitClient
+}
+
+// Refresh 返回跳过响应缓存读取的提交 LLM 客户端副本
+//
+// 用于重新生成：请求总是发送给 API，新的响应仍会写入缓存。不影响全局单例。
+//
+// 返回:
+//   - *CommitLLMClient: 新的提交 LLM 客户端实例
+func (c *CommitLLMClient) Refresh() *CommitLLMClient {
+	return newCommitLLMClient(cache.Refresh(c.llmClient))
+}
+
+// Generate 生成提交消息
+//
+// 根据暂存区的 diff 生成 Conventional Commits 格式的 type、scope、subject 和 body。
+//
+// 参数:
+//   - stagedDiff: 暂存区的 diff 内容
+//   - hint: 用户提供的提交意图（可选，作为主要输入）
+//   - types: 允许的提交类型（为空时不限制）
+//
+// 返回:
+//   - *CommitContent: 提交消息内容
+//   - error: 如果 LLM API 调用失败或响应格式不正确，返回相应的错误信息
+func (c *CommitLLMClient) Generate(stagedDiff, hint string, types []string) (*CommitContent, error) {
+	return GenerateCommitContent(stagedDiff, hint, types, c.llmClient)
+}
+
+// SummarizeFileChanges 逐个总结文件变更
+//
+// 用于压缩后仍超出 token 预算的暂存区 diff，每个文件的 diff 会截断到 maxTokens 以内。
+// 总结使用默认英文配置，与提交消息的 prompt 一致。
+//
+// 参数:
+//   - files: 按文件拆分的 diff（通常来自 compact.Result.Files）
+//   - maxTokens: 单个文件 diff 的 token 预算（<= 0 表示不限制）
+//   - progress: 开始总结每个文件前调用（可以为 nil），index 从 0 开始
+//
+// 返回:
+//   - []pr.FileChangeSummary: 每个文件的修改总结
+//   - error: 如果 LLM API 调用失败，返回相应的错误信息
+func (c *CommitLLMClient) SummarizeFileChanges(files []compact.FileDiff, maxTokens int, progress func(index, total int, path string)) ([]pr.FileChangeSummary, error) {
+	return pr.SummarizeFileChanges(files, maxTokens, progress, nil, c.llmClient)
+}
+
+// ============================================================================
+// GenerateCommitContent 相关函数
+// ============================================================================
+
+// GenerateCommitContent 使用 LLM 生成提交消息
+//
+// 参数:
+//   - stagedDiff: 暂存区的 diff 内容
+//   - hint: 用户提供的提交意图（可选，作为主要输入）
+//   - types: 允许的提交类型（为空时不限制）
+//   - llmClient: LLM 客户端实例
+//
+// 返回:
+//   - *CommitContent: 提交消息内容
+//   - error: 如果 LLM API 调用失败或响应格式不正确，返回相应的错误信息
+func GenerateCommitContent(stagedDiff, hint string, types []string, llmClient client.LLMClient) (*CommitContent, error) {
+	logger := logging.GetLogger()
+
+	// 记录提交消息生成开始
+	logger.WithFields(logging.Fields{
+		"diff_length": len(stagedDiff),
+		"has_hint":    hint != "",
+		"types_count": len(types),
+	}).Info("Starting commit message generation")
+
+	systemPrompt, err := prompt.GenerateCommitSystemPrompt(prompt.NewVars(nil, stagedDiff))
+	if err != nil {
+		return nil, fmt.Errorf("构建 system prompt 失败: %w", err)
+	}
+
+	params := &client.LLMRequestParams{
+		SystemPrompt: systemPrompt,
+		UserPrompt:   buildCommitUserPrompt(stagedDiff, hint, types),
+		MaxTokens:    nil,
+		Temperature:  0.3,
+		Feature:      client.FeatureCommit,
+	}
+
+	response, err := llmClient.Call(params)
+	if err != nil {
+		logger.WithError(err).Error("Failed to call LLM API for commit message generation")
+		return nil, fmt.Errorf("调用 LLM API 生成提交消息失败: %w", err)
+	}
+
+	content, err := parseCommitResponse(response)
+	if err != nil {
+		logger.WithError(err).Error("Failed to parse LLM response for commit message generation")
+		return nil, fmt.Errorf("解析 LLM 响应失败: %w", err)
+	}
+
+	// 记录提交消息生成成功
+	logger.WithFields(logging.Fields{
+		"type":     content.Type,
+		"scope":    content.Scope,
+		"subject":  content.Subject,
+		"has_body": content.Body != "",
+	}).Info("Commit message generation succeeded")
+
+	return content, nil
+}
+
+// buildCommitUserPrompt 生成提交消息的 user prompt
+func buildCommitUserPrompt(stagedDiff, hint string, types []string) string {
+	parts := []string{}
+
+	if len(types) > 0 {
+		parts = append(parts, fmt.Sprintf("Allowed types: %s", strings.Join(types, ", ")))
+	}
+
+	if strings.TrimSpace(hint) != "" {
+		parts = append(parts, fmt.Sprintf("Hint (PRIMARY INTENT): %s", strings.TrimSpace(hint)))
+	}
+
+	if len(parts) > 0 {
+		parts = append(parts, "")
+	}
+	parts = append(parts, "Staged changes:")
+	parts = append(parts, stagedDiff)
+
+	return strings.Join(parts, "\n")
+}
+
+// parseCommitResponse 解析 LLM 返回的 JSON 响应，提取 type、scope、subject 和 body
+//
+// type 和 subject 是必需的，scope 和 body 是可选的。
+// 支持处理包含 markdown 代码块的响应格式。
+func parseCommitResponse(response string) (*CommitContent, error) {
+	logger := logging.GetLogger()
+
+	// 使用公共方法提取并修复 JSON（修复转义问题）
+	jsonStr := utils.ExtractAndFixJSON(response)
+
+	var jsonData map[string]interface{}
+	if err := json.Unmarshal([]byte(jsonStr), &jsonData); err != nil {
+		logger.WithError(err).Error("Failed to parse commit message response")
+		return nil, fmt.Errorf("解析 LLM 响应为 JSON 失败。原始响应: %s: %w", jsonStr, err)
+	}
+
+	content := &CommitContent{}
+	for _, field := range []struct {
+		name     string
+		target   *string
+		required bool
+	}{
+		{"type", &content.Type, true},
+		{"scope", &content.Scope, false},
+		{"subject", &content.Subject, true},
+		{"body", &content.Body, false},
+	} {
+		raw, ok := jsonData[field.name]
+		if !ok || raw == nil {
+			if field.required {
+				logger.Errorf("Commit message response missing required field: %s", field.name)
+				return nil, fmt.Errorf("LLM 响应中缺少 '%s' 字段", field.name)
+			}
+			continue
+		}
+		value, ok := raw.(string)
+		if !ok {
+			logger.Errorf("Commit message response field type error: field=%s, expected=string", field.name)
+			return nil, fmt.Errorf("LLM 响应中 '%s' 字段类型错误", field.name)
+		}
+		*field.target = strings.TrimSpace(value)
+	}
+
+	content.Type = strings.ToLower(content.Type)
+	content.Scope = strings.ToLower(content.Scope)
+	content.Subject = strings.TrimSuffix(content.Subject, ".")
+
+	if content.Type == "" || content.Subject == "" {
+		return nil, fmt.Errorf("LLM 响应中的 'type' 或 'subject' 为空")
+	}
+
+	return content, nil
+}
@@ -0,0 +1,116 @@
+package commit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zevwings/workflow/internal/llm/client"
+	"github.com/zevwings/workflow/internal/llm/compact"
+)
+
+// fakeLLMClient 返回固定响应的 LLM 客户端，并记录请求参数
+type fakeLLMClient struct {
+	response string
+	err      error
+	params   *client.LLMRequestParams
+}
+
+func (f *fakeLLMClient) Call(params *client.LLMRequestParams) (string, error) {
+	f.params = params
+	return f.response, f.err
+}
+
+func (f *fakeLLMClient) Stream(ctx context.Context, params *client.LLMRequestParams, onDelta func(delta string)) (string, error) {
+	return f.Call(params)
+}
+
+// ==================== NewCommitLLMClient 测试 ====================
+
+func TestNewCommitLLMClient(t *testing.T) {
+	llmClient := &fakeLLMClient{}
+
+	commitClient := newCommitLLMClient(llmClient)
+	assert.NotNil(t, commitClient)
+	assert.Equal(t, llmClient, commitClient.llmClient)
+}
+
+func TestNewCommitLLMClient_NilLLMClient(t *testing.T) {
+	assert.Panics(t, func() {
+		newCommitLLMClient(nil)
+	}, "应该 panic 当 llmClient 为 nil")
+}
+
+// ==================== Generate 测试 ====================
+
+func TestCommitLLMClient_Generate(t *testing.T) {
+	llmClient := &fakeLLMClient{
+		response: "```json\n{\"type\": \"Feat\", \"scope\": \"jira\", \"subject\": \"add attachments download.\", \"body\": \"Download all attachments at once.\"}\n```",
+	}
+
+	content, err := newCommitLLMClient(llmClient).Generate("diff content", "download attachments", []string{"feat", "fix"})
+	require.NoError(t, err)
+	assert.Equal(t, &CommitContent{
+		Type:    "feat",
+		Scope:   "jira",
+		Subject: "add attachments download",
+		Body:    "Download all attachments at once.",
+	}, content)
+
+	require.NotNil(t, llmClient.params)
+	assert.Contains(t, llmClient.params.UserPrompt, "Allowed types: feat, fix")
+	assert.Contains(t, llmClient.params.UserPrompt, "download attachments")
+	assert.Contains(t, llmClient.params.UserPrompt, "diff content")
+}
+
+func TestCommitLLMClient_Generate_OptionalFields(t *testing.T) {
+	llmClient := &fakeLLMClient{response: `{"type": "fix", "subject": "handle empty config", "scope": null}`}
+
+	content, err := newCommitLLMClient(llmClient).Generate("diff content", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "fix", content.Type)
+	assert.Empty(t, content.Scope)
+	assert.Empty(t, content.Body)
+	assert.NotContains(t, llmClient.params.UserPrompt, "Allowed types")
+}
+
+func TestCommitLLMClient_Generate_MissingFields(t *testing.T) {
+	llmClient := &fakeLLMClient{response: `{"type": "fix"}`}
+
+	_, err := newCommitLLMClient(llmClient).Generate("diff content", "", nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "缺少 'subject' 字段")
+}
+
+func TestCommitLLMClient_Generate_InvalidJSON(t *testing.T) {
+	llmClient := &fakeLLMClient{response: "not json"}
+
+	_, err := newCommitLLMClient(llmClient).Generate("diff content", "", nil)
+	assert.Error(t, err)
+}
+
+func TestCommitLLMClient_Generate_CallError(t *testing.T) {
+	llmClient := &fakeLLMClient{err: errors.New("boom")}
+
+	_, err := newCommitLLMClient(llmClient).Generate("diff content", "", nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+}
+
+// ==================== SummarizeFileChanges 测试 ====================
+
+func TestCommitLLMClient_SummarizeFileChanges(t *testing.T) {
+	llmClient := &fakeLLMClient{response: "Handle empty config"}
+	files := []compact.FileDiff{
+		{Path: "config.go", Patch: "diff --git a/config.go b/config.go\n--- a/config.go\n+++ b/config.go\n@@ -1 +1 @@\n-old\n+new\n"},
+	}
+
+	summaries, err := newCommitLLMClient(llmClient).SummarizeFileChanges(files, 0, nil)
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, "config.go", summaries[0].Path)
+	assert.Equal(t, "Handle empty config", summaries[0].Summary)
+	assert.Equal(t, client.FeatureFileSummary, llmClient.params.Feature)
+}
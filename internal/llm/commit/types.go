@@ -0,0 +1,16 @@
+package commit
+
+// CommitContent 提交消息内容
+//
+// 由 LLM 根据暂存区的变更生成的 Conventional Commits 各部分，
+// 最终消息由调用者按仓库的提交模板渲染。
+type CommitContent struct {
+	// Type 提交类型（如 "feat"、"fix"）
+	Type string
+	// Scope 变更涉及的模块（小写，无法确定时为空字符串）
+	Scope string
+	// Subject 提交主题（祈使语气，不以句号结尾）
+	Subject string
+	// Body 提交正文（可选，为空表示不需要正文）
+	Body string
+}
@@ -0,0 +1,232 @@
+// Package compact 在发送给 LLM 之前按 token 预算压缩 diff
+//
+// 压缩按以下顺序进行，每一步之后重新估算 token 数，满足预算即停止:
+//  1. 移除依赖锁文件、第三方依赖目录和自动生成的文件（始终执行，只保留文件头）
+//  2. 将每个 hunk 的上下文缩减为变更前后各 ContextLines 行
+//  3. 仍超出预算时标记 OverBudget，由调用方改为逐个文件总结（SummarizeFileChange）
+package compact
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultContextLines 裁剪 hunk 时默认保留的上下文行数
+const DefaultContextLines = 1
+
+// Options 压缩选项
+type Options struct {
+	// MaxTokens token 预算（<= 0 表示不限制，只移除锁文件等文件）
+	MaxTokens int
+	// ContextLines 裁剪 hunk 时保留的上下文行数（0 表示使用 DefaultContextLines）
+	ContextLines int
+}
+
+// DroppedFile 被移除的文件
+type DroppedFile struct {
+	// Path 文件路径
+	Path string
+	// Reason 移除原因（ReasonLockfile、ReasonVendored 或 ReasonGenerated）
+	Reason string
+}
+
+// Result 压缩结果
+type Result struct {
+	// Diff 压缩后的 diff
+	Diff string
+	// Files 压缩后按文件拆分的 diff（不含被移除的文件），用于逐个文件总结
+	Files []FileDiff
+	// Dropped 被移除的文件
+	Dropped []DroppedFile
+	// TrimmedHunks 上下文被裁剪的 hunk 数量
+	TrimmedHunks int
+	// OriginalTokens 压缩前估算的 token 数
+	OriginalTokens int
+	// Tokens 压缩后估算的 token 数
+	Tokens int
+	// MaxTokens 使用的 token 预算
+	MaxTokens int
+	// OverBudget 压缩后仍超出预算
+	OverBudget bool
+}
+
+// Changed 判断 diff 是否被压缩过
+func (r *Result) Changed() bool {
+	return len(r.Dropped) > 0 || r.TrimmedHunks > 0
+}
+
+// Report 生成压缩过程的说明，用于在命令行中展示
+//
+// 返回:
+//   - []string: 每行一条说明，未做任何压缩时返回 nil
+func (r *Result) Report() []string {
+	var lines []string
+
+	if len(r.Dropped) > 0 {
+		byReason := map[string][]string{}
+		for _, file := range r.Dropped {
+			byReason[file.Reason] = append(byReason[file.Reason], file.Path)
+		}
+		reasons := make([]string, 0, len(byReason))
+		for reason := range byReason {
+			reasons = append(reasons, reason)
+		}
+		sort.Strings(reasons)
+		for _, reason := range reasons {
+			paths := byReason[reason]
+			lines = append(lines, fmt.Sprintf("Skipped %d %s file(s): %s", len(paths), reason, strings.Join(paths, ", ")))
+		}
+	}
+	if r.TrimmedHunks > 0 {
+		lines = append(lines, fmt.Sprintf("Trimmed the context of %d hunk(s)", r.TrimmedHunks))
+	}
+	if r.Tokens < r.OriginalTokens {
+		lines = append(lines, fmt.Sprintf("Diff reduced from ~%d to ~%d tokens", r.OriginalTokens, r.Tokens))
+	}
+	if r.OverBudget {
+		lines = append(lines, fmt.Sprintf("Diff still exceeds the budget of %d tokens, summarizing %d file(s) one by one", r.MaxTokens, len(r.Files)))
+	}
+
+	return lines
+}
+
+// Compact 按 token 预算压缩统一 diff
+//
+// 参数:
+//   - diff: 统一 diff
+//   - opts: 压缩选项
+//
+// 返回:
+//   - *Result: 压缩结果
+func Compact(diff string, opts Options) *Result {
+	contextLines := opts.ContextLines
+	if contextLines == 0 {
+		contextLines = DefaultContextLines
+	}
+
+	result := &Result{
+		Diff:           diff,
+		OriginalTokens: EstimateTokens(diff),
+		MaxTokens:      opts.MaxTokens,
+	}
+
+	// 1. 移除锁文件、第三方依赖和自动生成的文件，只保留文件头让 LLM 知道它们有变更
+	var sections []string
+	var kept []int
+	for _, file := range SplitFiles(diff) {
+		if reason := DropReason(file); reason != "" {
+			result.Dropped = append(result.Dropped, DroppedFile{Path: file.Path, Reason: reason})
+			sections = append(sections, fmt.Sprintf("%s[diff omitted: %s]\n", ensureNewline(fileHeader(file.Patch)), reason))
+			continue
+		}
+		kept = append(kept, len(sections))
+		result.Files = append(result.Files, file)
+		sections = append(sections, file.Patch)
+	}
+	result.update(sections)
+
+	// 2. 裁剪 hunk 上下文
+	if result.withinBudget() {
+		return result
+	}
+	for i, index := range kept {
+		patch, trimmed := TrimContext(result.Files[i].Patch, contextLines)
+		if trimmed == 0 {
+			continue
+		}
+		result.TrimmedHunks += trimmed
+		result.Files[i].Patch = patch
+		sections[index] = patch
+	}
+	result.update(sections)
+
+	// 3. 仍超出预算，交由调用方逐个文件总结
+	result.OverBudget = !result.withinBudget()
+	return result
+}
+
+// update 根据各文件的 diff 重新生成 Diff 和 Tokens
+func (r *Result) update(sections []string) {
+	if r.Changed() {
+		r.Diff = strings.Join(sections, "")
+	}
+	r.Tokens = EstimateTokens(r.Diff)
+}
+
+// withinBudget 判断压缩后的 diff 是否在预算内
+func (r *Result) withinBudget() bool {
+	return r.MaxTokens <= 0 || r.Tokens <= r.MaxTokens
+}
+
+// ensureNewline 确保文本以换行符结尾
+func ensureNewline(text string) string {
+	if text == "" || strings.HasSuffix(text, "\n") {
+		return text
+	}
+	return text + "\n"
+}
+
+// EstimateTokens 估算文本的 token 数
+//
+// 不依赖具体模型的分词器：ASCII 文本按约 4 个字符一个 token 计算，
+// 非 ASCII 字符（如中文）按每个字符一个 token 计算，结果偏保守。
+//
+// 参数:
+//   - text: 文本
+//
+// 返回:
+//   - int: 估算的 token 数
+func EstimateTokens(text string) int {
+	ascii, other := 0, 0
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+3)/4 + other
+}
+
+// Truncate 将文本截断到 token 预算内
+//
+// 在预算内的最后一个换行处截断，避免截断半行。
+//
+// 参数:
+//   - text: 文本
+//   - maxTokens: token 预算（<= 0 表示不限制）
+//
+// 返回:
+//   - string: 截断后的文本
+//   - bool: 是否被截断
+func Truncate(text string, maxTokens int) (string, bool) {
+	if maxTokens <= 0 || EstimateTokens(text) <= maxTokens {
+		return text, false
+	}
+
+	tokens, ascii := 0, 0
+	end := 0
+	for i, r := range text {
+		if r < utf8.RuneSelf {
+			ascii++
+			if ascii == 4 {
+				tokens++
+				ascii = 0
+			}
+		} else {
+			tokens++
+		}
+		if tokens >= maxTokens {
+			end = i
+			break
+		}
+	}
+
+	truncated := text[:end]
+	if cut := strings.LastIndexByte(truncated, '\n'); cut > 0 {
+		truncated = truncated[:cut+1]
+	}
+	return truncated, true
+}
@@ -0,0 +1,234 @@
+package compact
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// filePatch 生成修改单个文件的 diff，变更行位于 context 行上下文之间
+func filePatch(path string, context int, changes ...string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "diff --git a/%s b/%s\nindex 1111111..2222222 100644\n--- a/%s\n+++ b/%s\n", path, path, path, path)
+	fmt.Fprintf(&b, "@@ -1,%d +1,%d @@\n", 2*context+len(changes), 2*context+len(changes))
+	for i := 0; i < context; i++ {
+		fmt.Fprintf(&b, " before %d\n", i)
+	}
+	for _, change := range changes {
+		b.WriteString(change + "\n")
+	}
+	for i := 0; i < context; i++ {
+		fmt.Fprintf(&b, " after %d\n", i)
+	}
+	return b.String()
+}
+
+// ==================== EstimateTokens 测试 ====================
+
+func TestEstimateTokens(t *testing.T) {
+	assert.Equal(t, 0, EstimateTokens(""))
+	assert.Equal(t, 1, EstimateTokens("abc"))
+	assert.Equal(t, 2, EstimateTokens("abcdefgh"))
+	assert.Equal(t, 3, EstimateTokens("中文ab"))
+}
+
+// ==================== Truncate 测试 ====================
+
+func TestTruncate(t *testing.T) {
+	text := strings.Repeat("0123456789abcde\n", 10) // 每行 4 个 token
+
+	got, truncated := Truncate(text, 0)
+	assert.False(t, truncated)
+	assert.Equal(t, text, got)
+
+	got, truncated = Truncate(text, 100)
+	assert.False(t, truncated)
+	assert.Equal(t, text, got)
+
+	// 在预算内的最后一个换行处截断
+	got, truncated = Truncate(text, 10)
+	assert.True(t, truncated)
+	assert.Equal(t, strings.Repeat("0123456789abcde\n", 2), got)
+}
+
+// ==================== SplitFiles 测试 ====================
+
+func TestSplitFiles(t *testing.T) {
+	modified := filePatch("src/main.go", 1, "-old", "+new")
+	deleted := "diff --git a/old.txt b/old.txt\ndeleted file mode 100644\n--- a/old.txt\n+++ /dev/null\n@@ -1 +0,0 @@\n-gone\n"
+	renamed := "diff --git a/a.txt b/b.txt\nsimilarity index 100%\nrename from a.txt\nrename to b.txt\n"
+	binary := "diff --git a/logo.png b/logo.png\nnew file mode 100644\nBinary files /dev/null and b/logo.png differ\n"
+
+	files := SplitFiles(modified + deleted + renamed + binary)
+	require.Len(t, files, 4)
+	assert.Equal(t, FileDiff{Path: "src/main.go", Patch: modified}, files[0])
+	assert.Equal(t, FileDiff{Path: "old.txt", Patch: deleted}, files[1])
+	assert.Equal(t, FileDiff{Path: "b.txt", Patch: renamed}, files[2])
+	assert.Equal(t, FileDiff{Path: "logo.png", Patch: binary}, files[3])
+
+	assert.Nil(t, SplitFiles("  \n"))
+
+	// 没有 "diff --git" 行的 patch 作为一个文件
+	files = SplitFiles("@@ -1 +1 @@\n-a\n+b\n")
+	require.Len(t, files, 1)
+	assert.Empty(t, files[0].Path)
+}
+
+// ==================== DropReason 测试 ====================
+
+func TestDropReason(t *testing.T) {
+	tests := []struct {
+		name string
+		file FileDiff
+		want string
+	}{
+		{name: "go.sum", file: FileDiff{Path: "go.sum"}, want: ReasonLockfile},
+		{name: "嵌套的 package-lock.json", file: FileDiff{Path: "web/package-lock.json"}, want: ReasonLockfile},
+		{name: "vendor 目录", file: FileDiff{Path: "vendor/github.com/pkg/errors/errors.go"}, want: ReasonVendored},
+		{name: "node_modules 目录", file: FileDiff{Path: "web/node_modules/react/index.js"}, want: ReasonVendored},
+		{name: "protobuf", file: FileDiff{Path: "api/user.pb.go"}, want: ReasonGenerated},
+		{name: "压缩的 JS", file: FileDiff{Path: "static/app.min.js"}, want: ReasonGenerated},
+		{
+			name: "Go 生成标记",
+			file: FileDiff{Path: "internal/mock/client.go", Patch: filePatch("internal/mock/client.go", 0, "+// Code generated by MockGen. DO NOT EDIT.", "+package mock")},
+			want: ReasonGenerated,
+		},
+		{name: "普通文件", file: FileDiff{Path: "internal/vendors.go", Patch: filePatch("internal/vendors.go", 1, "+// DO NOT EDIT this list by hand")}},
+		{name: "没有路径", file: FileDiff{Patch: "@@ -1 +1 @@\n-a\n+b\n"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DropReason(tt.file))
+		})
+	}
+}
+
+// ==================== TrimContext 测试 ====================
+
+func TestTrimContext_SplitsDistantChanges(t *testing.T) {
+	patch := "diff --git a/f.txt b/f.txt\n--- a/f.txt\n+++ b/f.txt\n" +
+		"@@ -10,9 +10,9 @@ func main() {\n" +
+		" c1\n" +
+		" c2\n" +
+		"-old1\n" +
+		"+new1\n" +
+		" c3\n" +
+		" c4\n" +
+		" c5\n" +
+		" c6\n" +
+		"-old2\n" +
+		"+new2\n" +
+		" c7\n"
+
+	got, trimmed := TrimContext(patch, 1)
+	assert.Equal(t, 1, trimmed)
+	assert.Equal(t, "diff --git a/f.txt b/f.txt\n--- a/f.txt\n+++ b/f.txt\n"+
+		"@@ -11,3 +11,3 @@ func main() {\n"+
+		" c2\n"+
+		"-old1\n"+
+		"+new1\n"+
+		" c3\n"+
+		"@@ -16,3 +16,3 @@\n"+
+		" c6\n"+
+		"-old2\n"+
+		"+new2\n"+
+		" c7\n", got)
+}
+
+func TestTrimContext_ZeroContext(t *testing.T) {
+	patch := "@@ -1,4 +1,5 @@\n a\n b\n+added\n c\n d\n"
+
+	got, trimmed := TrimContext(patch, 0)
+	assert.Equal(t, 1, trimmed)
+	// 旧文件行数为 0 时起始行号为插入位置的前一行
+	assert.Equal(t, "@@ -2,0 +3,1 @@\n+added\n", got)
+}
+
+func TestTrimContext_NothingToTrim(t *testing.T) {
+	patch := filePatch("f.txt", 1, "-old", "+new")
+
+	got, trimmed := TrimContext(patch, 3)
+	assert.Equal(t, 0, trimmed)
+	assert.Equal(t, patch, got)
+
+	// 新文件没有上下文
+	added := "diff --git a/n.txt b/n.txt\nnew file mode 100644\n--- /dev/null\n+++ b/n.txt\n@@ -0,0 +1,2 @@\n+a\n+b\n"
+	got, trimmed = TrimContext(added, 0)
+	assert.Equal(t, 0, trimmed)
+	assert.Equal(t, added, got)
+}
+
+func TestTrimContext_NoNewlineMarker(t *testing.T) {
+	patch := "@@ -1,4 +1,4 @@\n a\n b\n c\n-d\n\\ No newline at end of file\n+e\n\\ No newline at end of file\n"
+
+	got, trimmed := TrimContext(patch, 0)
+	assert.Equal(t, 1, trimmed)
+	assert.Equal(t, "@@ -4,1 +4,1 @@\n-d\n\\ No newline at end of file\n+e\n\\ No newline at end of file\n", got)
+}
+
+// ==================== Compact 测试 ====================
+
+func TestCompact_WithinBudget(t *testing.T) {
+	diff := filePatch("main.go", 3, "-old", "+new")
+
+	result := Compact(diff, Options{MaxTokens: 1000})
+	assert.Equal(t, diff, result.Diff)
+	assert.False(t, result.Changed())
+	assert.False(t, result.OverBudget)
+	assert.Equal(t, result.OriginalTokens, result.Tokens)
+	assert.Nil(t, result.Report())
+	require.Len(t, result.Files, 1)
+}
+
+func TestCompact_DropsLockfilesEvenWithinBudget(t *testing.T) {
+	source := filePatch("main.go", 1, "+new")
+	lockfile := filePatch("go.sum", 0, "+github.com/pkg/errors v0.9.1 h1:abc=")
+
+	result := Compact(source+lockfile, Options{MaxTokens: 1000})
+	assert.Equal(t, []DroppedFile{{Path: "go.sum", Reason: ReasonLockfile}}, result.Dropped)
+	assert.Equal(t, source+"diff --git a/go.sum b/go.sum\nindex 1111111..2222222 100644\n--- a/go.sum\n+++ b/go.sum\n[diff omitted: lockfile]\n", result.Diff)
+	assert.Less(t, result.Tokens, result.OriginalTokens)
+	assert.Equal(t, 0, result.TrimmedHunks)
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, "main.go", result.Files[0].Path)
+	assert.Equal(t, []string{
+		"Skipped 1 lockfile file(s): go.sum",
+		fmt.Sprintf("Diff reduced from ~%d to ~%d tokens", result.OriginalTokens, result.Tokens),
+	}, result.Report())
+}
+
+func TestCompact_TrimsContextOverBudget(t *testing.T) {
+	diff := filePatch("a.go", 50, "-old", "+new") + filePatch("b.go", 50, "+added")
+
+	// 不限制预算时不裁剪
+	result := Compact(diff, Options{})
+	assert.Equal(t, diff, result.Diff)
+
+	result = Compact(diff, Options{MaxTokens: 100})
+	assert.Equal(t, 2, result.TrimmedHunks)
+	assert.False(t, result.OverBudget)
+	assert.LessOrEqual(t, result.Tokens, 100)
+	assert.Contains(t, result.Diff, "@@ -50,3 +50,3 @@\n before 49\n-old\n+new\n after 0\n")
+	assert.Contains(t, result.Diff, "diff --git a/b.go b/b.go")
+	require.Len(t, result.Files, 2)
+	assert.Equal(t, result.Diff, result.Files[0].Patch+result.Files[1].Patch)
+	assert.Contains(t, result.Report(), "Trimmed the context of 2 hunk(s)")
+}
+
+func TestCompact_OverBudget(t *testing.T) {
+	var changes []string
+	for i := 0; i < 100; i++ {
+		changes = append(changes, fmt.Sprintf("+added line %d", i))
+	}
+	diff := filePatch("a.go", 1, changes...) + filePatch("b.go", 1, changes...)
+
+	result := Compact(diff, Options{MaxTokens: 100})
+	assert.True(t, result.OverBudget)
+	assert.Len(t, result.Files, 2)
+	report := result.Report()
+	assert.Equal(t, "Diff still exceeds the budget of 100 tokens, summarizing 2 file(s) one by one", report[len(report)-1])
+}
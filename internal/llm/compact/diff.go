@@ -0,0 +1,105 @@
+package compact
+
+import "strings"
+
+// FileDiff 统一 diff 中单个文件的部分
+type FileDiff struct {
+	// Path 文件路径（删除的文件为原路径）
+	Path string
+	// Patch 该文件的 diff（从 "diff --git" 行开始）
+	Patch string
+}
+
+// SplitFiles 将统一 diff 按文件拆分
+//
+// 以 "diff --git" 行作为文件的开始。第一个 "diff --git" 之前的内容会并入第一个文件；
+// 不包含 "diff --git" 行的 diff（如平台返回的单文件 patch）作为一个路径为空的文件返回。
+//
+// 参数:
+//   - diff: 统一 diff
+//
+// 返回:
+//   - []FileDiff: 按出现顺序排列的文件 diff，diff 为空时返回 nil
+func SplitFiles(diff string) []FileDiff {
+	if strings.TrimSpace(diff) == "" {
+		return nil
+	}
+
+	var files []FileDiff
+	var current strings.Builder
+	started := false
+	flush := func() {
+		if current.Len() == 0 {
+			return
+		}
+		patch := current.String()
+		files = append(files, FileDiff{Path: patchPath(patch), Patch: patch})
+		current.Reset()
+	}
+
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			if started {
+				flush()
+			}
+			started = true
+		}
+		current.WriteString(line)
+	}
+	flush()
+
+	return files
+}
+
+// patchPath 从单个文件的 diff 中解析文件路径
+//
+// 依次使用 "+++ b/"、"--- a/"、"rename to " 和 "diff --git" 行。
+func patchPath(patch string) string {
+	var header, oldPath, renameTo string
+	for _, line := range strings.Split(patch, "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			// 文件头已结束
+			return firstNonEmpty(oldPath, renameTo, headerPath(header))
+		case strings.HasPrefix(line, "+++ b/"):
+			return strings.TrimPrefix(line, "+++ b/")
+		case strings.HasPrefix(line, "--- a/"):
+			oldPath = strings.TrimPrefix(line, "--- a/")
+		case strings.HasPrefix(line, "rename to "):
+			renameTo = strings.TrimPrefix(line, "rename to ")
+		case strings.HasPrefix(line, "diff --git ") && header == "":
+			header = line
+		}
+	}
+	return firstNonEmpty(renameTo, oldPath, headerPath(header))
+}
+
+// headerPath 从 "diff --git a/<path> b/<path>" 行中解析新路径
+func headerPath(header string) string {
+	if index := strings.LastIndex(header, " b/"); index >= 0 {
+		return header[index+len(" b/"):]
+	}
+	return ""
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// fileHeader 返回文件 diff 中第一个 hunk 之前的文件头
+func fileHeader(patch string) string {
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(patch, "\n") {
+		if strings.HasPrefix(line, "@@") || strings.HasPrefix(line, "Binary files ") {
+			break
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
@@ -0,0 +1,122 @@
+package compact
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// 文件被移除的原因
+const (
+	// ReasonLockfile 依赖锁文件
+	ReasonLockfile = "lockfile"
+	// ReasonVendored 第三方依赖目录中的文件
+	ReasonVendored = "vendored"
+	// ReasonGenerated 自动生成的文件
+	ReasonGenerated = "generated"
+)
+
+// lockfiles 依赖锁文件名
+var lockfiles = map[string]bool{
+	"go.sum":              true,
+	"go.work.sum":         true,
+	"package-lock.json":   true,
+	"npm-shrinkwrap.json": true,
+	"yarn.lock":           true,
+	"pnpm-lock.yaml":      true,
+	"bun.lockb":           true,
+	"Cargo.lock":          true,
+	"Gemfile.lock":        true,
+	"composer.lock":       true,
+	"poetry.lock":         true,
+	"Pipfile.lock":        true,
+	"uv.lock":             true,
+	"Podfile.lock":        true,
+	"pubspec.lock":        true,
+	"mix.lock":            true,
+	"flake.lock":          true,
+	"packages.lock.json":  true,
+}
+
+// vendoredDirs 第三方依赖目录名（出现在路径的任意一级）
+var vendoredDirs = map[string]bool{
+	"vendor":           true,
+	"node_modules":     true,
+	"third_party":      true,
+	"bower_components": true,
+	"Pods":             true,
+}
+
+// generatedSuffixes 自动生成文件的后缀
+var generatedSuffixes = []string{
+	".pb.go", ".pb.gw.go", "_pb2.py", "_pb2_grpc.py", ".pb.swift",
+	"_generated.go", ".gen.go", "_gen.go", ".generated.ts", ".g.dart", ".freezed.dart",
+	".min.js", ".min.css", ".js.map", ".css.map",
+	".snap",
+}
+
+// generatedPattern 自动生成文件头部的标记
+//
+// "Code generated ... DO NOT EDIT." 是 Go 的约定，"@generated" 被多种工具使用。
+var generatedPattern = regexp.MustCompile(`Code generated .*DO NOT EDIT|@generated\b`)
+
+// DropReason 判断文件是否应从发送给 LLM 的 diff 中移除
+//
+// 依赖锁文件、第三方依赖目录中的文件和自动生成的文件对理解变更意图帮助很小，
+// 却往往占据 diff 的大部分。
+//
+// 参数:
+//   - file: 文件 diff
+//
+// 返回:
+//   - string: 移除原因（ReasonLockfile、ReasonVendored 或 ReasonGenerated），应保留时返回空字符串
+func DropReason(file FileDiff) string {
+	filePath := file.Path
+	if filePath == "" {
+		return ""
+	}
+
+	if lockfiles[path.Base(filePath)] {
+		return ReasonLockfile
+	}
+
+	for _, dir := range strings.Split(path.Dir(filePath), "/") {
+		if vendoredDirs[dir] {
+			return ReasonVendored
+		}
+	}
+
+	for _, suffix := range generatedSuffixes {
+		if strings.HasSuffix(filePath, suffix) {
+			return ReasonGenerated
+		}
+	}
+	if hasGeneratedMarker(file.Patch) {
+		return ReasonGenerated
+	}
+
+	return ""
+}
+
+// hasGeneratedMarker 判断 diff 的前几行内容中是否包含自动生成标记
+func hasGeneratedMarker(patch string) bool {
+	const maxLines = 10
+
+	count := 0
+	for _, line := range strings.Split(patch, "\n") {
+		if !strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "-") && !strings.HasPrefix(line, " ") {
+			continue
+		}
+		if strings.HasPrefix(line, "+++ ") || strings.HasPrefix(line, "--- ") {
+			continue
+		}
+		if generatedPattern.MatchString(line) {
+			return true
+		}
+		count++
+		if count >= maxLines {
+			break
+		}
+	}
+	return false
+}
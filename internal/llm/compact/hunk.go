@@ -0,0 +1,213 @@
+package compact
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hunkHeaderPattern hunk 头，如 "@@ -10,7 +10,8 @@ func main() {"
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@(.*)$`)
+
+// hunk 解析后的 hunk
+type hunk struct {
+	// oldStart、newStart hunk 第一行在旧文件和新文件中的行号
+	oldStart int
+	newStart int
+	// section hunk 头中 "@@" 之后的内容（通常是函数名）
+	section string
+	// lines hunk 内容行（保留 ' '、'-'、'+'、'\' 前缀，不含换行符）
+	lines []string
+}
+
+// TrimContext 将文件 diff 中每个 hunk 的上下文缩减为变更前后各 contextLines 行
+//
+// 相距较远的变更会被拆分为多个 hunk，并重新计算 hunk 头中的行号，
+// 保证结果仍是合法的统一 diff。文件头和二进制文件标记保持不变。
+//
+// 参数:
+//   - patch: 单个文件的 diff
+//   - contextLines: 保留的上下文行数（小于 0 时按 0 处理）
+//
+// 返回:
+//   - string: 裁剪后的 diff
+//   - int: 被裁剪的 hunk 数量
+func TrimContext(patch string, contextLines int) (string, int) {
+	if contextLines < 0 {
+		contextLines = 0
+	}
+
+	var b strings.Builder
+	trimmed := 0
+	var current *hunk
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		out, changed := current.trim(contextLines)
+		b.WriteString(out)
+		if changed {
+			trimmed++
+		}
+		current = nil
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(patch, "\n"), "\n") {
+		if match := hunkHeaderPattern.FindStringSubmatch(line); match != nil {
+			flush()
+			current = &hunk{
+				oldStart: atoi(match[1]),
+				newStart: atoi(match[3]),
+				section:  match[5],
+			}
+			// 行数为 0 时起始行号为前一行，统一为下一行的行号
+			if match[2] == "0" {
+				current.oldStart++
+			}
+			if match[4] == "0" {
+				current.newStart++
+			}
+			continue
+		}
+		if current != nil && isHunkLine(line) {
+			current.lines = append(current.lines, line)
+			continue
+		}
+		flush()
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	flush()
+
+	if trimmed == 0 {
+		return patch, 0
+	}
+	return b.String(), trimmed
+}
+
+// isHunkLine 判断是否为 hunk 的内容行
+func isHunkLine(line string) bool {
+	if line == "" {
+		// 部分工具会去掉空上下文行的前导空格
+		return true
+	}
+	switch line[0] {
+	case ' ', '-', '+', '\\':
+		return true
+	}
+	return false
+}
+
+// trim 裁剪 hunk 的上下文，返回重新生成的 hunk 文本以及是否有行被移除
+func (h *hunk) trim(contextLines int) (string, bool) {
+	// 标记需要保留的行：变更行及其前后 contextLines 行上下文
+	keep := make([]bool, len(h.lines))
+	for i, line := range h.lines {
+		if !isChangeLine(line) {
+			continue
+		}
+		for j := i - contextLines; j <= i+contextLines; j++ {
+			if j >= 0 && j < len(h.lines) {
+				keep[j] = true
+			}
+		}
+	}
+	// "\ No newline at end of file" 跟随前一行
+	for i, line := range h.lines {
+		if strings.HasPrefix(line, "\\") && i > 0 {
+			keep[i] = keep[i-1]
+		}
+	}
+
+	changed := false
+	for _, k := range keep {
+		if !k {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return h.format(h.oldStart, h.newStart, h.section, h.lines), false
+	}
+
+	var b strings.Builder
+	oldLine, newLine := h.oldStart, h.newStart
+	section := h.section
+	for i := 0; i < len(h.lines); {
+		if !keep[i] {
+			oldLine, newLine = advance(h.lines[i], oldLine, newLine)
+			i++
+			continue
+		}
+
+		start := i
+		oldStart, newStart := oldLine, newLine
+		for i < len(h.lines) && keep[i] {
+			oldLine, newLine = advance(h.lines[i], oldLine, newLine)
+			i++
+		}
+		b.WriteString(h.format(oldStart, newStart, section, h.lines[start:i]))
+		// 函数名只对第一个拆分出的 hunk 准确
+		section = ""
+	}
+	return b.String(), true
+}
+
+// format 生成 hunk 文本
+func (h *hunk) format(oldStart, newStart int, section string, lines []string) string {
+	oldCount, newCount := 0, 0
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "-"):
+			oldCount++
+		case strings.HasPrefix(line, "+"):
+			newCount++
+		case strings.HasPrefix(line, "\\"):
+		default:
+			oldCount++
+			newCount++
+		}
+	}
+	// 统一 diff 中，行数为 0 时起始行号为前一行
+	if oldCount == 0 {
+		oldStart--
+	}
+	if newCount == 0 {
+		newStart--
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@%s\n", oldStart, oldCount, newStart, newCount, section)
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// advance 根据 hunk 内容行推进新旧文件的行号
+func advance(line string, oldLine, newLine int) (int, int) {
+	switch {
+	case strings.HasPrefix(line, "-"):
+		return oldLine + 1, newLine
+	case strings.HasPrefix(line, "+"):
+		return oldLine, newLine + 1
+	case strings.HasPrefix(line, "\\"):
+		return oldLine, newLine
+	default:
+		return oldLine + 1, newLine + 1
+	}
+}
+
+// isChangeLine 判断是否为新增或删除行
+func isChangeLine(line string) bool {
+	return strings.HasPrefix(line, "-") || strings.HasPrefix(line, "+")
+}
+
+// atoi 解析 hunk 头中的数字（已由正则保证格式）
+func atoi(value string) int {
+	n, _ := strconv.Atoi(value)
+	return n
+}
@@ -5,6 +5,11 @@
 //   - PR-related features: Generate PR content, summarize PR, reword PR, etc.
 //   - Translation functionality: Translate text to English
 //   - Language support: Multi-language prompt enhancement
+//   - Prompt templates: Layered lookup (repository, user, embedded) and rendering
+//   - Response cache: On-disk cache of responses to identical requests
+//   - Usage accounting: Token usage of each call, cost reports and a monthly soft budget
+//   - Routing: Per-feature providers and models, and fallback providers on transient errors
+//   - Structured output: JSON responses validated against a Go struct schema and repaired by re-prompting
 //
 // Usage example:
 //
@@ -22,10 +27,22 @@ package llm
 
 import (
 	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/zevwings/workflow/internal/llm/branch"
+	"github.com/zevwings/workflow/internal/llm/cache"
 	"github.com/zevwings/workflow/internal/llm/client"
+	"github.com/zevwings/workflow/internal/llm/commit"
+	"github.com/zevwings/workflow/internal/llm/compact"
 	"github.com/zevwings/workflow/internal/llm/pr"
+	"github.com/zevwings/workflow/internal/llm/prompt"
+	"github.com/zevwings/workflow/internal/llm/route"
+	"github.com/zevwings/workflow/internal/llm/structured"
+	"github.com/zevwings/workflow/internal/llm/usage"
+	"github.com/zevwings/workflow/internal/llm/utils"
 )
 
 // ============================================================================
@@ -71,6 +88,24 @@ type LLMConfigProvider interface {
 	GetLanguage() (*SupportedLanguage, error)
 }
 
+// LLMEndpointsProvider optional interface of LLMConfigProvider for fallback providers and per-feature routing
+//
+// When the provider passed to the constructors implements it, the requests of each feature
+// (see LLMFeatures) are sent to the providers it returns: the first one, then the next ones
+// when a request fails with a server error (5xx), a rate limit (429) or a timeout.
+// Otherwise all requests use GetProviderConfig.
+type LLMEndpointsProvider interface {
+	// GetProviderConfigs gets the providers that serve requests of a feature, in fallback order
+	//
+	// Parameters:
+	//   - feature: Feature name (empty for requests without a feature)
+	//
+	// Returns:
+	//   - []*ProviderConfig: Provider configurations, at least one
+	//   - error: Returns error if configuration is invalid
+	GetProviderConfigs(feature string) ([]*ProviderConfig, error)
+}
+
 // ============================================================================
 // Type Re-exports
 // ============================================================================
@@ -117,6 +152,12 @@ type PullRequestReword = pr.PullRequestReword
 // This type is a type alias for pr.PullRequestSummary.
 type PullRequestSummary = pr.PullRequestSummary
 
+// FileChangeSummary summary of the changes in a single file
+//
+// Generated by SummarizeFileChange and merged into a full PR summary for large diffs.
+// This type is a type alias for pr.FileChangeSummary.
+type FileChangeSummary = pr.FileChangeSummary
+
 // PullRequestLLMClient PR LLM client
 //
 // Encapsulates all PR-related LLM operations, including generating PR content, summarizing PR, rewording PR, and summarizing file changes.
@@ -131,6 +172,350 @@ type PullRequestLLMClient = pr.PullRequestLLMClient
 // This type is a type alias for branch.BranchLLMClient.
 type BranchLLMClient = branch.BranchLLMClient
 
+// CommitContent commit message parts, including type, scope, subject, and body
+//
+// Conventional Commits parts generated by LLM from the staged changes, rendered through the repository's commit template.
+// This type is a type alias for commit.CommitContent.
+type CommitContent = commit.CommitContent
+
+// CommitLLMClient commit LLM client
+//
+// Encapsulates all commit-related LLM operations, including commit message generation.
+// This type is a type alias for commit.CommitLLMClient.
+type CommitLLMClient = commit.CommitLLMClient
+
+// CompactResult result of compacting a diff to a token budget
+//
+// Contains the compacted diff, the per-file diffs, the skipped files and the estimated token counts.
+// This type is a type alias for compact.Result.
+type CompactResult = compact.Result
+
+// JSONFieldStream renders string fields of a streamed JSON response as they arrive
+//
+// Pass its Write method as the onDelta callback of PullRequestLLMClient.WithStream,
+// and call Reset from its onRetry callback.
+// This type is a type alias for utils.JSONFieldStream.
+type JSONFieldStream = utils.JSONFieldStream
+
+// ResponseCache on-disk cache of LLM responses, keyed by a hash of the request
+//
+// This type is a type alias for cache.Cache.
+type ResponseCache = cache.Cache
+
+// ResponseCacheStats statistics of the response cache
+//
+// This type is a type alias for cache.Stats.
+type ResponseCacheStats = cache.Stats
+
+// UsageRecord token usage of a single LLM call
+//
+// This type is a type alias for usage.Record.
+type UsageRecord = usage.Record
+
+// UsageStore append-only file of usage records
+//
+// This type is a type alias for usage.Store.
+type UsageStore = usage.Store
+
+// UsageOptions options of usage recording (repository, prices and monthly budget)
+//
+// This type is a type alias for usage.Options.
+type UsageOptions = usage.Options
+
+// UsagePrice price in USD per million tokens
+//
+// This type is a type alias for usage.Price.
+type UsagePrice = usage.Price
+
+// UsagePricer looks up the price of a provider and model
+//
+// This type is a type alias for usage.Pricer.
+type UsagePricer = usage.Pricer
+
+// UsageReport usage grouped by model, feature or repository
+//
+// This type is a type alias for usage.Report.
+type UsageReport = usage.Report
+
+// UsageRow a group of a usage report
+//
+// This type is a type alias for usage.Row.
+type UsageRow = usage.Row
+
+// PromptTemplate a resolved prompt template, including the layer it was loaded from
+//
+// This type is a type alias for prompt.Template.
+type PromptTemplate = prompt.Template
+
+// PromptVars variables available to prompt templates (language, repository, branch, ticket and diff stats)
+//
+// This type is a type alias for prompt.Vars.
+type PromptVars = prompt.Vars
+
+// StructuredOutputError the LLM response still did not match the expected JSON schema after re-prompting
+//
+// Returned (wrapped) by PR content generation, summarization and reword; use errors.As to detect it.
+// This type is a type alias for structured.ValidationError.
+type StructuredOutputError = structured.ValidationError
+
+// ============================================================================
+// Diff Compaction
+// ============================================================================
+
+// CompactDiff compacts a diff so that it fits the token budget of the LLM
+//
+// Lockfiles, vendored and generated files are always replaced by their file header.
+// When the diff is still over budget, the context of each hunk is trimmed to one line.
+// If that is not enough, the result is marked OverBudget and callers should summarize
+// result.Files one by one with PullRequestLLMClient.SummarizeFileChanges
+// (or CommitLLMClient.SummarizeFileChanges for staged changes).
+//
+// Parameters:
+//   - diff: Unified diff
+//   - maxTokens: Token budget, usually config.LLMConfig.DiffTokenBudget(feature) (<= 0 means unlimited)
+//
+// Returns:
+//   - *CompactResult: Compaction result, result.Report() describes what was trimmed
+func CompactDiff(diff string, maxTokens int) *CompactResult {
+	return compact.Compact(diff, compact.Options{MaxTokens: maxTokens})
+}
+
+// FormatFileSummaries formats file summaries as text used in place of a diff in prompts
+//
+// Parameters:
+//   - summaries: Summaries of the changes in each file
+//
+// Returns:
+//   - string: Formatted text
+func FormatFileSummaries(summaries []FileChangeSummary) string {
+	return pr.FormatFileSummaries(summaries)
+}
+
+// ============================================================================
+// Response Cache
+// ============================================================================
+
+var (
+	responseCacheMu sync.RWMutex
+	// responseCache response cache wrapped around LLM clients created by the constructors (nil disables it)
+	responseCache *ResponseCache
+)
+
+// NewResponseCache creates an on-disk response cache
+//
+// Parameters:
+//   - dir: Cache directory, created on the first write
+//   - ttl: How long responses are kept (<= 0 means forever)
+//   - maxSize: Size cap of the cache directory in bytes (<= 0 means unlimited)
+//
+// Returns:
+//   - *ResponseCache: Response cache
+func NewResponseCache(dir string, ttl time.Duration, maxSize int64) *ResponseCache {
+	return cache.New(dir, cache.Options{TTL: ttl, MaxSize: maxSize})
+}
+
+// SetResponseCache sets the response cache used by the LLM client constructors
+//
+// Identical requests (provider, model, prompts, temperature and max tokens) return the
+// cached response instead of calling the API. Must be called before the first client is
+// created, since the feature clients are process-level singletons.
+//
+// Parameters:
+//   - responses: Response cache (nil disables caching)
+func SetResponseCache(responses *ResponseCache) {
+	responseCacheMu.Lock()
+	defer responseCacheMu.Unlock()
+	responseCache = responses
+}
+
+// ============================================================================
+// Usage Accounting
+// ============================================================================
+
+// LLMFeatures features that can be routed to their own provider and model
+var LLMFeatures = client.Features
+
+// Features that can be routed to their own provider and model
+const (
+	FeaturePRCreate    = client.FeaturePRCreate
+	FeaturePRSummarize = client.FeaturePRSummarize
+	FeaturePRReword    = client.FeaturePRReword
+	FeatureCommit      = client.FeatureCommit
+)
+
+// Usage report groupings
+const (
+	UsageGroupByModel   = usage.GroupByModel
+	UsageGroupByFeature = usage.GroupByFeature
+	UsageGroupByRepo    = usage.GroupByRepo
+)
+
+// UsageGroupByOptions supported usage report groupings
+var UsageGroupByOptions = usage.GroupByOptions
+
+var (
+	usageMu sync.RWMutex
+	// usageStore usage file written by LLM clients created by the constructors (nil disables recording)
+	usageStore *UsageStore
+	// usageOptions options of usage recording
+	usageOptions UsageOptions
+)
+
+// NewUsageStore creates an append-only usage file
+//
+// Parameters:
+//   - path: Usage file, created on the first write
+//
+// Returns:
+//   - *UsageStore: Usage file
+func NewUsageStore(path string) *UsageStore {
+	return usage.NewStore(path)
+}
+
+// SetUsageStore sets the usage file written by the LLM client constructors
+//
+// Every API call (cache hits excluded) is recorded with its tokens, feature, repository
+// and latency. Provider and Model of opts are filled in from the provider configuration.
+// Must be called before the first client is created, since the feature clients are
+// process-level singletons.
+//
+// Parameters:
+//   - store: Usage file (nil disables recording)
+//   - opts: Repository, prices and monthly budget
+func SetUsageStore(store *UsageStore, opts UsageOptions) {
+	usageMu.Lock()
+	defer usageMu.Unlock()
+	usageStore = store
+	usageOptions = opts
+}
+
+// SummarizeUsage groups usage records by model, feature or repository
+//
+// Parameters:
+//   - records: Usage records
+//   - groupBy: UsageGroupByModel, UsageGroupByFeature or UsageGroupByRepo
+//   - pricer: Price lookup used to estimate costs (nil means no prices)
+//
+// Returns:
+//   - *UsageReport: Usage report
+//   - error: Returns error if groupBy is not supported
+func SummarizeUsage(records []UsageRecord, groupBy string, pricer UsagePricer) (*UsageReport, error) {
+	return usage.Summarize(records, groupBy, pricer)
+}
+
+// UsageSpent computes the total estimated cost of usage records
+//
+// Parameters:
+//   - records: Usage records
+//   - pricer: Price lookup (nil means no prices)
+//
+// Returns:
+//   - float64: Cost in USD of the records that have a price
+func UsageSpent(records []UsageRecord, pricer UsagePricer) float64 {
+	return usage.Spent(records, pricer)
+}
+
+// FormatUsageCost formats a cost in USD, with four decimals below one dollar
+func FormatUsageCost(cost float64) string {
+	return usage.FormatCost(cost)
+}
+
+// UsageMonthStart returns the start of the month of t
+func UsageMonthStart(t time.Time) time.Time {
+	return usage.MonthStart(t)
+}
+
+// ParseUsageSince parses the start time of a usage report
+//
+// Accepts "month" (or empty, the start of the month), "all", a number of days ("7d"),
+// a Go duration ("12h") or a date ("2024-05-01").
+//
+// Parameters:
+//   - value: Start time
+//   - now: Current time
+//
+// Returns:
+//   - time.Time: Start time (zero for "all")
+//   - error: Returns error if the value cannot be parsed
+func ParseUsageSince(value string, now time.Time) (time.Time, error) {
+	return usage.ParseSince(value, now)
+}
+
+// Prompt template sources, from the highest to the lowest priority
+const (
+	PromptSourceRepo     = prompt.SourceRepo
+	PromptSourceUser     = prompt.SourceUser
+	PromptSourceEmbedded = prompt.SourceEmbedded
+)
+
+// RepoPromptsDir directory of repository prompt overrides, relative to the repository root
+const RepoPromptsDir = prompt.RepoPromptsDir
+
+// ConfigurePrompts sets where prompt templates are looked up and the default template variables
+//
+// Templates are looked up in repoDir, then userDir, then the embedded defaults.
+//
+// Parameters:
+//   - repoDir: Repository override directory (empty to skip)
+//   - userDir: User override directory (empty to skip)
+//   - vars: Default variables (Repo, Branch, Ticket), language and diff stats are filled in per request
+func ConfigurePrompts(repoDir, userDir string, vars PromptVars) {
+	prompt.SetOverrideDirs(repoDir, userDir)
+	prompt.SetDefaultVars(vars)
+}
+
+// ResolvePromptTemplates resolves every prompt template to the layer it is loaded from
+//
+// Returns:
+//   - []*PromptTemplate: Templates sorted by name
+//   - error: Returns error if an override cannot be read
+func ResolvePromptTemplates() ([]*PromptTemplate, error) {
+	return prompt.ResolveTemplates()
+}
+
+// ResolvePromptTemplate resolves a prompt template to the layer it is loaded from
+//
+// Parameters:
+//   - name: Template file name (e.g. "commit.md")
+//
+// Returns:
+//   - *PromptTemplate: Resolved template
+//   - error: Returns error if the template does not exist or an override cannot be read
+func ResolvePromptTemplate(name string) (*PromptTemplate, error) {
+	return prompt.ResolveTemplate(name)
+}
+
+// LoadEmbeddedPromptTemplate loads the embedded default of a prompt template, ignoring overrides
+//
+// Parameters:
+//   - name: Template file name (e.g. "commit.md")
+//
+// Returns:
+//   - string: Template content
+//   - error: Returns error if the template does not exist
+func LoadEmbeddedPromptTemplate(name string) (string, error) {
+	return prompt.LoadEmbeddedTemplate(name)
+}
+
+// ============================================================================
+// Streaming
+// ============================================================================
+
+// NewJSONFieldStream creates a renderer for streamed JSON responses
+//
+// Only the decoded string values of the given top-level fields are written to w,
+// separated by a blank line, so that the user sees readable text instead of raw JSON.
+//
+// Parameters:
+//   - w: Output destination, usually os.Stdout
+//   - fields: Names of the fields to render
+//
+// Returns:
+//   - *JSONFieldStream: Renderer whose Write method receives the streamed deltas
+func NewJSONFieldStream(w io.Writer, fields ...string) *JSONFieldStream {
+	return utils.NewJSONFieldStream(w, fields...)
+}
+
 // ============================================================================
 // Internal Functions
 // ============================================================================
@@ -138,7 +523,8 @@ type BranchLLMClient = branch.BranchLLMClient
 // global gets global LLMClient singleton (internal function, not exported)
 //
 // Gets configuration from LLMConfigProvider interface, internally creates HTTP client and LLM client.
-// Returns process-level LLMClient singleton, initialized on first call, subsequent calls reuse the same instance.
+// When provider implements LLMEndpointsProvider, requests are routed by feature and fall back to
+// the next provider on transient errors, see LLMEndpointsProvider.
 //
 // Parameters:
 //   - provider: LLM configuration provider (cannot be nil)
@@ -157,10 +543,101 @@ func global(provider LLMConfigProvider) (LLMClient, error) {
 		return nil, fmt.Errorf("llm.global: failed to get LLM provider configuration: %w", err)
 	}
 
-	// Create global LLM client singleton (automatically uses http.Global())
-	llmClient := client.Global(providerConfig)
+	endpointsProvider, ok := provider.(LLMEndpointsProvider)
+	if !ok {
+		return newChainClient([]*ProviderConfig{providerConfig}, map[string]LLMClient{}), nil
+	}
+
+	// Features with the same providers share a client
+	var mu sync.Mutex
+	endpoints := map[string]LLMClient{}
+	chains := map[string]LLMClient{}
+	chainOf := func(feature string) (LLMClient, string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		configs, err := endpointsProvider.GetProviderConfigs(feature)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(configs) == 0 {
+			return nil, "", fmt.Errorf("no LLM provider configured")
+		}
+		key := chainKey(configs)
+		if _, ok := chains[key]; !ok {
+			chains[key] = newChainClient(configs, endpoints)
+		}
+		return chains[key], key, nil
+	}
+
+	defaultClient, defaultKey, err := chainOf("")
+	if err != nil {
+		return nil, fmt.Errorf("llm.global: failed to get LLM provider configuration: %w", err)
+	}
+
+	// Feature routes are resolved on first use, so that a misconfigured route
+	// only fails the feature that uses it
+	return route.NewRouter(defaultClient, func(feature string) (LLMClient, error) {
+		llmClient, key, err := chainOf(feature)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get LLM provider configuration of %s: %w", feature, err)
+		}
+		if key == defaultKey {
+			return nil, nil
+		}
+		return llmClient, nil
+	}), nil
+}
+
+// newChainClient creates the client of providers in fallback order
+//
+// Each provider records the usage of its API calls and has its own response cache, keyed by
+// that provider, so that a response is replayed under the provider that produced it and
+// cache hits are not recorded.
+//
+// Parameters:
+//   - configs: Provider configurations, in fallback order (at least one)
+//   - endpoints: Clients already created for a provider and model, shared between chains
+//
+// Returns:
+//   - LLMClient: LLM client instance
+func newChainClient(configs []*ProviderConfig, endpoints map[string]LLMClient) LLMClient {
+	usageMu.RLock()
+	store, opts := usageStore, usageOptions
+	usageMu.RUnlock()
+	responseCacheMu.RLock()
+	responses := responseCache
+	responseCacheMu.RUnlock()
+
+	targets := make([]route.Target, 0, len(configs))
+	for _, providerConfig := range configs {
+		key := chainKey([]*ProviderConfig{providerConfig})
+		llmClient, ok := endpoints[key]
+		if !ok {
+			llmClient = client.New(providerConfig)
+			// Record usage of the API calls, inside the cache so that cache hits are not recorded
+			if store != nil {
+				opts.Provider = providerConfig.Provider
+				opts.Model = providerConfig.Model
+				llmClient = usage.NewClient(llmClient, store, opts)
+			}
+			if responses != nil {
+				llmClient = cache.NewClient(llmClient, responses, providerConfig.Provider, providerConfig.Model)
+			}
+			endpoints[key] = llmClient
+		}
+		targets = append(targets, route.Target{Provider: providerConfig.Provider, Model: providerConfig.Model, Client: llmClient})
+	}
+	return route.NewChain(targets)
+}
 
-	return llmClient, nil
+// chainKey identifies providers in fallback order
+func chainKey(configs []*ProviderConfig) string {
+	parts := make([]string, 0, len(configs))
+	for _, providerConfig := range configs {
+		parts = append(parts, providerConfig.Provider+"|"+providerConfig.Model+"|"+providerConfig.URL)
+	}
+	return strings.Join(parts, ",")
 }
 
 // ============================================================================
@@ -278,3 +755,36 @@ func NewBranchLLMClient(provider LLMConfigProvider) *BranchLLMClient {
 	// Use singleton function from branch package
 	return branch.Global(llmClient)
 }
+
+// NewCommitLLMClient creates a new commit LLM client
+//
+// Gets configuration from LLMConfigProvider interface, internally automatically creates LLM client and HTTP client.
+// Returns process-level CommitLLMClient singleton, initialized on first call, subsequent calls reuse the same instance.
+//
+// Parameters:
+//   - provider: LLM configuration provider (cannot be nil)
+//
+// Returns:
+//   - *CommitLLMClient: Commit LLM client instance
+//
+// Note:
+//   - Function will panic if configuration is invalid
+//
+// Usage example:
+//
+//	commitClient := infrastructurellm.NewCommitLLMClient()
+//	content, err := commitClient.Generate(stagedDiff, "", nil)
+func NewCommitLLMClient(provider LLMConfigProvider) *CommitLLMClient {
+	if provider == nil {
+		panic(fmt.Errorf("llm.NewCommitLLMClient: LLMConfigProvider cannot be nil"))
+	}
+
+	// Create LLM client
+	llmClient, err := global(provider)
+	if err != nil {
+		panic(fmt.Errorf("llm.NewCommitLLMClient: failed to create LLM client: %w", err))
+	}
+
+	// Use singleton function from commit package
+	return commit.Global(llmClient)
+}
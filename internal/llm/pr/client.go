@@ -1,13 +1,17 @@
 package pr
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
 
+	"github.com/zevwings/workflow/internal/llm/cache"
 	"github.com/zevwings/workflow/internal/llm/client"
+	"github.com/zevwings/workflow/internal/llm/compact"
 	"github.com/zevwings/workflow/internal/llm/prompt"
+	"github.com/zevwings/workflow/internal/llm/structured"
 	"github.com/zevwings/workflow/internal/llm/utils"
 	"github.com/zevwings/workflow/internal/logging"
 )
@@ -76,6 +80,47 @@ func Global(llmClient client.LLMClient, lang *client.SupportedLanguage) *PullReq
 	return globalPRClient
 }
 
+// WithLanguage 返回使用指定语言的 PR LLM 客户端副本
+//
+// 复用同一个 LLM 客户端，不影响全局单例的语言配置。
+//
+// 参数:
+//   - lang: 语言配置（如果为 nil，使用默认英文配置）
+//
+// 返回:
+//   - *PullRequestLLMClient: 新的 PR LLM 客户端实例
+func (c *PullRequestLLMClient) WithLanguage(lang *client.SupportedLanguage) *PullRequestLLMClient {
+	return newPullRequestLLMClient(c.llmClient, lang)
+}
+
+// WithStream 返回以流式方式调用 LLM 的 PR LLM 客户端副本
+//
+// 副本的所有请求都通过 LLMClient.Stream 发送，生成的内容到达时会传给 onDelta，
+// 可用于在终端中实时显示。响应不符合格式而重新请求时，先调用 onRetry，
+// 之后的 onDelta 是新响应的内容。不影响全局单例。
+//
+// 参数:
+//   - ctx: 请求上下文，取消后正在进行的请求会中止
+//   - onDelta: 每段生成的内容到达时调用
+//   - onRetry: 重新请求之前调用（可以为 nil）
+//
+// 返回:
+//   - *PullRequestLLMClient: 新的 PR LLM 客户端实例
+func (c *PullRequestLLMClient) WithStream(ctx context.Context, onDelta func(delta string), onRetry func()) *PullRequestLLMClient {
+	return newPullRequestLLMClient(client.NewStreamingClient(ctx, c.llmClient, onDelta, onRetry), c.lang)
+}
+
+// Refresh 返回跳过响应缓存读取的 PR LLM 客户端副本
+//
+// 用于重新生成：请求总是发送给 API，新的响应仍会写入缓存。
+// 应在 WithStream 之前调用。不影响全局单例。
+//
+// 返回:
+//   - *PullRequestLLMClient: 新的 PR LLM 客户端实例
+func (c *PullRequestLLMClient) Refresh() *PullRequestLLMClient {
+	return newPullRequestLLMClient(cache.Refresh(c.llmClient), c.lang)
+}
+
 // GenerateContent 生成 PR 内容（分支名、标题、描述和 scope）
 //
 // 根据 commit 标题和 git diff 生成符合规范的分支名、PR 标题、描述和 scope。
@@ -108,6 +153,21 @@ func (c *PullRequestLLMClient) Summarize(prTitle, prDiff string) (*PullRequestSu
 	return SummarizePR(prTitle, prDiff, c.lang, c.llmClient)
 }
 
+// SummarizeFromFileSummaries 基于每个文件的修改总结生成 PR 总结文档和文件名
+//
+// 用于 diff 过大的 PR，文件总结通常来自 SummarizeFileChange。
+//
+// 参数:
+//   - prTitle: PR 标题
+//   - summaries: 每个文件的修改总结
+//
+// 返回:
+//   - *PullRequestSummary: PR 总结结果，包含总结文档和文件名
+//   - error: 如果 LLM API 调用失败或响应格式不正确，返回相应的错误信息
+func (c *PullRequestLLMClient) SummarizeFromFileSummaries(prTitle string, summaries []FileChangeSummary) (*PullRequestSummary, error) {
+	return SummarizePRFromFileSummaries(prTitle, summaries, c.lang, c.llmClient)
+}
+
 // Reword 重写 PR 标题和描述
 //
 // 根据当前 PR 标题和 PR diff 内容生成更新的标题和完整的描述，用于更新现有 PR。
@@ -141,6 +201,22 @@ func (c *PullRequestLLMClient) SummarizeFileChange(filePath, fileDiff string) (s
 	return SummarizeFileChange(filePath, fileDiff, c.lang, c.llmClient)
 }
 
+// SummarizeFileChanges 逐个总结文件变更
+//
+// 用于压缩后仍超出 token 预算的 diff，每个文件的 diff 会截断到 maxTokens 以内。
+//
+// 参数:
+//   - files: 按文件拆分的 diff（通常来自 compact.Result.Files）
+//   - maxTokens: 单个文件 diff 的 token 预算（<= 0 表示不限制）
+//   - progress: 开始总结每个文件前调用（可以为 nil），index 从 0 开始
+//
+// 返回:
+//   - []FileChangeSummary: 每个文件的修改总结
+//   - error: 如果 LLM API 调用失败，返回相应的错误信息
+func (c *PullRequestLLMClient) SummarizeFileChanges(files []compact.FileDiff, maxTokens int, progress func(index, total int, path string)) ([]FileChangeSummary, error) {
+	return SummarizeFileChanges(files, maxTokens, progress, c.lang, c.llmClient)
+}
+
 // ============================================================================
 // GeneratePRContent 相关函数
 // ============================================================================
@@ -171,7 +247,10 @@ func GeneratePRContent(commitTitle string, existsBranches []string, gitDiff stri
 
 	// 构建请求参数
 	userPrompt := buildCreateUserPrompt(commitTitle, existsBranches, gitDiff)
-	systemPrompt := prompt.GenerateBranchSystemPrompt
+	systemPrompt, err := prompt.GenerateBranchSystemPrompt(prompt.NewVars(nil, gitDiff))
+	if err != nil {
+		return nil, fmt.Errorf("构建 system prompt 失败: %w", err)
+	}
 
 	// 记录 Prompt 构建完成
 	logger.Debugf("PR content generation prompt built: length=%d", len(userPrompt))
@@ -181,23 +260,22 @@ func GeneratePRContent(commitTitle string, existsBranches []string, gitDiff stri
 		UserPrompt:   userPrompt,
 		MaxTokens:    nil, // 不限制，确保有足够空间返回完整的 JSON（包括 description）
 		Temperature:  0.5,
+		Feature:      client.FeaturePRCreate,
 	}
 
-	// 调用 LLM API
-	response, err := llmClient.Call(params)
+	// 调用 LLM API 并校验响应
+	response, err := structured.Call[createResponse](llmClient, "pr_content", params, structured.DefaultMaxRepairs)
 	if err != nil {
+		if isValidationError(err) {
+			logger.WithError(err).WithField("commit_title", commitTitle).
+				Error("Failed to parse LLM response for PR content generation")
+			return nil, fmt.Errorf("解析 LLM 响应失败 (commit title: '%s'): %w", commitTitle, err)
+		}
 		logger.WithError(err).WithField("commit_title", commitTitle).
 			Error("Failed to call LLM API for PR content generation")
 		return nil, fmt.Errorf("调用 LLM API 生成分支名失败 (commit title: '%s'): %w", commitTitle, err)
 	}
-
-	// 解析响应
-	content, err := parseCreateResponse(response, commitTitle)
-	if err != nil {
-		logger.WithError(err).WithField("commit_title", commitTitle).
-			Error("Failed to parse LLM response for PR content generation")
-		return nil, fmt.Errorf("解析 LLM 响应失败 (commit title: '%s'): %w", commitTitle, err)
-	}
+	content := response.content()
 
 	// 记录 PR 内容生成成功
 	logger.WithFields(logging.Fields{
@@ -243,78 +321,22 @@ func buildCreateUserPrompt(commitTitle string, existsBranches []string, gitDiff
 	return strings.Join(parts, "\n")
 }
 
-// parseCreateResponse 解析 LLM 返回的 JSON 响应，提取分支名、PR 标题、描述和 scope
-//
-// 从 LLM 的 JSON 响应中提取 `branch_name`、`pr_title`、`description` 和 `scope` 字段。
-// 支持处理包含 markdown 代码块的响应格式。
-func parseCreateResponse(response, commitTitle string) (*PullRequestContent, error) {
-	logger := logging.GetLogger()
-
-	// 使用公共方法提取并修复 JSON（修复转义问题）
-	jsonStr := utils.ExtractAndFixJSON(response)
-
-	// 解析 JSON
-	var jsonData map[string]interface{}
-	if err := json.Unmarshal([]byte(jsonStr), &jsonData); err != nil {
-		logger.WithError(err).Error("Failed to extract JSON from LLM response")
-		return nil, fmt.Errorf("解析 LLM 响应为 JSON 失败。原始响应: %s: %w", jsonStr, err)
-	}
-
-	// 提取 branch_name
-	branchNameRaw, ok := jsonData["branch_name"]
-	if !ok {
-		logger.Error("LLM response missing required field: branch_name")
-		return nil, fmt.Errorf("LLM 响应中缺少 'branch_name' 字段")
-	}
-	branchName, ok := branchNameRaw.(string)
-	if !ok {
-		logger.Error("LLM response field type error: field=branch_name, expected=string")
-		return nil, fmt.Errorf("LLM 响应中 'branch_name' 字段类型错误")
-	}
-
-	// 提取 pr_title
-	prTitleRaw, ok := jsonData["pr_title"]
-	if !ok {
-		logger.Error("LLM response missing required field: pr_title")
-		return nil, fmt.Errorf("LLM 响应中缺少 'pr_title' 字段")
-	}
-	prTitle, ok := prTitleRaw.(string)
-	if !ok {
-		logger.Error("LLM response field type error: field=pr_title, expected=string")
-		return nil, fmt.Errorf("LLM 响应中 'pr_title' 字段类型错误")
-	}
-
-	// description 是可选的
-	var description *string
-	if descRaw, ok := jsonData["description"]; ok {
-		if descStr, ok := descRaw.(string); ok {
-			descTrimmed := strings.TrimSpace(descStr)
-			if descTrimmed != "" {
-				description = &descTrimmed
-			}
-		}
-	}
-
-	// scope 是可选的
-	var scope *string
-	if scopeRaw, ok := jsonData["scope"]; ok {
-		if scopeStr, ok := scopeRaw.(string); ok {
-			scopeTrimmed := strings.TrimSpace(scopeStr)
-			if scopeTrimmed != "" {
-				scope = &scopeTrimmed
-			}
-		}
-	}
-
-	// 清理分支名，确保只保留 ASCII 字符
-	cleanedBranchName := utils.SanitizeBranchName(strings.TrimSpace(branchName))
+// createResponse 生成 PR 内容时 LLM 返回的 JSON 响应
+type createResponse struct {
+	BranchName  string  `json:"branch_name"`
+	PRTitle     string  `json:"pr_title"`
+	Description *string `json:"description"`
+	Scope       *string `json:"scope"`
+}
 
+// content 转换为 PR 内容，清理分支名（只保留 ASCII 字符）并去除空白
+func (r *createResponse) content() *PullRequestContent {
 	return &PullRequestContent{
-		BranchName:  cleanedBranchName,
-		PRTitle:     strings.TrimSpace(prTitle),
-		Description: description,
-		Scope:       scope,
-	}, nil
+		BranchName:  utils.SanitizeBranchName(strings.TrimSpace(r.BranchName)),
+		PRTitle:     strings.TrimSpace(r.PRTitle),
+		Description: optional(r.Description),
+		Scope:       optional(r.Scope),
+	}
 }
 
 // ============================================================================
@@ -344,33 +366,67 @@ func SummarizePR(prTitle, prDiff string, lang *client.SupportedLanguage, llmClie
 		"language":       lang,
 	}).Info("Starting PR summarization")
 
-	// 构建请求参数
-	userPrompt := buildSummaryUserPrompt(prTitle, prDiff)
+	return requestSummary(prTitle, buildSummaryUserPrompt(prTitle, prDiff), lang, prompt.NewVars(lang, prDiff), llmClient)
+}
+
+// SummarizePRFromFileSummaries 基于每个文件的修改总结生成 PR 总结文档和文件名
+//
+// 用于 diff 过大、无法一次性发送给 LLM 的 PR：先通过 SummarizeFileChange 逐个总结文件，
+// 再将所有文件总结合并为一份 PR 总结文档。
+//
+// 参数:
+//   - prTitle: PR 标题
+//   - summaries: 每个文件的修改总结（按顺序）
+//   - lang: 语言配置（如果为 nil，使用默认英文配置）
+//   - llmClient: LLM 客户端实例
+//
+// 返回:
+//   - *PullRequestSummary: PR 总结结果，包含总结文档和文件名
+//   - error: 如果 LLM API 调用失败或响应格式不正确，返回相应的错误信息
+func SummarizePRFromFileSummaries(prTitle string, summaries []FileChangeSummary, lang *client.SupportedLanguage, llmClient client.LLMClient) (*PullRequestSummary, error) {
+	logger := logging.GetLogger()
+
+	// 记录 PR 总结开始
+	logger.WithFields(logging.Fields{
+		"pr_title":   prTitle,
+		"file_count": len(summaries),
+		"language":   lang,
+	}).Info("Starting PR summarization from file summaries")
+
+	return requestSummary(prTitle, buildFileSummariesUserPrompt(prTitle, summaries), lang, prompt.NewVars(lang, ""), llmClient)
+}
+
+// requestSummary 发送 PR 总结请求并解析响应
+func requestSummary(prTitle, userPrompt string, lang *client.SupportedLanguage, vars prompt.Vars, llmClient client.LLMClient) (*PullRequestSummary, error) {
+	logger := logging.GetLogger()
+
 	// 根据语言生成 system prompt
-	systemPrompt := prompt.GenerateSummarizePRSystemPrompt(lang)
+	systemPrompt, err := prompt.GenerateSummarizePRSystemPrompt(lang, vars)
+	if err != nil {
+		return nil, fmt.Errorf("构建 system prompt 失败: %w", err)
+	}
 
 	params := &client.LLMRequestParams{
 		SystemPrompt: systemPrompt,
 		UserPrompt:   userPrompt,
 		MaxTokens:    nil, // 增加 token 数量，确保有足够空间返回完整的总结文档
 		Temperature:  0.3, // 降低温度，使输出更稳定
+		Feature:      client.FeaturePRSummarize,
 	}
 
-	// 调用 LLM API
-	response, err := llmClient.Call(params)
+	// 调用 LLM API 并校验响应
+	response, err := structured.Call[summaryResponse](llmClient, "pr_summary", params, structured.DefaultMaxRepairs)
 	if err != nil {
+		if isValidationError(err) {
+			logger.WithError(err).WithField("pr_title", prTitle).
+				Error("Failed to parse LLM response for PR summarization")
+			return nil, fmt.Errorf("解析 LLM 响应失败 (PR title: '%s'): %w", prTitle, err)
+		}
 		logger.WithError(err).WithField("pr_title", prTitle).
 			Error("Failed to call LLM API for PR summarization")
 		return nil, fmt.Errorf("调用 LLM API 总结 PR 失败 (PR title: '%s'): %w", prTitle, err)
 	}
-
-	// 解析响应
-	summary, err := parseSummaryResponse(response, prTitle)
-	if err != nil {
-		logger.WithError(err).WithField("pr_title", prTitle).
-			Error("Failed to parse LLM response for PR summarization")
-		return nil, fmt.Errorf("解析 LLM 响应失败 (PR title: '%s'): %w", prTitle, err)
-	}
+	summary := response.summary()
 
 	// 记录 PR 总结成功
 	logger.WithFields(logging.Fields{
@@ -393,59 +449,31 @@ func buildSummaryUserPrompt(prTitle, prDiff string) string {
 	return strings.Join(parts, "\n\n")
 }
 
-// parseSummaryResponse 解析 LLM 返回的 JSON 响应，提取总结文档和文件名
-//
-// 从 LLM 的 JSON 响应中提取 `summary` 和 `filename` 字段。
-// 支持处理包含 markdown 代码块的响应格式。
-func parseSummaryResponse(response, prTitle string) (*PullRequestSummary, error) {
-	logger := logging.GetLogger()
-
-	// 使用公共方法提取并修复 JSON（修复转义问题）
-	jsonStr := utils.ExtractAndFixJSON(response)
-
-	// 解析 JSON
-	var jsonData map[string]interface{}
-	if err := json.Unmarshal([]byte(jsonStr), &jsonData); err != nil {
-		logger.WithError(err).Error("Failed to parse PR summary response as JSON")
-		return nil, fmt.Errorf("解析 LLM 响应为 JSON 失败。原始响应: %s: %w", jsonStr, err)
-	}
-
-	// 提取 summary
-	summaryRaw, ok := jsonData["summary"]
-	if !ok {
-		logger.Error("PR summary response missing required field: summary")
-		return nil, fmt.Errorf("LLM 响应中缺少 'summary' 字段")
-	}
-	summary, ok := summaryRaw.(string)
-	if !ok {
-		logger.Error("PR summary response field type error: field=summary, expected=string")
-		return nil, fmt.Errorf("LLM 响应中 'summary' 字段类型错误")
-	}
-
-	// 提取 filename
-	filenameRaw, ok := jsonData["filename"]
-	if !ok {
-		logger.Error("PR summary response missing required field: filename")
-		return nil, fmt.Errorf("LLM 响应中缺少 'filename' 字段")
-	}
-	filename, ok := filenameRaw.(string)
-	if !ok {
-		logger.Error("PR summary response field type error: field=filename, expected=string")
-		return nil, fmt.Errorf("LLM 响应中 'filename' 字段类型错误")
-	}
+// buildFileSummariesUserPrompt 生成基于文件总结的 PR 总结 user prompt
+func buildFileSummariesUserPrompt(prTitle string, summaries []FileChangeSummary) string {
+	return fmt.Sprintf("PR Title: %s\n\n%s", prTitle, FormatFileSummaries(summaries))
+}
 
-	// 清理文件名，确保只包含有效的文件名字符
-	cleanedFilename := utils.CleanFilename(filename)
+// summaryResponse 总结 PR 时 LLM 返回的 JSON 响应
+type summaryResponse struct {
+	Summary  string `json:"summary"`
+	Filename string `json:"filename"`
+}
 
-	if cleanedFilename == "" {
-		logger.Error("Cleaned filename is empty after sanitization")
-		return nil, fmt.Errorf("清理后的文件名为空")
+// Validate 校验清理后的文件名不为空（实现 structured.Validator 接口）
+func (r *summaryResponse) Validate() error {
+	if utils.CleanFilename(r.Filename) == "" {
+		return fmt.Errorf("'filename' 字段清理后为空，应包含字母或数字")
 	}
+	return nil
+}
 
+// summary 转换为 PR 总结结果，清理文件名（只保留有效的文件名字符）
+func (r *summaryResponse) summary() *PullRequestSummary {
 	return &PullRequestSummary{
-		Summary:  strings.TrimSpace(summary),
-		Filename: cleanedFilename,
-	}, nil
+		Summary:  strings.TrimSpace(r.Summary),
+		Filename: utils.CleanFilename(r.Filename),
+	}
 }
 
 // ============================================================================
@@ -483,30 +511,32 @@ func RewordPR(prDiff string, currentTitle *string, llmClient client.LLMClient) (
 
 	// 构建请求参数
 	userPrompt := buildRewordUserPrompt(prDiff, currentTitle)
-	systemPrompt := prompt.RewordPRSystemPrompt
+	systemPrompt, err := prompt.RewordPRSystemPrompt(prompt.NewVars(nil, prDiff))
+	if err != nil {
+		return nil, fmt.Errorf("构建 system prompt 失败: %w", err)
+	}
 
 	params := &client.LLMRequestParams{
 		SystemPrompt: systemPrompt,
 		UserPrompt:   userPrompt,
 		MaxTokens:    nil, // 增加 token 限制以支持更完整的描述
 		Temperature:  0.5,
+		Feature:      client.FeaturePRReword,
 	}
 
-	// 调用 LLM API
-	response, err := llmClient.Call(params)
+	// 调用 LLM API 并校验响应
+	response, err := structured.Call[rewordResponse](llmClient, "pr_reword", params, structured.DefaultMaxRepairs)
 	if err != nil {
+		if isValidationError(err) {
+			logger.WithError(err).WithField("current_title", titleStr).
+				Error("Failed to parse LLM response for PR reword")
+			return nil, fmt.Errorf("解析 LLM 响应失败 (current title: '%s'): %w", titleStr, err)
+		}
 		logger.WithError(err).WithField("current_title", titleStr).
 			Error("Failed to call LLM API for PR reword")
 		return nil, fmt.Errorf("调用 LLM API 重写 PR 失败 (current title: '%s'): %w", titleStr, err)
 	}
-
-	// 解析响应
-	reword, err := parseRewordResponse(response, currentTitle)
-	if err != nil {
-		logger.WithError(err).WithField("current_title", titleStr).
-			Error("Failed to parse LLM response for PR reword")
-		return nil, fmt.Errorf("解析 LLM 响应失败 (current title: '%s'): %w", titleStr, err)
-	}
+	reword := response.reword()
 
 	// 记录 PR 重写成功
 	logger.WithFields(logging.Fields{
@@ -548,50 +578,36 @@ func buildRewordUserPrompt(prDiff string, currentTitle *string) string {
 	return strings.Join(parts, "\n")
 }
 
-// parseRewordResponse 解析 LLM 返回的 JSON 响应，提取 PR 标题和描述
-//
-// 从 LLM 的 JSON 响应中提取 `pr_title` 和 `description` 字段。
-// 支持处理包含 markdown 代码块的响应格式。
-func parseRewordResponse(response string, currentTitle *string) (*PullRequestReword, error) {
-	logger := logging.GetLogger()
-
-	// 使用公共方法提取并修复 JSON（修复转义问题）
-	jsonStr := utils.ExtractAndFixJSON(response)
+// rewordResponse 重写 PR 时 LLM 返回的 JSON 响应
+type rewordResponse struct {
+	PRTitle     string  `json:"pr_title"`
+	Description *string `json:"description"`
+}
 
-	// 解析 JSON
-	var jsonData map[string]interface{}
-	if err := json.Unmarshal([]byte(jsonStr), &jsonData); err != nil {
-		logger.WithError(err).Error("Failed to parse PR reword response")
-		return nil, fmt.Errorf("解析 LLM 响应为 JSON 失败。原始响应: %s: %w", jsonStr, err)
+// reword 转换为 PR Reword 结果并去除空白
+func (r *rewordResponse) reword() *PullRequestReword {
+	return &PullRequestReword{
+		PRTitle:     strings.TrimSpace(r.PRTitle),
+		Description: optional(r.Description),
 	}
+}
 
-	// 提取 pr_title
-	prTitleRaw, ok := jsonData["pr_title"]
-	if !ok {
-		logger.Error("PR reword response missing required field: pr_title")
-		return nil, fmt.Errorf("LLM 响应中缺少 'pr_title' 字段")
+// optional 去除可选字段的空白，为空时返回 nil
+func optional(value *string) *string {
+	if value == nil {
+		return nil
 	}
-	prTitle, ok := prTitleRaw.(string)
-	if !ok {
-		logger.Error("PR reword response field type error: field=pr_title, expected=string")
-		return nil, fmt.Errorf("LLM 响应中 'pr_title' 字段类型错误")
-	}
-
-	// description 是可选的
-	var description *string
-	if descRaw, ok := jsonData["description"]; ok {
-		if descStr, ok := descRaw.(string); ok {
-			descTrimmed := strings.TrimSpace(descStr)
-			if descTrimmed != "" {
-				description = &descTrimmed
-			}
-		}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
 	}
+	return &trimmed
+}
 
-	return &PullRequestReword{
-		PRTitle:     strings.TrimSpace(prTitle),
-		Description: description,
-	}, nil
+// isValidationError 判断错误是否是响应不符合 schema（而不是 LLM API 调用失败）
+func isValidationError(err error) bool {
+	var validationErr *structured.ValidationError
+	return errors.As(err, &validationErr)
 }
 
 // ============================================================================
@@ -624,13 +640,17 @@ func SummarizeFileChange(filePath, fileDiff string, lang *client.SupportedLangua
 	// 构建请求参数
 	userPrompt := buildFileSummaryUserPrompt(filePath, fileDiff)
 	// 根据语言生成 system prompt
-	systemPrompt := prompt.GenerateSummarizeFileChangeSystemPrompt(lang)
+	systemPrompt, err := prompt.GenerateSummarizeFileChangeSystemPrompt(lang, prompt.NewVars(lang, fileDiff))
+	if err != nil {
+		return "", fmt.Errorf("构建 system prompt 失败: %w", err)
+	}
 
 	params := &client.LLMRequestParams{
 		SystemPrompt: systemPrompt,
 		UserPrompt:   userPrompt,
 		MaxTokens:    nil, // 单个文件的总结应该比较简短
 		Temperature:  0.3,
+		Feature:      client.FeatureFileSummary,
 	}
 
 	// 调用 LLM API
@@ -653,6 +673,82 @@ func SummarizeFileChange(filePath, fileDiff string, lang *client.SupportedLangua
 	return summary, nil
 }
 
+// SummarizeFileChanges 逐个总结文件变更
+//
+// 没有 hunk 的文件（二进制文件、纯重命名、删除等）根据文件头描述，不调用 LLM。
+//
+// 参数:
+//   - files: 按文件拆分的 diff
+//   - maxTokens: 单个文件 diff 的 token 预算（<= 0 表示不限制）
+//   - progress: 开始总结每个文件前调用（可以为 nil），index 从 0 开始
+//   - lang: 语言配置（如果为 nil，使用默认英文配置）
+//   - llmClient: LLM 客户端实例
+//
+// 返回:
+//   - []FileChangeSummary: 每个文件的修改总结
+//   - error: 如果 LLM API 调用失败，返回相应的错误信息
+func SummarizeFileChanges(files []compact.FileDiff, maxTokens int, progress func(index, total int, path string), lang *client.SupportedLanguage, llmClient client.LLMClient) ([]FileChangeSummary, error) {
+	summaries := make([]FileChangeSummary, 0, len(files))
+	for i, file := range files {
+		if progress != nil {
+			progress(i, len(files), file.Path)
+		}
+
+		if !strings.Contains(file.Patch, "\n@@") {
+			summaries = append(summaries, FileChangeSummary{Path: file.Path, Summary: describeFileWithoutHunks(file.Patch)})
+			continue
+		}
+
+		patch, _ := compact.Truncate(file.Patch, maxTokens)
+		summary, err := SummarizeFileChange(file.Path, patch, lang, llmClient)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, FileChangeSummary{Path: file.Path, Summary: summary})
+	}
+	return summaries, nil
+}
+
+// describeFileWithoutHunks 根据文件头描述没有 hunk 的文件变更
+func describeFileWithoutHunks(patch string) string {
+	var description string
+	for _, line := range strings.Split(patch, "\n") {
+		switch {
+		case strings.HasPrefix(line, "Binary files "):
+			return "Binary file changed"
+		case strings.HasPrefix(line, "rename from "):
+			description = "Renamed from " + strings.TrimPrefix(line, "rename from ") + " without content changes"
+		case strings.HasPrefix(line, "deleted file mode"):
+			description = "Empty file deleted"
+		case strings.HasPrefix(line, "new file mode"):
+			description = "Empty file added"
+		case strings.HasPrefix(line, "new mode ") && description == "":
+			description = "File mode changed to " + strings.TrimPrefix(line, "new mode ")
+		}
+	}
+	if description == "" {
+		return "Diff not available"
+	}
+	return description
+}
+
+// FormatFileSummaries 将文件总结格式化为文本
+//
+// 用于 diff 过大时代替 diff 放入 prompt。
+//
+// 参数:
+//   - summaries: 每个文件的修改总结
+//
+// 返回:
+//   - string: 格式化后的文本
+func FormatFileSummaries(summaries []FileChangeSummary) string {
+	parts := []string{"The diff is too large to include. Summaries of the changes in each file:"}
+	for _, s := range summaries {
+		parts = append(parts, fmt.Sprintf("File: %s\n%s", s.Path, strings.TrimSpace(s.Summary)))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
 // buildFileSummaryUserPrompt 生成单个文件修改总结的 user prompt
 func buildFileSummaryUserPrompt(filePath, fileDiff string) string {
 	return fmt.Sprintf("File path: %s\n\nFile diff:\n%s", filePath, fileDiff)
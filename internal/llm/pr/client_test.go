@@ -1,14 +1,19 @@
 package pr
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zevwings/workflow/internal/llm/client"
+	"github.com/zevwings/workflow/internal/llm/compact"
+	"github.com/zevwings/workflow/internal/llm/structured"
 )
 
 // ==================== NewPullRequestLLMClient 测试 ====================
@@ -448,3 +453,234 @@ func TestPullRequestLLMClient_SummarizeFileChange(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, summary, "authentication")
 }
+
+// ==================== SummarizeFromFileSummaries 测试 ====================
+
+// recordingLLMClient 记录请求参数并返回固定响应的 LLM 客户端
+type recordingLLMClient struct {
+	response string
+	params   *client.LLMRequestParams
+	streamed bool
+}
+
+func (c *recordingLLMClient) Call(params *client.LLMRequestParams) (string, error) {
+	c.params = params
+	return c.response, nil
+}
+
+// Stream 记录请求参数并按 8 个字节一段返回固定响应
+func (c *recordingLLMClient) Stream(ctx context.Context, params *client.LLMRequestParams, onDelta func(delta string)) (string, error) {
+	c.params = params
+	c.streamed = true
+	for i := 0; i < len(c.response); i += 8 {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		onDelta(c.response[i:min(i+8, len(c.response))])
+	}
+	return c.response, nil
+}
+
+func TestPullRequestLLMClient_SummarizeFromFileSummaries(t *testing.T) {
+	llmClient := &recordingLLMClient{
+		response: `{"summary": "# PR Summary\n\nLarge refactoring.", "filename": "large-refactoring"}`,
+	}
+	prClient := newPullRequestLLMClient(llmClient, nil)
+
+	summary, err := prClient.SummarizeFromFileSummaries("Refactor", []FileChangeSummary{
+		{Path: "a.go", Summary: "Renamed helpers"},
+		{Path: "b.go", Summary: "Removed dead code\n"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "large-refactoring", summary.Filename)
+
+	require.NotNil(t, llmClient.params)
+	assert.Contains(t, llmClient.params.UserPrompt, "PR Title: Refactor")
+	assert.Contains(t, llmClient.params.UserPrompt, "File: a.go\nRenamed helpers")
+	assert.Contains(t, llmClient.params.UserPrompt, "File: b.go\nRemoved dead code")
+	assert.NotContains(t, llmClient.params.UserPrompt, "PR Diff:")
+}
+
+// ==================== 结构化输出测试 ====================
+
+// sequenceLLMClient 依次返回固定响应的 LLM 客户端，最后一个响应会重复使用
+type sequenceLLMClient struct {
+	responses []string
+	calls     int
+}
+
+func (c *sequenceLLMClient) Call(params *client.LLMRequestParams) (string, error) {
+	c.calls++
+	return c.responses[min(c.calls, len(c.responses))-1], nil
+}
+
+func (c *sequenceLLMClient) Stream(ctx context.Context, params *client.LLMRequestParams, onDelta func(delta string)) (string, error) {
+	response, err := c.Call(params)
+	if onDelta != nil {
+		onDelta(response)
+	}
+	return response, err
+}
+
+func TestPullRequestLLMClient_GenerateContent_RepairsResponse(t *testing.T) {
+	// Arrange: 第一次响应缺少 branch_name，重新请求后正确
+	llmClient := &sequenceLLMClient{responses: []string{
+		`{"pr_title": "Add user login"}`,
+		`{"branch_name": "user-login", "pr_title": " Add user login ", "description": "  ", "scope": "auth"}`,
+	}}
+	prClient := newPullRequestLLMClient(llmClient, nil)
+
+	// Act
+	content, err := prClient.GenerateContent("fix: add user login", nil, "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, llmClient.calls)
+	assert.Equal(t, "user-login", content.BranchName)
+	assert.Equal(t, "Add user login", content.PRTitle)
+	assert.Nil(t, content.Description)
+	require.NotNil(t, content.Scope)
+	assert.Equal(t, "auth", *content.Scope)
+}
+
+func TestPullRequestLLMClient_Summarize_InvalidResponse(t *testing.T) {
+	// Arrange: 文件名清理后始终为空
+	llmClient := &sequenceLLMClient{responses: []string{`{"summary": "# PR Summary", "filename": "???"}`}}
+	prClient := newPullRequestLLMClient(llmClient, nil)
+
+	// Act
+	_, err := prClient.Summarize("Add user authentication", "diff content")
+
+	// Assert: 重新请求后仍然失败，返回类型化的错误
+	require.Error(t, err)
+	var validationErr *structured.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "pr_summary", validationErr.Name)
+	assert.Equal(t, structured.DefaultMaxRepairs+1, llmClient.calls)
+	assert.Contains(t, err.Error(), "解析 LLM 响应失败")
+	assert.Contains(t, err.Error(), "'filename' 字段清理后为空")
+}
+
+func TestPullRequestLLMClient_Reword_TypeError(t *testing.T) {
+	llmClient := &sequenceLLMClient{responses: []string{`{"pr_title": ["Add", "login"]}`}}
+	prClient := newPullRequestLLMClient(llmClient, nil)
+
+	_, err := prClient.Reword("diff content", nil)
+
+	var fieldErr *structured.FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, &structured.FieldError{Field: "pr_title", Expected: "string"}, fieldErr)
+}
+
+// ==================== SummarizeFileChanges 测试 ====================
+
+func TestPullRequestLLMClient_SummarizeFileChanges(t *testing.T) {
+	llmClient := &recordingLLMClient{response: "Changed the greeting"}
+	prClient := newPullRequestLLMClient(llmClient, nil)
+
+	files := []compact.FileDiff{
+		{Path: "main.go", Patch: "diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n@@ -1 +1 @@\n-hello\n+" + strings.Repeat("x", 400) + "\n"},
+		{Path: "logo.png", Patch: "diff --git a/logo.png b/logo.png\nBinary files a/logo.png and b/logo.png differ\n"},
+		{Path: "new.txt", Patch: "diff --git a/old.txt b/new.txt\nsimilarity index 100%\nrename from old.txt\nrename to new.txt\n"},
+	}
+
+	var progress []string
+	summaries, err := prClient.SummarizeFileChanges(files, 30, func(index, total int, path string) {
+		progress = append(progress, fmt.Sprintf("%d/%d %s", index+1, total, path))
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []FileChangeSummary{
+		{Path: "main.go", Summary: "Changed the greeting"},
+		{Path: "logo.png", Summary: "Binary file changed"},
+		{Path: "new.txt", Summary: "Renamed from old.txt without content changes"},
+	}, summaries)
+	assert.Equal(t, []string{"1/3 main.go", "2/3 logo.png", "3/3 new.txt"}, progress)
+
+	// 只有 main.go 调用了 LLM，且 diff 被截断到预算以内
+	require.NotNil(t, llmClient.params)
+	assert.Contains(t, llmClient.params.UserPrompt, "File path: main.go")
+	assert.Contains(t, llmClient.params.UserPrompt, "-hello")
+	assert.NotContains(t, llmClient.params.UserPrompt, strings.Repeat("x", 400))
+}
+
+func TestFormatFileSummaries(t *testing.T) {
+	text := FormatFileSummaries([]FileChangeSummary{
+		{Path: "a.go", Summary: "Renamed helpers\n"},
+		{Path: "b.go", Summary: "Removed dead code"},
+	})
+	assert.Equal(t, "The diff is too large to include. Summaries of the changes in each file:\n\n"+
+		"File: a.go\nRenamed helpers\n\nFile: b.go\nRemoved dead code", text)
+}
+
+// ==================== WithStream 测试 ====================
+
+func TestPullRequestLLMClient_WithStream(t *testing.T) {
+	llmClient := &recordingLLMClient{
+		response: `{"summary": "# PR Summary\n\nStreamed.", "filename": "streamed"}`,
+	}
+	prClient := newPullRequestLLMClient(llmClient, nil)
+
+	var streamed strings.Builder
+	summary, err := prClient.WithStream(context.Background(), func(delta string) {
+		streamed.WriteString(delta)
+	}, nil).Summarize("Stream", "diff")
+	require.NoError(t, err)
+
+	assert.True(t, llmClient.streamed)
+	assert.Equal(t, llmClient.response, streamed.String())
+	assert.Equal(t, "streamed", summary.Filename)
+
+	// 原客户端不受影响
+	llmClient.streamed = false
+	_, err = prClient.Summarize("Stream", "diff")
+	require.NoError(t, err)
+	assert.False(t, llmClient.streamed)
+}
+
+func TestPullRequestLLMClient_WithStream_Cancelled(t *testing.T) {
+	llmClient := &recordingLLMClient{response: `{"pr_title": "Title"}`}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := newPullRequestLLMClient(llmClient, nil).WithStream(ctx, func(string) {}, nil).Reword("diff", nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPullRequestLLMClient_WithStream_Retry(t *testing.T) {
+	// Arrange: 第一次响应缺少 filename，重新请求后正确
+	llmClient := &sequenceLLMClient{responses: []string{
+		`{"summary": "Rejected"}`,
+		`{"summary": "Repaired", "filename": "repaired"}`,
+	}}
+	var attempts []string
+	streamed := ""
+
+	// Act
+	summary, err := newPullRequestLLMClient(llmClient, nil).WithStream(context.Background(), func(delta string) {
+		streamed += delta
+	}, func() {
+		attempts = append(attempts, streamed)
+		streamed = ""
+	}).Summarize("Stream", "diff")
+
+	// Assert: 重新请求前通知调用方，之后只收到新响应的内容
+	require.NoError(t, err)
+	assert.Equal(t, "repaired", summary.Filename)
+	assert.Equal(t, []string{`{"summary": "Rejected"}`}, attempts)
+	assert.Equal(t, `{"summary": "Repaired", "filename": "repaired"}`, streamed)
+}
+
+// ==================== WithLanguage 测试 ====================
+
+func TestPullRequestLLMClient_WithLanguage(t *testing.T) {
+	llmClient := &recordingLLMClient{}
+	prClient := newPullRequestLLMClient(llmClient, nil)
+	lang := &client.SupportedLanguage{Code: "zh-CN", Name: "Chinese"}
+
+	localized := prClient.WithLanguage(lang)
+
+	assert.Equal(t, lang, localized.lang)
+	assert.Nil(t, prClient.lang)
+	assert.Equal(t, prClient.llmClient, localized.llmClient)
+}
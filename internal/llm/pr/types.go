@@ -36,3 +36,13 @@ type PullRequestSummary struct {
 	// Filename 文件名（不含路径和扩展名）
 	Filename string
 }
+
+// FileChangeSummary 单个文件的修改总结
+//
+// 由 SummarizeFileChange 生成，用于合并为完整的 PR 总结。
+type FileChangeSummary struct {
+	// Path 文件路径
+	Path string
+	// Summary 文件的修改总结（纯文本）
+	Summary string
+}
@@ -3,5 +3,14 @@ package prompt
 // GenerateBranchSystemPrompt 生成分支名的 system prompt
 //
 // 用于根据 commit 标题和 git 变更生成分支名、PR 标题和描述。
-// 从嵌入的模板文件中加载。
-var GenerateBranchSystemPrompt = MustLoadTemplate("branch.md")
+// 使用 branch.md 模板渲染。
+//
+// 参数:
+//   - vars: 模板变量
+//
+// 返回:
+//   - string: system prompt
+//   - error: 如果模板加载或渲染失败，返回错误
+func GenerateBranchSystemPrompt(vars Vars) (string, error) {
+	return Render("branch.md", vars)
+}
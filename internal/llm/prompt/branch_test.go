@@ -4,13 +4,15 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // ==================== GenerateBranchSystemPrompt 测试 ====================
 
 func TestGenerateBranchSystemPrompt(t *testing.T) {
 	// Act: 获取分支 prompt
-	prompt := GenerateBranchSystemPrompt
+	prompt, err := GenerateBranchSystemPrompt(Vars{})
+	require.NoError(t, err)
 
 	// Assert: 验证 prompt 不为空
 	assert.NotEmpty(t, prompt, "分支 prompt 不应为空")
@@ -19,10 +21,11 @@ func TestGenerateBranchSystemPrompt(t *testing.T) {
 
 func TestGenerateBranchSystemPrompt_LoadedFromTemplate(t *testing.T) {
 	// Act: 获取分支 prompt
-	prompt := GenerateBranchSystemPrompt
+	prompt, err := GenerateBranchSystemPrompt(Vars{})
+	require.NoError(t, err)
 
 	// Assert: 验证 prompt 已从模板加载
-	// 由于 GenerateBranchSystemPrompt 是使用 MustLoadTemplate 在包初始化时加载的
+	// 由于 GenerateBranchSystemPrompt 渲染的是嵌入的默认模板
 	// 我们应该验证它包含一些预期的内容
 	assert.NotEmpty(t, prompt, "分支 prompt 不应为空")
 
@@ -33,8 +36,10 @@ func TestGenerateBranchSystemPrompt_LoadedFromTemplate(t *testing.T) {
 
 func TestGenerateBranchSystemPrompt_Consistent(t *testing.T) {
 	// Act: 多次获取分支 prompt
-	prompt1 := GenerateBranchSystemPrompt
-	prompt2 := GenerateBranchSystemPrompt
+	prompt1, err := GenerateBranchSystemPrompt(Vars{})
+	require.NoError(t, err)
+	prompt2, err := GenerateBranchSystemPrompt(Vars{})
+	require.NoError(t, err)
 
 	// Assert: 验证输出一致
 	assert.Equal(t, prompt1, prompt2, "分支 prompt 应该保持一致")
@@ -42,11 +47,9 @@ func TestGenerateBranchSystemPrompt_Consistent(t *testing.T) {
 
 func TestGenerateBranchSystemPrompt_NotPanic(t *testing.T) {
 	// Act & Assert: 验证获取 prompt 不会 panic
-	// 由于 GenerateBranchSystemPrompt 是使用 MustLoadTemplate 加载的
-	// 如果模板不存在，会在包初始化时 panic
-	// 这个测试主要验证变量可以正常访问
+	// 这个测试主要验证函数可以正常调用
 	assert.NotPanics(t, func() {
-		_ = GenerateBranchSystemPrompt
+		_, _ = GenerateBranchSystemPrompt(Vars{})
 	}, "获取分支 prompt 不应该 panic")
 }
 
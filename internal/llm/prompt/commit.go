@@ -0,0 +1,16 @@
+package prompt
+
+// GenerateCommitSystemPrompt 生成提交消息的 system prompt
+//
+// 用于根据暂存区的变更生成 Conventional Commits 格式的 type、scope、subject 和 body。
+// 使用 commit.md 模板渲染。
+//
+// 参数:
+//   - vars: 模板变量
+//
+// 返回:
+//   - string: system prompt
+//   - error: 如果模板加载或渲染失败，返回错误
+func GenerateCommitSystemPrompt(vars Vars) (string, error) {
+	return Render("commit.md", vars)
+}
@@ -0,0 +1,22 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==================== GenerateCommitSystemPrompt 测试 ====================
+
+func TestGenerateCommitSystemPrompt(t *testing.T) {
+	// Act: 获取提交消息 prompt
+	prompt, err := GenerateCommitSystemPrompt(Vars{})
+	require.NoError(t, err)
+
+	// Assert: 验证 prompt 已从模板加载，并说明了响应字段
+	assert.NotEmpty(t, prompt, "提交消息 prompt 不应为空")
+	for _, field := range []string{"type", "scope", "subject", "body"} {
+		assert.Contains(t, prompt, field, "提交消息 prompt 应该说明字段: %s", field)
+	}
+}
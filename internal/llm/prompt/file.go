@@ -8,17 +8,22 @@ import (
 //
 // 参数:
 //   - lang: 语言配置（如果为 nil，使用默认英文配置）
+//   - vars: 模板变量
 //
 // 返回:
 //   - string: 根据语言定制的 system prompt
+//   - error: 如果模板加载或渲染失败，返回错误
 //
 // 说明:
 //
 //	如果 lang 为 nil，将使用默认的英文配置。
-func GenerateSummarizeFileChangeSystemPrompt(lang *client.SupportedLanguage) string {
-	// 从嵌入的模板文件中加载基础 prompt
-	basePrompt := MustLoadTemplate("file-summary.md")
+func GenerateSummarizeFileChangeSystemPrompt(lang *client.SupportedLanguage, vars Vars) (string, error) {
+	// 渲染基础 prompt
+	basePrompt, err := Render("file-summary.md", vars)
+	if err != nil {
+		return "", err
+	}
 
 	// 使用语言增强功能
-	return client.GetLanguageRequirement(basePrompt, lang)
+	return client.GetLanguageRequirement(basePrompt, lang), nil
 }
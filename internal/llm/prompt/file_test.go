@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/zevwings/workflow/internal/llm/client"
 )
 
@@ -42,7 +43,8 @@ func TestGenerateSummarizeFileChangeSystemPrompt(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Act: 生成文件总结 prompt
-			prompt := GenerateSummarizeFileChangeSystemPrompt(tt.lang)
+			prompt, err := GenerateSummarizeFileChangeSystemPrompt(tt.lang, Vars{})
+			require.NoError(t, err)
 
 			// Assert: 验证 prompt 不为空
 			assert.NotEmpty(t, prompt, "prompt 不应为空")
@@ -53,7 +55,8 @@ func TestGenerateSummarizeFileChangeSystemPrompt(t *testing.T) {
 
 func TestGenerateSummarizeFileChangeSystemPrompt_ContainsBaseTemplate(t *testing.T) {
 	// Act: 生成文件总结 prompt（使用默认语言）
-	prompt := GenerateSummarizeFileChangeSystemPrompt(nil)
+	prompt, err := GenerateSummarizeFileChangeSystemPrompt(nil, Vars{})
+	require.NoError(t, err)
 
 	// Assert: 验证包含基础模板内容
 	// 由于基础模板是从 file-summary.md 加载的，我们应该验证 prompt 包含一些预期的内容
@@ -92,7 +95,8 @@ func TestGenerateSummarizeFileChangeSystemPrompt_LanguageRequirement(t *testing.
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Act: 生成文件总结 prompt
-			prompt := GenerateSummarizeFileChangeSystemPrompt(tt.lang)
+			prompt, err := GenerateSummarizeFileChangeSystemPrompt(tt.lang, Vars{})
+			require.NoError(t, err)
 
 			// Assert: 验证包含语言要求
 			assert.Contains(t, prompt, tt.expected, "应该包含语言要求: %s", tt.expected)
@@ -104,7 +108,8 @@ func TestGenerateSummarizeFileChangeSystemPrompt_LanguageRequirement(t *testing.
 
 func TestGenerateSummarizeFileChangeSystemPrompt_NilLanguageUsesDefault(t *testing.T) {
 	// Act: 生成文件总结 prompt（nil 语言）
-	prompt := GenerateSummarizeFileChangeSystemPrompt(nil)
+	prompt, err := GenerateSummarizeFileChangeSystemPrompt(nil, Vars{})
+	require.NoError(t, err)
 
 	// Assert: 验证使用默认英文配置
 	assert.Contains(t, prompt, "English", "nil 语言应该使用默认英文配置")
@@ -113,7 +118,8 @@ func TestGenerateSummarizeFileChangeSystemPrompt_NilLanguageUsesDefault(t *testi
 
 func TestGenerateSummarizeFileChangeSystemPrompt_Formatting(t *testing.T) {
 	// Act: 生成文件总结 prompt
-	prompt := GenerateSummarizeFileChangeSystemPrompt(nil)
+	prompt, err := GenerateSummarizeFileChangeSystemPrompt(nil, Vars{})
+	require.NoError(t, err)
 
 	// Assert: 验证格式正确
 	// 验证包含必要的分隔符和结构
@@ -126,8 +132,10 @@ func TestGenerateSummarizeFileChangeSystemPrompt_Formatting(t *testing.T) {
 
 func TestGenerateSummarizeFileChangeSystemPrompt_ConsistentOutput(t *testing.T) {
 	// Act: 多次生成 prompt（相同配置）
-	prompt1 := GenerateSummarizeFileChangeSystemPrompt(nil)
-	prompt2 := GenerateSummarizeFileChangeSystemPrompt(nil)
+	prompt1, err := GenerateSummarizeFileChangeSystemPrompt(nil, Vars{})
+	require.NoError(t, err)
+	prompt2, err := GenerateSummarizeFileChangeSystemPrompt(nil, Vars{})
+	require.NoError(t, err)
 
 	// Assert: 验证输出一致
 	assert.Equal(t, prompt1, prompt2, "相同配置应该生成相同的 prompt")
@@ -149,8 +157,10 @@ func TestGenerateSummarizeFileChangeSystemPrompt_DifferentLanguages(t *testing.T
 	}
 
 	// Act: 生成不同语言的 prompt
-	prompt1 := GenerateSummarizeFileChangeSystemPrompt(lang1)
-	prompt2 := GenerateSummarizeFileChangeSystemPrompt(lang2)
+	prompt1, err := GenerateSummarizeFileChangeSystemPrompt(lang1, Vars{})
+	require.NoError(t, err)
+	prompt2, err := GenerateSummarizeFileChangeSystemPrompt(lang2, Vars{})
+	require.NoError(t, err)
 
 	// Assert: 验证输出不同
 	assert.NotEqual(t, prompt1, prompt2, "不同语言配置应该生成不同的 prompt")
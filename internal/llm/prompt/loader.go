@@ -2,7 +2,12 @@ package prompt
 
 import (
 	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
 
 	"github.com/zevwings/workflow/internal/logging"
 )
@@ -10,7 +15,144 @@ import (
 //go:embed templates/*.md
 var templatesFS embed.FS
 
-// LoadTemplate 从嵌入的文件系统中加载模板文件
+// templatesReadme 模板目录的说明文件，不是模板
+const templatesReadme = "README.md"
+
+// 模板来源，按查找优先级从高到低排列
+const (
+	// SourceRepo 仓库中的 .workflow/prompts/ 目录
+	SourceRepo = "repo"
+	// SourceUser 用户配置目录中的 prompts/ 目录
+	SourceUser = "user"
+	// SourceEmbedded 编译时嵌入的默认模板
+	SourceEmbedded = "embedded"
+)
+
+// RepoPromptsDir 仓库中覆盖模板的目录（相对于仓库根目录）
+const RepoPromptsDir = ".workflow/prompts"
+
+// Template 解析后的模板
+type Template struct {
+	// Name 模板文件名（如 "translate.md"）
+	Name string
+	// Source 模板来源（SourceRepo、SourceUser 或 SourceEmbedded）
+	Source string
+	// Path 模板文件路径（嵌入的模板为 "templates/<name>"）
+	Path string
+	// Content 模板内容
+	Content string
+}
+
+var (
+	overrideMu sync.RWMutex
+	// repoOverrideDir 仓库覆盖目录，为空时跳过
+	repoOverrideDir string
+	// userOverrideDir 用户覆盖目录，为空时跳过
+	userOverrideDir string
+)
+
+// SetOverrideDirs 设置覆盖模板的查找目录
+//
+// 查找模板时依次使用 repoDir、userDir 和嵌入的默认模板。
+//
+// 参数:
+//   - repoDir: 仓库覆盖目录（通常为 <仓库根目录>/.workflow/prompts，为空时跳过）
+//   - userDir: 用户覆盖目录（通常为 <配置目录>/prompts，为空时跳过）
+func SetOverrideDirs(repoDir, userDir string) {
+	overrideMu.Lock()
+	defer overrideMu.Unlock()
+	repoOverrideDir = repoDir
+	userOverrideDir = userDir
+}
+
+// OverrideDirs 获取覆盖模板的查找目录
+//
+// 返回:
+//   - string: 仓库覆盖目录（未设置时为空）
+//   - string: 用户覆盖目录（未设置时为空）
+func OverrideDirs() (string, string) {
+	overrideMu.RLock()
+	defer overrideMu.RUnlock()
+	return repoOverrideDir, userOverrideDir
+}
+
+// ResolveTemplate 按仓库、用户、嵌入默认模板的顺序查找模板
+//
+// 只有嵌入的默认模板中存在的模板名才能被覆盖，覆盖文件不存在时继续查找下一层。
+//
+// 参数:
+//   - name: 模板文件名（不包含路径，如 "translate.md"）
+//
+// 返回:
+//   - *Template: 找到的模板
+//   - error: 如果模板不存在或读取覆盖文件失败，返回错误
+func ResolveTemplate(name string) (*Template, error) {
+	logger := logging.GetLogger()
+
+	embedded, err := LoadEmbeddedTemplate(name)
+	if err != nil {
+		return nil, err
+	}
+
+	repoDir, userDir := OverrideDirs()
+	layers := []struct {
+		source string
+		dir    string
+	}{
+		{SourceRepo, repoDir},
+		{SourceUser, userDir},
+	}
+	for _, layer := range layers {
+		if layer.dir == "" {
+			continue
+		}
+		path := filepath.Join(layer.dir, name)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			logger.WithError(err).WithField("path", path).Error("Failed to read prompt template override")
+			return nil, fmt.Errorf("读取模板文件失败 (%s): %w", path, err)
+		}
+
+		logger.WithFields(logging.Fields{
+			"template_name": name,
+			"source":        layer.source,
+			"path":          path,
+		}).Debug("Prompt template overridden")
+
+		return &Template{Name: name, Source: layer.source, Path: path, Content: string(data)}, nil
+	}
+
+	return &Template{Name: name, Source: SourceEmbedded, Path: "templates/" + name, Content: embedded}, nil
+}
+
+// ResolveTemplates 查找所有模板，返回每个模板实际使用的层
+//
+// 返回:
+//   - []*Template: 按模板文件名排序的模板列表
+//   - error: 如果列出或读取失败，返回错误
+func ResolveTemplates() ([]*Template, error) {
+	names, err := ListTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]*Template, 0, len(names))
+	for _, name := range names {
+		template, err := ResolveTemplate(name)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+// LoadTemplate 加载模板文件
+//
+// 依次查找仓库覆盖目录、用户覆盖目录和嵌入的默认模板，见 ResolveTemplate。
 //
 // 参数:
 //   - name: 模板文件名（不包含路径，如 "translate.md"）
@@ -26,6 +168,22 @@ var templatesFS embed.FS
 //	    log.Fatal(err)
 //	}
 func LoadTemplate(name string) (string, error) {
+	template, err := ResolveTemplate(name)
+	if err != nil {
+		return "", err
+	}
+	return template.Content, nil
+}
+
+// LoadEmbeddedTemplate 从嵌入的文件系统中加载默认模板，忽略覆盖目录
+//
+// 参数:
+//   - name: 模板文件名（不包含路径，如 "translate.md"）
+//
+// 返回:
+//   - string: 模板内容
+//   - error: 如果读取失败，返回错误
+func LoadEmbeddedTemplate(name string) (string, error) {
 	logger := logging.GetLogger()
 	logger.Debugf("Loading prompt template: %s", name)
 
@@ -45,7 +203,7 @@ func LoadTemplate(name string) (string, error) {
 	return string(data), nil
 }
 
-// MustLoadTemplate 从嵌入的文件系统中加载模板文件，如果失败则 panic
+// MustLoadTemplate 加载模板文件，如果失败则 panic
 //
 // 这个方法用于在编译时确保模板文件存在，如果文件不存在会导致编译失败。
 // 适用于在包初始化时加载模板。
@@ -69,6 +227,8 @@ func MustLoadTemplate(name string) string {
 
 // ListTemplates 列出所有可用的模板文件
 //
+// 只列出嵌入的默认模板（覆盖目录只能覆盖同名模板），不包含目录说明文件 README.md。
+//
 // 返回:
 //   - []string: 模板文件名列表
 //   - error: 如果列出失败，返回错误
@@ -83,7 +243,7 @@ func ListTemplates() ([]string, error) {
 
 	var files []string
 	for _, entry := range entries {
-		if !entry.IsDir() {
+		if !entry.IsDir() && entry.Name() != templatesReadme {
 			files = append(files, entry.Name())
 		}
 	}
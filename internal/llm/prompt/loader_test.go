@@ -2,6 +2,8 @@ package prompt
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -196,6 +198,7 @@ func TestListTemplates(t *testing.T) {
 		"pr-reword.md",
 		"file-summary.md",
 		"pr-summary.md",
+		"commit.md",
 	}
 
 	for _, expected := range expectedTemplates {
@@ -245,3 +248,105 @@ func TestListTemplates_ConsistentWithLoadTemplate(t *testing.T) {
 		})
 	}
 }
+
+// ==================== ResolveTemplate 测试 ====================
+
+// setOverrideDirs 设置覆盖目录，并在测试结束时恢复
+func setOverrideDirs(t *testing.T, repoDir, userDir string) {
+	t.Helper()
+	SetOverrideDirs(repoDir, userDir)
+	t.Cleanup(func() { SetOverrideDirs("", "") })
+}
+
+func TestResolveTemplate_Embedded(t *testing.T) {
+	// Arrange: 不设置覆盖目录
+	setOverrideDirs(t, "", "")
+
+	// Act: 查找模板
+	template, err := ResolveTemplate("commit.md")
+
+	// Assert: 使用嵌入的默认模板
+	require.NoError(t, err)
+	assert.Equal(t, SourceEmbedded, template.Source)
+	assert.Equal(t, "templates/commit.md", template.Path)
+	embedded, err := LoadEmbeddedTemplate("commit.md")
+	require.NoError(t, err)
+	assert.Equal(t, embedded, template.Content)
+}
+
+func TestResolveTemplate_Layers(t *testing.T) {
+	// Arrange: 仓库目录覆盖 commit.md，用户目录覆盖 commit.md 和 translate.md
+	repoDir, userDir := t.TempDir(), t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "commit.md"), []byte("repo commit"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(userDir, "commit.md"), []byte("user commit"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(userDir, "translate.md"), []byte("user translate"), 0644))
+	setOverrideDirs(t, repoDir, userDir)
+
+	tests := []struct {
+		name       string
+		template   string
+		wantSource string
+		wantPath   string
+		wantText   string
+	}{
+		{"仓库优先于用户", "commit.md", SourceRepo, filepath.Join(repoDir, "commit.md"), "repo commit"},
+		{"用户优先于嵌入", "translate.md", SourceUser, filepath.Join(userDir, "translate.md"), "user translate"},
+		{"未覆盖时使用嵌入", "branch.md", SourceEmbedded, "templates/branch.md", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act: 查找模板
+			template, err := ResolveTemplate(tt.template)
+
+			// Assert: 验证来源和内容
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSource, template.Source)
+			assert.Equal(t, tt.wantPath, template.Path)
+			if tt.wantText != "" {
+				assert.Equal(t, tt.wantText, template.Content)
+			}
+
+			content, err := LoadTemplate(tt.template)
+			require.NoError(t, err)
+			assert.Equal(t, template.Content, content, "LoadTemplate 应该使用相同的层")
+		})
+	}
+}
+
+func TestResolveTemplate_UnknownNameNotOverridable(t *testing.T) {
+	// Arrange: 覆盖目录中存在嵌入模板中没有的文件
+	repoDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "custom.md"), []byte("custom"), 0644))
+	setOverrideDirs(t, repoDir, "")
+
+	// Act: 查找模板
+	_, err := ResolveTemplate("custom.md")
+
+	// Assert: 只有嵌入的模板名可以被覆盖
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "读取模板文件失败")
+}
+
+func TestResolveTemplates(t *testing.T) {
+	// Arrange: 仓库目录覆盖 commit.md
+	repoDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "commit.md"), []byte("repo commit"), 0644))
+	setOverrideDirs(t, repoDir, "")
+
+	// Act: 查找所有模板
+	templates, err := ResolveTemplates()
+
+	// Assert: 每个模板都有来源，只有 commit.md 来自仓库
+	require.NoError(t, err)
+	names, err := ListTemplates()
+	require.NoError(t, err)
+	require.Len(t, templates, len(names))
+	for _, template := range templates {
+		if template.Name == "commit.md" {
+			assert.Equal(t, SourceRepo, template.Source)
+		} else {
+			assert.Equal(t, SourceEmbedded, template.Source, template.Name)
+		}
+	}
+}
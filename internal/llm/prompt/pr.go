@@ -1,39 +1,51 @@
 package prompt
 
 import (
-	"fmt"
-
 	"github.com/zevwings/workflow/internal/llm/client"
 )
 
 // RewordPRSystemPrompt PR Reword 的 system prompt
 //
 // 用于根据当前 PR 标题和 PR diff 生成简洁的 PR 标题和描述。
-// 从嵌入的模板文件中加载。
-var RewordPRSystemPrompt = MustLoadTemplate("pr-reword.md")
+// 使用 pr-reword.md 模板渲染。
+//
+// 参数:
+//   - vars: 模板变量
+//
+// 返回:
+//   - string: system prompt
+//   - error: 如果模板加载或渲染失败，返回错误
+func RewordPRSystemPrompt(vars Vars) (string, error) {
+	return Render("pr-reword.md", vars)
+}
 
 // GenerateSummarizePRSystemPrompt 根据语言生成 PR 总结的 system prompt
 //
 // 参数:
 //   - lang: 语言配置（如果为 nil，使用默认英文配置）
+//   - vars: 模板变量（Example 由本函数填充）
 //
 // 返回:
 //   - string: 根据语言定制的 system prompt
+//   - error: 如果模板加载或渲染失败，返回错误
 //
 // 说明:
 //
 //	如果 lang 为 nil，将使用默认的英文配置。
-func GenerateSummarizePRSystemPrompt(lang *client.SupportedLanguage) string {
+func GenerateSummarizePRSystemPrompt(lang *client.SupportedLanguage, vars Vars) (string, error) {
 	// 获取 JSON 响应示例（动态内容）
 	summarizeResponseExample := "{\n" +
 		"      \\\"summary\\\": \\\"# Add User Authentication\\\\\\\\n\\\\\\\\n## Overview\\\\\\\\nThis PR adds user authentication functionality to the application.\\\\\\\\n\\\\\\\\n## Requirements Analysis\\\\\\\\n\\\\\\\\n### Business Requirements\\\\\\\\nDevelopers need a secure way to authenticate users...\\\\\\\\n\\\\\\\\n### Functional Requirements\\\\\\\\nThe system accepts user credentials and returns authentication tokens...\\\\\\\\n\\\\\\\\n## Key Changes\\\\\\\\n- Added login endpoint\\\\\\\\n- Implemented JWT token generation\\\\\\\\n\\\\\\\\n## Files Changed\\\\\\\\n- src/auth/login.ts: Added login handler\\\\\\\\n- src/auth/jwt.ts: Added token generation\\\\\\\\n\\\\\\\\n## Technical Details\\\\\\\\nImplemented JWT-based authentication:\\\\\\\\n\\\\\\\\n[code block: typescript]\\\\\\\\nfunction generateToken(user: User): string {\\\\\\\\n  return jwt.sign({ userId: user.id }, secret);\\\\\\\\n}\\\\n[code block end]\\\\\\\\n\\\\\\\\n## Testing\\\\\\\\nAdded unit tests for authentication flow.\\\\\\\\n\\\\\\\\n## Usage Instructions\\\\\\\\nRun npm run test to execute tests.\\\",\\n" +
 		"      \\\"filename\\\": \\\"add-user-authentication\\\"\\n" +
 		"    }"
 
-	// 从嵌入的模板文件中加载基础 prompt，然后拼接动态内容
-	basePrompt := MustLoadTemplate("pr-summary.md")
-	fullPrompt := fmt.Sprintf(basePrompt, summarizeResponseExample)
+	// 渲染基础 prompt，响应示例通过 {{.Example}} 插入
+	vars.Example = summarizeResponseExample
+	fullPrompt, err := Render("pr-summary.md", vars)
+	if err != nil {
+		return "", err
+	}
 
 	// 使用语言增强功能
-	return client.GetLanguageRequirement(fullPrompt, lang)
+	return client.GetLanguageRequirement(fullPrompt, lang), nil
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/zevwings/workflow/internal/llm/client"
 )
 
@@ -51,7 +52,8 @@ func TestGenerateSummarizePRSystemPrompt(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Act: 生成 PR 总结 prompt
-			prompt := GenerateSummarizePRSystemPrompt(tt.lang)
+			prompt, err := GenerateSummarizePRSystemPrompt(tt.lang, Vars{})
+			require.NoError(t, err)
 
 			// Assert: 验证 prompt 不为空
 			assert.NotEmpty(t, prompt, "prompt 不应为空")
@@ -69,7 +71,8 @@ func TestGenerateSummarizePRSystemPrompt(t *testing.T) {
 
 func TestGenerateSummarizePRSystemPrompt_ContainsBaseTemplate(t *testing.T) {
 	// Act: 生成 PR 总结 prompt（使用默认语言）
-	prompt := GenerateSummarizePRSystemPrompt(nil)
+	prompt, err := GenerateSummarizePRSystemPrompt(nil, Vars{})
+	require.NoError(t, err)
 
 	// Assert: 验证包含基础模板内容
 	// 由于基础模板是从 pr-summary.md 加载的，我们应该验证 prompt 包含一些预期的内容
@@ -79,7 +82,8 @@ func TestGenerateSummarizePRSystemPrompt_ContainsBaseTemplate(t *testing.T) {
 
 func TestGenerateSummarizePRSystemPrompt_ContainsJSONExample(t *testing.T) {
 	// Act: 生成 PR 总结 prompt
-	prompt := GenerateSummarizePRSystemPrompt(nil)
+	prompt, err := GenerateSummarizePRSystemPrompt(nil, Vars{})
+	require.NoError(t, err)
 
 	// Assert: 验证包含 JSON 响应示例
 	// 注意：prompt 中包含的是转义后的 JSON 示例，所以需要检查转义版本
@@ -119,7 +123,8 @@ func TestGenerateSummarizePRSystemPrompt_LanguageRequirement(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Act: 生成 PR 总结 prompt
-			prompt := GenerateSummarizePRSystemPrompt(tt.lang)
+			prompt, err := GenerateSummarizePRSystemPrompt(tt.lang, Vars{})
+			require.NoError(t, err)
 
 			// Assert: 验证包含语言要求
 			assert.Contains(t, prompt, tt.expected, "应该包含语言要求: %s", tt.expected)
@@ -131,7 +136,8 @@ func TestGenerateSummarizePRSystemPrompt_LanguageRequirement(t *testing.T) {
 
 func TestGenerateSummarizePRSystemPrompt_NilLanguageUsesDefault(t *testing.T) {
 	// Act: 生成 PR 总结 prompt（nil 语言）
-	prompt := GenerateSummarizePRSystemPrompt(nil)
+	prompt, err := GenerateSummarizePRSystemPrompt(nil, Vars{})
+	require.NoError(t, err)
 
 	// Assert: 验证使用默认英文配置
 	assert.Contains(t, prompt, "English", "nil 语言应该使用默认英文配置")
@@ -140,7 +146,8 @@ func TestGenerateSummarizePRSystemPrompt_NilLanguageUsesDefault(t *testing.T) {
 
 func TestGenerateSummarizePRSystemPrompt_Formatting(t *testing.T) {
 	// Act: 生成 PR 总结 prompt
-	prompt := GenerateSummarizePRSystemPrompt(nil)
+	prompt, err := GenerateSummarizePRSystemPrompt(nil, Vars{})
+	require.NoError(t, err)
 
 	// Assert: 验证格式正确
 	// 验证包含必要的分隔符和结构
@@ -153,8 +160,10 @@ func TestGenerateSummarizePRSystemPrompt_Formatting(t *testing.T) {
 
 func TestGenerateSummarizePRSystemPrompt_ConsistentOutput(t *testing.T) {
 	// Act: 多次生成 prompt（相同配置）
-	prompt1 := GenerateSummarizePRSystemPrompt(nil)
-	prompt2 := GenerateSummarizePRSystemPrompt(nil)
+	prompt1, err := GenerateSummarizePRSystemPrompt(nil, Vars{})
+	require.NoError(t, err)
+	prompt2, err := GenerateSummarizePRSystemPrompt(nil, Vars{})
+	require.NoError(t, err)
 
 	// Assert: 验证输出一致
 	assert.Equal(t, prompt1, prompt2, "相同配置应该生成相同的 prompt")
@@ -176,8 +185,10 @@ func TestGenerateSummarizePRSystemPrompt_DifferentLanguages(t *testing.T) {
 	}
 
 	// Act: 生成不同语言的 prompt
-	prompt1 := GenerateSummarizePRSystemPrompt(lang1)
-	prompt2 := GenerateSummarizePRSystemPrompt(lang2)
+	prompt1, err := GenerateSummarizePRSystemPrompt(lang1, Vars{})
+	require.NoError(t, err)
+	prompt2, err := GenerateSummarizePRSystemPrompt(lang2, Vars{})
+	require.NoError(t, err)
 
 	// Assert: 验证输出不同
 	assert.NotEqual(t, prompt1, prompt2, "不同语言配置应该生成不同的 prompt")
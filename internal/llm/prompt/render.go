@@ -0,0 +1,121 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/zevwings/workflow/internal/llm/client"
+	"github.com/zevwings/workflow/internal/llm/compact"
+)
+
+// Vars 渲染模板时可用的变量
+//
+// 模板使用 Go text/template 语法，如 "{{.Language}}"、"{{if .Ticket}}...{{end}}"。
+type Vars struct {
+	// Language 输出语言的英文名称（如 "English"、"Chinese"）
+	Language string
+	// LanguageCode 输出语言代码（如 "en"、"zh-CN"）
+	LanguageCode string
+	// Repo 仓库名（如 "owner/repo"）
+	Repo string
+	// Branch 当前分支名
+	Branch string
+	// Ticket 从分支名中提取的 Jira ticket（如 "PROJ-123"）
+	Ticket string
+	// Files diff 中变更的文件数
+	Files int
+	// Additions diff 中新增的行数
+	Additions int
+	// Deletions diff 中删除的行数
+	Deletions int
+	// Example 响应示例（仅 pr-summary.md 使用）
+	Example string
+}
+
+var (
+	defaultVarsMu sync.RWMutex
+	// defaultVars NewVars 使用的默认变量（仓库、分支和 ticket）
+	defaultVars Vars
+)
+
+// SetDefaultVars 设置 NewVars 使用的默认变量
+//
+// 通常在启动时设置与当前仓库相关的变量（Repo、Branch、Ticket），
+// 语言和 diff 统计由 NewVars 按每次请求填充。
+//
+// 参数:
+//   - vars: 默认变量
+func SetDefaultVars(vars Vars) {
+	defaultVarsMu.Lock()
+	defer defaultVarsMu.Unlock()
+	defaultVars = vars
+}
+
+// NewVars 基于默认变量创建一次请求的模板变量
+//
+// 参数:
+//   - lang: 语言配置（如果为 nil，使用默认英文配置）
+//   - diff: 本次请求的统一 diff（为空时 diff 统计为 0）
+//
+// 返回:
+//   - Vars: 模板变量
+func NewVars(lang *client.SupportedLanguage, diff string) Vars {
+	defaultVarsMu.RLock()
+	vars := defaultVars
+	defaultVarsMu.RUnlock()
+
+	vars.Language, vars.LanguageCode = "English", "en"
+	if lang != nil {
+		vars.Language, vars.LanguageCode = lang.Name, lang.Code
+	}
+	vars.Files, vars.Additions, vars.Deletions = diffStats(diff)
+	return vars
+}
+
+// diffStats 统计统一 diff 中的文件数、新增行数和删除行数
+func diffStats(diff string) (int, int, int) {
+	files := compact.SplitFiles(diff)
+	additions, deletions := 0, 0
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++ "), strings.HasPrefix(line, "--- "):
+		case strings.HasPrefix(line, "+"):
+			additions++
+		case strings.HasPrefix(line, "-"):
+			deletions++
+		}
+	}
+	return len(files), additions, deletions
+}
+
+// Render 加载模板并使用变量渲染
+//
+// 模板按 ResolveTemplate 的顺序查找。引用不存在的变量会返回错误，
+// 便于发现覆盖模板中的拼写错误。
+//
+// 参数:
+//   - name: 模板文件名（如 "commit.md"）
+//   - vars: 模板变量
+//
+// 返回:
+//   - string: 渲染后的 prompt
+//   - error: 如果加载、解析或渲染失败，返回错误
+func Render(name string, vars Vars) (string, error) {
+	resolved, err := ResolveTemplate(name)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(resolved.Content)
+	if err != nil {
+		return "", fmt.Errorf("解析模板失败 (%s): %w", resolved.Path, err)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, vars); err != nil {
+		return "", fmt.Errorf("渲染模板失败 (%s): %w", resolved.Path, err)
+	}
+	return b.String(), nil
+}
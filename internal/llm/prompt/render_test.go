@@ -0,0 +1,113 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zevwings/workflow/internal/llm/client"
+)
+
+// ==================== NewVars 测试 ====================
+
+func TestNewVars(t *testing.T) {
+	// Arrange: 默认变量和包含两个文件的 diff
+	SetDefaultVars(Vars{Repo: "owner/repo", Branch: "feature/PROJ-1-login", Ticket: "PROJ-1"})
+	t.Cleanup(func() { SetDefaultVars(Vars{}) })
+	diff := "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -1,2 +1,2 @@\n-old\n+new\n+added\n" +
+		"diff --git a/b.go b/b.go\n--- a/b.go\n+++ b/b.go\n@@ -1 +1 @@\n-removed\n"
+
+	// Act: 创建变量
+	vars := NewVars(&client.SupportedLanguage{Code: "zh-CN", Name: "Chinese"}, diff)
+
+	// Assert: 包含默认变量、语言和 diff 统计
+	assert.Equal(t, "owner/repo", vars.Repo)
+	assert.Equal(t, "feature/PROJ-1-login", vars.Branch)
+	assert.Equal(t, "PROJ-1", vars.Ticket)
+	assert.Equal(t, "Chinese", vars.Language)
+	assert.Equal(t, "zh-CN", vars.LanguageCode)
+	assert.Equal(t, 2, vars.Files)
+	assert.Equal(t, 2, vars.Additions)
+	assert.Equal(t, 2, vars.Deletions)
+}
+
+func TestNewVars_NilLanguageUsesEnglish(t *testing.T) {
+	// Act: 不指定语言和 diff
+	vars := NewVars(nil, "")
+
+	// Assert: 使用英文，diff 统计为 0
+	assert.Equal(t, "English", vars.Language)
+	assert.Equal(t, "en", vars.LanguageCode)
+	assert.Zero(t, vars.Files)
+	assert.Zero(t, vars.Additions)
+	assert.Zero(t, vars.Deletions)
+}
+
+// ==================== Render 测试 ====================
+
+func TestRender_Variables(t *testing.T) {
+	// Arrange: 仓库目录覆盖 commit.md，引用模板变量
+	repoDir := t.TempDir()
+	content := "Repo {{.Repo}}{{if .Ticket}} for {{.Ticket}}{{end}}, {{.Files}} file(s) +{{.Additions}} -{{.Deletions}} in {{.Language}}"
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "commit.md"), []byte(content), 0644))
+	setOverrideDirs(t, repoDir, "")
+
+	// Act: 渲染模板
+	rendered, err := Render("commit.md", Vars{Repo: "owner/repo", Ticket: "PROJ-1", Files: 2, Additions: 3, Deletions: 1, Language: "English"})
+
+	// Assert: 变量被替换
+	require.NoError(t, err)
+	assert.Equal(t, "Repo owner/repo for PROJ-1, 2 file(s) +3 -1 in English", rendered)
+
+	// 覆盖模板同样用于生成 system prompt
+	prompt, err := GenerateCommitSystemPrompt(Vars{Repo: "owner/repo"})
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "Repo owner/repo")
+}
+
+func TestRender_UnknownVariable(t *testing.T) {
+	// Arrange: 覆盖模板引用不存在的变量
+	repoDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "translate.md"), []byte("{{.Unknown}}"), 0644))
+	setOverrideDirs(t, repoDir, "")
+
+	// Act: 渲染模板
+	_, err := Render("translate.md", Vars{})
+
+	// Assert: 返回包含模板路径的错误
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "渲染模板失败")
+	assert.Contains(t, err.Error(), filepath.Join(repoDir, "translate.md"))
+}
+
+func TestRender_InvalidSyntax(t *testing.T) {
+	// Arrange: 覆盖模板语法错误
+	repoDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "translate.md"), []byte("{{.Repo"), 0644))
+	setOverrideDirs(t, repoDir, "")
+
+	// Act: 渲染模板
+	_, err := Render("translate.md", Vars{})
+
+	// Assert: 返回解析错误
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "解析模板失败")
+}
+
+func TestRender_EmbeddedTemplatesRender(t *testing.T) {
+	// Arrange: 不设置覆盖目录
+	setOverrideDirs(t, "", "")
+	names, err := ListTemplates()
+	require.NoError(t, err)
+
+	// Act & Assert: 所有嵌入的模板都能渲染
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			rendered, err := Render(name, NewVars(nil, ""))
+			assert.NoError(t, err)
+			assert.NotEmpty(t, rendered)
+		})
+	}
+}
@@ -3,5 +3,14 @@ package prompt
 // TranslateSystemPrompt 翻译文本的 system prompt
 //
 // 用于将非英文文本（中文、俄文等）翻译为英文。
-// 从嵌入的模板文件中加载。
-var TranslateSystemPrompt = MustLoadTemplate("translate.md")
+// 使用 translate.md 模板渲染。
+//
+// 参数:
+//   - vars: 模板变量
+//
+// 返回:
+//   - string: system prompt
+//   - error: 如果模板加载或渲染失败，返回错误
+func TranslateSystemPrompt(vars Vars) (string, error) {
+	return Render("translate.md", vars)
+}
@@ -0,0 +1,138 @@
+// Package route 为每个功能选择 LLM 提供商，并在提供商暂时不可用时回退到下一个
+//
+// NewChain 按顺序尝试多个提供商：请求因服务器错误（5xx）、限流（429）、超时或
+// 无法连接而失败时，使用下一个提供商重试，其他错误直接返回。NewRouter 按请求的
+// 功能（LLMRequestParams.Feature）选择客户端，例如翻译使用便宜的模型，总结使用更强的模型。
+package route
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zevwings/workflow/internal/llm/cache"
+	"github.com/zevwings/workflow/internal/llm/client"
+	"github.com/zevwings/workflow/internal/logging"
+)
+
+// Target 回退链中的一个提供商
+type Target struct {
+	// Provider 提供商名称（用于日志）
+	Provider string
+	// Model 模型名称（用于日志）
+	Model string
+	// Client 该提供商的 LLM 客户端
+	Client client.LLMClient
+}
+
+// chainClient 按顺序尝试多个提供商的 LLM 客户端，实现 client.LLMClient 和 cache.Refresher 接口
+type chainClient struct {
+	targets []Target
+}
+
+// NewChain 创建按顺序尝试多个提供商的 LLM 客户端
+//
+// 请求失败且 client.IsTransient 返回 true 时使用下一个提供商重试。Stream 已经
+// 输出了部分内容时不再回退，避免重复输出。每次成功的请求都会记录是哪个提供商应答的。
+//
+// 参数:
+//   - targets: 提供商，按回退顺序排列（至少一个）
+//
+// 返回:
+//   - client.LLMClient: LLM 客户端
+func NewChain(targets []Target) client.LLMClient {
+	if len(targets) == 0 {
+		panic(fmt.Errorf("llm/route.NewChain: targets cannot be empty"))
+	}
+	for _, target := range targets {
+		if target.Client == nil {
+			panic(fmt.Errorf("llm/route.NewChain: client of %s cannot be nil", target.Provider))
+		}
+	}
+	return &chainClient{targets: targets}
+}
+
+// Call 依次调用提供商，直到请求成功或遇到不可回退的错误
+func (c *chainClient) Call(params *client.LLMRequestParams) (string, error) {
+	var err error
+	for i, target := range c.targets {
+		var response string
+		response, err = target.Client.Call(params)
+		if err == nil {
+			c.answered(i, params)
+			return response, nil
+		}
+		if !c.fallback(i, params, err) {
+			break
+		}
+	}
+	return "", err
+}
+
+// Stream 依次以流式方式调用提供商，直到请求成功、遇到不可回退的错误或已经输出了内容
+func (c *chainClient) Stream(ctx context.Context, params *client.LLMRequestParams, onDelta func(delta string)) (string, error) {
+	var err error
+	for i, target := range c.targets {
+		streamed := false
+		var response string
+		response, err = target.Client.Stream(ctx, params, func(delta string) {
+			streamed = true
+			if onDelta != nil {
+				onDelta(delta)
+			}
+		})
+		if err == nil {
+			c.answered(i, params)
+			return response, nil
+		}
+		// 已经输出的内容无法撤回，不再回退
+		if streamed || !c.fallback(i, params, err) {
+			break
+		}
+	}
+	return "", err
+}
+
+// Refresh 返回所有提供商都跳过缓存读取的副本
+func (c *chainClient) Refresh() client.LLMClient {
+	targets := make([]Target, len(c.targets))
+	for i, target := range c.targets {
+		target.Client = cache.Refresh(target.Client)
+		targets[i] = target
+	}
+	return &chainClient{targets: targets}
+}
+
+// answered 记录应答请求的提供商
+func (c *chainClient) answered(i int, params *client.LLMRequestParams) {
+	target := c.targets[i]
+	logging.GetLogger().WithFields(logging.Fields{
+		"provider": target.Provider,
+		"model":    modelOf(target, params),
+		"feature":  params.Feature,
+		"fallback": i > 0,
+	}).Info("LLM request answered")
+}
+
+// fallback 判断第 i 个提供商失败后是否使用下一个提供商
+func (c *chainClient) fallback(i int, params *client.LLMRequestParams, err error) bool {
+	if i == len(c.targets)-1 || !client.IsTransient(err) {
+		return false
+	}
+
+	target, next := c.targets[i], c.targets[i+1]
+	logging.GetLogger().WithError(err).WithFields(logging.Fields{
+		"provider": target.Provider,
+		"model":    modelOf(target, params),
+		"feature":  params.Feature,
+		"next":     next.Provider,
+	}).Warn("LLM provider failed, falling back to the next provider")
+	return true
+}
+
+// modelOf 返回请求实际使用的模型（请求参数中的 Model 优先）
+func modelOf(target Target, params *client.LLMRequestParams) string {
+	if params.Model != "" {
+		return params.Model
+	}
+	return target.Model
+}
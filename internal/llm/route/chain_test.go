@@ -0,0 +1,164 @@
+package route
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zevwings/workflow/internal/llm/cache"
+	"github.com/zevwings/workflow/internal/llm/client"
+)
+
+// fakeClient 返回固定响应或错误的 LLM 客户端
+type fakeClient struct {
+	calls    int
+	response string
+	err      error
+	// deltas Stream 在返回 err 之前输出的内容
+	deltas []string
+}
+
+func (f *fakeClient) Call(params *client.LLMRequestParams) (string, error) {
+	f.calls++
+	return f.response, f.err
+}
+
+func (f *fakeClient) Stream(ctx context.Context, params *client.LLMRequestParams, onDelta func(delta string)) (string, error) {
+	f.calls++
+	deltas := f.deltas
+	if f.err == nil {
+		deltas = []string{f.response}
+	}
+	for _, delta := range deltas {
+		onDelta(delta)
+	}
+	return f.response, f.err
+}
+
+func testParams() *client.LLMRequestParams {
+	return &client.LLMRequestParams{UserPrompt: "hello", Feature: client.FeatureCommit}
+}
+
+// ==================== NewChain 测试 ====================
+
+func TestChain_Call_FallsBackOnTransientError(t *testing.T) {
+	// Arrange: 第一个提供商限流，第二个服务器错误，第三个成功
+	rateLimited := &fakeClient{err: &client.RequestError{Status: http.StatusTooManyRequests}}
+	unavailable := &fakeClient{err: &client.RequestError{Status: http.StatusServiceUnavailable}}
+	ok := &fakeClient{response: "response"}
+	chain := NewChain([]Target{
+		{Provider: "openai", Client: rateLimited},
+		{Provider: "deepseek", Client: unavailable},
+		{Provider: "ollama", Client: ok},
+	})
+
+	// Act
+	response, err := chain.Call(testParams())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "response", response)
+	assert.Equal(t, 1, rateLimited.calls)
+	assert.Equal(t, 1, unavailable.calls)
+	assert.Equal(t, 1, ok.calls)
+}
+
+func TestChain_Call_StopsOnPermanentError(t *testing.T) {
+	// Arrange: API key 无效，回退不会有帮助
+	unauthorized := &fakeClient{err: &client.RequestError{Status: http.StatusUnauthorized, Message: "invalid api key"}}
+	next := &fakeClient{response: "response"}
+	chain := NewChain([]Target{{Provider: "openai", Client: unauthorized}, {Provider: "deepseek", Client: next}})
+
+	// Act
+	_, err := chain.Call(testParams())
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid api key")
+	assert.Equal(t, 0, next.calls)
+}
+
+func TestChain_Call_AllProvidersFail(t *testing.T) {
+	first := &fakeClient{err: &client.RequestError{Status: http.StatusBadGateway}}
+	last := &fakeClient{err: &client.RequestError{Err: errors.New("connection refused")}}
+	chain := NewChain([]Target{{Provider: "openai", Client: first}, {Provider: "ollama", Client: last}})
+
+	_, err := chain.Call(testParams())
+
+	// 返回最后一个提供商的错误
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+}
+
+func TestChain_Stream_FallsBackBeforeOutput(t *testing.T) {
+	// Arrange
+	unavailable := &fakeClient{err: &client.RequestError{Status: http.StatusServiceUnavailable}}
+	ok := &fakeClient{response: "streamed"}
+	chain := NewChain([]Target{{Provider: "openai", Client: unavailable}, {Provider: "deepseek", Client: ok}})
+
+	// Act
+	var deltas []string
+	response, err := chain.Stream(context.Background(), testParams(), func(delta string) { deltas = append(deltas, delta) })
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "streamed", response)
+	assert.Equal(t, []string{"streamed"}, deltas)
+}
+
+func TestChain_Stream_NoFallbackAfterOutput(t *testing.T) {
+	// Arrange: 输出了部分内容后连接中断
+	interrupted := &fakeClient{err: &client.RequestError{Err: errors.New("connection reset")}, deltas: []string{"partial"}}
+	next := &fakeClient{response: "streamed"}
+	chain := NewChain([]Target{{Provider: "openai", Client: interrupted}, {Provider: "deepseek", Client: next}})
+
+	// Act
+	var deltas []string
+	_, err := chain.Stream(context.Background(), testParams(), func(delta string) { deltas = append(deltas, delta) })
+
+	// Assert: 不再回退，避免重复输出
+	require.Error(t, err)
+	assert.Equal(t, []string{"partial"}, deltas)
+	assert.Equal(t, 0, next.calls)
+}
+
+func TestChain_CachePerProvider(t *testing.T) {
+	// Arrange: 每个提供商有自己的响应缓存，第一个提供商不可用
+	responses := cache.New(t.TempDir(), cache.Options{})
+	unavailable := &fakeClient{err: &client.RequestError{Status: http.StatusServiceUnavailable}}
+	fallback := &fakeClient{response: "from ollama"}
+	chain := NewChain([]Target{
+		{Provider: "openai", Model: "gpt-4o", Client: cache.NewClient(unavailable, responses, "openai", "gpt-4o")},
+		{Provider: "ollama", Model: "llama3", Client: cache.NewClient(fallback, responses, "ollama", "llama3")},
+	})
+	params := testParams()
+
+	// Act
+	first, err := chain.Call(params)
+	require.NoError(t, err)
+	second, err := chain.Call(params)
+	require.NoError(t, err)
+
+	// Assert: 回退的响应缓存在应答的提供商下，而不是第一个提供商下
+	assert.Equal(t, "from ollama", first)
+	assert.Equal(t, "from ollama", second)
+	assert.Equal(t, 1, fallback.calls)
+	assert.Equal(t, 2, unavailable.calls)
+	_, cachedAsPrimary := responses.Get(cache.Key("openai", "gpt-4o", params))
+	assert.False(t, cachedAsPrimary)
+	_, cachedAsFallback := responses.Get(cache.Key("ollama", "llama3", params))
+	assert.True(t, cachedAsFallback)
+
+	// cache.Refresh 对每个提供商生效
+	_, err = cache.Refresh(chain).Call(params)
+	require.NoError(t, err)
+	assert.Equal(t, 2, fallback.calls)
+}
+
+func TestNewChain_InvalidTargets(t *testing.T) {
+	assert.Panics(t, func() { NewChain(nil) })
+	assert.Panics(t, func() { NewChain([]Target{{Provider: "openai"}}) })
+}
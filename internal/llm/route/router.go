@@ -0,0 +1,105 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/zevwings/workflow/internal/llm/cache"
+	"github.com/zevwings/workflow/internal/llm/client"
+)
+
+// Resolver 返回功能使用的客户端
+//
+// 返回 nil 客户端表示该功能使用默认客户端。返回的错误只影响该功能的请求。
+type Resolver func(feature string) (client.LLMClient, error)
+
+// routerClient 按请求的功能选择 LLM 客户端，实现 client.LLMClient 和 cache.Refresher 接口
+type routerClient struct {
+	defaultClient client.LLMClient
+	resolve       Resolver
+	// refresh 是否对解析出的客户端应用 cache.Refresh
+	refresh bool
+
+	mu     sync.Mutex
+	routes map[string]resolved
+}
+
+// resolved 功能的解析结果
+type resolved struct {
+	client client.LLMClient
+	err    error
+}
+
+// NewRouter 创建按功能选择客户端的 LLM 客户端
+//
+// 每个功能第一次请求时才调用 resolve 解析客户端，解析结果会被复用。某个功能的
+// 路由配置有误时，只有该功能的请求返回错误，其他功能不受影响。
+// cache.Refresh 对每个客户端生效。
+//
+// 参数:
+//   - defaultClient: 没有路由的功能使用的客户端
+//   - resolve: 解析功能（如 client.FeatureTranslate）使用的客户端（可以为 nil，表示都使用 defaultClient）
+//
+// 返回:
+//   - client.LLMClient: LLM 客户端
+func NewRouter(defaultClient client.LLMClient, resolve Resolver) client.LLMClient {
+	if defaultClient == nil {
+		panic(fmt.Errorf("llm/route.NewRouter: defaultClient cannot be nil"))
+	}
+	return &routerClient{defaultClient: defaultClient, resolve: resolve, routes: map[string]resolved{}}
+}
+
+// Call 使用请求功能对应的客户端调用 LLM API
+func (r *routerClient) Call(params *client.LLMRequestParams) (string, error) {
+	llmClient, err := r.route(params)
+	if err != nil {
+		return "", err
+	}
+	return llmClient.Call(params)
+}
+
+// Stream 使用请求功能对应的客户端以流式方式调用 LLM API
+func (r *routerClient) Stream(ctx context.Context, params *client.LLMRequestParams, onDelta func(delta string)) (string, error) {
+	llmClient, err := r.route(params)
+	if err != nil {
+		return "", err
+	}
+	return llmClient.Stream(ctx, params, onDelta)
+}
+
+// Refresh 返回所有客户端都跳过缓存读取的副本
+func (r *routerClient) Refresh() client.LLMClient {
+	return &routerClient{
+		defaultClient: cache.Refresh(r.defaultClient),
+		resolve:       r.resolve,
+		refresh:       true,
+		routes:        map[string]resolved{},
+	}
+}
+
+// route 返回请求功能对应的客户端
+func (r *routerClient) route(params *client.LLMRequestParams) (client.LLMClient, error) {
+	if r.resolve == nil || params.Feature == "" {
+		return r.defaultClient, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result, ok := r.routes[params.Feature]
+	if !ok {
+		result.client, result.err = r.resolve(params.Feature)
+		if result.client != nil && r.refresh {
+			result.client = cache.Refresh(result.client)
+		}
+		r.routes[params.Feature] = result
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+	if result.client == nil {
+		return r.defaultClient, nil
+	}
+	return result.client, nil
+}
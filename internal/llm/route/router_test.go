@@ -0,0 +1,85 @@
+package route
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zevwings/workflow/internal/llm/cache"
+	"github.com/zevwings/workflow/internal/llm/client"
+)
+
+// routes 返回按固定映射解析客户端的 Resolver
+func routes(clients map[string]client.LLMClient) Resolver {
+	return func(feature string) (client.LLMClient, error) {
+		return clients[feature], nil
+	}
+}
+
+// ==================== NewRouter 测试 ====================
+
+func TestRouter_RoutesByFeature(t *testing.T) {
+	// Arrange: 翻译使用单独的客户端
+	defaultClient := &fakeClient{response: "default"}
+	translateClient := &fakeClient{response: "translated"}
+	router := NewRouter(defaultClient, routes(map[string]client.LLMClient{client.FeatureTranslate: translateClient}))
+
+	// Act
+	translated, err := router.Call(&client.LLMRequestParams{Feature: client.FeatureTranslate})
+	require.NoError(t, err)
+	other, err := router.Call(&client.LLMRequestParams{Feature: client.FeatureCommit})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, "translated", translated)
+	assert.Equal(t, "default", other)
+}
+
+func TestRouter_Refresh(t *testing.T) {
+	// Arrange: 路由的客户端带响应缓存，且已缓存了响应
+	responses := cache.New(t.TempDir(), cache.Options{})
+	inner := &fakeClient{response: "fresh"}
+	cached := cache.NewClient(inner, responses, "openai", "gpt-4o-mini")
+	router := NewRouter(&fakeClient{response: "default"}, routes(map[string]client.LLMClient{client.FeatureTranslate: cached}))
+	params := &client.LLMRequestParams{UserPrompt: "hello", Feature: client.FeatureTranslate}
+
+	_, err := router.Call(params)
+	require.NoError(t, err)
+	_, err = router.Call(params)
+	require.NoError(t, err)
+	require.Equal(t, 1, inner.calls)
+
+	// Act: cache.Refresh 对路由的客户端生效
+	_, err = cache.Refresh(router).Call(params)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.calls)
+}
+
+func TestRouter_ResolveError(t *testing.T) {
+	// Arrange: 翻译的路由配置有误
+	defaultClient := &fakeClient{response: "default"}
+	resolves := 0
+	router := NewRouter(defaultClient, func(feature string) (client.LLMClient, error) {
+		resolves++
+		if feature == client.FeatureTranslate {
+			return nil, errors.New("invalid route provider foo")
+		}
+		return nil, nil
+	})
+
+	// Act
+	_, translateErr := router.Call(&client.LLMRequestParams{Feature: client.FeatureTranslate})
+	_, translateErrAgain := router.Call(&client.LLMRequestParams{Feature: client.FeatureTranslate})
+	commit, commitErr := router.Call(&client.LLMRequestParams{Feature: client.FeatureCommit})
+
+	// Assert: 只有翻译失败，且每个功能只解析一次
+	require.Error(t, translateErr)
+	assert.Contains(t, translateErr.Error(), "invalid route provider foo")
+	assert.Equal(t, translateErr, translateErrAgain)
+	require.NoError(t, commitErr)
+	assert.Equal(t, "default", commit)
+	assert.Equal(t, 2, resolves)
+}
@@ -0,0 +1,212 @@
+package structured
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// FieldError JSON 响应中的字段不符合 schema
+type FieldError struct {
+	// Field 字段路径（如 "branch_name"、"files[0].path"）
+	Field string
+	// Expected 期望的 JSON 类型（如 "string"），字段缺失时为空
+	Expected string
+}
+
+func (e *FieldError) Error() string {
+	if e.Expected == "" {
+		return fmt.Sprintf("LLM 响应中缺少 '%s' 字段", e.Field)
+	}
+	return fmt.Sprintf("LLM 响应中 '%s' 字段类型错误（应为 %s）", e.Field, e.Expected)
+}
+
+// feedback 返回发送给模型的修复说明
+func (e *FieldError) feedback() string {
+	if e.Expected == "" {
+		return fmt.Sprintf("the required field %q is missing", e.Field)
+	}
+	return fmt.Sprintf("the field %q must be of type %s", e.Field, e.Expected)
+}
+
+// Schema 根据 Go 结构体生成 JSON Schema
+//
+// 字段名取自 json 标签。非指针且没有 omitempty 的字段是必需的，
+// 指针字段和带 omitempty 的字段是可选的。
+//
+// 参数:
+//   - t: 结构体类型
+//
+// 返回:
+//   - map[string]interface{}: JSON Schema
+func Schema(t reflect.Type) map[string]interface{} {
+	t = deref(t)
+
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := map[string]interface{}{}
+		required := []string{}
+		for _, field := range fields(t) {
+			properties[field.name] = Schema(field.typ)
+			if field.required {
+				required = append(required, field.name)
+			}
+		}
+		return map[string]interface{}{
+			"type":       "object",
+			"properties": properties,
+			"required":   required,
+		}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": Schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": Schema(t.Elem())}
+	case reflect.Interface:
+		return map[string]interface{}{}
+	default:
+		return map[string]interface{}{"type": jsonType(t)}
+	}
+}
+
+// validate 校验解码后的 JSON 值是否符合类型 t
+//
+// 参数:
+//   - value: json.Unmarshal 到 interface{} 得到的值
+//   - t: 期望的 Go 类型
+//   - path: 字段路径（用于错误信息）
+//
+// 返回:
+//   - error: 第一个不符合的字段（*FieldError），符合时返回 nil
+func validate(value interface{}, t reflect.Type, path string) error {
+	t = deref(t)
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return &FieldError{Field: path, Expected: "object"}
+		}
+		for _, field := range fields(t) {
+			fieldValue, ok := object[field.name]
+			if !ok || fieldValue == nil {
+				if field.required {
+					return &FieldError{Field: join(path, field.name)}
+				}
+				continue
+			}
+			if err := validate(fieldValue, field.typ, join(path, field.name)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]interface{})
+		if !ok {
+			return &FieldError{Field: path, Expected: "array"}
+		}
+		for i, item := range items {
+			if err := validate(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return &FieldError{Field: path, Expected: "object"}
+		}
+		for key, item := range object {
+			if err := validate(item, t.Elem(), join(path, key)); err != nil {
+				return err
+			}
+		}
+	case reflect.Interface:
+	default:
+		if !matches(value, t) {
+			return &FieldError{Field: path, Expected: jsonType(t)}
+		}
+	}
+	return nil
+}
+
+// field 结构体中映射到 JSON 的字段
+type field struct {
+	name     string
+	typ      reflect.Type
+	required bool
+}
+
+// fields 返回结构体中映射到 JSON 的字段（跳过未导出字段和 json:"-"）
+func fields(t reflect.Type) []field {
+	var result []field
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		tag := structField.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = structField.Name
+		}
+
+		result = append(result, field{
+			name:     name,
+			typ:      structField.Type,
+			required: structField.Type.Kind() != reflect.Pointer && !strings.Contains(options, "omitempty"),
+		})
+	}
+	return result
+}
+
+// jsonType 返回基本类型对应的 JSON Schema 类型
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+// matches 判断 JSON 基本值是否符合类型 t
+func matches(value interface{}, t reflect.Type) bool {
+	switch jsonType(t) {
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		_, ok := value.(string)
+		return ok
+	}
+}
+
+// deref 返回指针指向的类型
+func deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// join 拼接字段路径
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,92 @@
+package structured
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaFile struct {
+	Path    string `json:"path"`
+	Added   int    `json:"added"`
+	Deleted int    `json:"deleted,omitempty"`
+}
+
+type schemaSample struct {
+	Title    string            `json:"title"`
+	Draft    bool              `json:"draft"`
+	Score    float64           `json:"score"`
+	Body     *string           `json:"body"`
+	Files    []schemaFile      `json:"files"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Ignored  string            `json:"-"`
+	internal string
+}
+
+// ==================== Schema 测试 ====================
+
+func TestSchema(t *testing.T) {
+	schema := Schema(reflect.TypeOf(schemaSample{}))
+
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, []string{"title", "draft", "score", "files"}, schema["required"])
+
+	properties := schema["properties"].(map[string]interface{})
+	assert.Len(t, properties, 6)
+	assert.Equal(t, map[string]interface{}{"type": "string"}, properties["title"])
+	assert.Equal(t, map[string]interface{}{"type": "boolean"}, properties["draft"])
+	assert.Equal(t, map[string]interface{}{"type": "number"}, properties["score"])
+	assert.Equal(t, map[string]interface{}{"type": "string"}, properties["body"])
+	assert.Equal(t, map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "string"}}, properties["labels"])
+
+	files := properties["files"].(map[string]interface{})
+	assert.Equal(t, "array", files["type"])
+	assert.Equal(t, []string{"path", "added"}, files["items"].(map[string]interface{})["required"])
+}
+
+// ==================== validate 测试 ====================
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want *FieldError
+	}{
+		{"符合", `{"title": "t", "draft": false, "score": 1.5, "files": [{"path": "a.go", "added": 3}]}`, nil},
+		{"可选字段为 null", `{"title": "t", "draft": true, "score": 1, "body": null, "files": []}`, nil},
+		{"缺少必需字段", `{"draft": true, "score": 1, "files": []}`, &FieldError{Field: "title"}},
+		{"必需字段为 null", `{"title": null, "draft": true, "score": 1, "files": []}`, &FieldError{Field: "title"}},
+		{"类型错误", `{"title": 1, "draft": true, "score": 1, "files": []}`, &FieldError{Field: "title", Expected: "string"}},
+		{"整数为小数", `{"title": "t", "draft": true, "score": 1, "files": [{"path": "a.go", "added": 1.5}]}`, &FieldError{Field: "files[0].added", Expected: "integer"}},
+		{"嵌套字段缺失", `{"title": "t", "draft": true, "score": 1, "files": [{"added": 1}]}`, &FieldError{Field: "files[0].path"}},
+		{"map 值类型错误", `{"title": "t", "draft": true, "score": 1, "files": [], "labels": {"area": 1}}`, &FieldError{Field: "labels.area", Expected: "string"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.json), &value))
+
+			err := validate(value, reflect.TypeOf(schemaSample{}), "")
+
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
+
+func TestFieldError(t *testing.T) {
+	missing := &FieldError{Field: "branch_name"}
+	assert.Equal(t, "LLM 响应中缺少 'branch_name' 字段", missing.Error())
+	assert.Equal(t, `the required field "branch_name" is missing`, missing.feedback())
+
+	wrongType := &FieldError{Field: "pr_title", Expected: "string"}
+	assert.Equal(t, "LLM 响应中 'pr_title' 字段类型错误（应为 string）", wrongType.Error())
+	assert.Equal(t, `the field "pr_title" must be of type string`, wrongType.feedback())
+}
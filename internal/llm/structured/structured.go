@@ -0,0 +1,175 @@
+// Package structured 以结构化输出方式调用 LLM：要求以 JSON 对象响应，并按 Go 结构体校验
+//
+// Call 根据结构体生成 JSON Schema，提供商支持时通过 response_format 发送，同时写入
+// system prompt。响应不是 JSON 对象或不符合 schema 时，把错误原因告诉模型并重新请求，
+// 最多重试 maxRepairs 次，仍然失败时返回 *ValidationError。
+package structured
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/zevwings/workflow/internal/llm/client"
+	"github.com/zevwings/workflow/internal/llm/utils"
+	"github.com/zevwings/workflow/internal/logging"
+)
+
+// DefaultMaxRepairs 响应校验失败时默认的最大重新请求次数
+const DefaultMaxRepairs = 2
+
+// Validator 结构体可以实现的可选接口，在 schema 校验通过后校验字段的取值
+//
+// 返回的错误信息会发送给模型，用于修复响应。
+type Validator interface {
+	Validate() error
+}
+
+// ValidationError 重新请求后 LLM 的响应仍然不符合 schema
+type ValidationError struct {
+	// Name schema 名称
+	Name string
+	// Attempts 请求次数（包括重新请求）
+	Attempts int
+	// Response 最后一次的响应
+	Response string
+	// Err 最后一次的校验错误
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("LLM 响应不符合 %s 格式（共请求 %d 次）: %v", e.Name, e.Attempts, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// syntaxError 响应不是合法的 JSON 对象
+type syntaxError struct {
+	err error
+}
+
+func (e *syntaxError) Error() string {
+	return fmt.Sprintf("解析 LLM 响应为 JSON 失败: %v", e.err)
+}
+
+func (e *syntaxError) Unwrap() error {
+	return e.err
+}
+
+// feedback 返回发送给模型的修复说明
+func (e *syntaxError) feedback() string {
+	return fmt.Sprintf("it is not a valid JSON object (%v)", e.err)
+}
+
+// Call 调用 LLM 并把响应解析为 T
+//
+// 请求会附带由 T 生成的 JSON Schema（见 Schema）。响应先去除 markdown 代码块并修复转义，
+// 再按 schema 校验；T 实现了 Validator 时还会调用 Validate。校验失败时把响应和错误原因
+// 附加到 user prompt 后重新请求，llmClient 实现了 client.RetryObserver 时先调用 OnRetry
+// （流式客户端借此丢弃已显示的无效响应）。LLM API 调用失败时直接返回该错误，不重新请求。
+//
+// 参数:
+//   - llmClient: LLM 客户端实例
+//   - name: schema 名称（只能包含字母、数字、下划线和连字符，如 "pr_summary"）
+//   - params: LLM 请求参数（不会被修改）
+//   - maxRepairs: 校验失败时的最大重新请求次数（通常为 DefaultMaxRepairs）
+//
+// 返回:
+//   - *T: 解析后的响应
+//   - error: LLM API 调用失败时返回该错误，响应始终不符合 schema 时返回 *ValidationError
+func Call[T any](llmClient client.LLMClient, name string, params *client.LLMRequestParams, maxRepairs int) (*T, error) {
+	logger := logging.GetLogger()
+
+	schema := Schema(reflect.TypeOf((*T)(nil)).Elem())
+	request := *params
+	request.SystemPrompt = withSchema(params.SystemPrompt, schema)
+	request.ResponseFormat = &client.ResponseFormat{Name: name, Schema: schema}
+
+	var response string
+	var validationErr error
+	attempts := 0
+	for attempts <= maxRepairs {
+		if validationErr != nil {
+			logger.WithError(validationErr).WithFields(logging.Fields{
+				"schema":  name,
+				"feature": params.Feature,
+				"attempt": attempts + 1,
+			}).Warn("LLM response does not match the schema, asking the model to repair it")
+			request.UserPrompt = repairPrompt(params.UserPrompt, response, validationErr)
+			if observer, ok := llmClient.(client.RetryObserver); ok {
+				observer.OnRetry()
+			}
+		}
+
+		var err error
+		response, err = llmClient.Call(&request)
+		if err != nil {
+			return nil, err
+		}
+		attempts++
+
+		result, err := decode[T](response)
+		if err == nil {
+			return result, nil
+		}
+		validationErr = err
+	}
+
+	logger.WithError(validationErr).WithFields(logging.Fields{
+		"schema":   name,
+		"feature":  params.Feature,
+		"attempts": attempts,
+	}).Error("LLM response does not match the schema")
+	return nil, &ValidationError{Name: name, Attempts: attempts, Response: response, Err: validationErr}
+}
+
+// decode 解析并校验响应
+func decode[T any](response string) (*T, error) {
+	jsonStr := utils.ExtractAndFixJSON(response)
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(jsonStr), &value); err != nil {
+		return nil, &syntaxError{err: err}
+	}
+	if _, ok := value.(map[string]interface{}); !ok {
+		return nil, &syntaxError{err: errors.New("the top-level value is not an object")}
+	}
+
+	result := new(T)
+	if err := validate(value, reflect.TypeOf(result).Elem(), ""); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(jsonStr), result); err != nil {
+		return nil, &syntaxError{err: err}
+	}
+	if validator, ok := any(result).(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// withSchema 在 system prompt 末尾附加 JSON Schema（不支持 response_format 的提供商依赖它）
+func withSchema(systemPrompt string, schema map[string]interface{}) string {
+	data, _ := json.MarshalIndent(schema, "", "  ")
+	return fmt.Sprintf("%s\n\nRespond with a single JSON object that matches this JSON schema:\n%s", systemPrompt, data)
+}
+
+// repairPrompt 生成要求模型修复响应的 user prompt
+func repairPrompt(userPrompt, response string, err error) string {
+	return fmt.Sprintf("%s\n\nYour previous response was:\n%s\n\nIt was rejected because %s. Reply again with only the corrected JSON object.",
+		userPrompt, response, feedback(err))
+}
+
+// feedback 返回校验错误对应的修复说明
+func feedback(err error) string {
+	var f interface{ feedback() string }
+	if errors.As(err, &f) {
+		return f.feedback()
+	}
+	return err.Error()
+}
@@ -0,0 +1,191 @@
+package structured
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zevwings/workflow/internal/llm/client"
+)
+
+// fakeClient 依次返回固定响应并记录请求参数的 LLM 客户端
+type fakeClient struct {
+	responses []string
+	err       error
+	requests  []client.LLMRequestParams
+}
+
+func (f *fakeClient) Call(params *client.LLMRequestParams) (string, error) {
+	f.requests = append(f.requests, *params)
+	if f.err != nil {
+		return "", f.err
+	}
+	response := f.responses[min(len(f.requests), len(f.responses))-1]
+	return response, nil
+}
+
+func (f *fakeClient) Stream(ctx context.Context, params *client.LLMRequestParams, onDelta func(delta string)) (string, error) {
+	return f.Call(params)
+}
+
+// observingClient 记录 OnRetry 调用时已发送请求数的 fakeClient
+type observingClient struct {
+	fakeClient
+	retries []int
+}
+
+func (o *observingClient) OnRetry() {
+	o.retries = append(o.retries, len(o.requests))
+}
+
+type titleResponse struct {
+	Title       string  `json:"title"`
+	Description *string `json:"description"`
+}
+
+// nonEmptyTitle 要求 title 不为空的响应
+type nonEmptyTitle struct {
+	Title string `json:"title"`
+}
+
+func (r *nonEmptyTitle) Validate() error {
+	if r.Title == "" {
+		return fmt.Errorf("'title' must not be empty")
+	}
+	return nil
+}
+
+func testParams() *client.LLMRequestParams {
+	return &client.LLMRequestParams{SystemPrompt: "Generate a title.", UserPrompt: "diff", Feature: client.FeaturePRCreate}
+}
+
+// ==================== Call 测试 ====================
+
+func TestCall(t *testing.T) {
+	// Arrange: 响应包含 markdown 代码块
+	llmClient := &fakeClient{responses: []string{"```json\n{\"title\": \"Add login\", \"description\": \"- Add login page\"}\n```"}}
+	params := testParams()
+
+	// Act
+	result, err := Call[titleResponse](llmClient, "title", params, DefaultMaxRepairs)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Add login", result.Title)
+	require.NotNil(t, result.Description)
+	assert.Equal(t, "- Add login page", *result.Description)
+
+	// 请求附带 schema，且不修改调用方的参数
+	require.Len(t, llmClient.requests, 1)
+	request := llmClient.requests[0]
+	require.NotNil(t, request.ResponseFormat)
+	assert.Equal(t, "title", request.ResponseFormat.Name)
+	assert.Equal(t, []string{"title"}, request.ResponseFormat.Schema["required"])
+	assert.Contains(t, request.SystemPrompt, "Generate a title.")
+	assert.Contains(t, request.SystemPrompt, `"required": [`)
+	assert.Equal(t, client.FeaturePRCreate, request.Feature)
+	assert.Equal(t, "Generate a title.", params.SystemPrompt)
+	assert.Nil(t, params.ResponseFormat)
+}
+
+func TestCall_RepairsInvalidResponse(t *testing.T) {
+	// Arrange: 第一次响应缺少字段，第二次正确
+	llmClient := &fakeClient{responses: []string{`{"description": "no title"}`, `{"title": "Add login"}`}}
+
+	// Act
+	result, err := Call[titleResponse](llmClient, "title", testParams(), DefaultMaxRepairs)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Add login", result.Title)
+
+	// 重新请求时附带上一次的响应和错误原因
+	require.Len(t, llmClient.requests, 2)
+	repair := llmClient.requests[1].UserPrompt
+	assert.Contains(t, repair, "diff")
+	assert.Contains(t, repair, `{"description": "no title"}`)
+	assert.Contains(t, repair, `the required field "title" is missing`)
+}
+
+func TestCall_RepairsValidatorError(t *testing.T) {
+	llmClient := &fakeClient{responses: []string{`{"title": ""}`, `{"title": "Add login"}`}}
+
+	result, err := Call[nonEmptyTitle](llmClient, "title", testParams(), DefaultMaxRepairs)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Add login", result.Title)
+	require.Len(t, llmClient.requests, 2)
+	assert.Contains(t, llmClient.requests[1].UserPrompt, "'title' must not be empty")
+}
+
+func TestCall_NotifiesRetry(t *testing.T) {
+	// Arrange: 第一次响应校验失败，第二次正确
+	llmClient := &observingClient{fakeClient: fakeClient{responses: []string{`{"title": ""}`, `{"title": "Add login"}`}}}
+
+	// Act
+	result, err := Call[nonEmptyTitle](llmClient, "title", testParams(), DefaultMaxRepairs)
+
+	// Assert: 第二次请求之前调用一次 OnRetry
+	require.NoError(t, err)
+	assert.Equal(t, "Add login", result.Title)
+	assert.Equal(t, []int{1}, llmClient.retries)
+}
+
+func TestCall_ValidationError(t *testing.T) {
+	// Arrange: 模型始终返回无效的 JSON
+	llmClient := &fakeClient{responses: []string{"Sure! Here is the title: Add login"}}
+
+	// Act
+	_, err := Call[titleResponse](llmClient, "title", testParams(), 1)
+
+	// Assert: 最多重新请求 1 次，返回类型化的错误
+	require.Error(t, err)
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "title", validationErr.Name)
+	assert.Equal(t, 2, validationErr.Attempts)
+	assert.Equal(t, "Sure! Here is the title: Add login", validationErr.Response)
+	assert.Contains(t, err.Error(), "解析 LLM 响应为 JSON 失败")
+	assert.Len(t, llmClient.requests, 2)
+	assert.Contains(t, llmClient.requests[1].UserPrompt, "it is not a valid JSON object")
+}
+
+func TestCall_NotAnObject(t *testing.T) {
+	llmClient := &fakeClient{responses: []string{`["Add login"]`}}
+
+	_, err := Call[titleResponse](llmClient, "title", testParams(), 0)
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, 1, validationErr.Attempts)
+	assert.Contains(t, err.Error(), "the top-level value is not an object")
+}
+
+func TestCall_MissingFieldError(t *testing.T) {
+	llmClient := &fakeClient{responses: []string{`{"description": "no title"}`}}
+
+	_, err := Call[titleResponse](llmClient, "title", testParams(), 0)
+
+	var fieldErr *FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "title", fieldErr.Field)
+	assert.Contains(t, err.Error(), "缺少 'title' 字段")
+}
+
+func TestCall_RequestError(t *testing.T) {
+	// Arrange: LLM API 调用失败时不重新请求
+	requestErr := &client.RequestError{Err: errors.New("connection refused")}
+	llmClient := &fakeClient{err: requestErr}
+
+	// Act
+	_, err := Call[titleResponse](llmClient, "title", testParams(), DefaultMaxRepairs)
+
+	// Assert
+	assert.ErrorIs(t, err, requestErr)
+	var validationErr *ValidationError
+	assert.False(t, errors.As(err, &validationErr))
+	assert.Len(t, llmClient.requests, 1)
+}
@@ -0,0 +1,185 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zevwings/workflow/internal/llm/client"
+	"github.com/zevwings/workflow/internal/llm/compact"
+	"github.com/zevwings/workflow/internal/logging"
+)
+
+// Options 用量记录选项
+type Options struct {
+	// Provider 提供商
+	Provider string
+	// Model 默认模型名称（请求参数中的 Model 优先）
+	Model string
+	// Repo 当前仓库
+	Repo string
+	// Pricer 价格查询函数（nil 表示没有价格，不检查预算）
+	Pricer Pricer
+	// MonthlyBudget 每月的软预算，单位美元（<= 0 表示不检查）
+	MonthlyBudget float64
+	// Warn 本月费用达到预算时，在调用之前调用一次（nil 时只记录日志）
+	Warn func(spent, budget float64)
+}
+
+// budget 本月费用和预算提醒状态
+//
+// 保存在 Store 中，同一个用量文件的所有客户端（如回退链中的每个提供商）共享，
+// 任何客户端的调用都计入本月费用，达到预算时只提醒一次。
+type budget struct {
+	mu sync.Mutex
+	// spent 本月已花费的费用（第一次检查时从用量文件加载）
+	spent  float64
+	loaded bool
+	warned bool
+}
+
+// recordingClient 记录用量的 LLM 客户端，实现 client.LLMClient 接口
+type recordingClient struct {
+	llmClient client.LLMClient
+	store     *Store
+	opts      Options
+	now       func() time.Time
+}
+
+// NewClient 为 LLM 客户端添加用量记录
+//
+// 每次成功的调用追加一条记录到 store。被装饰的客户端实现 client.UsageReporter 时
+// 使用 API 返回的 token 数，否则按提示词和响应估算。设置了 MonthlyBudget 时，
+// 每次调用之前检查本月费用，达到预算时调用 Warn（同一个 store 只提醒一次），调用不会被阻止。
+//
+// 参数:
+//   - llmClient: 被装饰的 LLM 客户端
+//   - store: 用量文件
+//   - opts: 用量记录选项
+//
+// 返回:
+//   - client.LLMClient: 记录用量的 LLM 客户端
+func NewClient(llmClient client.LLMClient, store *Store, opts Options) client.LLMClient {
+	if llmClient == nil {
+		panic(fmt.Errorf("llm/usage.NewClient: llmClient cannot be nil"))
+	}
+	if store == nil {
+		panic(fmt.Errorf("llm/usage.NewClient: store cannot be nil"))
+	}
+	return &recordingClient{llmClient: llmClient, store: store, opts: opts, now: time.Now}
+}
+
+// Call 调用 LLM API 并记录用量
+func (c *recordingClient) Call(params *client.LLMRequestParams) (string, error) {
+	c.checkBudget()
+
+	start := c.now()
+	var response string
+	var reported client.Usage
+	var err error
+	if reporter, ok := c.llmClient.(client.UsageReporter); ok {
+		response, reported, err = reporter.CallWithUsage(params)
+	} else {
+		response, err = c.llmClient.Call(params)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	c.record(params, response, reported, c.now().Sub(start))
+	return response, nil
+}
+
+// Stream 以流式方式调用 LLM API 并记录用量
+func (c *recordingClient) Stream(ctx context.Context, params *client.LLMRequestParams, onDelta func(delta string)) (string, error) {
+	c.checkBudget()
+
+	start := c.now()
+	var response string
+	var reported client.Usage
+	var err error
+	if reporter, ok := c.llmClient.(client.UsageReporter); ok {
+		response, reported, err = reporter.StreamWithUsage(ctx, params, onDelta)
+	} else {
+		response, err = c.llmClient.Stream(ctx, params, onDelta)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	c.record(params, response, reported, c.now().Sub(start))
+	return response, nil
+}
+
+// record 写入用量记录，写入失败只记录日志，不影响本次调用
+func (c *recordingClient) record(params *client.LLMRequestParams, response string, reported client.Usage, latency time.Duration) {
+	model := c.opts.Model
+	if params.Model != "" {
+		model = params.Model
+	}
+
+	record := Record{
+		Time:             c.now(),
+		Provider:         c.opts.Provider,
+		Model:            model,
+		Feature:          params.Feature,
+		Repo:             c.opts.Repo,
+		PromptTokens:     reported.PromptTokens,
+		CompletionTokens: reported.CompletionTokens,
+		LatencyMS:        latency.Milliseconds(),
+	}
+	if record.PromptTokens == 0 && record.CompletionTokens == 0 {
+		record.PromptTokens = compact.EstimateTokens(params.SystemPrompt) + compact.EstimateTokens(params.UserPrompt)
+		record.CompletionTokens = compact.EstimateTokens(response)
+		record.Estimated = true
+	}
+
+	if err := c.store.Append(record); err != nil {
+		logging.GetLogger().WithError(err).Warn("Failed to record LLM usage")
+	}
+
+	// 本月费用已加载时累加，之后的调用不需要重新读取用量文件
+	if cost, ok := Cost(record, c.opts.Pricer); ok {
+		b := &c.store.budget
+		b.mu.Lock()
+		if b.loaded {
+			b.spent += cost
+		}
+		b.mu.Unlock()
+	}
+}
+
+// checkBudget 本月费用达到预算时提醒一次
+func (c *recordingClient) checkBudget() {
+	if c.opts.MonthlyBudget <= 0 || c.opts.Pricer == nil {
+		return
+	}
+
+	b := &c.store.budget
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.warned {
+		return
+	}
+	if !b.loaded {
+		records, err := c.store.Load(MonthStart(c.now()))
+		if err != nil {
+			logging.GetLogger().WithError(err).Debug("Failed to load LLM usage for the budget check")
+		}
+		b.spent = Spent(records, c.opts.Pricer)
+		b.loaded = true
+	}
+	if b.spent < c.opts.MonthlyBudget {
+		return
+	}
+
+	b.warned = true
+	logging.GetLogger().WithFields(logging.Fields{
+		"spent":  b.spent,
+		"budget": c.opts.MonthlyBudget,
+	}).Warn("LLM monthly budget reached")
+	if c.opts.Warn != nil {
+		c.opts.Warn(b.spent, c.opts.MonthlyBudget)
+	}
+}
@@ -0,0 +1,186 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zevwings/workflow/internal/llm/client"
+)
+
+// fakeClient 不返回用量的 LLM 客户端
+type fakeClient struct {
+	calls    int
+	response string
+	err      error
+}
+
+func (f *fakeClient) Call(params *client.LLMRequestParams) (string, error) {
+	f.calls++
+	return f.response, f.err
+}
+
+func (f *fakeClient) Stream(ctx context.Context, params *client.LLMRequestParams, onDelta func(delta string)) (string, error) {
+	f.calls++
+	if f.err == nil && onDelta != nil {
+		onDelta(f.response)
+	}
+	return f.response, f.err
+}
+
+// reportingClient 返回用量的 LLM 客户端
+type reportingClient struct {
+	fakeClient
+	usage client.Usage
+}
+
+func (r *reportingClient) CallWithUsage(params *client.LLMRequestParams) (string, client.Usage, error) {
+	response, err := r.Call(params)
+	return response, r.usage, err
+}
+
+func (r *reportingClient) StreamWithUsage(ctx context.Context, params *client.LLMRequestParams, onDelta func(delta string)) (string, client.Usage, error) {
+	response, err := r.Stream(ctx, params, onDelta)
+	return response, r.usage, err
+}
+
+func testParams() *client.LLMRequestParams {
+	return &client.LLMRequestParams{SystemPrompt: "system", UserPrompt: "user prompt", Feature: client.FeatureCommit}
+}
+
+func testStore(t *testing.T) *Store {
+	return NewStore(filepath.Join(t.TempDir(), "usage.jsonl"))
+}
+
+// ==================== NewClient 测试 ====================
+
+func TestClient_Call_ReportedUsage(t *testing.T) {
+	// Arrange: 返回用量的客户端
+	inner := &reportingClient{fakeClient: fakeClient{response: "response"}, usage: client.Usage{PromptTokens: 120, CompletionTokens: 30}}
+	store := testStore(t)
+	llmClient := NewClient(inner, store, Options{Provider: "openai", Model: "gpt-4o", Repo: "owner/repo"})
+
+	// Act
+	response, err := llmClient.Call(testParams())
+
+	// Assert: 使用 API 返回的 token 数
+	require.NoError(t, err)
+	assert.Equal(t, "response", response)
+	records, err := store.Load(time.Time{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "openai", records[0].Provider)
+	assert.Equal(t, "gpt-4o", records[0].Model)
+	assert.Equal(t, client.FeatureCommit, records[0].Feature)
+	assert.Equal(t, "owner/repo", records[0].Repo)
+	assert.Equal(t, 120, records[0].PromptTokens)
+	assert.Equal(t, 30, records[0].CompletionTokens)
+	assert.False(t, records[0].Estimated)
+}
+
+func TestClient_Stream_EstimatedUsage(t *testing.T) {
+	// Arrange: 不返回用量的客户端，请求指定了模型
+	inner := &fakeClient{response: "streamed response"}
+	store := testStore(t)
+	llmClient := NewClient(inner, store, Options{Provider: "proxy", Model: "default"})
+	params := testParams()
+	params.Model = "custom"
+
+	// Act
+	var deltas []string
+	_, err := llmClient.Stream(context.Background(), params, func(delta string) { deltas = append(deltas, delta) })
+
+	// Assert: token 数为估算值，模型使用请求参数中的模型
+	require.NoError(t, err)
+	assert.Equal(t, []string{"streamed response"}, deltas)
+	records, err := store.Load(time.Time{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "custom", records[0].Model)
+	assert.True(t, records[0].Estimated)
+	assert.Greater(t, records[0].PromptTokens, 0)
+	assert.Greater(t, records[0].CompletionTokens, 0)
+}
+
+func TestClient_ErrorNotRecorded(t *testing.T) {
+	inner := &fakeClient{err: errors.New("boom")}
+	store := testStore(t)
+	llmClient := NewClient(inner, store, Options{Provider: "openai", Model: "gpt-4o"})
+
+	_, err := llmClient.Call(testParams())
+
+	require.Error(t, err)
+	records, err := store.Load(time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestClient_MonthlyBudget(t *testing.T) {
+	// Arrange: 本月已花费 $0.014，上个月的记录不计入
+	store := testStore(t)
+	now := time.Now()
+	require.NoError(t, store.Append(Record{Time: MonthStart(now).AddDate(0, -1, 0), Model: "gpt-4o", PromptTokens: 1_000_000}))
+	require.NoError(t, store.Append(Record{Time: now, Model: "gpt-4o", PromptTokens: 4000, CompletionTokens: 400}))
+
+	var warnings []float64
+	newClient := func(budget float64) client.LLMClient {
+		return NewClient(&fakeClient{response: "response"}, store, Options{
+			Model:         "gpt-4o",
+			Pricer:        testPricer,
+			MonthlyBudget: budget,
+			Warn:          func(spent, budget float64) { warnings = append(warnings, spent) },
+		})
+	}
+
+	// Act: 预算内的调用不提醒
+	_, err := newClient(1).Call(testParams())
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	// Act: 达到预算后，调用之前提醒一次，调用仍然执行
+	overBudget := newClient(0.01)
+	_, err = overBudget.Call(testParams())
+	require.NoError(t, err)
+	_, err = overBudget.Call(testParams())
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, warnings, 1)
+	assert.Greater(t, warnings[0], 0.01)
+	assert.Less(t, warnings[0], 1.0)
+}
+
+func TestClient_MonthlyBudgetSharedByStore(t *testing.T) {
+	// Arrange: 两个提供商的客户端写入同一个用量文件
+	store := testStore(t)
+	var warnings []float64
+	newClient := func(provider string) client.LLMClient {
+		return NewClient(&fakeClient{response: "response"}, store, Options{
+			Provider:      provider,
+			Model:         "gpt-4o",
+			Pricer:        testPricer,
+			MonthlyBudget: 0.01,
+			Warn:          func(spent, budget float64) { warnings = append(warnings, spent) },
+		})
+	}
+	primary, fallback := newClient("openai"), newClient("deepseek")
+	require.NoError(t, store.Append(Record{Time: time.Now(), Model: "gpt-4o", PromptTokens: 4000, CompletionTokens: 400}))
+
+	// Act
+	_, err := primary.Call(testParams())
+	require.NoError(t, err)
+	_, err = fallback.Call(testParams())
+	require.NoError(t, err)
+
+	// Assert: 本月费用共享，只提醒一次
+	assert.Len(t, warnings, 1)
+}
+
+func TestNewClient_NilArguments(t *testing.T) {
+	assert.Panics(t, func() { NewClient(nil, testStore(t), Options{}) })
+	assert.Panics(t, func() { NewClient(&fakeClient{}, nil, Options{}) })
+}
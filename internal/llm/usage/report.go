@@ -0,0 +1,254 @@
+package usage
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 用量报告的分组方式
+const (
+	// GroupByModel 按模型分组
+	GroupByModel = "model"
+	// GroupByFeature 按功能分组
+	GroupByFeature = "feature"
+	// GroupByRepo 按仓库分组
+	GroupByRepo = "repo"
+)
+
+// GroupByOptions 支持的分组方式
+var GroupByOptions = []string{GroupByModel, GroupByFeature, GroupByRepo}
+
+// unknownKey 分组字段为空时使用的分组名
+const unknownKey = "(unknown)"
+
+// Price 价格，单位美元 / 百万 token
+type Price struct {
+	// Input 提示词价格
+	Input float64
+	// Output 完成价格
+	Output float64
+}
+
+// Cost 计算费用
+//
+// 参数:
+//   - promptTokens: 提示词 token 数
+//   - completionTokens: 完成 token 数
+//
+// 返回:
+//   - float64: 费用，单位美元
+func (p Price) Cost(promptTokens, completionTokens int) float64 {
+	return (float64(promptTokens)*p.Input + float64(completionTokens)*p.Output) / 1_000_000
+}
+
+// Pricer 查询提供商和模型的价格，没有价格时返回 false
+type Pricer func(provider, model string) (Price, bool)
+
+// FormatCost 格式化费用
+//
+// 不足 1 美元的费用保留 4 位小数，避免小额费用显示为 $0.00。
+//
+// 参数:
+//   - cost: 费用，单位美元
+//
+// 返回:
+//   - string: 如 "$12.50"、"$0.0035"
+func FormatCost(cost float64) string {
+	if cost != 0 && cost < 1 {
+		return fmt.Sprintf("$%.4f", cost)
+	}
+	return fmt.Sprintf("$%.2f", cost)
+}
+
+// Cost 计算一条记录的费用
+//
+// 参数:
+//   - record: 用量记录
+//   - pricer: 价格查询函数（nil 表示没有价格）
+//
+// 返回:
+//   - float64: 费用，单位美元
+//   - bool: 是否找到价格
+func Cost(record Record, pricer Pricer) (float64, bool) {
+	if pricer == nil {
+		return 0, false
+	}
+	price, ok := pricer(record.Provider, record.Model)
+	if !ok {
+		return 0, false
+	}
+	return price.Cost(record.PromptTokens, record.CompletionTokens), true
+}
+
+// Row 用量报告中的一行
+type Row struct {
+	// Key 分组名（模型、功能或仓库）
+	Key string
+	// Calls 调用次数
+	Calls int
+	// PromptTokens、CompletionTokens token 数
+	PromptTokens     int
+	CompletionTokens int
+	// Cost 估算的费用，单位美元（不包括没有价格的调用）
+	Cost float64
+	// Unpriced 没有价格的调用次数
+	Unpriced int
+	// Estimated token 数为估算值的调用次数
+	Estimated int
+	// Latency 总耗时
+	Latency time.Duration
+}
+
+// AverageLatency 平均耗时
+func (r Row) AverageLatency() time.Duration {
+	if r.Calls == 0 {
+		return 0
+	}
+	return r.Latency / time.Duration(r.Calls)
+}
+
+// add 将一条记录计入该行
+func (r *Row) add(record Record, pricer Pricer) {
+	r.Calls++
+	r.PromptTokens += record.PromptTokens
+	r.CompletionTokens += record.CompletionTokens
+	r.Latency += record.Latency()
+	if record.Estimated {
+		r.Estimated++
+	}
+	if cost, ok := Cost(record, pricer); ok {
+		r.Cost += cost
+	} else {
+		r.Unpriced++
+	}
+}
+
+// Report 用量报告
+type Report struct {
+	// Rows 按费用、调用次数从高到低排列的分组
+	Rows []Row
+	// Total 所有记录的合计（Key 为空）
+	Total Row
+}
+
+// Summarize 按模型、功能或仓库汇总用量
+//
+// 参数:
+//   - records: 用量记录
+//   - groupBy: 分组方式（GroupByModel、GroupByFeature 或 GroupByRepo）
+//   - pricer: 价格查询函数（nil 表示没有价格）
+//
+// 返回:
+//   - *Report: 用量报告
+//   - error: 分组方式不支持时返回错误
+func Summarize(records []Record, groupBy string, pricer Pricer) (*Report, error) {
+	var keyOf func(Record) string
+	switch groupBy {
+	case GroupByModel:
+		keyOf = func(r Record) string { return r.Model }
+	case GroupByFeature:
+		keyOf = func(r Record) string { return r.Feature }
+	case GroupByRepo:
+		keyOf = func(r Record) string { return r.Repo }
+	default:
+		return nil, fmt.Errorf("不支持的分组方式: %s（可选: %s）", groupBy, strings.Join(GroupByOptions, ", "))
+	}
+
+	report := &Report{}
+	rows := map[string]*Row{}
+	for _, record := range records {
+		key := keyOf(record)
+		if key == "" {
+			key = unknownKey
+		}
+		row, ok := rows[key]
+		if !ok {
+			row = &Row{Key: key}
+			rows[key] = row
+		}
+		row.add(record, pricer)
+		report.Total.add(record, pricer)
+	}
+
+	for _, row := range rows {
+		report.Rows = append(report.Rows, *row)
+	}
+	sort.Slice(report.Rows, func(i, j int) bool {
+		a, b := report.Rows[i], report.Rows[j]
+		if a.Cost != b.Cost {
+			return a.Cost > b.Cost
+		}
+		if a.Calls != b.Calls {
+			return a.Calls > b.Calls
+		}
+		return a.Key < b.Key
+	})
+
+	return report, nil
+}
+
+// Spent 计算记录的总费用
+//
+// 参数:
+//   - records: 用量记录
+//   - pricer: 价格查询函数（nil 表示没有价格）
+//
+// 返回:
+//   - float64: 有价格的记录的总费用，单位美元
+func Spent(records []Record, pricer Pricer) float64 {
+	total := 0.0
+	for _, record := range records {
+		if cost, ok := Cost(record, pricer); ok {
+			total += cost
+		}
+	}
+	return total
+}
+
+// MonthStart 返回 t 所在月份第一天的零点（t 的时区）
+func MonthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// ParseSince 解析用量报告的起始时间
+//
+// 支持以下格式:
+//   - 空字符串或 "month": 本月第一天
+//   - "all": 全部记录
+//   - 天数，如 "7d"
+//   - Go 时间长度，如 "12h"
+//   - 日期，如 "2024-05-01"（本地时区）
+//
+// 参数:
+//   - value: 起始时间
+//   - now: 当前时间
+//
+// 返回:
+//   - time.Time: 起始时间（"all" 时为零值）
+//   - error: 格式不支持时返回错误
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	switch value {
+	case "", "month":
+		return MonthStart(now), nil
+	case "all":
+		return time.Time{}, nil
+	}
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if duration, err := time.ParseDuration(value); err == nil && duration >= 0 {
+		return now.Add(-duration), nil
+	}
+	if date, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return date, nil
+	}
+
+	return time.Time{}, fmt.Errorf("无法解析起始时间: %s（支持 month、all、7d、12h 或 2024-05-01）", value)
+}
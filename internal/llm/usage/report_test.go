@@ -0,0 +1,133 @@
+package usage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPricer gpt-4o 有价格，其他模型没有
+func testPricer(provider, model string) (Price, bool) {
+	if model == "gpt-4o" {
+		return Price{Input: 2.5, Output: 10}, true
+	}
+	return Price{}, false
+}
+
+// ==================== Price 测试 ====================
+
+func TestPrice_Cost(t *testing.T) {
+	price := Price{Input: 2.5, Output: 10}
+
+	assert.InDelta(t, 0.0035, price.Cost(1000, 100), 1e-9)
+	assert.Equal(t, 0.0, Price{}.Cost(1000, 100))
+}
+
+func TestFormatCost(t *testing.T) {
+	assert.Equal(t, "$0.00", FormatCost(0))
+	assert.Equal(t, "$0.0035", FormatCost(0.0035))
+	assert.Equal(t, "$12.50", FormatCost(12.5))
+}
+
+// ==================== Summarize 测试 ====================
+
+func TestSummarize(t *testing.T) {
+	// Arrange
+	records := []Record{
+		{Model: "gpt-4o", Feature: "commit", Repo: "a", PromptTokens: 1000, CompletionTokens: 100, LatencyMS: 1000},
+		{Model: "gpt-4o", Feature: "pr-create", Repo: "b", PromptTokens: 3000, CompletionTokens: 300, LatencyMS: 3000},
+		{Model: "llama3.2", Feature: "commit", PromptTokens: 500, CompletionTokens: 50, Estimated: true, LatencyMS: 500},
+	}
+
+	// Act
+	report, err := Summarize(records, GroupByModel, testPricer)
+
+	// Assert: 按费用从高到低排列，没有价格的调用单独计数
+	require.NoError(t, err)
+	require.Len(t, report.Rows, 2)
+	assert.Equal(t, "gpt-4o", report.Rows[0].Key)
+	assert.Equal(t, 2, report.Rows[0].Calls)
+	assert.Equal(t, 4000, report.Rows[0].PromptTokens)
+	assert.Equal(t, 400, report.Rows[0].CompletionTokens)
+	assert.InDelta(t, 0.014, report.Rows[0].Cost, 1e-9)
+	assert.Equal(t, 2*time.Second, report.Rows[0].AverageLatency())
+	assert.Equal(t, "llama3.2", report.Rows[1].Key)
+	assert.Equal(t, 1, report.Rows[1].Unpriced)
+	assert.Equal(t, 1, report.Rows[1].Estimated)
+
+	assert.Equal(t, 3, report.Total.Calls)
+	assert.Equal(t, 4500, report.Total.PromptTokens)
+	assert.InDelta(t, 0.014, report.Total.Cost, 1e-9)
+	assert.Equal(t, 1, report.Total.Unpriced)
+}
+
+func TestSummarize_GroupBy(t *testing.T) {
+	records := []Record{
+		{Model: "gpt-4o", Feature: "commit", Repo: "a"},
+		{Model: "gpt-4o", Feature: "commit"},
+		{Model: "gpt-4o", Feature: "translate", Repo: "a"},
+	}
+
+	tests := []struct {
+		groupBy string
+		want    map[string]int
+	}{
+		{GroupByFeature, map[string]int{"commit": 2, "translate": 1}},
+		{GroupByRepo, map[string]int{"a": 2, unknownKey: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.groupBy, func(t *testing.T) {
+			report, err := Summarize(records, tt.groupBy, nil)
+			require.NoError(t, err)
+
+			calls := map[string]int{}
+			for _, row := range report.Rows {
+				calls[row.Key] = row.Calls
+			}
+			assert.Equal(t, tt.want, calls)
+		})
+	}
+}
+
+func TestSummarize_InvalidGroupBy(t *testing.T) {
+	_, err := Summarize(nil, "provider", nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "不支持的分组方式")
+}
+
+// ==================== ParseSince 测试 ====================
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 5, 10, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"month", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"all", time.Time{}},
+		{"7d", time.Date(2024, 5, 3, 15, 30, 0, 0, time.UTC)},
+		{"12h", time.Date(2024, 5, 10, 3, 30, 0, 0, time.UTC)},
+		{"2024-04-15", time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSince(tt.value, now)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func TestParseSince_Invalid(t *testing.T) {
+	for _, value := range []string{"yesterday", "-3d", "2024/04/15"} {
+		_, err := ParseSince(value, time.Now())
+		assert.Error(t, err, value)
+	}
+}
@@ -0,0 +1,157 @@
+// Package usage 记录 LLM 调用的 token 用量并估算费用
+//
+// 每次成功的 API 调用（不包括命中响应缓存的调用）追加为用量文件中的一行 JSON，
+// 记录时间、提供商、模型、功能、仓库、token 数和耗时。API 没有返回 token 数时，
+// 按 compact.EstimateTokens 估算并标记为 Estimated。费用按价格表（美元 / 百万 token）估算。
+package usage
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/zevwings/workflow/internal/logging"
+)
+
+// Record 一次 LLM 调用的用量
+type Record struct {
+	// Time 调用完成的时间
+	Time time.Time `json:"time"`
+	// Provider 提供商
+	Provider string `json:"provider"`
+	// Model 模型名称
+	Model string `json:"model"`
+	// Feature 发起调用的功能（如 client.FeatureCommit）
+	Feature string `json:"feature,omitempty"`
+	// Repo 调用时所在的仓库
+	Repo string `json:"repo,omitempty"`
+	// PromptTokens 提示词 token 数
+	PromptTokens int `json:"prompt_tokens"`
+	// CompletionTokens 完成 token 数
+	CompletionTokens int `json:"completion_tokens"`
+	// Estimated token 数是估算的（API 没有返回用量）
+	Estimated bool `json:"estimated,omitempty"`
+	// LatencyMS 调用耗时，单位毫秒
+	LatencyMS int64 `json:"latency_ms"`
+}
+
+// Latency 调用耗时
+func (r Record) Latency() time.Duration {
+	return time.Duration(r.LatencyMS) * time.Millisecond
+}
+
+// Store 只追加的用量文件（每行一条 JSON 记录）
+type Store struct {
+	path string
+	mu   sync.Mutex
+	// budget 预算检查状态，写入此文件的所有客户端共享
+	budget budget
+}
+
+// NewStore 创建用量文件
+//
+// 文件和目录在第一次写入时创建。
+//
+// 参数:
+//   - path: 用量文件路径
+//
+// 返回:
+//   - *Store: 用量文件
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path 返回用量文件路径
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Append 追加一条用量记录
+//
+// 参数:
+//   - record: 用量记录
+//
+// 返回:
+//   - error: 创建目录或写入文件失败时返回错误
+func (s *Store) Append(record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("序列化用量记录失败: %w", err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("创建用量目录失败: %w", err)
+	}
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("打开用量文件失败: %w", err)
+	}
+	defer file.Close()
+
+	// 一次写入整行，O_APPEND 保证多个进程同时写入时行不会交错
+	if _, err := file.Write(line); err != nil {
+		return fmt.Errorf("写入用量文件失败: %w", err)
+	}
+	return nil
+}
+
+// Load 读取指定时间之后的用量记录
+//
+// 无法解析的行会被跳过。
+//
+// 参数:
+//   - since: 起始时间（零值表示全部记录）
+//
+// 返回:
+//   - []Record: 按写入顺序排列的记录，文件不存在时返回 nil
+//   - error: 读取文件失败时返回错误
+func (s *Store) Load(since time.Time) ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.Open(s.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("打开用量文件失败: %w", err)
+	}
+	defer file.Close()
+
+	var records []Record
+	skipped := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(line, &record); err != nil {
+			skipped++
+			continue
+		}
+		if record.Time.Before(since) {
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取用量文件失败: %w", err)
+	}
+	if skipped > 0 {
+		logging.GetLogger().WithField("skipped", skipped).Debug("Skipped malformed LLM usage records")
+	}
+
+	return records, nil
+}
@@ -0,0 +1,67 @@
+package usage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==================== Store 测试 ====================
+
+func TestStore_AppendAndLoad(t *testing.T) {
+	// Arrange: 目录尚不存在的用量文件
+	store := NewStore(filepath.Join(t.TempDir(), "llm", "usage.jsonl"))
+	base := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	old := Record{Time: base.AddDate(0, -1, 0), Provider: "openai", Model: "gpt-4o", Feature: "commit", PromptTokens: 100, CompletionTokens: 10, LatencyMS: 800}
+	recent := Record{Time: base, Provider: "openai", Model: "gpt-4o", Feature: "pr-create", Repo: "owner/repo", PromptTokens: 200, CompletionTokens: 20, Estimated: true, LatencyMS: 1200}
+
+	// Act: 追加两条记录后读取
+	require.NoError(t, store.Append(old))
+	require.NoError(t, store.Append(recent))
+	all, err := store.Load(time.Time{})
+	require.NoError(t, err)
+	since, err := store.Load(base.AddDate(0, 0, -1))
+	require.NoError(t, err)
+
+	// Assert: 按写入顺序返回，since 之前的记录被过滤
+	require.Len(t, all, 2)
+	assert.True(t, old.Time.Equal(all[0].Time))
+	assert.Equal(t, "commit", all[0].Feature)
+	require.Len(t, since, 1)
+	assert.Equal(t, "owner/repo", since[0].Repo)
+	assert.True(t, since[0].Estimated)
+	assert.Equal(t, 1200*time.Millisecond, since[0].Latency())
+}
+
+func TestStore_LoadMissingFile(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "usage.jsonl"))
+
+	records, err := store.Load(time.Time{})
+
+	require.NoError(t, err)
+	assert.Nil(t, records)
+}
+
+func TestStore_LoadSkipsMalformedLines(t *testing.T) {
+	// Arrange: 包含损坏行和空行的用量文件
+	path := filepath.Join(t.TempDir(), "usage.jsonl")
+	content := `{"time":"2024-05-10T12:00:00Z","provider":"openai","model":"gpt-4o","prompt_tokens":1,"completion_tokens":2,"latency_ms":3}
+{"time":"2024-05-10T12:01:00Z","provider":
+
+{"time":"2024-05-10T12:02:00Z","provider":"deepseek","model":"deepseek-chat","prompt_tokens":4,"completion_tokens":5,"latency_ms":6}
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	// Act
+	records, err := NewStore(path).Load(time.Time{})
+
+	// Assert: 只返回可以解析的记录
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "gpt-4o", records[0].Model)
+	assert.Equal(t, "deepseek-chat", records[1].Model)
+}
@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"io"
+	"strconv"
+	"unicode/utf16"
+)
+
+// JSONFieldStream 从流式返回的 JSON 响应中实时提取字符串字段
+//
+// LLM 以 JSON 格式返回结果时，直接输出原始内容可读性很差。JSONFieldStream 逐段接收
+// 响应内容，只把顶层对象中指定字段的字符串值（已解码转义字符）写入 Writer，
+// 多个字段之间以空行分隔。第一个 '{' 之前的内容（如 markdown 代码块标记）会被忽略。
+type JSONFieldStream struct {
+	w      io.Writer
+	fields map[string]bool
+
+	started   bool   // 是否已遇到第一个 '{'
+	depth     int    // 当前嵌套深度
+	expectKey bool   // 顶层对象中下一个字符串是否为键
+	inString  bool   // 是否在字符串中
+	isKey     bool   // 当前字符串是否为键
+	emit      bool   // 当前字符串是否需要输出
+	escape    bool   // 上一个字符是否为反斜杠
+	unicode   []byte // \u 转义序列中已读取的十六进制字符
+	surrogate rune   // 等待低位代理项的高位代理项
+	key       []byte // 当前键
+	field     string // 当前值所属的键
+	written   int    // 已输出的字段数
+}
+
+// NewJSONFieldStream 创建 JSONFieldStream
+//
+// 参数:
+//   - w: 输出目标
+//   - fields: 需要输出的字段名，按响应中出现的顺序输出
+//
+// 返回:
+//   - *JSONFieldStream: JSONFieldStream 实例
+func NewJSONFieldStream(w io.Writer, fields ...string) *JSONFieldStream {
+	set := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		set[field] = true
+	}
+	return &JSONFieldStream{w: w, fields: set}
+}
+
+// Write 处理一段响应内容，可直接作为 LLMClient.Stream 的 onDelta 回调
+//
+// 参数:
+//   - delta: 新收到的响应内容
+func (s *JSONFieldStream) Write(delta string) {
+	var out []byte
+	for i := 0; i < len(delta); i++ {
+		out = s.next(delta[i], out)
+	}
+	if len(out) > 0 {
+		_, _ = s.w.Write(out)
+	}
+}
+
+// Reset 丢弃已接收的响应内容，之后的 Write 作为新响应处理
+//
+// 用于重新请求时：新响应的第一个字段前不再输出空行。已写入 Writer 的内容不会被撤回。
+func (s *JSONFieldStream) Reset() {
+	*s = JSONFieldStream{w: s.w, fields: s.fields}
+}
+
+// Written 返回已输出的字段数
+func (s *JSONFieldStream) Written() int {
+	return s.written
+}
+
+// next 处理一个字节，将需要输出的内容追加到 out
+func (s *JSONFieldStream) next(ch byte, out []byte) []byte {
+	if !s.started {
+		if ch == '{' {
+			s.started = true
+			s.depth = 1
+			s.expectKey = true
+		}
+		return out
+	}
+
+	if s.inString {
+		return s.nextInString(ch, out)
+	}
+
+	switch ch {
+	case '"':
+		s.inString = true
+		s.isKey = s.depth == 1 && s.expectKey
+		s.emit = !s.isKey && s.depth == 1 && s.fields[s.field]
+		s.key = s.key[:0]
+		if s.emit {
+			if s.written > 0 {
+				out = append(out, '\n', '\n')
+			}
+			s.written++
+		}
+	case '{', '[':
+		s.depth++
+	case '}', ']':
+		s.depth--
+	case ':':
+		if s.depth == 1 {
+			s.expectKey = false
+		}
+	case ',':
+		if s.depth == 1 {
+			s.expectKey = true
+			s.field = ""
+		}
+	}
+	return out
+}
+
+// nextInString 处理字符串中的一个字节
+func (s *JSONFieldStream) nextInString(ch byte, out []byte) []byte {
+	if s.unicode != nil {
+		s.unicode = append(s.unicode, ch)
+		if len(s.unicode) < 4 {
+			return out
+		}
+		code, err := strconv.ParseUint(string(s.unicode), 16, 32)
+		s.unicode = nil
+		if err != nil {
+			return out
+		}
+		return s.appendRune(rune(code), out)
+	}
+
+	if s.escape {
+		s.escape = false
+		switch ch {
+		case 'u':
+			s.unicode = make([]byte, 0, 4)
+			return out
+		case 'n':
+			ch = '\n'
+		case 't':
+			ch = '\t'
+		case 'r':
+			ch = '\r'
+		case 'b', 'f':
+			return out
+		}
+		return s.appendByte(ch, out)
+	}
+
+	switch ch {
+	case '\\':
+		s.escape = true
+		return out
+	case '"':
+		s.inString = false
+		if s.isKey {
+			s.field = string(s.key)
+		}
+		s.emit = false
+		return out
+	}
+	return s.appendByte(ch, out)
+}
+
+// appendByte 将字符串中的一个字节追加到当前键或输出
+func (s *JSONFieldStream) appendByte(ch byte, out []byte) []byte {
+	if s.isKey {
+		s.key = append(s.key, ch)
+		return out
+	}
+	if s.emit {
+		out = append(out, ch)
+	}
+	return out
+}
+
+// appendRune 将 \u 转义得到的字符追加到当前键或输出，处理 UTF-16 代理对
+func (s *JSONFieldStream) appendRune(r rune, out []byte) []byte {
+	if utf16.IsSurrogate(r) {
+		if s.surrogate == 0 {
+			s.surrogate = r
+			return out
+		}
+		r = utf16.DecodeRune(s.surrogate, r)
+	}
+	s.surrogate = 0
+
+	for _, b := range []byte(string(r)) {
+		out = s.appendByte(b, out)
+	}
+	return out
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ==================== JSONFieldStream 测试 ====================
+
+// writeInChunks 按固定长度分段写入，模拟流式响应
+func writeInChunks(s *JSONFieldStream, response string, size int) {
+	for len(response) > 0 {
+		n := size
+		if n > len(response) {
+			n = len(response)
+		}
+		s.Write(response[:n])
+		response = response[n:]
+	}
+}
+
+func TestJSONFieldStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		fields   []string
+		want     string
+	}{
+		{
+			name:     "单个字段",
+			response: `{"summary": "# Title\n\nSome \"quoted\" text\\path", "filename": "pr-summary"}`,
+			fields:   []string{"summary"},
+			want:     "# Title\n\nSome \"quoted\" text\\path",
+		},
+		{
+			name:     "多个字段以空行分隔",
+			response: `{"pr_title": "feat: add streaming", "description": "- Stream tokens\n- Cancel on Ctrl-C"}`,
+			fields:   []string{"pr_title", "description"},
+			want:     "feat: add streaming\n\n- Stream tokens\n- Cancel on Ctrl-C",
+		},
+		{
+			name:     "忽略 markdown 代码块标记",
+			response: "```json\n{\"summary\": \"text with ``` inside\"}\n```",
+			fields:   []string{"summary"},
+			want:     "text with ``` inside",
+		},
+		{
+			name:     "忽略嵌套对象中的同名字段",
+			response: `{"meta": {"summary": "nested", "list": ["a", "b"]}, "summary": "top"}`,
+			fields:   []string{"summary"},
+			want:     "top",
+		},
+		{
+			name:     "unicode 转义",
+			response: `{"summary": "\u4e2d\u6587 \ud83d\ude80"}`,
+			fields:   []string{"summary"},
+			want:     "中文 🚀",
+		},
+		{
+			name:     "非字符串值不输出",
+			response: `{"summary": null, "count": 3}`,
+			fields:   []string{"summary"},
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 不同的分段长度应得到相同的结果
+			for _, size := range []int{1, 3, 7, len(tt.response)} {
+				var b strings.Builder
+				writeInChunks(NewJSONFieldStream(&b, tt.fields...), tt.response, size)
+				assert.Equal(t, tt.want, b.String(), "chunk size %d", size)
+			}
+		})
+	}
+}
+
+func TestJSONFieldStream_Written(t *testing.T) {
+	var b strings.Builder
+	s := NewJSONFieldStream(&b, "pr_title", "description")
+	assert.Equal(t, 0, s.Written())
+
+	s.Write(`{"pr_title": "fix: typo"`)
+	assert.Equal(t, 1, s.Written())
+
+	s.Write(`, "description": "Fix a typo"}`)
+	assert.Equal(t, 2, s.Written())
+}
+
+func TestJSONFieldStream_Reset(t *testing.T) {
+	var b strings.Builder
+	s := NewJSONFieldStream(&b, "pr_title", "description")
+
+	// 被拒绝的响应只输出了一部分
+	s.Write(`{"pr_title": "fix: ty`)
+	s.Reset()
+	assert.Equal(t, 0, s.Written())
+
+	// 新响应从头解析，第一个字段前不输出空行
+	b.Reset()
+	s.Write(`{"pr_title": "fix: typo", "description": "Fix a typo"}`)
+	assert.Equal(t, "fix: typo\n\nFix a typo", b.String())
+	assert.Equal(t, 2, s.Written())
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"strings"
+	"unicode"
 )
 
 // SanitizeBranchName 清理分支名，确保只保留 ASCII 字符
@@ -24,6 +25,61 @@ func SanitizeBranchName(name string) string {
 	return result.String()
 }
 
+// IsASCII 判断文本是否只包含 ASCII 字符
+//
+// 用于判断文本（如 Jira ticket 标题）是否需要翻译为英文后再生成分支名。
+//
+// 参数:
+//   - text: 文本
+//
+// 返回:
+//   - bool: 只包含 ASCII 字符时返回 true
+func IsASCII(text string) bool {
+	for _, r := range text {
+		if r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+// BranchSlug 将文本转换为分支名片段
+//
+// 转换为小写，连续的非字母数字字符替换为一个连字符，去除首尾连字符，
+// 并在不超过 maxLength 的最后一个单词边界处截断。非 ASCII 字符会被移除。
+//
+// 参数:
+//   - text: 原始文本（如翻译后的 ticket 标题）
+//   - maxLength: 最大长度（<= 0 表示不限制）
+//
+// 返回:
+//   - string: 分支名片段（如 "add-user-login"）
+func BranchSlug(text string, maxLength int) string {
+	var result strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(text) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pendingHyphen && result.Len() > 0 {
+				result.WriteByte('-')
+			}
+			pendingHyphen = false
+			result.WriteRune(r)
+			continue
+		}
+		pendingHyphen = true
+	}
+
+	slug := result.String()
+	if maxLength > 0 && len(slug) > maxLength {
+		slug = slug[:maxLength]
+		if cut := strings.LastIndexByte(slug, '-'); cut > 0 {
+			slug = slug[:cut]
+		}
+		slug = strings.Trim(slug, "-")
+	}
+	return slug
+}
+
 // CleanFilename 清理文件名，确保只包含有效的文件名字符
 //
 // 将文件名转换为小写，替换空格为连字符，只保留字母、数字、连字符和下划线，
@@ -161,3 +161,62 @@ func TestCleanFilename(t *testing.T) {
 		})
 	}
 }
+
+// ==================== IsASCII 测试 ====================
+
+func TestIsASCII(t *testing.T) {
+	assert.True(t, IsASCII("Add user login"))
+	assert.True(t, IsASCII(""))
+	assert.False(t, IsASCII("添加用户登录"))
+	assert.False(t, IsASCII("Fix café menu"))
+}
+
+// ==================== BranchSlug 测试 ====================
+
+func TestBranchSlug(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		maxLength int
+		want      string
+	}{
+		{
+			name:  "普通标题",
+			input: "Add user login",
+			want:  "add-user-login",
+		},
+		{
+			name:  "特殊字符和多余空白",
+			input: "  [API] Fix: login   fails (500)!  ",
+			want:  "api-fix-login-fails-500",
+		},
+		{
+			name:  "移除非 ASCII 字符",
+			input: "Fix café menu",
+			want:  "fix-caf-menu",
+		},
+		{
+			name:      "在单词边界截断",
+			input:     "Support exporting reports to spreadsheet files",
+			maxLength: 20,
+			want:      "support-exporting",
+		},
+		{
+			name:      "单个长单词直接截断",
+			input:     "internationalization",
+			maxLength: 10,
+			want:      "internatio",
+		},
+		{
+			name:  "只有非 ASCII 字符",
+			input: "添加用户登录",
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, BranchSlug(tt.input, tt.maxLength))
+		})
+	}
+}
@@ -11,6 +11,9 @@ import (
 	"golang.org/x/oauth2"
 )
 
+// maxPerPage GitHub API 单页返回的最大条数
+const maxPerPage = 100
+
 // GitHub 实现 PlatformProvider 接口
 //
 // 封装 google/go-github 库，提供统一的 PR 操作接口
@@ -35,6 +38,23 @@ type GitHub struct {
 //   - *GitHub: GitHub 实例
 //   - error: 如果创建失败，返回错误
 func NewGitHub(token, owner, repo string) (*GitHub, error) {
+	return NewGitHubEnterprise(token, "", owner, repo)
+}
+
+// NewGitHubEnterprise 创建连接 GitHub Enterprise Server 的 GitHub 实例
+//
+// API 地址为 <baseURL>/api/v3/，baseURL 为空时连接 github.com。
+//
+// 参数:
+//   - token: GitHub Personal Access Token
+//   - baseURL: GitHub Enterprise 实例地址（如 "https://github.example.com"）
+//   - owner: 仓库所有者
+//   - repo: 仓库名称
+//
+// 返回:
+//   - *GitHub: GitHub 实例
+//   - error: 如果创建失败，返回错误
+func NewGitHubEnterprise(token, baseURL, owner, repo string) (*GitHub, error) {
 	logger := logging.GetLogger()
 
 	if token == "" {
@@ -48,8 +68,9 @@ func NewGitHub(token, owner, repo string) (*GitHub, error) {
 
 	// 记录客户端创建开始
 	logger.WithFields(logging.Fields{
-		"owner": owner,
-		"repo":  repo,
+		"owner":    owner,
+		"repo":     repo,
+		"base_url": baseURL,
 	}).Info("Creating GitHub client")
 
 	// 创建 OAuth 客户端
@@ -61,6 +82,15 @@ func NewGitHub(token, owner, repo string) (*GitHub, error) {
 
 	// 创建 GitHub 客户端
 	client := github.NewClient(tc)
+	if baseURL != "" {
+		baseURL = strings.TrimSuffix(baseURL, "/")
+		enterpriseClient, err := client.WithEnterpriseURLs(baseURL+"/api/v3/", baseURL+"/api/uploads/")
+		if err != nil {
+			logger.WithError(err).WithField("base_url", baseURL).Error("Invalid GitHub Enterprise URL")
+			return nil, fmt.Errorf("invalid GitHub Enterprise URL %s: %w", baseURL, err)
+		}
+		client = enterpriseClient
+	}
 
 	// 记录客户端创建成功
 	logger.WithFields(logging.Fields{
@@ -258,21 +288,41 @@ func (g *GitHub) GetPullRequestStatus(ctx context.Context, prID string) (*pr.Pul
 	}
 
 	status := &pr.PullRequestStatus{
-		State:     ghPR.GetState(),
-		Merged:    ghPR.GetMerged(),
-		Mergeable: ghPR.Mergeable,
-		UpdatedAt: ghPR.GetUpdatedAt().Time,
-	}
+		Number:     ghPR.GetNumber(),
+		Title:      ghPR.GetTitle(),
+		Body:       ghPR.GetBody(),
+		HTMLURL:    ghPR.GetHTMLURL(),
+		State:      ghPR.GetState(),
+		Draft:      ghPR.GetDraft(),
+		Merged:     ghPR.GetMerged(),
+		Mergeable:  ghPR.Mergeable,
+		HeadBranch: ghPR.GetHead().GetRef(),
+		BaseBranch: ghPR.GetBase().GetRef(),
+		Labels:     labelNames(ghPR.Labels),
+		Reviews:    g.getReviews(ctx, ghPR),
+		UpdatedAt:  ghPR.GetUpdatedAt().Time,
+	}
+	status.CheckResults = g.getCheckResults(ctx, ghPR.GetHead().GetSHA())
+	status.Checks = aggregateChecks(status.CheckResults)
 
 	return status, nil
 }
 
 // ListPullRequests 列出 Pull Requests
-func (g *GitHub) ListPullRequests(ctx context.Context, state string, limit int) ([]*pr.PullRequestInfo, error) {
-	// 验证状态
+//
+// state 支持 "open"、"closed"、"merged" 和 "all"，默认 "open"。
+// 源分支过滤不带 owner 时，按当前仓库的 owner 补全为 "owner:branch"。
+func (g *GitHub) ListPullRequests(ctx context.Context, listOpts pr.ListOptions) ([]*pr.PullRequestInfo, error) {
+	state, limit := listOpts.State, listOpts.Limit
+
+	// 验证状态；GitHub 没有 merged 状态，按 closed 查询后过滤
 	if state == "" {
 		state = "open"
 	}
+	mergedOnly := state == "merged"
+	if mergedOnly {
+		state = "closed"
+	}
 	validStates := map[string]bool{
 		"open":   true,
 		"closed": true,
@@ -282,42 +332,159 @@ func (g *GitHub) ListPullRequests(ctx context.Context, state string, limit int)
 		state = "open"
 	}
 
-	// 设置分页
+	// 设置分页；每页最多 100 条，超过时翻页直到 limit。
+	// API 不支持按作者和是否合并过滤，需要翻页后在本地过滤
+	localFilter := listOpts.Author != "" || mergedOnly
+	perPage := min(limit, maxPerPage)
+	if localFilter {
+		perPage = maxPerPage
+	}
 	opts := &github.PullRequestListOptions{
 		State: state,
 		ListOptions: github.ListOptions{
-			PerPage: limit,
+			PerPage: perPage,
 		},
 	}
+	if head := listOpts.Head; head != "" {
+		if !strings.Contains(head, ":") {
+			head = g.owner + ":" + head
+		}
+		opts.Head = head
+	}
 
-	ghPRs, _, err := g.client.PullRequests.List(ctx, g.owner, g.repo, opts)
-	if err != nil {
-		logger := logging.GetLogger()
-		logger.WithError(err).WithFields(logging.Fields{
-			"state": state,
-			"limit": limit,
-		}).Error("Failed to list pull requests")
-		return nil, fmt.Errorf("failed to list PRs: %w", err)
-	}
-
-	// 转换为统一格式
-	result := make([]*pr.PullRequestInfo, 0, len(ghPRs))
-	for _, ghPR := range ghPRs {
-		info := &pr.PullRequestInfo{
-			Number:    ghPR.GetNumber(),
-			Title:     ghPR.GetTitle(),
-			State:     ghPR.GetState(),
-			HTMLURL:   ghPR.GetHTMLURL(),
-			CreatedAt: ghPR.GetCreatedAt().Time,
-			UpdatedAt: ghPR.GetUpdatedAt().Time,
-			Author:    ghPR.GetUser().GetLogin(),
+	result := make([]*pr.PullRequestInfo, 0)
+	for {
+		ghPRs, resp, err := g.client.PullRequests.List(ctx, g.owner, g.repo, opts)
+		if err != nil {
+			logger := logging.GetLogger()
+			logger.WithError(err).WithFields(logging.Fields{
+				"state":  state,
+				"head":   listOpts.Head,
+				"author": listOpts.Author,
+				"limit":  limit,
+			}).Error("Failed to list pull requests")
+			return nil, fmt.Errorf("failed to list PRs: %w", err)
 		}
-		result = append(result, info)
+
+		// 转换为统一格式
+		for _, ghPR := range ghPRs {
+			if listOpts.Author != "" && !strings.EqualFold(ghPR.GetUser().GetLogin(), listOpts.Author) {
+				continue
+			}
+			if mergedOnly && ghPR.MergedAt == nil {
+				continue
+			}
+			result = append(result, toPullRequestInfo(ghPR))
+		}
+
+		if resp == nil || resp.NextPage == 0 || (limit > 0 && len(result) >= limit) || (limit <= 0 && !localFilter) {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
 	}
 
 	return result, nil
 }
 
+// toPullRequestInfo 将 GitHub PR 转换为统一的 PR 信息
+func toPullRequestInfo(ghPR *github.PullRequest) *pr.PullRequestInfo {
+	reviewers := make([]string, 0, len(ghPR.RequestedReviewers))
+	for _, reviewer := range ghPR.RequestedReviewers {
+		reviewers = append(reviewers, reviewer.GetLogin())
+	}
+
+	state := ghPR.GetState()
+	if ghPR.MergedAt != nil {
+		state = "merged"
+	}
+
+	return &pr.PullRequestInfo{
+		Number:     ghPR.GetNumber(),
+		Title:      ghPR.GetTitle(),
+		State:      state,
+		Draft:      ghPR.GetDraft(),
+		HTMLURL:    ghPR.GetHTMLURL(),
+		HeadBranch: ghPR.GetHead().GetRef(),
+		BaseBranch: ghPR.GetBase().GetRef(),
+		Labels:     labelNames(ghPR.Labels),
+		Reviewers:  reviewers,
+		CreatedAt:  ghPR.GetCreatedAt().Time,
+		UpdatedAt:  ghPR.GetUpdatedAt().Time,
+		Author:     ghPR.GetUser().GetLogin(),
+	}
+}
+
+// labelNames 提取标签名称
+func labelNames(labels []*github.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.GetName())
+	}
+	return names
+}
+
+// GetPullRequestDiff 获取 PR 的完整 diff
+func (g *GitHub) GetPullRequestDiff(ctx context.Context, prID string) (string, error) {
+	logger := logging.GetLogger()
+
+	prNumber, err := parsePRNumber(prID)
+	if err != nil {
+		return "", err
+	}
+
+	diff, _, err := g.client.PullRequests.GetRaw(ctx, g.owner, g.repo, prNumber, github.RawOptions{Type: github.Diff})
+	if err != nil {
+		logger.WithError(err).WithField("pr_id", prID).Error("Failed to get pull request diff")
+		return "", fmt.Errorf("failed to get PR diff: %w", err)
+	}
+
+	return diff, nil
+}
+
+// ListPullRequestFiles 列出 PR 中变更的文件
+//
+// 自动翻页获取全部文件（GitHub API 最多返回 3000 个文件）。
+func (g *GitHub) ListPullRequestFiles(ctx context.Context, prID string) ([]*pr.PullRequestFile, error) {
+	logger := logging.GetLogger()
+
+	prNumber, err := parsePRNumber(prID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.ListOptions{PerPage: maxPerPage}
+	var files []*pr.PullRequestFile
+	for {
+		ghFiles, resp, err := g.client.PullRequests.ListFiles(ctx, g.owner, g.repo, prNumber, opts)
+		if err != nil {
+			logger.WithError(err).WithField("pr_id", prID).Error("Failed to list pull request files")
+			return nil, fmt.Errorf("failed to list PR files: %w", err)
+		}
+
+		for _, f := range ghFiles {
+			files = append(files, &pr.PullRequestFile{
+				Filename:         f.GetFilename(),
+				PreviousFilename: f.GetPreviousFilename(),
+				Status:           f.GetStatus(),
+				Additions:        f.GetAdditions(),
+				Deletions:        f.GetDeletions(),
+				Patch:            f.GetPatch(),
+			})
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return files, nil
+}
+
 // UpdatePullRequest 更新 Pull Request
 func (g *GitHub) UpdatePullRequest(ctx context.Context, prID string, title, body *string, state *string) error {
 	logger := logging.GetLogger()
@@ -381,6 +548,136 @@ func (g *GitHub) ApprovePullRequest(ctx context.Context, prID string) error {
 	return nil
 }
 
+// getReviews 获取 PR 的评审状态
+//
+// 每个评审人只保留最新的评审结果（仅评论不会覆盖批准或要求修改），
+// 已请求但尚未评审的评审人标记为 pending。获取评审记录失败时只记录日志。
+func (g *GitHub) getReviews(ctx context.Context, ghPR *github.PullRequest) []pr.Review {
+	var order []string
+	states := make(map[string]string)
+	set := func(reviewer, state string) {
+		if _, ok := states[reviewer]; !ok {
+			order = append(order, reviewer)
+		}
+		states[reviewer] = state
+	}
+
+	reviews, _, err := g.client.PullRequests.ListReviews(ctx, g.owner, g.repo, ghPR.GetNumber(), &github.ListOptions{PerPage: maxPerPage})
+	if err != nil {
+		logging.GetLogger().WithError(err).WithField("pr_id", ghPR.GetNumber()).Warn("Failed to list reviews")
+	}
+	for _, review := range reviews {
+		reviewer := review.GetUser().GetLogin()
+		switch review.GetState() {
+		case "APPROVED":
+			set(reviewer, pr.ReviewApproved)
+		case "CHANGES_REQUESTED":
+			set(reviewer, pr.ReviewChangesRequested)
+		case "COMMENTED":
+			if _, ok := states[reviewer]; !ok {
+				set(reviewer, pr.ReviewCommented)
+			}
+		}
+	}
+
+	// 重新请求评审后，之前的评审结果不再有效
+	for _, reviewer := range ghPR.RequestedReviewers {
+		set(reviewer.GetLogin(), pr.ReviewPending)
+	}
+
+	result := make([]pr.Review, 0, len(order))
+	for _, reviewer := range order {
+		result = append(result, pr.Review{Reviewer: reviewer, State: states[reviewer]})
+	}
+	return result
+}
+
+// getCheckResults 获取提交的各项 CI 检查结果
+//
+// 合并 commit status 和 check runs 两种来源。获取失败时只记录日志，
+// 对应来源按无检查处理，不影响 PR 状态的获取。
+func (g *GitHub) getCheckResults(ctx context.Context, sha string) []pr.CheckResult {
+	if sha == "" {
+		return nil
+	}
+	logger := logging.GetLogger()
+
+	var results []pr.CheckResult
+
+	combined, _, err := g.client.Repositories.GetCombinedStatus(ctx, g.owner, g.repo, sha, nil)
+	if err != nil {
+		logger.WithError(err).WithField("sha", sha).Warn("Failed to get commit statuses")
+	} else {
+		for _, status := range combined.Statuses {
+			results = append(results, pr.CheckResult{
+				Name:  status.GetContext(),
+				State: commitStatusState(status.GetState()),
+				URL:   status.GetTargetURL(),
+			})
+		}
+	}
+
+	runs, _, err := g.client.Checks.ListCheckRunsForRef(ctx, g.owner, g.repo, sha, &github.ListCheckRunsOptions{
+		ListOptions: github.ListOptions{PerPage: maxPerPage},
+	})
+	if err != nil {
+		logger.WithError(err).WithField("sha", sha).Warn("Failed to list check runs")
+	} else {
+		for _, run := range runs.CheckRuns {
+			results = append(results, pr.CheckResult{
+				Name:  run.GetName(),
+				State: checkRunState(run),
+				URL:   run.GetHTMLURL(),
+			})
+		}
+	}
+
+	return results
+}
+
+// commitStatusState 将 commit status 的状态转换为统一的检查状态
+func commitStatusState(state string) string {
+	switch state {
+	case "success":
+		return pr.ChecksSuccess
+	case "failure", "error":
+		return pr.ChecksFailure
+	default:
+		return pr.ChecksPending
+	}
+}
+
+// checkRunState 将 check run 的状态转换为统一的检查状态
+func checkRunState(run *github.CheckRun) string {
+	if run.GetStatus() != "completed" {
+		return pr.ChecksPending
+	}
+	switch run.GetConclusion() {
+	case "success", "neutral", "skipped":
+		return pr.ChecksSuccess
+	default:
+		return pr.ChecksFailure
+	}
+}
+
+// aggregateChecks 汇总各项检查结果：任一失败即失败，其次任一运行中即运行中
+func aggregateChecks(results []pr.CheckResult) string {
+	result := ""
+	for _, check := range results {
+		switch check.State {
+		case pr.ChecksFailure:
+			return pr.ChecksFailure
+		case pr.ChecksPending:
+			result = pr.ChecksPending
+		case pr.ChecksSuccess:
+			if result == "" {
+				result = pr.ChecksSuccess
+			}
+		}
+	}
+	return result
+}
+
 // parsePRNumber 解析 PR ID（支持数字、URL 等格式）
 func parsePRNumber(prID string) (int, error) {
 	// 使用 helpers 包解析
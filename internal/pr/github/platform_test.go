@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -12,6 +13,7 @@ import (
 	"github.com/google/go-github/v57/github"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/zevwings/workflow/internal/pr"
 	"golang.org/x/oauth2"
 )
 
@@ -58,6 +60,8 @@ func defaultMockGitHubHandler(w http.ResponseWriter, r *http.Request) {
 		mergeable := true
 		json.NewEncoder(w).Encode(&github.PullRequest{
 			Number:    github.Int(123),
+			Title:     github.String("Test PR"),
+			HTMLURL:   github.String("https://github.com/owner/repo/pull/123"),
 			State:     github.String("open"),
 			Merged:    github.Bool(false),
 			Mergeable: &mergeable,
@@ -237,6 +241,20 @@ func TestNewGitHub(t *testing.T) {
 	}
 }
 
+func TestNewGitHubEnterprise(t *testing.T) {
+	gh, err := NewGitHubEnterprise("test-token", "https://github.example.com/", "owner", "repo")
+	require.NoError(t, err)
+	require.NotNil(t, gh)
+	assert.Equal(t, "https://github.example.com/api/v3/", gh.client.BaseURL.String())
+	assert.Equal(t, "https://github.example.com/api/uploads/", gh.client.UploadURL.String())
+}
+
+func TestNewGitHubEnterprise_EmptyBaseURL(t *testing.T) {
+	gh, err := NewGitHubEnterprise("test-token", "", "owner", "repo")
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.github.com/", gh.client.BaseURL.String())
+}
+
 // ==================== GetPlatformName 测试 ====================
 
 func TestGitHub_GetPlatformName(t *testing.T) {
@@ -413,12 +431,138 @@ func TestGitHub_GetPullRequestStatus(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.NotNil(t, status)
+	assert.Equal(t, 123, status.Number)
+	assert.Equal(t, "Test PR", status.Title)
+	assert.Equal(t, "https://github.com/owner/repo/pull/123", status.HTMLURL)
 	assert.Equal(t, "open", status.State)
 	assert.False(t, status.Merged)
 	assert.NotNil(t, status.Mergeable)
 	assert.True(t, *status.Mergeable)
 }
 
+func TestGitHub_GetPullRequestStatus_Checks(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   string
+		checkRuns  string
+		wantChecks string
+	}{
+		{
+			name:       "没有检查",
+			statuses:   `{"state":"pending","total_count":0,"statuses":[]}`,
+			checkRuns:  `{"total_count":0,"check_runs":[]}`,
+			wantChecks: "",
+		},
+		{
+			name:       "全部通过",
+			statuses:   `{"state":"success","total_count":1}`,
+			checkRuns:  `{"total_count":2,"check_runs":[{"status":"completed","conclusion":"success"},{"status":"completed","conclusion":"skipped"}]}`,
+			wantChecks: "success",
+		},
+		{
+			name:       "check run 失败",
+			statuses:   `{"state":"success","total_count":1}`,
+			checkRuns:  `{"total_count":2,"check_runs":[{"status":"in_progress"},{"status":"completed","conclusion":"timed_out"}]}`,
+			wantChecks: "failure",
+		},
+		{
+			name:       "check run 运行中",
+			statuses:   `{"state":"success","total_count":1}`,
+			checkRuns:  `{"total_count":1,"check_runs":[{"status":"queued"}]}`,
+			wantChecks: "pending",
+		},
+		{
+			name:       "commit status 失败",
+			statuses:   `{"state":"failure","total_count":1,"statuses":[{"context":"ci/build","state":"error"}]}`,
+			checkRuns:  `{"total_count":0,"check_runs":[]}`,
+			wantChecks: "failure",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := setupMockGitHubServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/repos/owner/repo/pulls/123":
+					json.NewEncoder(w).Encode(&github.PullRequest{
+						Number: github.Int(123),
+						State:  github.String("open"),
+						Head:   &github.PullRequestBranch{Ref: github.String("feature"), SHA: github.String("abc123")},
+						Base:   &github.PullRequestBranch{Ref: github.String("main")},
+					})
+				case "/repos/owner/repo/commits/abc123/status":
+					fmt.Fprint(w, tt.statuses)
+				case "/repos/owner/repo/commits/abc123/check-runs":
+					fmt.Fprint(w, tt.checkRuns)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+			defer server.Close()
+
+			gh := createTestGitHubClient(t, server.URL)
+
+			status, err := gh.GetPullRequestStatus(context.Background(), "123")
+			require.NoError(t, err)
+			assert.Equal(t, "feature", status.HeadBranch)
+			assert.Equal(t, "main", status.BaseBranch)
+			assert.Equal(t, tt.wantChecks, status.Checks)
+		})
+	}
+}
+
+func TestGitHub_GetPullRequestStatus_Details(t *testing.T) {
+	server := setupMockGitHubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/owner/repo/pulls/123":
+			fmt.Fprint(w, `{
+				"number": 123,
+				"body": "Current description",
+				"state": "open",
+				"draft": true,
+				"labels": [{"name": "bug"}, {"name": "urgent"}],
+				"requested_reviewers": [{"login": "carol"}],
+				"head": {"ref": "feature", "sha": "abc123"},
+				"base": {"ref": "main"}
+			}`)
+		case "/repos/owner/repo/pulls/123/reviews":
+			fmt.Fprint(w, `[
+				{"user": {"login": "alice"}, "state": "APPROVED"},
+				{"user": {"login": "alice"}, "state": "COMMENTED"},
+				{"user": {"login": "bob"}, "state": "COMMENTED"},
+				{"user": {"login": "bob"}, "state": "CHANGES_REQUESTED"}
+			]`)
+		case "/repos/owner/repo/commits/abc123/status":
+			fmt.Fprint(w, `{"state":"success","total_count":1,"statuses":[{"context":"ci/lint","state":"success","target_url":"https://ci.example.com/1"}]}`)
+		case "/repos/owner/repo/commits/abc123/check-runs":
+			fmt.Fprint(w, `{"total_count":1,"check_runs":[{"name":"test","status":"in_progress","html_url":"https://github.com/owner/repo/runs/1"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	gh := createTestGitHubClient(t, server.URL)
+
+	status, err := gh.GetPullRequestStatus(context.Background(), "123")
+	require.NoError(t, err)
+	assert.Equal(t, "Current description", status.Body)
+	assert.True(t, status.Draft)
+	assert.Equal(t, []string{"bug", "urgent"}, status.Labels)
+	assert.Equal(t, []pr.Review{
+		{Reviewer: "alice", State: pr.ReviewApproved},
+		{Reviewer: "bob", State: pr.ReviewChangesRequested},
+		{Reviewer: "carol", State: pr.ReviewPending},
+	}, status.Reviews)
+	assert.Equal(t, []pr.CheckResult{
+		{Name: "ci/lint", State: pr.ChecksSuccess, URL: "https://ci.example.com/1"},
+		{Name: "test", State: pr.ChecksPending, URL: "https://github.com/owner/repo/runs/1"},
+	}, status.CheckResults)
+	assert.Equal(t, pr.ChecksPending, status.Checks)
+}
+
 func TestGitHub_GetPullRequestStatus_WithURL(t *testing.T) {
 	server := setupMockGitHubServer(t, nil)
 	defer server.Close()
@@ -507,7 +651,7 @@ func TestGitHub_ListPullRequests(t *testing.T) {
 	gh := createTestGitHubClient(t, server.URL)
 
 	ctx := context.Background()
-	prs, err := gh.ListPullRequests(ctx, "open", 10)
+	prs, err := gh.ListPullRequests(ctx, pr.ListOptions{State: "open", Limit: 10})
 
 	assert.NoError(t, err)
 	assert.Len(t, prs, 1)
@@ -516,6 +660,131 @@ func TestGitHub_ListPullRequests(t *testing.T) {
 	assert.Equal(t, "open", prs[0].State)
 }
 
+func TestGitHub_ListPullRequests_Paginates(t *testing.T) {
+	// Arrange
+	requests := 0
+	server := setupMockGitHubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+		w.Header().Set("Content-Type", "application/json")
+		page := r.URL.Query().Get("page")
+		offset := 0
+		if page == "2" {
+			offset = 100
+		} else {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/owner/repo/pulls?page=2>; rel="next"`, "http://"+r.Host))
+		}
+		prs := make([]*github.PullRequest, 0, 100)
+		for i := 1; i <= 100; i++ {
+			prs = append(prs, &github.PullRequest{Number: github.Int(offset + i)})
+		}
+		json.NewEncoder(w).Encode(prs)
+	})
+	defer server.Close()
+
+	gh := createTestGitHubClient(t, server.URL)
+
+	// Act
+	prs, err := gh.ListPullRequests(context.Background(), pr.ListOptions{State: "open", Limit: 150})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	require.Len(t, prs, 150)
+	assert.Equal(t, 150, prs[149].Number)
+}
+
+func TestGitHub_ListPullRequests_Head(t *testing.T) {
+	tests := []struct {
+		name     string
+		head     string
+		wantHead string
+	}{
+		{name: "不过滤", head: "", wantHead: ""},
+		{name: "补全 owner", head: "feature", wantHead: "owner:feature"},
+		{name: "已带 owner", head: "fork:feature", wantHead: "fork:feature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := setupMockGitHubServer(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.wantHead, r.URL.Query().Get("head"))
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode([]*github.PullRequest{
+					{
+						Number: github.Int(7),
+						Head:   &github.PullRequestBranch{Ref: github.String("feature")},
+						Base:   &github.PullRequestBranch{Ref: github.String("main")},
+					},
+				})
+			})
+			defer server.Close()
+
+			gh := createTestGitHubClient(t, server.URL)
+
+			prs, err := gh.ListPullRequests(context.Background(), pr.ListOptions{State: "open", Head: tt.head, Limit: 10})
+			require.NoError(t, err)
+			require.Len(t, prs, 1)
+			assert.Equal(t, "feature", prs[0].HeadBranch)
+			assert.Equal(t, "main", prs[0].BaseBranch)
+		})
+	}
+}
+
+func TestGitHub_ListPullRequests_Author(t *testing.T) {
+	server := setupMockGitHubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/owner/repo/pulls?page=2>; rel="next"`, "http://"+r.Host))
+			fmt.Fprint(w, `[
+				{"number": 1, "user": {"login": "alice"}},
+				{"number": 2, "user": {"login": "bob"}}
+			]`)
+		case "2":
+			fmt.Fprint(w, `[
+				{"number": 3, "user": {"login": "Alice"}, "draft": true, "labels": [{"name": "wip"}], "requested_reviewers": [{"login": "bob"}]},
+				{"number": 4, "user": {"login": "alice"}}
+			]`)
+		default:
+			t.Fatalf("unexpected page %s", r.URL.Query().Get("page"))
+		}
+	})
+	defer server.Close()
+
+	gh := createTestGitHubClient(t, server.URL)
+
+	prs, err := gh.ListPullRequests(context.Background(), pr.ListOptions{State: "open", Author: "alice", Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	assert.Equal(t, 1, prs[0].Number)
+	assert.Equal(t, 3, prs[1].Number)
+	assert.True(t, prs[1].Draft)
+	assert.Equal(t, []string{"wip"}, prs[1].Labels)
+	assert.Equal(t, []string{"bob"}, prs[1].Reviewers)
+}
+
+func TestGitHub_ListPullRequests_Merged(t *testing.T) {
+	server := setupMockGitHubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "closed", r.URL.Query().Get("state"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[
+			{"number": 1, "state": "closed"},
+			{"number": 2, "state": "closed", "merged_at": "2026-01-02T03:04:05Z"}
+		]`)
+	})
+	defer server.Close()
+
+	gh := createTestGitHubClient(t, server.URL)
+
+	prs, err := gh.ListPullRequests(context.Background(), pr.ListOptions{State: "merged", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, 2, prs[0].Number)
+	assert.Equal(t, "merged", prs[0].State)
+}
+
 func TestGitHub_ListPullRequests_InvalidState(t *testing.T) {
 	server := setupMockGitHubServer(t, nil)
 	defer server.Close()
@@ -523,7 +792,7 @@ func TestGitHub_ListPullRequests_InvalidState(t *testing.T) {
 	gh := createTestGitHubClient(t, server.URL)
 
 	ctx := context.Background()
-	prs, err := gh.ListPullRequests(ctx, "invalid", 10)
+	prs, err := gh.ListPullRequests(ctx, pr.ListOptions{State: "invalid", Limit: 10})
 
 	// 无效状态应该回退到 "open"
 	assert.NoError(t, err)
@@ -537,7 +806,7 @@ func TestGitHub_ListPullRequests_EmptyState(t *testing.T) {
 	gh := createTestGitHubClient(t, server.URL)
 
 	ctx := context.Background()
-	prs, err := gh.ListPullRequests(ctx, "", 10)
+	prs, err := gh.ListPullRequests(ctx, pr.ListOptions{State: "", Limit: 10})
 
 	// 空状态应该使用默认值 "open"
 	assert.NoError(t, err)
@@ -557,7 +826,7 @@ func TestGitHub_ListPullRequests_ClosedState(t *testing.T) {
 	gh := createTestGitHubClient(t, server.URL)
 
 	ctx := context.Background()
-	prs, err := gh.ListPullRequests(ctx, "closed", 10)
+	prs, err := gh.ListPullRequests(ctx, pr.ListOptions{State: "closed", Limit: 10})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, prs)
@@ -577,7 +846,7 @@ func TestGitHub_ListPullRequests_AllState(t *testing.T) {
 	gh := createTestGitHubClient(t, server.URL)
 
 	ctx := context.Background()
-	prs, err := gh.ListPullRequests(ctx, "all", 10)
+	prs, err := gh.ListPullRequests(ctx, pr.ListOptions{State: "all", Limit: 10})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, prs)
@@ -912,9 +1181,90 @@ func TestGitHub_ListPullRequests_Error(t *testing.T) {
 	gh := createTestGitHubClient(t, server.URL)
 
 	ctx := context.Background()
-	_, err := gh.ListPullRequests(ctx, "open", 10)
+	_, err := gh.ListPullRequests(ctx, pr.ListOptions{State: "open", Limit: 10})
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to list PRs")
 }
 
+
+// ==================== Diff 测试 ====================
+
+func TestGitHub_GetPullRequestDiff(t *testing.T) {
+	server := setupMockGitHubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/owner/repo/pulls/123", r.URL.Path)
+		assert.Equal(t, "application/vnd.github.v3.diff", r.Header.Get("Accept"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("diff --git a/main.go b/main.go\n"))
+	})
+	defer server.Close()
+
+	gh := createTestGitHubClient(t, server.URL)
+
+	diff, err := gh.GetPullRequestDiff(context.Background(), "123")
+	assert.NoError(t, err)
+	assert.Equal(t, "diff --git a/main.go b/main.go\n", diff)
+}
+
+func TestGitHub_GetPullRequestDiff_InvalidPRID(t *testing.T) {
+	gh := createTestGitHubClient(t, "http://localhost")
+
+	_, err := gh.GetPullRequestDiff(context.Background(), "invalid")
+	assert.Error(t, err)
+}
+
+func TestGitHub_ListPullRequestFiles(t *testing.T) {
+	var serverURL string
+	server := setupMockGitHubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/owner/repo/pulls/123/files", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		// 第一页返回 Link 头，指向第二页
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/owner/repo/pulls/123/files?page=2>; rel="next"`, serverURL))
+			json.NewEncoder(w).Encode([]*github.CommitFile{
+				{
+					Filename:  github.String("main.go"),
+					Status:    github.String("modified"),
+					Additions: github.Int(2),
+					Deletions: github.Int(1),
+					Patch:     github.String("@@ -1 +1,2 @@"),
+				},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode([]*github.CommitFile{
+			{
+				Filename:         github.String("new.go"),
+				PreviousFilename: github.String("old.go"),
+				Status:           github.String("renamed"),
+			},
+		})
+	})
+	defer server.Close()
+	serverURL = server.URL
+
+	gh := createTestGitHubClient(t, server.URL)
+
+	files, err := gh.ListPullRequestFiles(context.Background(), "123")
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "main.go", files[0].Filename)
+	assert.Equal(t, 2, files[0].Additions)
+	assert.Equal(t, 1, files[0].Deletions)
+	assert.Equal(t, "@@ -1 +1,2 @@", files[0].Patch)
+	assert.Equal(t, "renamed", files[1].Status)
+	assert.Equal(t, "old.go", files[1].PreviousFilename)
+}
+
+func TestGitHub_ListPullRequestFiles_Error(t *testing.T) {
+	server := setupMockGitHubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer server.Close()
+
+	gh := createTestGitHubClient(t, server.URL)
+
+	_, err := gh.ListPullRequestFiles(context.Background(), "123")
+	assert.Error(t, err)
+}
@@ -0,0 +1,721 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/zevwings/workflow/internal/http"
+	"github.com/zevwings/workflow/internal/logging"
+	"github.com/zevwings/workflow/internal/pr"
+)
+
+// DefaultBaseURL GitLab.com 的默认地址
+const DefaultBaseURL = "https://gitlab.com"
+
+// maxPerPage GitLab API 每页返回的最大条目数
+const maxPerPage = 100
+
+// GitLab 实现 PlatformProvider 接口
+//
+// 基于 GitLab Merge Request REST API（v4），通过 internal/http 发送请求。
+// 支持 gitlab.com 和自建 GitLab 实例。
+type GitLab struct {
+	httpClient http.Client
+	baseURL    string
+	token      string
+	owner      string
+	repo       string
+}
+
+// NewGitLab 创建新的 GitLab 实例
+//
+// 业务逻辑（如获取 owner/repo、读取配置）应由调用方（commands 层）完成。
+//
+// 参数:
+//   - token: GitLab Personal Access Token（需要 api 权限）
+//   - baseURL: GitLab 实例地址（如 "https://gitlab.example.com"，为空时使用 gitlab.com）
+//   - owner: 项目所属的 namespace（如 "group" 或 "group/subgroup"）
+//   - repo: 项目名称（如 "workflow"）
+//
+// 返回:
+//   - *GitLab: GitLab 实例
+//   - error: 如果创建失败，返回错误
+func NewGitLab(token, baseURL, owner, repo string) (*GitLab, error) {
+	logger := logging.GetLogger()
+
+	if token == "" {
+		logger.Error("GitLab client creation failed: missing required parameters")
+		return nil, fmt.Errorf("token is required")
+	}
+	if owner == "" || repo == "" {
+		logger.Error("GitLab client creation failed: missing required parameters")
+		return nil, fmt.Errorf("owner and repo are required")
+	}
+
+	baseURL = strings.TrimSuffix(strings.TrimSpace(baseURL), "/")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	logger.WithFields(logging.Fields{
+		"base_url": baseURL,
+		"owner":    owner,
+		"repo":     repo,
+	}).Info("GitLab client created successfully")
+
+	return &GitLab{
+		httpClient: http.Global(),
+		baseURL:    baseURL,
+		token:      token,
+		owner:      owner,
+		repo:       repo,
+	}, nil
+}
+
+// GetPlatformName 获取平台名称
+func (g *GitLab) GetPlatformName() string {
+	return "gitlab"
+}
+
+// GetDefaultBranch 获取项目的默认分支
+//
+// 返回:
+//   - string: 默认分支名（如 "main", "master"）
+//   - error: 如果获取失败，返回错误
+func (g *GitLab) GetDefaultBranch(ctx context.Context) (string, error) {
+	resp, err := g.httpClient.GetWithConfig(g.projectURL(), g.requestConfig(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to get project: %w", err)
+	}
+	if _, err := resp.EnsureSuccessWith(apiError); err != nil {
+		return "", fmt.Errorf("failed to get project: %w", err)
+	}
+
+	p, err := http.AsJSON[project](resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse project: %w", err)
+	}
+	if p.DefaultBranch == "" {
+		return "", fmt.Errorf("project has no default branch")
+	}
+
+	return p.DefaultBranch, nil
+}
+
+// CreatePullRequest 创建 Merge Request
+func (g *GitLab) CreatePullRequest(ctx context.Context, title, body, sourceBranch string, targetBranch *string) (string, error) {
+	logger := logging.GetLogger()
+
+	// 1. 如果没有指定目标分支，获取默认分支
+	baseBranch := "main"
+	if targetBranch != nil && *targetBranch != "" {
+		baseBranch = *targetBranch
+	} else if defaultBranch, err := g.GetDefaultBranch(ctx); err == nil {
+		baseBranch = defaultBranch
+	}
+
+	logger.WithFields(logging.Fields{
+		"title":         title,
+		"source_branch": sourceBranch,
+		"target_branch": baseBranch,
+	}).Info("Creating merge request")
+
+	// 2. 创建 MR
+	config := g.requestConfig(ctx).WithBody(&createMergeRequestRequest{
+		SourceBranch: sourceBranch,
+		TargetBranch: baseBranch,
+		Title:        title,
+		Description:  body,
+	})
+	resp, err := g.httpClient.PostWithConfig(g.projectURL()+"/merge_requests", config)
+	if err == nil {
+		_, err = resp.EnsureSuccessWith(apiError)
+	}
+	if err != nil {
+		logger.WithError(err).WithFields(logging.Fields{
+			"title":         title,
+			"source_branch": sourceBranch,
+			"target_branch": baseBranch,
+		}).Error("Failed to create merge request")
+		return "", fmt.Errorf("failed to create MR: %w", err)
+	}
+
+	mr, err := http.AsJSON[mergeRequest](resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse MR: %w", err)
+	}
+
+	logger.WithFields(logging.Fields{
+		"mr_url": mr.WebURL,
+		"mr_iid": mr.IID,
+	}).Info("Merge request created successfully")
+
+	return mr.WebURL, nil
+}
+
+// MergePullRequest 合并 Merge Request
+//
+// mergeMethod 支持 "merge" 和 "squash"，其他值返回错误。
+// GitLab 的 rebase 合并由项目设置（fast-forward merge）决定，无法通过单次 API 调用指定。
+func (g *GitLab) MergePullRequest(ctx context.Context, prID string, mergeMethod string, deleteBranch bool) error {
+	logger := logging.GetLogger()
+
+	iid, err := parseMRIID(prID)
+	if err != nil {
+		return err
+	}
+
+	switch mergeMethod {
+	case "merge", "squash":
+	case "rebase":
+		return fmt.Errorf("rebase merge is not supported by GitLab API, use merge or squash")
+	default:
+		return fmt.Errorf("unsupported merge method %q, use merge or squash", mergeMethod)
+	}
+
+	logger.WithFields(logging.Fields{
+		"pr_id":         prID,
+		"merge_method":  mergeMethod,
+		"delete_branch": deleteBranch,
+	}).Info("Merging merge request")
+
+	config := g.requestConfig(ctx).WithBody(&mergeMergeRequestRequest{
+		Squash:                   mergeMethod == "squash",
+		ShouldRemoveSourceBranch: deleteBranch,
+	})
+	resp, err := g.httpClient.PutWithConfig(g.mergeRequestURL(iid)+"/merge", config)
+	if err == nil {
+		_, err = resp.EnsureSuccessWith(apiError)
+	}
+	if err != nil {
+		logger.WithError(err).WithField("pr_id", prID).Error("Failed to merge merge request")
+		return fmt.Errorf("failed to merge MR: %w", err)
+	}
+
+	logger.WithField("pr_id", prID).Info("Merge request merged successfully")
+
+	return nil
+}
+
+// ClosePullRequest 关闭 Merge Request
+func (g *GitLab) ClosePullRequest(ctx context.Context, prID string) error {
+	logger := logging.GetLogger()
+	logger.WithField("pr_id", prID).Info("Closing merge request")
+
+	state := "closed"
+	if err := g.UpdatePullRequest(ctx, prID, nil, nil, &state); err != nil {
+		logger.WithError(err).WithField("pr_id", prID).Error("Failed to close merge request")
+		return fmt.Errorf("failed to close MR: %w", err)
+	}
+
+	return nil
+}
+
+// GetPullRequestStatus 获取 Merge Request 状态
+func (g *GitLab) GetPullRequestStatus(ctx context.Context, prID string) (*pr.PullRequestStatus, error) {
+	logger := logging.GetLogger()
+
+	mr, err := g.getMergeRequest(ctx, prID)
+	if err != nil {
+		logger.WithError(err).WithField("pr_id", prID).Error("Failed to get merge request status")
+		return nil, err
+	}
+
+	return &pr.PullRequestStatus{
+		Number:       mr.IID,
+		Title:        mr.Title,
+		Body:         mr.Description,
+		HTMLURL:      mr.WebURL,
+		State:        toPlatformState(mr.State),
+		Draft:        mr.Draft,
+		Merged:       mr.State == "merged",
+		Mergeable:    mergeable(mr),
+		HeadBranch:   mr.SourceBranch,
+		BaseBranch:   mr.TargetBranch,
+		Labels:       mr.Labels,
+		Reviews:      g.getReviews(ctx, mr),
+		Checks:       pipelineChecks(mr.HeadPipeline),
+		CheckResults: g.getCheckResults(ctx, mr.HeadPipeline),
+		UpdatedAt:    mr.UpdatedAt,
+	}, nil
+}
+
+// ListPullRequests 列出 Merge Requests
+//
+// state 支持 "open"、"closed"、"merged" 和 "all"，默认 "open"。
+func (g *GitLab) ListPullRequests(ctx context.Context, opts pr.ListOptions) ([]*pr.PullRequestInfo, error) {
+	state, limit := opts.State, opts.Limit
+
+	apiState := map[string]string{
+		"open":   "opened",
+		"closed": "closed",
+		"merged": "merged",
+		"all":    "all",
+	}[state]
+	if apiState == "" {
+		apiState = "opened"
+	}
+
+	query := map[string]string{
+		"state": apiState,
+	}
+	if limit > 0 {
+		query["per_page"] = strconv.Itoa(min(limit, maxPerPage))
+	}
+	if opts.Head != "" {
+		query["source_branch"] = opts.Head
+	}
+	if opts.Author != "" {
+		query["author_username"] = opts.Author
+	}
+
+	// per_page 最大为 100，超过时按 X-Next-Page 翻页直到满足 limit
+	var mrs []mergeRequest
+	page := "1"
+	for page != "" {
+		query["page"] = page
+		resp, err := g.httpClient.GetWithConfig(g.projectURL()+"/merge_requests", g.requestConfig(ctx).WithQuery(query))
+		if err == nil {
+			_, err = resp.EnsureSuccessWith(apiError)
+		}
+		if err != nil {
+			logger := logging.GetLogger()
+			logger.WithError(err).WithFields(logging.Fields{
+				"state": state,
+				"head":  opts.Head,
+				"limit": limit,
+			}).Error("Failed to list merge requests")
+			return nil, fmt.Errorf("failed to list MRs: %w", err)
+		}
+
+		pageMRs, err := http.AsJSON[[]mergeRequest](resp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse MRs: %w", err)
+		}
+		mrs = append(mrs, pageMRs...)
+
+		if limit <= 0 || len(mrs) >= limit {
+			break
+		}
+		page = resp.Headers["X-Next-Page"]
+	}
+	if limit > 0 && len(mrs) > limit {
+		mrs = mrs[:limit]
+	}
+
+	// 转换为统一格式
+	result := make([]*pr.PullRequestInfo, 0, len(mrs))
+	for _, mr := range mrs {
+		result = append(result, &pr.PullRequestInfo{
+			Number:     mr.IID,
+			Title:      mr.Title,
+			State:      toPlatformState(mr.State),
+			Draft:      mr.Draft,
+			HTMLURL:    mr.WebURL,
+			HeadBranch: mr.SourceBranch,
+			BaseBranch: mr.TargetBranch,
+			Labels:     mr.Labels,
+			Reviewers:  usernames(mr.Reviewers),
+			CreatedAt:  mr.CreatedAt,
+			UpdatedAt:  mr.UpdatedAt,
+			Author:     mr.Author.Username,
+		})
+	}
+
+	return result, nil
+}
+
+// GetPullRequestDiff 获取 Merge Request 的完整 diff
+//
+// GitLab 只返回每个文件的 hunk，这里补齐 diff --git 和 ---/+++ 头部，拼接为 unified diff。
+func (g *GitLab) GetPullRequestDiff(ctx context.Context, prID string) (string, error) {
+	diffs, err := g.listMergeRequestDiffs(ctx, prID)
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	for _, d := range diffs {
+		oldPath, newPath := "a/"+d.OldPath, "b/"+d.NewPath
+		fmt.Fprintf(&builder, "diff --git %s %s\n", oldPath, newPath)
+		if d.NewFile {
+			oldPath = "/dev/null"
+		}
+		if d.DeletedFile {
+			newPath = "/dev/null"
+		}
+		fmt.Fprintf(&builder, "--- %s\n+++ %s\n", oldPath, newPath)
+		builder.WriteString(d.Diff)
+		if d.Diff != "" && !strings.HasSuffix(d.Diff, "\n") {
+			builder.WriteString("\n")
+		}
+	}
+
+	return builder.String(), nil
+}
+
+// ListPullRequestFiles 列出 Merge Request 中变更的文件
+func (g *GitLab) ListPullRequestFiles(ctx context.Context, prID string) ([]*pr.PullRequestFile, error) {
+	diffs, err := g.listMergeRequestDiffs(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*pr.PullRequestFile, 0, len(diffs))
+	for _, d := range diffs {
+		file := &pr.PullRequestFile{
+			Filename: d.NewPath,
+			Status:   "modified",
+			Patch:    d.Diff,
+		}
+		switch {
+		case d.NewFile:
+			file.Status = "added"
+		case d.DeletedFile:
+			file.Status = "removed"
+		case d.RenamedFile:
+			file.Status = "renamed"
+			file.PreviousFilename = d.OldPath
+		}
+		file.Additions, file.Deletions = countDiffLines(d.Diff)
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// UpdatePullRequest 更新 Merge Request
+//
+// state 支持 "closed"（关闭）和 "open"（重新打开）。
+func (g *GitLab) UpdatePullRequest(ctx context.Context, prID string, title, body *string, state *string) error {
+	logger := logging.GetLogger()
+	logger.WithField("pr_id", prID).Info("Updating merge request")
+
+	iid, err := parseMRIID(prID)
+	if err != nil {
+		return err
+	}
+
+	update := &updateMergeRequestRequest{
+		Title:       title,
+		Description: body,
+	}
+	if state != nil {
+		var event string
+		switch *state {
+		case "closed", "close":
+			event = "close"
+		case "open", "opened", "reopen":
+			event = "reopen"
+		default:
+			return fmt.Errorf("unsupported MR state: %s", *state)
+		}
+		update.StateEvent = &event
+	}
+
+	resp, err := g.httpClient.PutWithConfig(g.mergeRequestURL(iid), g.requestConfig(ctx).WithBody(update))
+	if err == nil {
+		_, err = resp.EnsureSuccessWith(apiError)
+	}
+	if err != nil {
+		logger.WithError(err).WithField("pr_id", prID).Error("Failed to update merge request")
+		return fmt.Errorf("failed to update MR: %w", err)
+	}
+
+	return nil
+}
+
+// AddComment 添加评论
+func (g *GitLab) AddComment(ctx context.Context, prID string, body string) error {
+	iid, err := parseMRIID(prID)
+	if err != nil {
+		return err
+	}
+
+	config := g.requestConfig(ctx).WithBody(&createNoteRequest{Body: body})
+	resp, err := g.httpClient.PostWithConfig(g.mergeRequestURL(iid)+"/notes", config)
+	if err == nil {
+		_, err = resp.EnsureSuccessWith(apiError)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	return nil
+}
+
+// ApprovePullRequest 批准 Merge Request
+func (g *GitLab) ApprovePullRequest(ctx context.Context, prID string) error {
+	iid, err := parseMRIID(prID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := g.httpClient.PostWithConfig(g.mergeRequestURL(iid)+"/approve", g.requestConfig(ctx))
+	if err == nil {
+		_, err = resp.EnsureSuccessWith(apiError)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to approve MR: %w", err)
+	}
+
+	return nil
+}
+
+// getMergeRequest 获取 Merge Request 详情
+func (g *GitLab) getMergeRequest(ctx context.Context, prID string) (*mergeRequest, error) {
+	iid, err := parseMRIID(prID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := g.httpClient.GetWithConfig(g.mergeRequestURL(iid), g.requestConfig(ctx))
+	if err == nil {
+		_, err = resp.EnsureSuccessWith(apiError)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MR: %w", err)
+	}
+
+	mr, err := http.AsJSON[mergeRequest](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse MR: %w", err)
+	}
+
+	return &mr, nil
+}
+
+// getReviews 获取 Merge Request 的评审状态
+//
+// 已批准的用户标记为 approved，其余评审人标记为 pending。
+// 获取批准记录失败时只记录日志。
+func (g *GitLab) getReviews(ctx context.Context, mr *mergeRequest) []pr.Review {
+	var reviews []pr.Review
+	approved := make(map[string]bool)
+
+	resp, err := g.httpClient.GetWithConfig(g.mergeRequestURL(mr.IID)+"/approvals", g.requestConfig(ctx))
+	if err == nil {
+		_, err = resp.EnsureSuccessWith(apiError)
+	}
+	var result approvals
+	if err == nil {
+		result, err = http.AsJSON[approvals](resp)
+	}
+	if err != nil {
+		logging.GetLogger().WithError(err).WithField("pr_id", mr.IID).Warn("Failed to get merge request approvals")
+	}
+
+	for _, approval := range result.ApprovedBy {
+		approved[approval.User.Username] = true
+		reviews = append(reviews, pr.Review{Reviewer: approval.User.Username, State: pr.ReviewApproved})
+	}
+	for _, reviewer := range mr.Reviewers {
+		if !approved[reviewer.Username] {
+			reviews = append(reviews, pr.Review{Reviewer: reviewer.Username, State: pr.ReviewPending})
+		}
+	}
+
+	return reviews
+}
+
+// getCheckResults 获取 head pipeline 中各个 job 的结果
+//
+// 跳过的 job 和手动 job 不计入；允许失败的 job 失败时按通过处理。
+// 获取失败时只记录日志。
+func (g *GitLab) getCheckResults(ctx context.Context, p *pipeline) []pr.CheckResult {
+	if p == nil || p.ID == 0 {
+		return nil
+	}
+
+	query := map[string]string{"per_page": strconv.Itoa(maxPerPage)}
+	resp, err := g.httpClient.GetWithConfig(fmt.Sprintf("%s/pipelines/%d/jobs", g.projectURL(), p.ID), g.requestConfig(ctx).WithQuery(query))
+	if err == nil {
+		_, err = resp.EnsureSuccessWith(apiError)
+	}
+	var jobs []job
+	if err == nil {
+		jobs, err = http.AsJSON[[]job](resp)
+	}
+	if err != nil {
+		logging.GetLogger().WithError(err).WithField("pipeline_id", p.ID).Warn("Failed to list pipeline jobs")
+		return nil
+	}
+
+	results := make([]pr.CheckResult, 0, len(jobs))
+	for _, j := range jobs {
+		if j.Status == "manual" || j.Status == "skipped" {
+			continue
+		}
+		state := pipelineChecks(&pipeline{Status: j.Status})
+		if state == "" {
+			continue
+		}
+		if state == pr.ChecksFailure && j.AllowFailure {
+			state = pr.ChecksSuccess
+		}
+		results = append(results, pr.CheckResult{Name: j.Name, State: state, URL: j.WebURL})
+	}
+	return results
+}
+
+// usernames 提取用户名列表
+func usernames(users []user) []string {
+	names := make([]string, 0, len(users))
+	for _, u := range users {
+		names = append(names, u.Username)
+	}
+	return names
+}
+
+// listMergeRequestDiffs 获取 Merge Request 中所有文件的 diff（自动翻页）
+func (g *GitLab) listMergeRequestDiffs(ctx context.Context, prID string) ([]mergeRequestDiff, error) {
+	logger := logging.GetLogger()
+
+	iid, err := parseMRIID(prID)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []mergeRequestDiff
+	page := "1"
+	for page != "" {
+		query := map[string]string{
+			"page":     page,
+			"per_page": strconv.Itoa(maxPerPage),
+		}
+		resp, err := g.httpClient.GetWithConfig(g.mergeRequestURL(iid)+"/diffs", g.requestConfig(ctx).WithQuery(query))
+		if err == nil {
+			_, err = resp.EnsureSuccessWith(apiError)
+		}
+		if err != nil {
+			logger.WithError(err).WithField("pr_id", prID).Error("Failed to get merge request diffs")
+			return nil, fmt.Errorf("failed to get MR diffs: %w", err)
+		}
+
+		pageDiffs, err := http.AsJSON[[]mergeRequestDiff](resp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse MR diffs: %w", err)
+		}
+		diffs = append(diffs, pageDiffs...)
+
+		page = resp.Headers["X-Next-Page"]
+	}
+
+	return diffs, nil
+}
+
+// projectURL 构建项目 API 地址（项目路径需要 URL 编码，如 group%2Frepo）
+func (g *GitLab) projectURL() string {
+	projectPath := url.PathEscape(g.owner + "/" + g.repo)
+	return fmt.Sprintf("%s/api/v4/projects/%s", g.baseURL, projectPath)
+}
+
+// mergeRequestURL 构建 Merge Request API 地址
+func (g *GitLab) mergeRequestURL(iid int) string {
+	return fmt.Sprintf("%s/merge_requests/%d", g.projectURL(), iid)
+}
+
+// requestConfig 创建带认证 Header 的请求配置（ctx 取消后请求会中止）
+func (g *GitLab) requestConfig(ctx context.Context) *http.RequestConfig {
+	return http.NewRequestConfig().
+		WithContext(ctx).
+		WithHeader("PRIVATE-TOKEN", g.token).
+		WithHeader("Content-Type", "application/json")
+}
+
+// apiError 将 GitLab API 错误响应转换为错误
+func apiError(r *http.HttpResponse) error {
+	return fmt.Errorf("GitLab API error (status %d): %s", r.Status, r.ExtractErrorMessage())
+}
+
+// toPlatformState 将 GitLab 状态转换为统一状态（open, closed, merged）
+func toPlatformState(state string) string {
+	switch state {
+	case "opened":
+		return "open"
+	case "merged":
+		return "merged"
+	default:
+		return "closed"
+	}
+}
+
+// pipelineChecks 将 head pipeline 状态转换为统一的检查状态，没有 pipeline 时返回空
+//
+// manual 表示自动执行的 job 都已结束、只剩等待手动触发的 job，skipped 表示没有 job 需要执行，
+// 两者都不会再自行变化，与 GitHub 的 neutral/skipped 一样按通过处理。
+func pipelineChecks(p *pipeline) string {
+	if p == nil {
+		return ""
+	}
+
+	switch p.Status {
+	case "success", "manual", "skipped":
+		return pr.ChecksSuccess
+	case "failed", "canceled":
+		return pr.ChecksFailure
+	case "":
+		return ""
+	default:
+		// created, waiting_for_resource, preparing, pending, running, scheduled
+		return pr.ChecksPending
+	}
+}
+
+// mergeable 根据 GitLab 合并状态判断是否可合并（nil 表示未知）
+func mergeable(mr *mergeRequest) *bool {
+	result := func(v bool) *bool { return &v }
+
+	switch mr.DetailedMergeStatus {
+	case "mergeable":
+		return result(true)
+	case "", "unchecked", "checking", "preparing", "approvals_syncing":
+		// 未知，回退到 merge_status
+	default:
+		return result(false)
+	}
+
+	switch mr.MergeStatus {
+	case "can_be_merged":
+		return result(true)
+	case "cannot_be_merged", "cannot_be_merged_recheck":
+		return result(false)
+	}
+
+	return nil
+}
+
+// countDiffLines 统计 hunk 中新增和删除的行数（GitLab 返回的 diff 不包含 ---/+++ 头部）
+func countDiffLines(diff string) (additions, deletions int) {
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			additions++
+		case strings.HasPrefix(line, "-"):
+			deletions++
+		}
+	}
+	return additions, deletions
+}
+
+// parseMRIID 解析 MR ID（支持数字、"!123" 和 MR URL）
+func parseMRIID(prID string) (int, error) {
+	id := strings.TrimSpace(prID)
+
+	// 如果是 URL，提取数字
+	if strings.Contains(id, "/merge_requests/") {
+		parts := strings.Split(id, "/merge_requests/")
+		id = strings.SplitN(strings.Trim(parts[len(parts)-1], "/"), "/", 2)[0]
+	}
+	id = strings.TrimPrefix(id, "!")
+
+	iid, err := strconv.Atoi(id)
+	if err != nil || iid <= 0 {
+		return 0, fmt.Errorf("invalid MR ID format: %s", prID)
+	}
+
+	return iid, nil
+}
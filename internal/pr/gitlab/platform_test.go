@@ -0,0 +1,672 @@
+//go:build test
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zevwings/workflow/internal/pr"
+	"github.com/zevwings/workflow/internal/testutils"
+)
+
+// ==================== 测试辅助函数 ====================
+
+// projectPath Mock 服务器中项目的 API 路径（owner/repo 已编码）
+const projectPath = "/api/v4/projects/group%2Frepo"
+
+// setupMockGitLab 创建 Mock GitLab API 服务器和指向它的 GitLab 实例
+func setupMockGitLab(t *testing.T, handler http.HandlerFunc) *GitLab {
+	t.Helper()
+
+	server := testutils.NewHTTPTestServer().
+		WithHandler(handler).
+		Build(t)
+
+	gl, err := NewGitLab("test-token", server.URL(), "group", "repo")
+	require.NoError(t, err)
+	return gl
+}
+
+// writeJSON 写入 JSON 响应
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// ==================== NewGitLab 测试 ====================
+
+func TestNewGitLab(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       string
+		baseURL     string
+		owner       string
+		repo        string
+		wantErr     bool
+		wantBaseURL string
+	}{
+		{
+			name:        "默认地址",
+			token:       "token",
+			owner:       "group",
+			repo:        "repo",
+			wantBaseURL: DefaultBaseURL,
+		},
+		{
+			name:        "自建实例地址",
+			token:       "token",
+			baseURL:     "https://gitlab.example.com/",
+			owner:       "group",
+			repo:        "repo",
+			wantBaseURL: "https://gitlab.example.com",
+		},
+		{
+			name:    "空 token",
+			owner:   "group",
+			repo:    "repo",
+			wantErr: true,
+		},
+		{
+			name:    "空 owner",
+			token:   "token",
+			repo:    "repo",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gl, err := NewGitLab(tt.token, tt.baseURL, tt.owner, tt.repo)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, gl)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBaseURL, gl.baseURL)
+			assert.Equal(t, "gitlab", gl.GetPlatformName())
+		})
+	}
+}
+
+func TestGitLab_ProjectURL_NestedGroup(t *testing.T) {
+	gl, err := NewGitLab("token", "https://gitlab.example.com", "group/subgroup", "repo")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://gitlab.example.com/api/v4/projects/group%2Fsubgroup%2Frepo", gl.projectURL())
+}
+
+// ==================== CreatePullRequest 测试 ====================
+
+func TestGitLab_CreatePullRequest(t *testing.T) {
+	gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, projectPath+"/merge_requests", r.URL.EscapedPath())
+		assert.Equal(t, "test-token", r.Header.Get("PRIVATE-TOKEN"))
+
+		var body createMergeRequestRequest
+		testutils.ReadRequestBody(t, r, &body)
+		assert.Equal(t, "feature", body.SourceBranch)
+		assert.Equal(t, "develop", body.TargetBranch)
+		assert.Equal(t, "Add feature", body.Title)
+		assert.Equal(t, "Description", body.Description)
+
+		writeJSON(w, http.StatusCreated, mergeRequest{
+			IID:    7,
+			WebURL: "https://gitlab.example.com/group/repo/-/merge_requests/7",
+		})
+	})
+
+	target := "develop"
+	url, err := gl.CreatePullRequest(context.Background(), "Add feature", "Description", "feature", &target)
+	require.NoError(t, err)
+	assert.Equal(t, "https://gitlab.example.com/group/repo/-/merge_requests/7", url)
+}
+
+func TestGitLab_CreatePullRequest_DefaultTargetBranch(t *testing.T) {
+	gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == projectPath:
+			writeJSON(w, http.StatusOK, project{ID: 1, DefaultBranch: "master"})
+		case r.Method == http.MethodPost:
+			var body createMergeRequestRequest
+			testutils.ReadRequestBody(t, r, &body)
+			assert.Equal(t, "master", body.TargetBranch)
+			writeJSON(w, http.StatusCreated, mergeRequest{IID: 1, WebURL: "mr-url"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	url, err := gl.CreatePullRequest(context.Background(), "Title", "", "feature", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "mr-url", url)
+}
+
+func TestGitLab_CreatePullRequest_Error(t *testing.T) {
+	gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusConflict, map[string]interface{}{
+			"message": []string{"Another open merge request already exists for this source branch"},
+		})
+	})
+
+	target := "main"
+	_, err := gl.CreatePullRequest(context.Background(), "Title", "", "feature", &target)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create MR")
+	assert.Contains(t, err.Error(), "409")
+}
+
+// ==================== MergePullRequest 测试 ====================
+
+func TestGitLab_MergePullRequest(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		deleteBranch bool
+		wantSquash   bool
+	}{
+		{name: "squash 合并", method: "squash", deleteBranch: true, wantSquash: true},
+		{name: "普通合并", method: "merge", deleteBranch: false, wantSquash: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, projectPath+"/merge_requests/12/merge", r.URL.EscapedPath())
+
+				var body mergeMergeRequestRequest
+				testutils.ReadRequestBody(t, r, &body)
+				assert.Equal(t, tt.wantSquash, body.Squash)
+				assert.Equal(t, tt.deleteBranch, body.ShouldRemoveSourceBranch)
+
+				writeJSON(w, http.StatusOK, mergeRequest{IID: 12, State: "merged"})
+			})
+
+			err := gl.MergePullRequest(context.Background(), "12", tt.method, tt.deleteBranch)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGitLab_MergePullRequest_UnsupportedMethod(t *testing.T) {
+	gl, err := NewGitLab("token", "", "group", "repo")
+	require.NoError(t, err)
+
+	// 不支持的方法在发送请求之前返回错误，不会默认按 squash 合并
+	for _, method := range []string{"rebase", "sqaush", ""} {
+		err = gl.MergePullRequest(context.Background(), "12", method, false)
+		require.Error(t, err, method)
+		assert.Contains(t, err.Error(), "use merge or squash", method)
+	}
+}
+
+func TestGitLab_MergePullRequest_NotMergeable(t *testing.T) {
+	gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"message": "405 Method Not Allowed"})
+	})
+
+	err := gl.MergePullRequest(context.Background(), "12", "squash", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to merge MR")
+}
+
+// ==================== ClosePullRequest / UpdatePullRequest 测试 ====================
+
+func TestGitLab_ClosePullRequest(t *testing.T) {
+	gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, projectPath+"/merge_requests/3", r.URL.EscapedPath())
+
+		var body map[string]interface{}
+		testutils.ReadRequestBody(t, r, &body)
+		assert.Equal(t, "close", body["state_event"])
+		assert.NotContains(t, body, "title")
+
+		writeJSON(w, http.StatusOK, mergeRequest{IID: 3, State: "closed"})
+	})
+
+	err := gl.ClosePullRequest(context.Background(), "3")
+	assert.NoError(t, err)
+}
+
+func TestGitLab_UpdatePullRequest(t *testing.T) {
+	gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		testutils.ReadRequestBody(t, r, &body)
+		assert.Equal(t, "New title", body["title"])
+		assert.Equal(t, "New body", body["description"])
+		assert.Equal(t, "reopen", body["state_event"])
+
+		writeJSON(w, http.StatusOK, mergeRequest{IID: 3})
+	})
+
+	title, desc, state := "New title", "New body", "open"
+	err := gl.UpdatePullRequest(context.Background(), "3", &title, &desc, &state)
+	assert.NoError(t, err)
+}
+
+func TestGitLab_UpdatePullRequest_InvalidState(t *testing.T) {
+	gl, err := NewGitLab("token", "", "group", "repo")
+	require.NoError(t, err)
+
+	state := "merged"
+	err = gl.UpdatePullRequest(context.Background(), "3", nil, nil, &state)
+	assert.Error(t, err)
+}
+
+// ==================== GetPullRequestStatus 测试 ====================
+
+func TestGitLab_GetPullRequestStatus(t *testing.T) {
+	updatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		mr            mergeRequest
+		wantState     string
+		wantMerged    bool
+		wantMergeable *bool
+		wantChecks    string
+	}{
+		{
+			name:          "可合并",
+			mr:            mergeRequest{IID: 5, State: "opened", DetailedMergeStatus: "mergeable"},
+			wantState:     "open",
+			wantMergeable: boolPtr(true),
+		},
+		{
+			name:          "存在冲突",
+			mr:            mergeRequest{IID: 5, State: "opened", DetailedMergeStatus: "broken_status"},
+			wantState:     "open",
+			wantMergeable: boolPtr(false),
+		},
+		{
+			name:          "检查中回退到 merge_status",
+			mr:            mergeRequest{IID: 5, State: "opened", DetailedMergeStatus: "checking", MergeStatus: "can_be_merged"},
+			wantState:     "open",
+			wantMergeable: boolPtr(true),
+		},
+		{
+			name:      "状态未知",
+			mr:        mergeRequest{IID: 5, State: "opened", MergeStatus: "unchecked"},
+			wantState: "open",
+		},
+		{
+			name:       "已合并",
+			mr:         mergeRequest{IID: 5, State: "merged"},
+			wantState:  "merged",
+			wantMerged: true,
+		},
+		{
+			name:       "pipeline 成功",
+			mr:         mergeRequest{IID: 5, State: "opened", HeadPipeline: &pipeline{ID: 1, Status: "success"}},
+			wantState:  "open",
+			wantChecks: "success",
+		},
+		{
+			name:       "pipeline 失败",
+			mr:         mergeRequest{IID: 5, State: "opened", HeadPipeline: &pipeline{ID: 1, Status: "failed"}},
+			wantState:  "open",
+			wantChecks: "failure",
+		},
+		{
+			name:       "pipeline 运行中",
+			mr:         mergeRequest{IID: 5, State: "opened", HeadPipeline: &pipeline{ID: 1, Status: "running"}},
+			wantState:  "open",
+			wantChecks: "pending",
+		},
+		{
+			name:       "pipeline 等待手动 job",
+			mr:         mergeRequest{IID: 5, State: "opened", HeadPipeline: &pipeline{ID: 1, Status: "manual"}},
+			wantState:  "open",
+			wantChecks: "success",
+		},
+		{
+			name:       "pipeline 已跳过",
+			mr:         mergeRequest{IID: 5, State: "opened", HeadPipeline: &pipeline{ID: 1, Status: "skipped"}},
+			wantState:  "open",
+			wantChecks: "success",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := tt.mr
+			mr.UpdatedAt = updatedAt
+			gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				switch r.URL.EscapedPath() {
+				case projectPath + "/merge_requests/5":
+					writeJSON(w, http.StatusOK, mr)
+				case projectPath + "/merge_requests/5/approvals", projectPath + "/pipelines/1/jobs":
+					writeJSON(w, http.StatusOK, map[string]interface{}{})
+				default:
+					t.Errorf("unexpected path %s", r.URL.EscapedPath())
+				}
+			})
+
+			status, err := gl.GetPullRequestStatus(context.Background(), "https://gitlab.example.com/group/repo/-/merge_requests/5")
+			require.NoError(t, err)
+			assert.Equal(t, 5, status.Number)
+			assert.Equal(t, tt.wantState, status.State)
+			assert.Equal(t, tt.wantMerged, status.Merged)
+			assert.Equal(t, tt.wantMergeable, status.Mergeable)
+			assert.Equal(t, tt.wantChecks, status.Checks)
+			assert.True(t, updatedAt.Equal(status.UpdatedAt))
+		})
+	}
+}
+
+func TestGitLab_GetPullRequestStatus_Details(t *testing.T) {
+	gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case projectPath + "/merge_requests/5":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"iid":           5,
+				"description":   "Current description",
+				"state":         "opened",
+				"draft":         true,
+				"labels":        []string{"backend"},
+				"reviewers":     []map[string]string{{"username": "alice"}, {"username": "bob"}},
+				"head_pipeline": map[string]interface{}{"id": 9, "status": "running"},
+			})
+		case projectPath + "/merge_requests/5/approvals":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"approved_by": []map[string]interface{}{{"user": map[string]string{"username": "alice"}}},
+			})
+		case projectPath + "/pipelines/9/jobs":
+			writeJSON(w, http.StatusOK, []map[string]interface{}{
+				{"name": "build", "status": "success", "web_url": "https://gitlab.example.com/jobs/1"},
+				{"name": "lint", "status": "failed", "allow_failure": true},
+				{"name": "test", "status": "running"},
+				{"name": "deploy", "status": "manual"},
+				{"name": "docs", "status": "skipped"},
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.EscapedPath())
+		}
+	})
+
+	status, err := gl.GetPullRequestStatus(context.Background(), "5")
+	require.NoError(t, err)
+	assert.Equal(t, "Current description", status.Body)
+	assert.True(t, status.Draft)
+	assert.Equal(t, []string{"backend"}, status.Labels)
+	assert.Equal(t, []pr.Review{
+		{Reviewer: "alice", State: pr.ReviewApproved},
+		{Reviewer: "bob", State: pr.ReviewPending},
+	}, status.Reviews)
+	assert.Equal(t, pr.ChecksPending, status.Checks)
+	assert.Equal(t, []pr.CheckResult{
+		{Name: "build", State: pr.ChecksSuccess, URL: "https://gitlab.example.com/jobs/1"},
+		{Name: "lint", State: pr.ChecksSuccess},
+		{Name: "test", State: pr.ChecksPending},
+	}, status.CheckResults)
+}
+
+func TestGitLab_GetPullRequestStatus_NotFound(t *testing.T) {
+	gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "404 Not found"})
+	})
+
+	_, err := gl.GetPullRequestStatus(context.Background(), "5")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+}
+
+func TestGitLab_GetPullRequestStatus_Canceled(t *testing.T) {
+	// Arrange
+	requested := false
+	gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		writeJSON(w, http.StatusOK, map[string]interface{}{"iid": 5})
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	_, err := gl.GetPullRequestStatus(ctx, "5")
+
+	// Assert
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, requested)
+}
+
+// ==================== ListPullRequests 测试 ====================
+
+func TestGitLab_ListPullRequests(t *testing.T) {
+	tests := []struct {
+		name      string
+		state     string
+		wantState string
+	}{
+		{name: "open 状态", state: "open", wantState: "opened"},
+		{name: "closed 状态", state: "closed", wantState: "closed"},
+		{name: "all 状态", state: "all", wantState: "all"},
+		{name: "空状态默认 opened", state: "", wantState: "opened"},
+		{name: "无效状态默认 opened", state: "invalid", wantState: "opened"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, projectPath+"/merge_requests", r.URL.EscapedPath())
+				assert.Equal(t, tt.wantState, r.URL.Query().Get("state"))
+				assert.Equal(t, "10", r.URL.Query().Get("per_page"))
+
+				writeJSON(w, http.StatusOK, []mergeRequest{
+					{
+						IID:    1,
+						Title:  "First MR",
+						State:  "opened",
+						WebURL: "https://gitlab.com/group/repo/-/merge_requests/1",
+						Author: user{Username: "alice"},
+					},
+					{IID: 2, Title: "Second MR", State: "merged"},
+				})
+			})
+
+			prs, err := gl.ListPullRequests(context.Background(), pr.ListOptions{State: tt.state, Limit: 10})
+			require.NoError(t, err)
+			require.Len(t, prs, 2)
+			assert.Equal(t, 1, prs[0].Number)
+			assert.Equal(t, "First MR", prs[0].Title)
+			assert.Equal(t, "open", prs[0].State)
+			assert.Equal(t, "alice", prs[0].Author)
+			assert.Equal(t, "merged", prs[1].State)
+		})
+	}
+}
+
+func TestGitLab_ListPullRequests_Paginates(t *testing.T) {
+	// Arrange: 每页最多 100 条，共 3 页
+	var pages []string
+	gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pages = append(pages, r.URL.Query().Get("page"))
+
+		mrs := make([]mergeRequest, 100)
+		for i := range mrs {
+			mrs[i] = mergeRequest{IID: (page-1)*100 + i + 1, State: "opened"}
+		}
+		if page < 3 {
+			w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+		}
+		writeJSON(w, http.StatusOK, mrs)
+	})
+
+	// Act
+	prs, err := gl.ListPullRequests(context.Background(), pr.ListOptions{State: "open", Limit: 150})
+
+	// Assert: 满足 limit 后不再请求下一页
+	require.NoError(t, err)
+	require.Len(t, prs, 150)
+	assert.Equal(t, 150, prs[149].Number)
+	assert.Equal(t, []string{"1", "2"}, pages)
+}
+
+func TestGitLab_ListPullRequests_Head(t *testing.T) {
+	gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "feature", r.URL.Query().Get("source_branch"))
+		assert.Equal(t, "alice", r.URL.Query().Get("author_username"))
+		writeJSON(w, http.StatusOK, []mergeRequest{
+			{IID: 3, State: "opened", SourceBranch: "feature", TargetBranch: "main"},
+		})
+	})
+
+	prs, err := gl.ListPullRequests(context.Background(), pr.ListOptions{Head: "feature", Author: "alice"})
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, "feature", prs[0].HeadBranch)
+	assert.Equal(t, "main", prs[0].BaseBranch)
+}
+
+// ==================== AddComment / ApprovePullRequest 测试 ====================
+
+// ==================== Diff 测试 ====================
+
+// mockDiffsHandler 返回分两页的 MR diff
+func mockDiffsHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, projectPath+"/merge_requests/7/diffs", r.URL.EscapedPath())
+
+		if r.URL.Query().Get("page") == "1" {
+			w.Header().Set("X-Next-Page", "2")
+			writeJSON(w, http.StatusOK, []map[string]interface{}{
+				{"old_path": "main.go", "new_path": "main.go", "diff": "@@ -1,2 +1,2 @@\n-old\n+new\n context\n"},
+				{"old_path": "new.go", "new_path": "new.go", "new_file": true, "diff": "@@ -0,0 +1 @@\n+package main\n"},
+			})
+			return
+		}
+		writeJSON(w, http.StatusOK, []map[string]interface{}{
+			{"old_path": "old.go", "new_path": "renamed.go", "renamed_file": true, "diff": ""},
+			{"old_path": "gone.go", "new_path": "gone.go", "deleted_file": true, "diff": "@@ -1 +0,0 @@\n-package gone\n"},
+		})
+	}
+}
+
+func TestGitLab_ListPullRequestFiles(t *testing.T) {
+	gl := setupMockGitLab(t, mockDiffsHandler(t))
+
+	files, err := gl.ListPullRequestFiles(context.Background(), "!7")
+	require.NoError(t, err)
+	require.Len(t, files, 4)
+
+	assert.Equal(t, "main.go", files[0].Filename)
+	assert.Equal(t, "modified", files[0].Status)
+	assert.Equal(t, 1, files[0].Additions)
+	assert.Equal(t, 1, files[0].Deletions)
+
+	assert.Equal(t, "added", files[1].Status)
+
+	assert.Equal(t, "renamed", files[2].Status)
+	assert.Equal(t, "renamed.go", files[2].Filename)
+	assert.Equal(t, "old.go", files[2].PreviousFilename)
+
+	assert.Equal(t, "removed", files[3].Status)
+	assert.Equal(t, 1, files[3].Deletions)
+}
+
+func TestGitLab_GetPullRequestDiff(t *testing.T) {
+	gl := setupMockGitLab(t, mockDiffsHandler(t))
+
+	diff, err := gl.GetPullRequestDiff(context.Background(), "7")
+	require.NoError(t, err)
+
+	assert.Contains(t, diff, "diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n@@ -1,2 +1,2 @@\n-old\n+new\n")
+	assert.Contains(t, diff, "diff --git a/new.go b/new.go\n--- /dev/null\n+++ b/new.go\n")
+	assert.Contains(t, diff, "diff --git a/gone.go b/gone.go\n--- a/gone.go\n+++ /dev/null\n")
+	assert.Contains(t, diff, "diff --git a/old.go b/renamed.go\n")
+}
+
+func TestGitLab_GetPullRequestDiff_NotFound(t *testing.T) {
+	gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "404 Not found"})
+	})
+
+	_, err := gl.GetPullRequestDiff(context.Background(), "7")
+	assert.Error(t, err)
+}
+
+func TestGitLab_AddComment(t *testing.T) {
+	gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, projectPath+"/merge_requests/9/notes", r.URL.EscapedPath())
+
+		var body createNoteRequest
+		testutils.ReadRequestBody(t, r, &body)
+		assert.Equal(t, "LGTM", body.Body)
+
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"id": 1, "body": "LGTM"})
+	})
+
+	err := gl.AddComment(context.Background(), "!9", "LGTM")
+	assert.NoError(t, err)
+}
+
+func TestGitLab_ApprovePullRequest(t *testing.T) {
+	gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, projectPath+"/merge_requests/9/approve", r.URL.EscapedPath())
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"id": 9})
+	})
+
+	err := gl.ApprovePullRequest(context.Background(), "9")
+	assert.NoError(t, err)
+}
+
+func TestGitLab_ApprovePullRequest_Unauthorized(t *testing.T) {
+	gl := setupMockGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "401 Unauthorized"})
+	})
+
+	err := gl.ApprovePullRequest(context.Background(), "9")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+}
+
+// ==================== parseMRIID 测试 ====================
+
+func TestParseMRIID(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{name: "数字", input: "42", want: 42},
+		{name: "感叹号前缀", input: "!42", want: 42},
+		{name: "MR URL", input: "https://gitlab.com/group/repo/-/merge_requests/42", want: 42},
+		{name: "MR URL 带子路径", input: "https://gitlab.com/group/repo/-/merge_requests/42/diffs", want: 42},
+		{name: "无效格式", input: "abc", wantErr: true},
+		{name: "零", input: "0", wantErr: true},
+		{name: "空字符串", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMRIID(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
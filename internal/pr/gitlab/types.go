@@ -0,0 +1,94 @@
+package gitlab
+
+import "time"
+
+// mergeRequest GitLab Merge Request API 响应
+type mergeRequest struct {
+	IID                 int        `json:"iid"`
+	Title               string     `json:"title"`
+	Description         string     `json:"description"`
+	State               string     `json:"state"` // opened, closed, merged, locked
+	WebURL              string     `json:"web_url"`
+	SourceBranch        string     `json:"source_branch"`
+	TargetBranch        string     `json:"target_branch"`
+	MergeStatus         string     `json:"merge_status"`
+	DetailedMergeStatus string     `json:"detailed_merge_status"`
+	HasConflicts        bool       `json:"has_conflicts"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	MergedAt            *time.Time `json:"merged_at"`
+	Draft               bool       `json:"draft"`
+	Labels              []string   `json:"labels"`
+	Author              user       `json:"author"`
+	Reviewers           []user     `json:"reviewers"`
+	HeadPipeline        *pipeline  `json:"head_pipeline"`
+}
+
+// pipeline GitLab CI pipeline
+type pipeline struct {
+	ID     int    `json:"id"`
+	Status string `json:"status"` // created, pending, running, success, failed, canceled, skipped, manual
+}
+
+// job GitLab CI pipeline 中的 job
+type job struct {
+	Name         string `json:"name"`
+	Status       string `json:"status"`
+	WebURL       string `json:"web_url"`
+	AllowFailure bool   `json:"allow_failure"`
+}
+
+// approvals Merge Request 的批准情况
+type approvals struct {
+	ApprovedBy []struct {
+		User user `json:"user"`
+	} `json:"approved_by"`
+}
+
+// user GitLab 用户
+type user struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+// project GitLab 项目
+type project struct {
+	ID            int    `json:"id"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+// mergeRequestDiff Merge Request 中单个文件的 diff
+type mergeRequestDiff struct {
+	OldPath     string `json:"old_path"`
+	NewPath     string `json:"new_path"`
+	Diff        string `json:"diff"`
+	NewFile     bool   `json:"new_file"`
+	RenamedFile bool   `json:"renamed_file"`
+	DeletedFile bool   `json:"deleted_file"`
+}
+
+// createMergeRequestRequest 创建 Merge Request 请求体
+type createMergeRequestRequest struct {
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	Title        string `json:"title"`
+	Description  string `json:"description,omitempty"`
+}
+
+// updateMergeRequestRequest 更新 Merge Request 请求体
+type updateMergeRequestRequest struct {
+	Title       *string `json:"title,omitempty"`
+	Description *string `json:"description,omitempty"`
+	StateEvent  *string `json:"state_event,omitempty"` // close, reopen
+}
+
+// mergeMergeRequestRequest 合并 Merge Request 请求体
+type mergeMergeRequestRequest struct {
+	Squash                   bool `json:"squash"`
+	ShouldRemoveSourceBranch bool `json:"should_remove_source_branch"`
+}
+
+// createNoteRequest 创建评论请求体
+type createNoteRequest struct {
+	Body string `json:"body"`
+}
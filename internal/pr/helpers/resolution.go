@@ -1,18 +1,35 @@
 package helpers
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/zevwings/workflow/internal/pr"
 )
 
+// ErrNoPullRequestForBranch 分支没有打开的 PR
+var ErrNoPullRequestForBranch = errors.New("no open pull request for branch")
+
+// PullRequestLister 可以列出 PR 的平台（PlatformProvider 的子集）
+type PullRequestLister interface {
+	ListPullRequests(ctx context.Context, opts pr.ListOptions) ([]*pr.PullRequestInfo, error)
+}
+
+// ChooseFunc 从多个匹配的 PR 中选择一个，返回选中的索引
+type ChooseFunc func(prs []*pr.PullRequestInfo) (int, error)
+
 // ParsePRID 解析 PR ID
 //
 // 支持多种格式：
 //   - 数字：123
 //   - URL：https://github.com/owner/repo/pull/123
+//   - GitLab MR URL：https://gitlab.com/group/project/-/merge_requests/123
 //   - 短 URL：owner/repo#123
+//   - GitLab 短格式：!123
 //
 // 返回:
 //   - string: 解析后的 PR ID（数字字符串）
@@ -39,6 +56,24 @@ func ParsePRID(prID string) (string, error) {
 		}
 	}
 
+	// https://gitlab.com/group/project/-/merge_requests/123
+	if strings.Contains(prID, "/merge_requests/") {
+		parts := strings.Split(prID, "/merge_requests/")
+		if len(parts) == 2 {
+			number := strings.TrimSuffix(strings.TrimPrefix(parts[1], "/"), "/")
+			if matched, _ := regexp.MatchString(`^\d+$`, number); matched {
+				return number, nil
+			}
+		}
+	}
+
+	// !123
+	if number, ok := strings.CutPrefix(prID, "!"); ok {
+		if matched, _ := regexp.MatchString(`^\d+$`, number); matched {
+			return number, nil
+		}
+	}
+
 	// 尝试从短格式中提取
 	// owner/repo#123
 	if strings.Contains(prID, "#") {
@@ -76,3 +111,50 @@ func ParsePRNumber(prID string) (int, error) {
 	return number, nil
 }
 
+// ResolvePRForBranch 查找源分支为指定分支的打开 PR
+//
+// 只有一个匹配时直接返回；多个匹配时调用 choose 选择。
+//
+// 参数:
+//   - lister: 用于列出 PR 的平台
+//   - branch: 源分支名
+//   - choose: 多个匹配时的选择函数
+//
+// 返回:
+//   - string: PR ID（数字字符串）
+//   - error: 没有匹配时返回 ErrNoPullRequestForBranch
+func ResolvePRForBranch(ctx context.Context, lister PullRequestLister, branch string, choose ChooseFunc) (string, error) {
+	if strings.TrimSpace(branch) == "" {
+		return "", fmt.Errorf("%w: branch is empty", ErrNoPullRequestForBranch)
+	}
+
+	prs, err := lister.ListPullRequests(ctx, pr.ListOptions{State: "open", Head: branch, Limit: 100})
+	if err != nil {
+		return "", err
+	}
+
+	// 平台的过滤可能不精确（例如 fork 的同名分支），再按分支名过滤一次
+	matched := make([]*pr.PullRequestInfo, 0, len(prs))
+	for _, info := range prs {
+		if info.HeadBranch == "" || info.HeadBranch == branch {
+			matched = append(matched, info)
+		}
+	}
+
+	switch len(matched) {
+	case 0:
+		return "", fmt.Errorf("%w: %s", ErrNoPullRequestForBranch, branch)
+	case 1:
+		return strconv.Itoa(matched[0].Number), nil
+	}
+
+	index, err := choose(matched)
+	if err != nil {
+		return "", err
+	}
+	if index < 0 || index >= len(matched) {
+		return "", fmt.Errorf("invalid selection: %d", index)
+	}
+
+	return strconv.Itoa(matched[index].Number), nil
+}
@@ -1,10 +1,13 @@
 package helpers
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/zevwings/workflow/internal/pr"
 )
 
 // ==================== ParsePRID 测试 ====================
@@ -46,6 +49,24 @@ func TestParsePRID(t *testing.T) {
 			want:    "123",
 			wantErr: false,
 		},
+		{
+			name:    "GitLab MR URL 格式",
+			prID:    "https://gitlab.com/group/project/-/merge_requests/42",
+			want:    "42",
+			wantErr: false,
+		},
+		{
+			name:    "GitLab 短格式 !42",
+			prID:    "!42",
+			want:    "42",
+			wantErr: false,
+		},
+		{
+			name:    "无效格式 - GitLab 短格式但无数字",
+			prID:    "!abc",
+			want:    "",
+			wantErr: true,
+		},
 		{
 			name:    "无效格式 - 非数字",
 			prID:    "abc",
@@ -237,3 +258,89 @@ func TestParsePRID_ThenParsePRNumber(t *testing.T) {
 		})
 	}
 }
+
+// ==================== ResolvePRForBranch 测试 ====================
+
+// fakeLister 返回固定 PR 列表并记录过滤条件
+type fakeLister struct {
+	prs  []*pr.PullRequestInfo
+	err  error
+	opts pr.ListOptions
+}
+
+func (f *fakeLister) ListPullRequests(ctx context.Context, opts pr.ListOptions) ([]*pr.PullRequestInfo, error) {
+	f.opts = opts
+	return f.prs, f.err
+}
+
+func TestResolvePRForBranch(t *testing.T) {
+	noChoose := func(prs []*pr.PullRequestInfo) (int, error) {
+		t.Fatal("choose should not be called")
+		return 0, nil
+	}
+
+	tests := []struct {
+		name    string
+		prs     []*pr.PullRequestInfo
+		choose  ChooseFunc
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "唯一匹配",
+			prs:    []*pr.PullRequestInfo{{Number: 12, HeadBranch: "feature"}},
+			choose: noChoose,
+			want:   "12",
+		},
+		{
+			name:    "没有匹配",
+			prs:     nil,
+			choose:  noChoose,
+			wantErr: ErrNoPullRequestForBranch,
+		},
+		{
+			name:    "过滤其他分支",
+			prs:     []*pr.PullRequestInfo{{Number: 3, HeadBranch: "other"}},
+			choose:  noChoose,
+			wantErr: ErrNoPullRequestForBranch,
+		},
+		{
+			name: "多个匹配时选择",
+			prs: []*pr.PullRequestInfo{
+				{Number: 1, HeadBranch: "feature"},
+				{Number: 2, HeadBranch: "feature"},
+			},
+			choose: func(prs []*pr.PullRequestInfo) (int, error) { return 1, nil },
+			want:   "2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lister := &fakeLister{prs: tt.prs}
+
+			got, err := ResolvePRForBranch(context.Background(), lister, "feature", tt.choose)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, pr.ListOptions{State: "open", Head: "feature", Limit: 100}, lister.opts)
+		})
+	}
+}
+
+func TestResolvePRForBranch_Errors(t *testing.T) {
+	choose := func(prs []*pr.PullRequestInfo) (int, error) { return 0, errors.New("cancelled") }
+
+	_, err := ResolvePRForBranch(context.Background(), &fakeLister{}, "", choose)
+	assert.ErrorIs(t, err, ErrNoPullRequestForBranch)
+
+	_, err = ResolvePRForBranch(context.Background(), &fakeLister{err: errors.New("boom")}, "feature", choose)
+	assert.EqualError(t, err, "boom")
+
+	lister := &fakeLister{prs: []*pr.PullRequestInfo{{Number: 1, HeadBranch: "feature"}, {Number: 2, HeadBranch: "feature"}}}
+	_, err = ResolvePRForBranch(context.Background(), lister, "feature", choose)
+	assert.EqualError(t, err, "cancelled")
+}
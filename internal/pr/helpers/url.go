@@ -67,3 +67,77 @@ func BuildPRURL(baseURL, owner, repo string, prNumber int) string {
 	return fmt.Sprintf("%s/%s/%s/pull/%d", baseURL, owner, repo, prNumber)
 }
 
+// RemoteURL 解析后的 Git 远程仓库地址
+type RemoteURL struct {
+	// Host 主机名（小写，不含端口和用户名，如 "github.com"）
+	Host string
+	// Owner 仓库所有者，GitLab 子组会保留完整路径（如 "group/subgroup"）
+	Owner string
+	// Repo 仓库名称（不含 .git 后缀）
+	Repo string
+}
+
+// ParseRemoteURL 解析 Git 远程仓库地址
+//
+// 与 ExtractRepoFromURL 不同，此函数会保留主机名，并把最后一级路径作为仓库名、
+// 之前的所有路径作为所有者，以支持 GitLab 的多级子组。
+//
+// 支持的格式：
+//   - https://host/owner/repo(.git)
+//   - http://host:port/owner/repo(.git)
+//   - ssh://git@host(:port)/owner/repo(.git)
+//   - git@host:owner/repo(.git)
+//
+// 参数:
+//   - url: 远程仓库地址
+//
+// 返回:
+//   - *RemoteURL: 解析结果
+//   - error: 如果格式无法识别，返回错误
+func ParseRemoteURL(url string) (*RemoteURL, error) {
+	raw := strings.TrimSpace(url)
+
+	var host, path string
+	switch {
+	case strings.Contains(raw, "://"):
+		// URL 格式: scheme://[user@]host[:port]/path
+		rest := raw[strings.Index(raw, "://")+3:]
+		slash := strings.Index(rest, "/")
+		if slash < 0 {
+			return nil, fmt.Errorf("failed to parse remote URL: %s", url)
+		}
+		host, path = rest[:slash], rest[slash+1:]
+	case strings.Contains(raw, ":"):
+		// scp 风格 SSH 格式: [user@]host:path
+		colon := strings.Index(raw, ":")
+		host, path = raw[:colon], raw[colon+1:]
+	default:
+		return nil, fmt.Errorf("failed to parse remote URL: %s", url)
+	}
+
+	// 移除用户名和端口
+	if at := strings.LastIndex(host, "@"); at >= 0 {
+		host = host[at+1:]
+	}
+	if colon := strings.Index(host, ":"); colon >= 0 {
+		host = host[:colon]
+	}
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	segments := strings.Split(path, "/")
+	if host == "" || len(segments) < 2 {
+		return nil, fmt.Errorf("failed to parse remote URL: %s", url)
+	}
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, fmt.Errorf("failed to parse remote URL: %s", url)
+		}
+	}
+
+	return &RemoteURL{
+		Host:  host,
+		Owner: strings.Join(segments[:len(segments)-1], "/"),
+		Repo:  segments[len(segments)-1],
+	}, nil
+}
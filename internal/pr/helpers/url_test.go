@@ -240,3 +240,94 @@ func TestExtractRepoFromURL_ThenBuildPRURL(t *testing.T) {
 	}
 }
 
+// ==================== ParseRemoteURL 测试 ====================
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		wantHost  string
+		wantOwner string
+		wantRepo  string
+		wantErr   bool
+	}{
+		{
+			name:      "HTTPS 格式",
+			url:       "https://github.com/owner/repo.git",
+			wantHost:  "github.com",
+			wantOwner: "owner",
+			wantRepo:  "repo",
+		},
+		{
+			name:      "HTTPS 格式（带用户名和端口）",
+			url:       "https://user@git.example.com:8443/owner/repo",
+			wantHost:  "git.example.com",
+			wantOwner: "owner",
+			wantRepo:  "repo",
+		},
+		{
+			name:      "SCP 风格 SSH 格式",
+			url:       "git@gitlab.com:group/repo.git",
+			wantHost:  "gitlab.com",
+			wantOwner: "group",
+			wantRepo:  "repo",
+		},
+		{
+			name:      "SSH URL 格式（带端口）",
+			url:       "ssh://git@gitea.example.com:2222/owner/repo.git",
+			wantHost:  "gitea.example.com",
+			wantOwner: "owner",
+			wantRepo:  "repo",
+		},
+		{
+			name:      "GitLab 多级子组",
+			url:       "https://gitlab.example.com/group/subgroup/repo.git",
+			wantHost:  "gitlab.example.com",
+			wantOwner: "group/subgroup",
+			wantRepo:  "repo",
+		},
+		{
+			name:      "主机名大小写和 www 前缀",
+			url:       "https://www.GitHub.com/owner/repo/",
+			wantHost:  "github.com",
+			wantOwner: "owner",
+			wantRepo:  "repo",
+		},
+		{
+			name:    "无效格式 - 无协议",
+			url:     "github.com/owner/repo",
+			wantErr: true,
+		},
+		{
+			name:    "无效格式 - 路径不完整",
+			url:     "https://github.com/owner",
+			wantErr: true,
+		},
+		{
+			name:    "无效格式 - 只有域名",
+			url:     "https://github.com",
+			wantErr: true,
+		},
+		{
+			name:    "无效格式 - 空字符串",
+			url:     "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remote, err := ParseRemoteURL(tt.url)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, remote)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantHost, remote.Host)
+				assert.Equal(t, tt.wantOwner, remote.Owner)
+				assert.Equal(t, tt.wantRepo, remote.Repo)
+			}
+		})
+	}
+}
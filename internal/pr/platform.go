@@ -55,13 +55,32 @@ type PlatformProvider interface {
 	// ListPullRequests 列出 Pull Requests
 	//
 	// 参数:
-	//   - state: 状态过滤（"open", "closed", "all"）
-	//   - limit: 返回数量限制
+	//   - opts: 过滤条件（状态、源分支、数量限制）
 	//
 	// 返回:
 	//   - []*PullRequestInfo: PR 列表
 	//   - error: 错误信息
-	ListPullRequests(ctx context.Context, state string, limit int) ([]*PullRequestInfo, error)
+	ListPullRequests(ctx context.Context, opts ListOptions) ([]*PullRequestInfo, error)
+
+	// GetPullRequestDiff 获取 PR 的完整 diff
+	//
+	// 参数:
+	//   - prID: PR ID
+	//
+	// 返回:
+	//   - string: unified diff 格式的 PR 变更内容
+	//   - error: 错误信息
+	GetPullRequestDiff(ctx context.Context, prID string) (string, error)
+
+	// ListPullRequestFiles 列出 PR 中变更的文件
+	//
+	// 参数:
+	//   - prID: PR ID
+	//
+	// 返回:
+	//   - []*PullRequestFile: 变更文件列表（包含每个文件的 diff）
+	//   - error: 错误信息
+	ListPullRequestFiles(ctx context.Context, prID string) ([]*PullRequestFile, error)
 
 	// UpdatePullRequest 更新 Pull Request
 	//
@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zevwings/workflow/internal/git"
+	"github.com/zevwings/workflow/internal/logging"
+	"github.com/zevwings/workflow/internal/pr/helpers"
+)
+
+// 平台名称
+const (
+	PlatformGitHub    = "github"
+	PlatformGitLab    = "gitlab"
+	PlatformBitbucket = "bitbucket"
+	PlatformGitea     = "gitea"
+)
+
+// defaultRemoteName 优先用于检测平台的远程仓库名称
+const defaultRemoteName = "origin"
+
+// knownHosts 公共托管平台的主机名
+var knownHosts = map[string]string{
+	"github.com":    PlatformGitHub,
+	"gitlab.com":    PlatformGitLab,
+	"bitbucket.org": PlatformBitbucket,
+	"gitea.com":     PlatformGitea,
+	"codeberg.org":  PlatformGitea,
+}
+
+// platformAliases 主机名映射中允许使用的平台名称
+//
+// Forgejo 是 Gitea 的分支，API 兼容，统一归为 gitea。
+var platformAliases = map[string]string{
+	PlatformGitHub:    PlatformGitHub,
+	PlatformGitLab:    PlatformGitLab,
+	PlatformBitbucket: PlatformBitbucket,
+	PlatformGitea:     PlatformGitea,
+	"forgejo":         PlatformGitea,
+}
+
+// PlatformInfo 从远程仓库地址检测到的平台信息
+type PlatformInfo struct {
+	// Platform 平台名称（如 "github"、"gitlab"）
+	Platform string
+	// Host 主机名（如 "github.com"、"gitlab.example.com"）
+	Host string
+	// Owner 仓库所有者（GitLab 子组保留完整路径）
+	Owner string
+	// Repo 仓库名称
+	Repo string
+	// RemoteName 远程仓库名称（如 "origin"）
+	RemoteName string
+	// RemoteURL 远程仓库地址
+	RemoteURL string
+}
+
+// hostKeywords 主机名中可以用于猜测平台的关键字
+var hostKeywords = map[string]string{
+	"github":    PlatformGitHub,
+	"gitlab":    PlatformGitLab,
+	"bitbucket": PlatformBitbucket,
+	"gitea":     PlatformGitea,
+	"forgejo":   PlatformGitea,
+}
+
+// WithHostMapping 设置自定义主机名到平台的映射
+//
+// 用于 GitHub Enterprise、自建 GitLab 等实例。映射优先于内置规则，同时匹配该主机名的子域名，
+// 主机名不区分大小写；平台名称支持 "github"、"gitlab"、"bitbucket"、"gitea"、"forgejo"。
+func WithHostMapping(hosts map[string]string) Option {
+	return func(o *options) {
+		o.hosts = hosts
+	}
+}
+
+// DetectPlatformFromHost 根据主机名识别平台
+//
+// 识别顺序：
+//  1. 自定义主机名映射（包括子域名，多个映射匹配时使用最长的主机名）
+//  2. 公共托管平台及其子域名（github.com、gitlab.com、bitbucket.org、gitea.com、codeberg.org）
+//  3. 主机名中包含的平台关键字（如 "gitlab.example.com"、"forgejo.example.org"），
+//     只在前两步都没有匹配时猜测；包含多个平台的关键字时返回错误
+//
+// 参数:
+//   - host: 主机名（可包含端口）
+//   - hosts: 自定义主机名映射，可为 nil
+//
+// 返回:
+//   - string: 平台名称
+//   - error: 如果无法识别平台或映射配置无效，返回错误
+func DetectPlatformFromHost(host string, hosts map[string]string) (string, error) {
+	host = normalizeHost(host)
+	if host == "" {
+		return "", fmt.Errorf("empty host")
+	}
+
+	configured := make(map[string]string, len(hosts))
+	for configuredHost, platform := range hosts {
+		configured[normalizeHost(configuredHost)] = platform
+	}
+	if configuredHost, ok := matchHost(host, configured); ok {
+		platform := configured[configuredHost]
+		name, ok := platformAliases[strings.ToLower(strings.TrimSpace(platform))]
+		if !ok {
+			return "", fmt.Errorf("invalid platform %q configured for host %s", platform, configuredHost)
+		}
+		return name, nil
+	}
+
+	if knownHost, ok := matchHost(host, knownHosts); ok {
+		return knownHosts[knownHost], nil
+	}
+
+	return guessPlatform(host)
+}
+
+// matchHost 返回与主机名相同或是其父域名的最长候选主机名
+//
+// 参数:
+//   - host: 规范化后的主机名
+//   - candidates: 规范化后的候选主机名
+//
+// 返回:
+//   - string: 匹配的候选主机名
+//   - bool: 是否匹配
+func matchHost(host string, candidates map[string]string) (string, bool) {
+	matched := ""
+	for candidate := range candidates {
+		if candidate == "" || len(candidate) <= len(matched) {
+			continue
+		}
+		if host == candidate || strings.HasSuffix(host, "."+candidate) {
+			matched = candidate
+		}
+	}
+	return matched, matched != ""
+}
+
+// guessPlatform 根据主机名中包含的平台关键字猜测平台
+func guessPlatform(host string) (string, error) {
+	platform := ""
+	for keyword, name := range hostKeywords {
+		if !strings.Contains(host, keyword) {
+			continue
+		}
+		if platform != "" && platform != name {
+			return "", fmt.Errorf("ambiguous platform for host %s (add it to [platform.hosts] in the config file)", host)
+		}
+		platform = name
+	}
+	if platform == "" {
+		return "", fmt.Errorf("unknown platform for host %s (add it to [platform.hosts] in the config file)", host)
+	}
+
+	logging.GetLogger().WithFields(logging.Fields{
+		"host":     host,
+		"platform": platform,
+	}).Debug("Guessed platform from host name, add the host to [platform.hosts] to set it explicitly")
+	return platform, nil
+}
+
+// DetectFromRemotes 从远程仓库列表检测平台信息
+//
+// 优先使用 origin，不存在时使用第一个远程仓库。
+//
+// 参数:
+//   - remotes: 远程仓库列表
+//   - opts: 可选配置（如 WithHostMapping）
+//
+// 返回:
+//   - *PlatformInfo: 平台信息
+//   - error: 如果没有远程仓库、地址无法解析或平台无法识别，返回错误
+func DetectFromRemotes(remotes []git.RemoteInfo, opts ...Option) (*PlatformInfo, error) {
+	o := newOptions(opts)
+
+	if len(remotes) == 0 {
+		return nil, fmt.Errorf("no git remotes configured")
+	}
+
+	remote := remotes[0]
+	for _, r := range remotes {
+		if r.Name == defaultRemoteName {
+			remote = r
+			break
+		}
+	}
+
+	parsed, err := helpers.ParseRemoteURL(remote.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse remote %s: %w", remote.Name, err)
+	}
+
+	platform, err := DetectPlatformFromHost(parsed.Host, o.hosts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlatformInfo{
+		Platform:   platform,
+		Host:       parsed.Host,
+		Owner:      parsed.Owner,
+		Repo:       parsed.Repo,
+		RemoteName: remote.Name,
+		RemoteURL:  remote.URL,
+	}, nil
+}
+
+// AutoDetect 从当前 Git 仓库的远程仓库检测平台信息
+//
+// 参数:
+//   - opts: 可选配置（如 WithHostMapping）
+//
+// 返回:
+//   - *PlatformInfo: 平台信息
+//   - error: 如果不在 Git 仓库中或检测失败，返回错误
+func AutoDetect(opts ...Option) (*PlatformInfo, error) {
+	repo, err := git.OpenCurrent()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository: %w", err)
+	}
+
+	remotes, err := repo.ListRemotes()
+	if err != nil {
+		return nil, err
+	}
+
+	return DetectFromRemotes(remotes, opts...)
+}
+
+// normalizeHost 统一主机名格式（小写，去除端口和 www 前缀）
+func normalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if colon := strings.Index(host, ":"); colon >= 0 {
+		host = host[:colon]
+	}
+	return strings.TrimPrefix(host, "www.")
+}
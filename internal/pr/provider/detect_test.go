@@ -0,0 +1,207 @@
+//go:build test
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zevwings/workflow/internal/git"
+	"github.com/zevwings/workflow/internal/testutils"
+)
+
+// ==================== DetectPlatformFromHost 测试 ====================
+
+func TestDetectPlatformFromHost(t *testing.T) {
+	hosts := map[string]string{
+		"github.example.com":  "github",
+		"Code.Example.com":    "gitlab",
+		"git.example.org":     "forgejo",
+		"bad.example.com":     "svn",
+		"corp.example":        "github",
+		"gitlab.corp.example": "gitlab",
+	}
+
+	tests := []struct {
+		name     string
+		host     string
+		hosts    map[string]string
+		expected string
+		wantErr  bool
+	}{
+		{name: "GitHub", host: "github.com", expected: PlatformGitHub},
+		{name: "GitLab", host: "gitlab.com", expected: PlatformGitLab},
+		{name: "Bitbucket", host: "bitbucket.org", expected: PlatformBitbucket},
+		{name: "Codeberg", host: "codeberg.org", expected: PlatformGitea},
+		{name: "大写和端口", host: "GitHub.com:443", expected: PlatformGitHub},
+		{name: "主机名包含 gitlab", host: "gitlab.internal.net", expected: PlatformGitLab},
+		{name: "主机名包含 forgejo", host: "forgejo.example.net", expected: PlatformGitea},
+		{name: "自定义映射 - GitHub Enterprise", host: "github.example.com", hosts: hosts, expected: PlatformGitHub},
+		{name: "自定义映射 - 不区分大小写", host: "code.example.com", hosts: hosts, expected: PlatformGitLab},
+		{name: "自定义映射 - forgejo 归为 gitea", host: "git.example.org", hosts: hosts, expected: PlatformGitea},
+		{name: "自定义映射 - 无效平台", host: "bad.example.com", hosts: hosts, wantErr: true},
+		{name: "公共托管平台的子域名", host: "ssh.github.com", expected: PlatformGitHub},
+		{name: "自定义映射 - 子域名", host: "git.corp.example", hosts: hosts, expected: PlatformGitHub},
+		{name: "自定义映射 - 最长匹配优先", host: "ssh.gitlab.corp.example", hosts: hosts, expected: PlatformGitLab},
+		{name: "自定义映射优先于关键字", host: "github.example.com", hosts: map[string]string{"github.example.com": "gitea"}, expected: PlatformGitea},
+		{name: "主机名包含多个平台关键字", host: "gitlab-to-github.example.net", wantErr: true},
+		{name: "未知主机", host: "code.example.net", wantErr: true},
+		{name: "空主机名", host: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platform, err := DetectPlatformFromHost(tt.host, tt.hosts)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, platform)
+			}
+		})
+	}
+}
+
+// ==================== DetectFromRemotes 测试 ====================
+
+func TestDetectFromRemotes(t *testing.T) {
+	t.Run("优先使用 origin", func(t *testing.T) {
+		remotes := []git.RemoteInfo{
+			{Name: "upstream", URL: "https://github.com/upstream/repo.git"},
+			{Name: "origin", URL: "git@gitlab.com:group/sub/repo.git"},
+		}
+
+		info, err := DetectFromRemotes(remotes)
+		require.NoError(t, err)
+		assert.Equal(t, PlatformGitLab, info.Platform)
+		assert.Equal(t, "gitlab.com", info.Host)
+		assert.Equal(t, "group/sub", info.Owner)
+		assert.Equal(t, "repo", info.Repo)
+		assert.Equal(t, "origin", info.RemoteName)
+	})
+
+	t.Run("没有 origin 时使用第一个远程", func(t *testing.T) {
+		remotes := []git.RemoteInfo{
+			{Name: "upstream", URL: "https://github.com/upstream/repo.git"},
+		}
+
+		info, err := DetectFromRemotes(remotes)
+		require.NoError(t, err)
+		assert.Equal(t, PlatformGitHub, info.Platform)
+		assert.Equal(t, "upstream", info.Owner)
+		assert.Equal(t, "upstream", info.RemoteName)
+	})
+
+	t.Run("使用自定义主机名映射", func(t *testing.T) {
+		remotes := []git.RemoteInfo{
+			{Name: "origin", URL: "ssh://git@code.example.com:2222/team/repo.git"},
+		}
+
+		info, err := DetectFromRemotes(remotes, WithHostMapping(map[string]string{"code.example.com": "gitlab"}))
+		require.NoError(t, err)
+		assert.Equal(t, PlatformGitLab, info.Platform)
+		assert.Equal(t, "code.example.com", info.Host)
+	})
+
+	t.Run("没有远程仓库", func(t *testing.T) {
+		_, err := DetectFromRemotes(nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("无法解析的远程地址", func(t *testing.T) {
+		_, err := DetectFromRemotes([]git.RemoteInfo{{Name: "origin", URL: "/tmp/local/repo"}})
+		assert.Error(t, err)
+	})
+}
+
+// ==================== AutoDetect 测试 ====================
+
+func TestAutoDetect(t *testing.T) {
+	repo := testutils.NewGitTestRepo().
+		WithRemote("origin", "git@github.com:zevwings/workflow.git").
+		Build(t)
+	t.Chdir(repo.Path())
+
+	info, err := AutoDetect()
+	require.NoError(t, err)
+	assert.Equal(t, PlatformGitHub, info.Platform)
+	assert.Equal(t, "zevwings", info.Owner)
+	assert.Equal(t, "workflow", info.Repo)
+
+	platform, err := AutoDetectPlatform()
+	require.NoError(t, err)
+	assert.Equal(t, PlatformGitHub, platform)
+}
+
+func TestAutoDetect_NoRemotes(t *testing.T) {
+	repo := testutils.NewGitTestRepo().Build(t)
+	t.Chdir(repo.Path())
+
+	_, err := AutoDetectPlatform()
+	assert.Error(t, err)
+}
+
+// ==================== NewPlatformProviderAuto 测试 ====================
+
+func TestNewPlatformProviderAuto(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		token    string
+		opts     []Option
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "GitHub 远程",
+			url:      "https://github.com/owner/repo.git",
+			token:    "test-token",
+			expected: PlatformGitHub,
+		},
+		{
+			name:     "自建 GitLab 远程",
+			url:      "git@code.example.com:group/repo.git",
+			token:    "test-token",
+			opts:     []Option{WithHostMapping(map[string]string{"code.example.com": "gitlab"})},
+			expected: PlatformGitLab,
+		},
+		{
+			name:    "空 token",
+			url:     "https://github.com/owner/repo.git",
+			token:   "",
+			wantErr: true,
+		},
+		{
+			name:    "已识别但不支持的平台",
+			url:     "https://bitbucket.org/owner/repo.git",
+			token:   "test-token",
+			wantErr: true,
+		},
+		{
+			name:    "未知主机",
+			url:     "https://code.example.com/owner/repo.git",
+			token:   "test-token",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := testutils.NewGitTestRepo().WithRemote("origin", tt.url).Build(t)
+			t.Chdir(repo.Path())
+
+			provider, err := NewPlatformProviderAuto(tt.token, tt.opts...)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, provider)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, provider)
+				assert.Equal(t, tt.expected, provider.GetPlatformName())
+			}
+		})
+	}
+}
@@ -5,16 +5,44 @@ import (
 
 	"github.com/zevwings/workflow/internal/pr"
 	"github.com/zevwings/workflow/internal/pr/github"
+	"github.com/zevwings/workflow/internal/pr/gitlab"
 )
 
+// options 平台提供者的可选配置
+type options struct {
+	baseURL string
+	hosts   map[string]string
+}
+
+// newOptions 应用可选配置
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Option 平台提供者的可选配置项
+type Option func(*options)
+
+// WithBaseURL 设置平台实例地址
+//
+// 用于自建实例（如 GitHub Enterprise Server、自建 GitLab），为空时使用平台默认地址。
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
 // NewPlatformProvider 创建平台提供者实例
 //
 // 根据平台名称自动创建对应的平台提供者。
 // 目前支持：
-//   - "github": 创建 GitHub 平台提供者
+//   - "github": 创建 GitHub 平台提供者（支持通过 WithBaseURL 指定 GitHub Enterprise 实例）
+//   - "gitlab": 创建 GitLab 平台提供者（支持通过 WithBaseURL 指定自建实例）
 //
 // 未来可以扩展支持：
-//   - "gitlab": GitLab 平台
 //   - "bitbucket": Bitbucket 平台
 //
 // 参数:
@@ -22,14 +50,19 @@ import (
 //   - token: 平台认证 token（如 GitHub Personal Access Token）
 //   - owner: 仓库所有者（如 "zevwings"）
 //   - repo: 仓库名称（如 "workflow"）
+//   - opts: 可选配置（如 WithBaseURL）
 //
 // 返回:
 //   - PlatformProvider: 平台提供者实例
 //   - error: 如果平台不支持或创建失败，返回错误
-func NewPlatformProvider(platform, token, owner, repo string) (pr.PlatformProvider, error) {
+func NewPlatformProvider(platform, token, owner, repo string, opts ...Option) (pr.PlatformProvider, error) {
+	o := newOptions(opts)
+
 	switch platform {
-	case "github":
-		return github.NewGitHub(token, owner, repo)
+	case PlatformGitHub:
+		return github.NewGitHubEnterprise(token, o.baseURL, owner, repo)
+	case PlatformGitLab:
+		return gitlab.NewGitLab(token, o.baseURL, owner, repo)
 	default:
 		return nil, fmt.Errorf("unsupported platform: %s", platform)
 	}
@@ -38,39 +71,58 @@ func NewPlatformProvider(platform, token, owner, repo string) (pr.PlatformProvid
 // AutoDetectPlatform 自动检测平台
 //
 // 从当前 Git 仓库的远程 URL 自动检测平台类型。
+// 识别规则见 DetectPlatformFromHost。
+//
+// 参数:
+//   - opts: 可选配置（如 WithHostMapping）
 //
 // 返回:
 //   - string: 平台名称（如 "github"）
 //   - error: 如果检测失败，返回错误
-func AutoDetectPlatform() (string, error) {
-	// TODO: 实现自动检测逻辑
-	// 1. 获取当前仓库的远程 URL
-	// 2. 根据 URL 判断平台（github.com -> github, gitlab.com -> gitlab）
-	// 3. 返回平台名称
-
-	// 目前默认返回 github
-	return "github", nil
+func AutoDetectPlatform(opts ...Option) (string, error) {
+	info, err := AutoDetect(opts...)
+	if err != nil {
+		return "", err
+	}
+	return info.Platform, nil
 }
 
 // NewPlatformProviderAuto 自动检测并创建平台提供者
 //
-// 自动检测当前仓库的平台类型，并创建对应的平台提供者。
-// 注意：此函数需要 token、owner 和 repo 参数，业务逻辑应由调用方（commands 层）完成。
+// 从当前仓库的远程 URL 检测平台类型、所有者和仓库名称，并创建对应的平台提供者。
+// 对于 GitHub Enterprise 和自建 GitLab 实例，未通过 WithBaseURL 指定地址时使用 https://<host>。
 //
 // 参数:
 //   - token: 平台认证 token（如 GitHub Personal Access Token）
-//   - owner: 仓库所有者（如 "zevwings"）
-//   - repo: 仓库名称（如 "workflow"）
+//   - opts: 可选配置（如 WithBaseURL、WithHostMapping）
 //
 // 返回:
 //   - PlatformProvider: 平台提供者实例
 //   - error: 如果检测失败或创建失败，返回错误
-func NewPlatformProviderAuto(token, owner, repo string) (pr.PlatformProvider, error) {
-	platform, err := AutoDetectPlatform()
+func NewPlatformProviderAuto(token string, opts ...Option) (pr.PlatformProvider, error) {
+	info, err := AutoDetect(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to detect platform: %w", err)
 	}
 
-	return NewPlatformProvider(platform, token, owner, repo)
+	return NewPlatformProviderFromInfo(info, token, opts...)
 }
 
+// NewPlatformProviderFromInfo 根据检测到的平台信息创建平台提供者
+//
+// 参数:
+//   - info: 平台信息（通常来自 AutoDetect 或 DetectFromRemotes）
+//   - token: 平台认证 token
+//   - opts: 可选配置（如 WithBaseURL）
+//
+// 返回:
+//   - PlatformProvider: 平台提供者实例
+//   - error: 如果平台不支持或创建失败，返回错误
+func NewPlatformProviderFromInfo(info *PlatformInfo, token string, opts ...Option) (pr.PlatformProvider, error) {
+	o := newOptions(opts)
+	if _, known := matchHost(normalizeHost(info.Host), knownHosts); o.baseURL == "" && info.Host != "" && !known {
+		opts = append(opts, WithBaseURL("https://"+info.Host))
+	}
+
+	return NewPlatformProvider(info.Platform, token, info.Owner, info.Repo, opts...)
+}
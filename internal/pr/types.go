@@ -2,22 +2,83 @@ package pr
 
 import "time"
 
+// CI 检查的汇总状态
+const (
+	ChecksSuccess = "success" // 全部通过
+	ChecksFailure = "failure" // 至少一个失败
+	ChecksPending = "pending" // 仍在运行
+)
+
+// 评审状态
+const (
+	ReviewApproved         = "approved"          // 已批准
+	ReviewChangesRequested = "changes_requested" // 要求修改
+	ReviewCommented        = "commented"         // 仅评论
+	ReviewPending          = "pending"           // 已请求评审，尚未评审
+)
+
 // PullRequestStatus PR 状态信息
 type PullRequestStatus struct {
-	State     string    // 状态（"open", "closed", "merged"）
-	Merged    bool      // 是否已合并
-	Mergeable *bool     // 是否可合并（nil 表示未知）
-	UpdatedAt time.Time // 更新时间
+	Number       int           `json:"number"`                  // PR 编号
+	Title        string        `json:"title"`                   // 标题
+	Body         string        `json:"body"`                    // 描述
+	HTMLURL      string        `json:"html_url"`                // PR URL
+	State        string        `json:"state"`                   // 状态（"open", "closed", "merged"）
+	Draft        bool          `json:"draft"`                   // 是否为草稿
+	Merged       bool          `json:"merged"`                  // 是否已合并
+	Mergeable    *bool         `json:"mergeable"`               // 是否可合并（nil 表示未知）
+	HeadBranch   string        `json:"head_branch"`             // 源分支
+	BaseBranch   string        `json:"base_branch"`             // 目标分支
+	Labels       []string      `json:"labels,omitempty"`        // 标签
+	Reviews      []Review      `json:"reviews,omitempty"`       // 评审人及评审状态
+	Checks       string        `json:"checks"`                  // CI 检查汇总状态（ChecksSuccess/ChecksFailure/ChecksPending，空表示没有检查）
+	CheckResults []CheckResult `json:"check_results,omitempty"` // 各项 CI 检查结果
+	UpdatedAt    time.Time     `json:"updated_at"`              // 更新时间
+}
+
+// Review 评审人及其最新评审状态
+type Review struct {
+	Reviewer string `json:"reviewer"` // 评审人用户名
+	State    string `json:"state"`    // 评审状态（ReviewApproved 等）
+}
+
+// CheckResult 单项 CI 检查结果
+type CheckResult struct {
+	Name  string `json:"name"`          // 检查名称
+	State string `json:"state"`         // 检查状态（ChecksSuccess/ChecksFailure/ChecksPending）
+	URL   string `json:"url,omitempty"` // 检查详情 URL
 }
 
 // PullRequestInfo PR 信息
 type PullRequestInfo struct {
-	Number    int       // PR 编号
-	Title     string    // 标题
-	State     string    // 状态
-	HTMLURL   string    // PR URL
-	CreatedAt time.Time // 创建时间
-	UpdatedAt time.Time // 更新时间
-	Author    string    // 作者
+	Number     int       `json:"number"`              // PR 编号
+	Title      string    `json:"title"`               // 标题
+	State      string    `json:"state"`               // 状态
+	Draft      bool      `json:"draft"`               // 是否为草稿
+	HTMLURL    string    `json:"html_url"`            // PR URL
+	HeadBranch string    `json:"head_branch"`         // 源分支
+	BaseBranch string    `json:"base_branch"`         // 目标分支
+	Labels     []string  `json:"labels,omitempty"`    // 标签
+	Reviewers  []string  `json:"reviewers,omitempty"` // 已请求的评审人
+	CreatedAt  time.Time `json:"created_at"`          // 创建时间
+	UpdatedAt  time.Time `json:"updated_at"`          // 更新时间
+	Author     string    `json:"author"`              // 作者
+}
+
+// ListOptions 列出 PR 的过滤条件
+type ListOptions struct {
+	State  string // 状态过滤（"open", "closed", "merged", "all"），默认 "open"
+	Head   string // 源分支过滤（为空表示不过滤）
+	Author string // 作者用户名过滤（为空表示不过滤）
+	Limit  int    // 返回数量限制
 }
 
+// PullRequestFile PR 中变更的文件
+type PullRequestFile struct {
+	Filename         string // 文件路径
+	PreviousFilename string // 重命名前的路径（仅重命名时有值）
+	Status           string // 变更类型（"added", "modified", "removed", "renamed"）
+	Additions        int    // 新增行数
+	Deletions        int    // 删除行数
+	Patch            string // 文件的 diff 内容（二进制文件或过大的文件为空）
+}
@@ -0,0 +1,66 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultEditor 未设置 $VISUAL 和 $EDITOR 时使用的编辑器
+const defaultEditor = "vi"
+
+// EditText 在外部编辑器中编辑文本
+//
+// 将文本写入临时文件，使用 $VISUAL、$EDITOR（依次回退到 vi）打开，
+// 编辑器退出后读取并返回文件内容。编辑器命令可以带参数，如 "code --wait"。
+//
+// 参数:
+//   - text: 初始文本
+//   - pattern: 临时文件名模式（如 "pr-*.md"，扩展名用于编辑器的语法高亮）
+//
+// 返回:
+//   - string: 编辑后的文本
+//   - error: 如果编辑器启动失败或以非零状态退出，返回错误
+func EditText(text, pattern string) (string, error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("创建临时文件失败: %w", err)
+	}
+	path := file.Name()
+	defer os.Remove(path)
+
+	if _, err := file.WriteString(text); err != nil {
+		file.Close()
+		return "", fmt.Errorf("写入临时文件失败: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("写入临时文件失败: %w", err)
+	}
+
+	args := strings.Fields(editorCommand())
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("运行编辑器 %s 失败: %w", args[0], err)
+	}
+
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("读取编辑结果失败: %w", err)
+	}
+
+	return string(edited), nil
+}
+
+// editorCommand 返回用户配置的编辑器命令
+func editorCommand() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); editor != "" {
+			return editor
+		}
+	}
+	return defaultEditor
+}
@@ -0,0 +1,52 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeEditorScript 创建一个把文件内容替换为 content 的编辑器脚本
+func writeEditorScript(t *testing.T, content string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("editor script requires a POSIX shell")
+	}
+
+	script := filepath.Join(t.TempDir(), "editor.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nprintf '"+content+"' > \"$1\"\n"), 0755))
+	return script
+}
+
+func TestEditText(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", writeEditorScript(t, "edited"))
+
+	got, err := EditText("original", "edit-*.md")
+	require.NoError(t, err)
+	assert.Equal(t, "edited", got)
+}
+
+func TestEditText_VisualTakesPrecedence(t *testing.T) {
+	t.Setenv("VISUAL", writeEditorScript(t, "from visual"))
+	t.Setenv("EDITOR", "false")
+
+	got, err := EditText("original", "edit-*.md")
+	require.NoError(t, err)
+	assert.Equal(t, "from visual", got)
+}
+
+func TestEditText_EditorFails(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires the false command")
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "false")
+
+	_, err := EditText("original", "edit-*.md")
+	assert.Error(t, err)
+}
@@ -1,5 +1,10 @@
 package util
 
+import (
+	"fmt"
+	"time"
+)
+
 // MaskSensitiveValue 掩码显示敏感值
 //
 // 用于在日志或输出中隐藏敏感信息（如 API key、密码等）。
@@ -44,3 +49,50 @@ func FormatBool(b bool) string {
 	}
 	return "No"
 }
+
+// FormatSize 格式化字节数为易读的大小
+//
+// 参数:
+//   - size: 字节数
+//
+// 返回:
+//   - string: 格式化后的大小（如 "512 B"、"1.5 KB"、"20.0 MB"）
+func FormatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// FormatAge 格式化时间间隔为简短的相对时间
+//
+// 参数:
+//   - d: 时间间隔
+//
+// 返回:
+//   - string: 相对时间（如 "just now"、"5m ago"、"3h ago"、"2d ago"、"4mo ago"、"1y ago"）
+func FormatAge(d time.Duration) string {
+	const day = 24 * time.Hour
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	case d < day:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	case d < 30*day:
+		return fmt.Sprintf("%dd ago", int(d/day))
+	case d < 365*day:
+		return fmt.Sprintf("%dmo ago", int(d/(30*day)))
+	default:
+		return fmt.Sprintf("%dy ago", int(d/(365*day)))
+	}
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"testing"
+	"time"
 )
 
 func TestMaskSensitiveValue(t *testing.T) {
@@ -84,3 +85,89 @@ func TestFormatBool(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    int64
+		expected string
+	}{
+		{
+			name:     "bytes",
+			input:    512,
+			expected: "512 B",
+		},
+		{
+			name:     "kilobytes",
+			input:    1536,
+			expected: "1.5 KB",
+		},
+		{
+			name:     "megabytes",
+			input:    20 * 1024 * 1024,
+			expected: "20.0 MB",
+		},
+		{
+			name:     "zero",
+			input:    0,
+			expected: "0 B",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FormatSize(tt.input)
+			if result != tt.expected {
+				t.Errorf("FormatSize(%d) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    time.Duration
+		expected string
+	}{
+		{
+			name:     "seconds",
+			input:    30 * time.Second,
+			expected: "just now",
+		},
+		{
+			name:     "minutes",
+			input:    5 * time.Minute,
+			expected: "5m ago",
+		},
+		{
+			name:     "hours",
+			input:    3 * time.Hour,
+			expected: "3h ago",
+		},
+		{
+			name:     "days",
+			input:    50 * time.Hour,
+			expected: "2d ago",
+		},
+		{
+			name:     "months",
+			input:    95 * 24 * time.Hour,
+			expected: "3mo ago",
+		},
+		{
+			name:     "years",
+			input:    400 * 24 * time.Hour,
+			expected: "1y ago",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FormatAge(tt.input)
+			if result != tt.expected {
+				t.Errorf("FormatAge(%v) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
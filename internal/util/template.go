@@ -0,0 +1,37 @@
+package util
+
+import (
+	"strings"
+)
+
+// RenderTemplate 渲染简单占位符模板
+//
+// 将模板中的 `{key}` 替换为 vars 中对应的值，未提供的占位符保持原样。
+// 用于渲染仓库配置中的 branch、commit、pull_requests 模板。
+//
+// 参数:
+//   - tmpl: 模板字符串
+//   - vars: 占位符名称到值的映射
+//
+// 返回:
+//   - string: 渲染后的字符串
+//
+// 示例:
+//
+//	RenderTemplate("{prefix}/{ticket}-{slug}", map[string]string{
+//		"prefix": "feature",
+//		"ticket": "PROJ-123",
+//		"slug":   "add-login",
+//	}) // "feature/PROJ-123-add-login"
+func RenderTemplate(tmpl string, vars map[string]string) string {
+	if tmpl == "" || len(vars) == 0 {
+		return tmpl
+	}
+
+	pairs := make([]string, 0, len(vars)*2)
+	for key, value := range vars {
+		pairs = append(pairs, "{"+key+"}", value)
+	}
+
+	return strings.NewReplacer(pairs...).Replace(tmpl)
+}
@@ -0,0 +1,52 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		tmpl     string
+		vars     map[string]string
+		expected string
+	}{
+		{
+			name:     "empty template",
+			tmpl:     "",
+			vars:     map[string]string{"title": "x"},
+			expected: "",
+		},
+		{
+			name:     "no vars",
+			tmpl:     "{title}",
+			vars:     nil,
+			expected: "{title}",
+		},
+		{
+			name: "branch template",
+			tmpl: "{prefix}/{ticket}-{slug}",
+			vars: map[string]string{
+				"prefix": "feature",
+				"ticket": "PROJ-123",
+				"slug":   "add-login",
+			},
+			expected: "feature/PROJ-123-add-login",
+		},
+		{
+			name:     "unknown placeholder kept",
+			tmpl:     "{title} ({unknown})",
+			vars:     map[string]string{"title": "Fix bug"},
+			expected: "Fix bug ({unknown})",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RenderTemplate(tt.tmpl, tt.vars)
+			if result != tt.expected {
+				t.Errorf("RenderTemplate(%q) = %q, want %q", tt.tmpl, result, tt.expected)
+			}
+		})
+	}
+}